@@ -0,0 +1,194 @@
+package paramcontext
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"  // GIF support for ReadQR.
+	_ "image/jpeg" // JPEG support for ReadQR.
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/nspcc-dev/neo-go/pkg/encoding/fountain"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/qr"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/context"
+)
+
+// DefaultQRFragmentSize is the default maximum number of context bytes per
+// QR code.
+const DefaultQRFragmentSize = 200
+
+// qrPrefix is the prefix of QR code payloads containing context parts.
+var qrPrefix = []byte("NC1")
+
+// qrScale is the number of pixels per module in QR code images.
+const qrScale = 4
+
+// QREncoder generates a sequence of QR codes for the parameter context. The
+// context is encoded in binary form and split into fragments with fountain
+// code, so the receiver doesn't need to get every code.
+type QREncoder struct {
+	enc *fountain.Encoder
+}
+
+// NewQREncoder creates an encoder for the context with at most fragmentSize
+// context bytes per QR code.
+func NewQREncoder(c *context.ParameterContext, fragmentSize int) (*QREncoder, error) {
+	data, err := encodeBinary(c)
+	if err != nil {
+		return nil, err
+	}
+	enc, err := fountain.NewEncoder(data, fragmentSize)
+	if err != nil {
+		return nil, err
+	}
+	return &QREncoder{enc: enc}, nil
+}
+
+// Frames returns the recommended number of QR codes to generate, it's all
+// fragments plus a half of that for additional mixed parts compensating
+// losses.
+func (e *QREncoder) Frames() int {
+	n := e.enc.SeqLen()
+	if n == 1 {
+		return 1
+	}
+	return n + (n+1)/2
+}
+
+// Next returns the next QR code, the sequence is infinite.
+func (e *QREncoder) Next() (*qr.Code, error) {
+	w := io.NewBufBinWriter()
+	w.WriteBytes(qrPrefix)
+	e.enc.NextPart().EncodeBinary(w.BinWriter)
+	if w.Err != nil {
+		return nil, w.Err
+	}
+	return qr.Encode(w.Bytes(), qr.Medium)
+}
+
+// SaveQR writes the parameter context as a set of PNG files with QR codes
+// (at most fragmentSize context bytes and the given number of frames, 0 for
+// the recommended one). The filename is used as is for a single code,
+// otherwise codes are numbered (like "ctx-1.png", "ctx-2.png"). It returns
+// names of the files written.
+func SaveQR(c *context.ParameterContext, filename string, fragmentSize int, frames int) ([]string, error) {
+	e, err := NewQREncoder(c, fragmentSize)
+	if err != nil {
+		return nil, err
+	}
+	if frames <= 0 {
+		frames = e.Frames()
+	}
+	var (
+		ext   = filepath.Ext(filename)
+		base  = strings.TrimSuffix(filename, ext)
+		names = make([]string, 0, frames)
+	)
+	for i := 1; i <= frames; i++ {
+		code, err := e.Next()
+		if err != nil {
+			return nil, fmt.Errorf("can't create QR code: %w", err)
+		}
+		name := filename
+		if frames > 1 {
+			name = fmt.Sprintf("%s-%d%s", base, i, ext)
+		}
+		var buf bytes.Buffer
+		if err := png.Encode(&buf, code.Image(qrScale)); err != nil {
+			return nil, fmt.Errorf("can't encode QR code image: %w", err)
+		}
+		if err := os.WriteFile(name, buf.Bytes(), 0644); err != nil {
+			return nil, fmt.Errorf("can't write QR code to file: %w", err)
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+// ReadQR reads the parameter context from image files (PNG, JPEG or GIF)
+// with QR codes produced by SaveQR or QREncoder. Patterns are expanded as
+// filepath.Glob does. Images without proper codes are skipped as long as the
+// context can be restored from the others.
+func ReadQR(patterns []string) (*context.ParameterContext, error) {
+	var (
+		dec     = fountain.NewDecoder()
+		skipped int
+	)
+	for _, pattern := range patterns {
+		files, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %s: %w", pattern, err)
+		}
+		if len(files) == 0 {
+			files = []string{pattern}
+		}
+		for _, f := range files {
+			if dec.Complete() {
+				break
+			}
+			p, err := readQRPart(f)
+			if err != nil {
+				if os.IsNotExist(err) {
+					return nil, err
+				}
+				skipped++
+				continue
+			}
+			if err := dec.Add(p); err != nil {
+				return nil, fmt.Errorf("%s: %w", f, err)
+			}
+		}
+	}
+	data, err := dec.Message()
+	if err != nil {
+		known, total := dec.Progress()
+		return nil, fmt.Errorf("not enough QR codes (%d of %d fragments restored, %d images skipped): %w", known, total, skipped, err)
+	}
+	c := new(context.ParameterContext)
+	r := io.NewBinReaderFromBuf(data)
+	c.DecodeBinary(r)
+	if r.Err != nil {
+		return nil, fmt.Errorf("can't parse transaction: %w", r.Err)
+	}
+	return c, nil
+}
+
+func readQRPart(filename string) (*fountain.Part, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	img, _, err := image.Decode(f)
+	if err != nil {
+		return nil, err
+	}
+	data, err := qr.Decode(img)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.HasPrefix(data, qrPrefix) {
+		return nil, errors.New("not a parameter context QR code")
+	}
+	p := new(fountain.Part)
+	r := io.NewBinReaderFromBuf(data[len(qrPrefix):])
+	p.DecodeBinary(r)
+	if r.Err != nil {
+		return nil, r.Err
+	}
+	return p, nil
+}
+
+func encodeBinary(c *context.ParameterContext) ([]byte, error) {
+	w := io.NewBufBinWriter()
+	c.EncodeBinary(w.BinWriter)
+	if w.Err != nil {
+		return nil, fmt.Errorf("can't encode transaction: %w", w.Err)
+	}
+	return w.Bytes(), nil
+}
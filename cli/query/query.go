@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/base64"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"sort"
 	"strconv"
@@ -17,6 +18,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/fixedn"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc"
 	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient/invoker"
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient/neo"
@@ -34,6 +36,24 @@ func NewCommands() []cli.Command {
 			Usage: "Output full tx info and execution logs",
 		},
 	}, options.RPC...)
+	queryTraceFlags := append([]cli.Flag{
+		cli.StringFlag{
+			Name:  "level, l",
+			Usage: "Trace detail level: 'syscalls', 'opcodes' or 'full' (default)",
+		},
+		cli.IntFlag{
+			Name:  "max-steps",
+			Usage: "Maximum number of steps to return (limited by the server)",
+		},
+		cli.IntFlag{
+			Name:  "stack-depth",
+			Usage: "Number of top stack items to show for every step (full level only)",
+		},
+		cli.IntFlag{
+			Name:  "max-item-size",
+			Usage: "Maximum size of stack item JSON to show (bigger ones only have their type shown)",
+		},
+	}, options.RPC...)
 	return []cli.Command{{
 		Name:  "query",
 		Usage: "Query data from RPC node",
@@ -66,6 +86,30 @@ func NewCommands() []cli.Command {
 				Action:    queryTx,
 				Flags:     queryTxFlags,
 			},
+			{
+				Name:      "trace-block",
+				Usage:     "Replay block and print execution traces of all its scripts",
+				UsageText: "neo-go query trace-block <index|hash> -r endpoint [-s timeout] [-l level] [--max-steps steps] [--stack-depth depth] [--max-item-size size]",
+				Description: `Replays the block with the given index or hash using the node state
+   before it and outputs JSON execution traces for OnPersist script, all
+   block transactions and PostPersist script. The node must store old
+   states (not use KeepOnlyLatestState or RemoveUntraceableBlocks).
+`,
+				Action: queryTraceBlock,
+				Flags:  queryTraceFlags,
+			},
+			{
+				Name:      "trace-tx",
+				Usage:     "Replay transaction and print its execution trace",
+				UsageText: "neo-go query trace-tx <hash> -r endpoint [-s timeout] [-l level] [--max-steps steps] [--stack-depth depth] [--max-item-size size]",
+				Description: `Replays the block containing the given transaction up to it using the
+   node state before the block and outputs JSON execution trace of the
+   transaction script. The node must store old states (not use
+   KeepOnlyLatestState or RemoveUntraceableBlocks).
+`,
+				Action: queryTraceTx,
+				Flags:  queryTraceFlags,
+			},
 			{
 				Name:      "voter",
 				Usage:     "Print NEO holder account state",
@@ -264,6 +308,100 @@ func queryHeight(ctx *cli.Context) error {
 	return nil
 }
 
+func queryTraceTx(ctx *cli.Context) error {
+	args := ctx.Args()
+	if len(args) == 0 {
+		return cli.NewExitError("Transaction hash is missing", 1)
+	} else if len(args) > 1 {
+		return cli.NewExitError("only one transaction hash is accepted", 1)
+	}
+	txHash, err := util.Uint256DecodeStringLE(strings.TrimPrefix(args[0], "0x"))
+	if err != nil {
+		return cli.NewExitError(fmt.Sprintf("Invalid tx hash: %s", args[0]), 1)
+	}
+
+	gctx, cancel := options.GetTimeoutContext(ctx)
+	defer cancel()
+	c, err := options.GetRPCClient(gctx, ctx)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+
+	res, err := c.TraceTransaction(txHash, getTraceOptions(ctx))
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	return dumpJSON(ctx, res)
+}
+
+func queryTraceBlock(ctx *cli.Context) error {
+	args := ctx.Args()
+	if len(args) == 0 {
+		return cli.NewExitError("Block index or hash is missing", 1)
+	} else if len(args) > 1 {
+		return cli.NewExitError("only one block index or hash is accepted", 1)
+	}
+
+	var (
+		index   uint64
+		hash    util.Uint256
+		byIndex bool
+		err     error
+	)
+	s := strings.TrimPrefix(args[0], "0x")
+	if len(s) == util.Uint256Size*2 {
+		hash, err = util.Uint256DecodeStringLE(s)
+	} else {
+		index, err = strconv.ParseUint(args[0], 10, 32)
+		byIndex = true
+	}
+	if err != nil {
+		return cli.NewExitError(fmt.Sprintf("Invalid block index or hash: %s", args[0]), 1)
+	}
+
+	gctx, cancel := options.GetTimeoutContext(ctx)
+	defer cancel()
+	c, err := options.GetRPCClient(gctx, ctx)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+
+	var res []result.ExecutionTrace
+	if byIndex {
+		res, err = c.TraceBlockByIndex(uint32(index), getTraceOptions(ctx))
+	} else {
+		res, err = c.TraceBlockByHash(hash, getTraceOptions(ctx))
+	}
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	return dumpJSON(ctx, res)
+}
+
+// getTraceOptions returns trace options set via command flags, nil is
+// returned if none of them are set to use server defaults.
+func getTraceOptions(ctx *cli.Context) *neorpc.TraceOptions {
+	opts := &neorpc.TraceOptions{
+		Level:       neorpc.TraceLevel(ctx.String("level")),
+		MaxSteps:    ctx.Int("max-steps"),
+		StackDepth:  ctx.Int("stack-depth"),
+		MaxItemSize: ctx.Int("max-item-size"),
+	}
+	if *opts == (neorpc.TraceOptions{}) {
+		return nil
+	}
+	return opts
+}
+
+func dumpJSON(ctx *cli.Context, v any) error {
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	fmt.Fprintln(ctx.App.Writer, string(b))
+	return nil
+}
+
 func queryVoter(ctx *cli.Context) error {
 	args := ctx.Args()
 	if len(args) == 0 {
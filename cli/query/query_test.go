@@ -2,6 +2,7 @@ package query_test
 
 import (
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"regexp"
 	"strconv"
@@ -14,6 +15,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/fixedn"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm"
@@ -153,3 +155,88 @@ func TestQueryHeight(t *testing.T) {
 		e.RunWithError(t, append(args, "something")...)
 	})
 }
+
+func TestQueryTrace(t *testing.T) {
+	e := testcli.NewExecutor(t, true)
+
+	rpcArgs := []string{"--rpc-endpoint", "http://" + e.RPC.Addresses()[0]}
+	e.In.WriteString("one\r")
+	e.Run(t, append([]string{"neo-go", "wallet", "nep17", "transfer",
+		"--wallet", testcli.ValidatorWallet,
+		"--to", address.Uint160ToString(random.Uint160()),
+		"--token", "NEO",
+		"--from", testcli.ValidatorAddr,
+		"--amount", "1",
+		"--force"}, rpcArgs...)...)
+	txHash, err := util.Uint256DecodeStringLE(e.GetNextLine(t))
+	require.NoError(t, err)
+	require.Eventually(t, func() bool { _, aerErr := e.Chain.GetAppExecResults(txHash, trigger.Application); return aerErr == nil }, time.Second*2, time.Millisecond*50)
+	aer, err := e.Chain.GetAppExecResults(txHash, trigger.Application)
+	require.NoError(t, err)
+	tx, height, err := e.Chain.GetTransaction(txHash)
+	require.NoError(t, err)
+	b, err := e.Chain.GetBlock(e.Chain.GetHeaderHash(height))
+	require.NoError(t, err)
+	t.Run("tx", func(t *testing.T) {
+		args := append([]string{"neo-go", "query", "trace-tx"}, rpcArgs...)
+		e.Run(t, append(args, "--level", "syscalls", tx.Hash().StringLE())...)
+		res := new(result.ExecutionTrace)
+		require.NoError(t, json.Unmarshal(e.Out.Bytes(), res))
+		require.Equal(t, tx.Hash(), res.Container)
+		require.Equal(t, aer[0].VMState.String(), res.VMState)
+		require.Equal(t, aer[0].GasConsumed, res.GasConsumed)
+		require.NotEqual(t, 0, len(res.Steps))
+		for _, s := range res.Steps {
+			require.Equal(t, "SYSCALL", s.Opcode)
+			require.NotNil(t, s.Syscall)
+		}
+
+		t.Run("missing hash", func(t *testing.T) {
+			e.RunWithError(t, args...)
+		})
+		t.Run("excessive arguments", func(t *testing.T) {
+			e.RunWithError(t, append(args, tx.Hash().StringLE(), tx.Hash().StringLE())...)
+		})
+		t.Run("invalid hash", func(t *testing.T) {
+			e.RunWithError(t, append(args, "notahash")...)
+		})
+		t.Run("unknown level", func(t *testing.T) {
+			e.RunWithError(t, append(args, "--level", "bad", tx.Hash().StringLE())...)
+		})
+	})
+	t.Run("block", func(t *testing.T) {
+		args := append([]string{"neo-go", "query", "trace-block"}, rpcArgs...)
+		check := func(t *testing.T, arg string) {
+			e.Run(t, append(args, "--max-steps", "5", arg)...)
+			var res []result.ExecutionTrace
+			require.NoError(t, json.Unmarshal(e.Out.Bytes(), &res))
+			require.Equal(t, len(b.Transactions)+2, len(res))
+			require.Equal(t, trigger.OnPersist.String(), res[0].Trigger)
+			require.Equal(t, b.Hash(), res[0].Container)
+			require.Equal(t, tx.Hash(), res[1].Container)
+			require.Equal(t, aer[0].VMState.String(), res[1].VMState)
+			require.Equal(t, trigger.PostPersist.String(), res[len(res)-1].Trigger)
+			var steps int
+			for _, r := range res {
+				steps += len(r.Steps)
+			}
+			require.Equal(t, 5, steps)
+			require.True(t, res[len(res)-1].Truncated)
+		}
+		t.Run("by index", func(t *testing.T) {
+			check(t, strconv.FormatUint(uint64(height), 10))
+		})
+		t.Run("by hash", func(t *testing.T) {
+			check(t, b.Hash().StringLE())
+		})
+		t.Run("missing argument", func(t *testing.T) {
+			e.RunWithError(t, args...)
+		})
+		t.Run("invalid index", func(t *testing.T) {
+			e.RunWithError(t, append(args, "notanumber")...)
+		})
+		t.Run("unknown block", func(t *testing.T) {
+			e.RunWithError(t, append(args, "100500")...)
+		})
+	})
+}
@@ -130,3 +130,23 @@ func TestServerStart(t *testing.T) {
 		})
 	}
 }
+
+func TestSignerStart(t *testing.T) {
+	e := testcli.NewExecutor(t, false)
+	wallet := testcli.ValidatorWallet
+
+	t.Run("no wallet", func(t *testing.T) {
+		e.RunWithError(t, "neo-go", "signer")
+	})
+	t.Run("excessive parameters", func(t *testing.T) {
+		e.RunWithError(t, "neo-go", "signer", "--wallet", wallet, "something")
+	})
+	t.Run("bad password", func(t *testing.T) {
+		e.In.WriteString("bad\r")
+		e.RunWithError(t, "neo-go", "signer", "--wallet", wallet)
+	})
+	t.Run("bad protection file", func(t *testing.T) {
+		e.In.WriteString(testcli.ValidatorPass + "\r")
+		e.RunWithError(t, "neo-go", "signer", "--wallet", wallet, "--protection", t.TempDir())
+	})
+}
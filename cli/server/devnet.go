@@ -0,0 +1,360 @@
+package server
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/cli/cmdargs"
+	"github.com/nspcc-dev/neo-go/cli/flags"
+	"github.com/nspcc-dev/neo-go/cli/options"
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage/dbconfig"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/fixedn"
+	"github.com/nspcc-dev/neo-go/pkg/network"
+	"github.com/nspcc-dev/neo-go/pkg/services/devnet"
+	"github.com/nspcc-dev/neo-go/pkg/services/rpcsrv"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
+	"github.com/urfave/cli"
+)
+
+const (
+	// devnetMagic is the network magic used by development networks by default.
+	devnetMagic = netmode.PrivNet
+	// devnetValidatorLabel is the label of the validator account in the
+	// development network wallet.
+	devnetValidatorLabel = "validator"
+	// devnetCommitteeLabel is the label of the committee multisignature account
+	// in the development network wallet.
+	devnetCommitteeLabel = "committee"
+)
+
+func newDevnetCommand() cli.Command {
+	return cli.Command{
+		Name:  "devnet",
+		Usage: "start a single-node development network",
+		UsageText: "neo-go devnet [--wallet file] [--password pass] [--accounts N] [--db file] [--rpc addr] [--p2p addr]\n" +
+			"\t[--block-time duration] [--automine] [--neo amount] [--gas amount] [--magic number] [-d]",
+		Description: `Starts a private network with a single consensus node, RPC server and a
+   set of prefunded accounts. The network wallet is created at the specified path
+   unless it exists already, it contains the validator key, the committee
+   multisignature account owning initial NEO and GAS supply and a number of
+   regular accounts. Regular accounts get the specified amount of NEO and GAS
+   once the network is started with an empty chain. Keys of all accounts are
+   printed at startup.
+
+   By default, blocks are produced by a solo dBFT instance every --block-time.
+   With --automine flag dBFT is disabled and a block is generated as soon as a
+   transaction appears in the memory pool. In both modes additional blocks can
+   be generated with "mineblocks" RPC call (accepting the number of blocks to
+   generate as the only parameter), dBFT is paused while it's processed.
+
+   The chain is kept in memory unless --db option is given, in which case it's
+   stored in a BoltDB file at the specified path.
+`,
+		Action: startDevnet,
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:  "wallet, w",
+				Value: "devnet.wallet.json",
+				Usage: "path to the development network wallet (created if it doesn't exist)",
+			},
+			cli.StringFlag{
+				Name:  "password",
+				Value: "devnet",
+				Usage: "password for the development network wallet accounts",
+			},
+			cli.UintFlag{
+				Name:  "accounts",
+				Value: 3,
+				Usage: "number of regular accounts to create in a new wallet",
+			},
+			cli.StringFlag{
+				Name:  "db",
+				Usage: "path to the BoltDB file to store the chain in (in-memory DB is used if not set)",
+			},
+			cli.StringFlag{
+				Name:  "rpc",
+				Value: "localhost:50012",
+				Usage: "RPC server listening address",
+			},
+			cli.StringFlag{
+				Name:  "p2p",
+				Value: "localhost:0",
+				Usage: "P2P server listening address",
+			},
+			cli.DurationFlag{
+				Name:  "block-time",
+				Value: time.Second,
+				Usage: "time between blocks generated by dBFT",
+			},
+			cli.BoolFlag{
+				Name:  "automine",
+				Usage: "generate a block right after a transaction is received instead of running dBFT",
+			},
+			cli.UintFlag{
+				Name:  "neo",
+				Value: 1000,
+				Usage: "amount of NEO transferred to each regular account",
+			},
+			flags.Fixed8Flag{
+				Name:  "gas",
+				Value: flags.Fixed8{Value: fixedn.Fixed8FromInt64(10000)},
+				Usage: "amount of GAS transferred to each regular account (10000 by default)",
+			},
+			cli.UintFlag{
+				Name:  "magic",
+				Value: uint(devnetMagic),
+				Usage: "network magic",
+			},
+			options.Debug,
+		},
+	}
+}
+
+func startDevnet(ctx *cli.Context) error {
+	if err := cmdargs.EnsureNone(ctx); err != nil {
+		return err
+	}
+	blockTime := ctx.Duration("block-time")
+	if blockTime < time.Millisecond || blockTime%time.Millisecond != 0 {
+		return cli.NewExitError("block time must be a positive integer number of milliseconds", 1)
+	}
+	w, validator, err := openDevnetWallet(ctx.String("wallet"), ctx.String("password"), int(ctx.Uint("accounts")))
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	defer w.Close()
+
+	cfg := newDevnetConfig(ctx, validator.PublicKey(), w.Path())
+	log, _, logCloser, err := options.HandleLoggingParams(ctx.Bool("debug"), cfg.ApplicationConfiguration)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	if logCloser != nil {
+		defer func() { _ = logCloser() }()
+	}
+
+	grace, cancel := context.WithCancel(newGraceContext())
+	defer cancel()
+
+	serverConfig, err := network.NewServerConfig(cfg)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	chain, _, err := initBlockChain(cfg, log)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	go chain.Run()
+	defer chain.Close()
+
+	serv, err := network.NewServer(serverConfig, chain, chain.GetStateSyncModule(), log)
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("failed to create network server: %w", err), 1)
+	}
+	miner, err := devnet.New(devnet.Config{
+		Chain:    chain,
+		Log:      log,
+		Key:      validator.PrivateKey(),
+		AutoMine: ctx.Bool("automine"),
+	})
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("failed to create block generator: %w", err), 1)
+	}
+	serv.AddService(miner)
+
+	var accounts []*wallet.Account
+	for _, acc := range w.Accounts {
+		if acc.Label != devnetValidatorLabel && acc.Label != devnetCommitteeLabel {
+			accounts = append(accounts, acc)
+		}
+	}
+	if chain.BlockHeight() == 0 && len(accounts) > 0 {
+		hashes := make([]util.Uint160, len(accounts))
+		for i, acc := range accounts {
+			hashes[i] = acc.ScriptHash()
+		}
+		neoAmount := new(big.Int).SetUint64(uint64(ctx.Uint("neo")))
+		gasAmount := big.NewInt(int64(flags.Fixed8FromContext(ctx, "gas")))
+		if _, err := miner.Prefund(hashes, neoAmount, gasAmount); err != nil {
+			return cli.NewExitError(fmt.Errorf("failed to prefund accounts: %w", err), 1)
+		}
+	}
+
+	// Miner is used as the block queue for dBFT to pause it while mineblocks
+	// call is being processed.
+	_, err = mkConsensus(cfg.ApplicationConfiguration.Consensus, serverConfig.TimePerBlock, chain, serv, miner, log)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	errChan := make(chan error)
+	rpcServer := rpcsrv.New(chain, cfg.ApplicationConfiguration.RPC, serv, nil, log, errChan)
+	rpcServer.SetBlockMiner(miner)
+	serv.AddService(&rpcServer)
+
+	go serv.Start()
+	go rpcServer.Start()
+
+	fmt.Fprintln(ctx.App.Writer, Logo())
+	fmt.Fprintln(ctx.App.Writer, serv.UserAgent)
+	fmt.Fprintf(ctx.App.Writer, "Development network %s, RPC endpoint: http://%s\n", cfg.ProtocolConfiguration.Magic, ctx.String("rpc"))
+	fmt.Fprintf(ctx.App.Writer, "Wallet: %s\n\n", w.Path())
+	for _, acc := range w.Accounts {
+		fmt.Fprintf(ctx.App.Writer, "%-10s %s", acc.Label, acc.Address)
+		if acc.Label != devnetCommitteeLabel {
+			fmt.Fprintf(ctx.App.Writer, " %s", acc.PrivateKey().WIF())
+		}
+		fmt.Fprintln(ctx.App.Writer)
+	}
+	fmt.Fprintln(ctx.App.Writer)
+
+	var shutdownErr error
+	select {
+	case err := <-errChan:
+		shutdownErr = fmt.Errorf("server error: %w", err)
+	case <-grace.Done():
+	}
+	serv.Shutdown()
+
+	if shutdownErr != nil {
+		return cli.NewExitError(shutdownErr, 1)
+	}
+	return nil
+}
+
+// newDevnetConfig creates node configuration for the development network with
+// the given validator.
+func newDevnetConfig(ctx *cli.Context, validator *keys.PublicKey, walletPath string) config.Config {
+	var dbCfg = dbconfig.DBConfiguration{Type: "inmemory"}
+	if path := ctx.String("db"); path != "" {
+		dbCfg = dbconfig.DBConfiguration{
+			Type:          "boltdb",
+			BoltDBOptions: dbconfig.BoltDBOptions{FilePath: path},
+		}
+	}
+	return config.Config{
+		ProtocolConfiguration: config.ProtocolConfiguration{
+			Magic:              netmode.Magic(ctx.Uint("magic")),
+			MaxTraceableBlocks: 2102400,
+			MemPoolSize:        50000,
+			StandbyCommittee:   []string{hex.EncodeToString(validator.Bytes())},
+			TimePerBlock:       ctx.Duration("block-time"),
+			ValidatorsCount:    1,
+			VerifyTransactions: true,
+		},
+		ApplicationConfiguration: config.ApplicationConfiguration{
+			DBConfiguration: dbCfg,
+			P2P: config.P2P{
+				Addresses:    []string{ctx.String("p2p")},
+				DialTimeout:  3 * time.Second,
+				MaxPeers:     10,
+				PingInterval: 30 * time.Second,
+				PingTimeout:  90 * time.Second,
+			},
+			Relay: true,
+			Consensus: config.Consensus{
+				Enabled: !ctx.Bool("automine"),
+				UnlockWallet: config.Wallet{
+					Path:     walletPath,
+					Password: ctx.String("password"),
+				},
+			},
+			RPC: config.RPC{
+				BasicService: config.BasicService{
+					Enabled:   true,
+					Addresses: []string{ctx.String("rpc")},
+				},
+				MaxGasInvoke:           fixedn.Fixed8FromInt64(100),
+				MaxIteratorResultItems: config.DefaultMaxIteratorResultItems,
+				MaxFindResultItems:     100,
+				MaxNEP11Tokens:         100,
+				SessionEnabled:         true,
+			},
+		},
+	}
+}
+
+// openDevnetWallet opens the development network wallet at the given path (or
+// creates it with the specified number of regular accounts if it doesn't
+// exist) and returns it along with the decrypted validator account. All
+// accounts of the returned wallet are decrypted.
+func openDevnetWallet(path string, pass string, accounts int) (*wallet.Wallet, *wallet.Account, error) {
+	var (
+		w   *wallet.Wallet
+		err error
+	)
+	if _, statErr := os.Stat(path); statErr == nil {
+		w, err = wallet.NewWalletFromFile(path)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to open wallet: %w", err)
+		}
+	} else {
+		w, err = createDevnetWallet(path, pass, accounts)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create wallet: %w", err)
+		}
+	}
+	var validator *wallet.Account
+	for _, acc := range w.Accounts {
+		if err := acc.Decrypt(pass, w.Scrypt); err != nil {
+			w.Close()
+			return nil, nil, fmt.Errorf("failed to decrypt account %s: %w", acc.Address, err)
+		}
+		if acc.Label == devnetValidatorLabel {
+			validator = acc
+		}
+	}
+	if validator == nil {
+		w.Close()
+		return nil, nil, errors.New("wallet doesn't contain validator account")
+	}
+	return w, validator, nil
+}
+
+// createDevnetWallet creates a new development network wallet with the
+// validator account, the committee account and the given number of regular
+// accounts.
+func createDevnetWallet(path string, pass string, accounts int) (*wallet.Wallet, error) {
+	w, err := wallet.NewWallet(path)
+	if err != nil {
+		return nil, err
+	}
+	key, err := keys.NewPrivateKey()
+	if err != nil {
+		return nil, err
+	}
+	validator := wallet.NewAccountFromPrivateKey(key)
+	validator.Label = devnetValidatorLabel
+	committee := wallet.NewAccountFromPrivateKey(key)
+	committee.Label = devnetCommitteeLabel
+	if err := committee.ConvertMultisig(1, keys.PublicKeys{key.PublicKey()}); err != nil {
+		return nil, err
+	}
+	var accs = []*wallet.Account{validator, committee}
+	for i := 0; i < accounts; i++ {
+		acc, err := wallet.NewAccount()
+		if err != nil {
+			return nil, err
+		}
+		acc.Label = fmt.Sprintf("account-%d", i+1)
+		accs = append(accs, acc)
+	}
+	for _, acc := range accs {
+		if err := acc.Encrypt(pass, w.Scrypt); err != nil {
+			return nil, err
+		}
+		w.AddAccount(acc)
+	}
+	if err := w.Save(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
@@ -89,6 +89,8 @@ func NewCommands() []cli.Command {
 			Action:    startServer,
 			Flags:     cfgFlags,
 		},
+		newDevnetCommand(),
+		newSignerCommand(),
 		{
 			Name:  "db",
 			Usage: "database manipulations",
@@ -379,7 +381,25 @@ func mkOracle(config config.OracleConfiguration, magic netmode.Magic, chain *cor
 	return orc, nil
 }
 
-func mkConsensus(config config.Consensus, tpb time.Duration, chain *core.Blockchain, serv *network.Server, log *zap.Logger) (consensus.Service, error) {
+// oracleHandler returns the oracle service as the RPC server handler, nil
+// service is converted to nil handler to make the server reject oracle calls.
+func oracleHandler(orc *oracle.Oracle) rpcsrv.OracleHandler {
+	if orc == nil {
+		return nil
+	}
+	return orc
+}
+
+// notaryHandler returns the notary service as the RPC server handler, nil
+// service is converted to nil handler to make the server reject notary calls.
+func notaryHandler(n *notary.Notary) rpcsrv.NotaryHandler {
+	if n == nil {
+		return nil
+	}
+	return n
+}
+
+func mkConsensus(config config.Consensus, tpb time.Duration, chain *core.Blockchain, serv *network.Server, bq consensus.BlockQueuer, log *zap.Logger) (consensus.Service, error) {
 	if !config.Enabled {
 		return nil, nil
 	}
@@ -387,11 +407,12 @@ func mkConsensus(config config.Consensus, tpb time.Duration, chain *core.Blockch
 		Logger:                log,
 		Broadcast:             serv.BroadcastExtensible,
 		Chain:                 chain,
-		BlockQueue:            serv.GetBlockQueue(),
+		BlockQueue:            bq,
 		ProtocolConfiguration: chain.GetConfig().ProtocolConfiguration,
 		RequestTx:             serv.RequestTx,
 		StopTxFlow:            serv.StopTxFlow,
 		Wallet:                config.UnlockWallet,
+		RemoteSigner:          config.RemoteSigner,
 		TimePerBlock:          tpb,
 	})
 	if err != nil {
@@ -480,7 +501,7 @@ func startServer(ctx *cli.Context) error {
 	if err != nil {
 		return cli.NewExitError(err, 1)
 	}
-	dbftSrv, err := mkConsensus(cfg.ApplicationConfiguration.Consensus, serverConfig.TimePerBlock, chain, serv, log)
+	dbftSrv, err := mkConsensus(cfg.ApplicationConfiguration.Consensus, serverConfig.TimePerBlock, chain, serv, serv.GetBlockQueue(), log)
 	if err != nil {
 		return cli.NewExitError(err, 1)
 	}
@@ -489,7 +510,9 @@ func startServer(ctx *cli.Context) error {
 		return cli.NewExitError(err, 1)
 	}
 	errChan := make(chan error)
-	rpcServer := rpcsrv.New(chain, cfg.ApplicationConfiguration.RPC, serv, oracleSrv, log, errChan)
+	rpcServer := rpcsrv.New(chain, cfg.ApplicationConfiguration.RPC, serv, oracleHandler(oracleSrv), log, errChan)
+	rpcServer.SetConsensusHandler(dbftSrv)
+	rpcServer.SetNotaryHandler(notaryHandler(p2pNotary))
 	serv.AddService(&rpcServer)
 
 	go serv.Start()
@@ -549,7 +572,9 @@ Main:
 				}
 				serv.DelService(&rpcServer)
 				rpcServer.Shutdown()
-				rpcServer = rpcsrv.New(chain, cfgnew.ApplicationConfiguration.RPC, serv, oracleSrv, log, errChan)
+				rpcServer = rpcsrv.New(chain, cfgnew.ApplicationConfiguration.RPC, serv, oracleHandler(oracleSrv), log, errChan)
+				rpcServer.SetConsensusHandler(dbftSrv)
+				rpcServer.SetNotaryHandler(notaryHandler(p2pNotary))
 				serv.AddService(&rpcServer)
 				if !cfgnew.ApplicationConfiguration.RPC.StartWhenSynchronized || serv.IsInSync() {
 					// Here similar to the initial run (see above for-loop), so async.
@@ -590,6 +615,7 @@ Main:
 				if p2pNotary != nil {
 					serv.DelService(p2pNotary)
 					chain.SetNotary(nil)
+					rpcServer.SetNotaryHandler(nil)
 					p2pNotary.Shutdown()
 				}
 				p2pNotary, err = mkP2PNotary(cfgnew.ApplicationConfiguration.P2PNotary, chain, serv, log)
@@ -597,8 +623,11 @@ Main:
 					log.Error("failed to create notary service", zap.Error(err))
 					break // Keep going.
 				}
-				if p2pNotary != nil && serv.IsInSync() {
-					p2pNotary.Start()
+				if p2pNotary != nil {
+					rpcServer.SetNotaryHandler(p2pNotary)
+					if serv.IsInSync() {
+						p2pNotary.Start()
+					}
 				}
 				serv.DelExtensibleService(sr, stateroot.Category)
 				srMod.SetUpdateValidatorsCallback(nil)
@@ -615,15 +644,19 @@ Main:
 			case sigusr2:
 				if dbftSrv != nil {
 					serv.DelConsensusService(dbftSrv)
+					rpcServer.SetConsensusHandler(nil)
 					dbftSrv.Shutdown()
 				}
-				dbftSrv, err = mkConsensus(cfgnew.ApplicationConfiguration.Consensus, serverConfig.TimePerBlock, chain, serv, log)
+				dbftSrv, err = mkConsensus(cfgnew.ApplicationConfiguration.Consensus, serverConfig.TimePerBlock, chain, serv, serv.GetBlockQueue(), log)
 				if err != nil {
 					log.Error("failed to create consensus service", zap.Error(err))
 					break // Whatever happens, I'll leave it all to chance.
 				}
-				if dbftSrv != nil && serv.IsInSync() {
-					dbftSrv.Start()
+				if dbftSrv != nil {
+					rpcServer.SetConsensusHandler(dbftSrv)
+					if serv.IsInSync() {
+						dbftSrv.Start()
+					}
 				}
 			}
 			cfg = cfgnew
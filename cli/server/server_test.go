@@ -414,3 +414,27 @@ func TestResetDB(t *testing.T) {
 	err = resetDB(ctx)
 	require.NoError(t, err)
 }
+
+func TestOpenDevnetWallet(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "devnet.json")
+	w, validator, err := openDevnetWallet(path, "pass", 2)
+	require.NoError(t, err)
+	require.Equal(t, 4, len(w.Accounts))
+	require.Equal(t, devnetValidatorLabel, validator.Label)
+	require.True(t, validator.CanSign())
+	require.Equal(t, devnetCommitteeLabel, w.Accounts[1].Label)
+	require.Equal(t, validator.PublicKey(), w.Accounts[1].PublicKey())
+	w.Close()
+
+	t.Run("reopen", func(t *testing.T) {
+		w2, validator2, err := openDevnetWallet(path, "pass", 5)
+		require.NoError(t, err)
+		require.Equal(t, 4, len(w2.Accounts))
+		require.Equal(t, validator.Address, validator2.Address)
+		w2.Close()
+	})
+	t.Run("bad password", func(t *testing.T) {
+		_, _, err := openDevnetWallet(path, "wrong", 0)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,127 @@
+package server
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nspcc-dev/neo-go/cli/cmdargs"
+	"github.com/nspcc-dev/neo-go/cli/input"
+	"github.com/nspcc-dev/neo-go/cli/options"
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/services/signer"
+	"github.com/urfave/cli"
+	"go.uber.org/zap"
+)
+
+func newSignerCommand() cli.Command {
+	return cli.Command{
+		Name:      "signer",
+		Usage:     "start a remote signer for node services",
+		UsageText: "neo-go signer --wallet file [--socket path] [--protection file] [--allow address:domain[,domain...]]... [-d]",
+		Description: `Starts a signing server holding keys of the given wallet, consensus, state
+   root, notary and oracle services of the node can use it instead of unlocking
+   the wallet themselves (see RemoteSigner configuration section). The server
+   listens on the Unix socket at the given path, the socket is only accessible
+   by the user running the signer. All wallet accounts that can be decrypted
+   with the password entered at startup are available to the node.
+
+   The signer refuses to sign different blocks (and state roots) for the same
+   height and view. Signed data is tracked in the --protection file which is
+   kept between restarts, without it the protection only works while the
+   signer is running.
+
+   Keys can be restricted to some signing domains (consensus,
+   consensusmessage, stateroot, staterootmessage, notary and oracle) with
+   --allow flags, e.g. '--allow NVTiAjNgagDkTr5HTzDmQP9kPwPHN5BgVq:notary'.
+   Keys without restrictions are treated as consensus ones, so they can only
+   sign consensus and state root data, notary and oracle domains must be
+   allowed explicitly (and can't be combined with consensus or state root
+   ones for the same key).
+`,
+		Action: startSigner,
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:  "wallet, w",
+				Usage: "path to the wallet with node keys",
+			},
+			cli.StringFlag{
+				Name:  "socket",
+				Value: "neo-go-signer.sock",
+				Usage: "path to the Unix socket to listen on",
+			},
+			cli.StringFlag{
+				Name:  "protection",
+				Usage: "path to the double-signing protection state file",
+			},
+			cli.StringSliceFlag{
+				Name:  "allow",
+				Usage: "domains allowed for the key in 'address:domain[,domain...]' format",
+			},
+			options.Debug,
+		},
+	}
+}
+
+func startSigner(ctx *cli.Context) error {
+	if err := cmdargs.EnsureNone(ctx); err != nil {
+		return err
+	}
+	path := ctx.String("wallet")
+	if len(path) == 0 {
+		return cli.NewExitError("wallet is required", 1)
+	}
+	log, _, logCloser, err := options.HandleLoggingParams(ctx.Bool("debug"), config.ApplicationConfiguration{})
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	if logCloser != nil {
+		defer func() { _ = logCloser() }()
+	}
+	pass, err := input.ReadPassword("Enter password > ")
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("failed to read password: %w", err), 1)
+	}
+	s, err := signer.NewWalletSigner(config.Wallet{Path: path, Password: pass})
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	defer s.Close()
+	protection, err := signer.NewProtection(ctx.String("protection"))
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	s.SetProtection(protection)
+
+	pubs, _ := s.PublicKeys()
+	for _, a := range ctx.StringSlice("allow") {
+		if err := allowDomains(s, pubs, a); err != nil {
+			return cli.NewExitError(err, 1)
+		}
+	}
+	for _, pub := range pubs {
+		log.Info("key unlocked", zap.String("address", pub.Address()))
+	}
+	srv := signer.NewServer(ctx.String("socket"), s, log)
+	if err := srv.Start(); err != nil {
+		return cli.NewExitError(fmt.Errorf("failed to start signing server: %w", err), 1)
+	}
+	<-newGraceContext().Done()
+	srv.Shutdown()
+	return nil
+}
+
+// allowDomains parses the 'address:domain[,domain...]' string and restricts
+// the key with the given address to the domains.
+func allowDomains(s *signer.Local, pubs keys.PublicKeys, allow string) error {
+	addr, domains, ok := strings.Cut(allow, ":")
+	if !ok || len(domains) == 0 {
+		return fmt.Errorf("invalid allowed domains %q", allow)
+	}
+	for _, pub := range pubs {
+		if pub.Address() == addr {
+			return s.AllowDomains(pub, strings.Split(domains, ",")...)
+		}
+	}
+	return fmt.Errorf("no unlocked key for %s", addr)
+}
@@ -290,6 +290,45 @@ func TestContractInitAndCompile(t *testing.T) {
 		e.CheckNextLine(t, "^[0-9a-hA-H]+$")
 	})
 
+	t.Run("custom standard", func(t *testing.T) {
+		cfg, err := os.ReadFile(cfgPath)
+		require.NoError(t, err)
+		cfg = bytes.Replace(cfg, []byte("supportedstandards: []"), []byte("supportedstandards: [NEP-CLI-Test]"), 1)
+		stdCfgPath := filepath.Join(tmpDir, "standard.yml")
+		require.NoError(t, os.WriteFile(stdCfgPath, cfg, os.ModePerm))
+		stdCmd := append(cmd[:len(cmd)-2:len(cmd)-2], "--config", stdCfgPath)
+
+		// Unknown standards are not checked.
+		e.Run(t, stdCmd...)
+
+		specPath := filepath.Join(tmpDir, "spec.yml")
+		e.RunWithError(t, append(stdCmd, "--standard", specPath)...)
+
+		require.NoError(t, os.WriteFile(specPath, []byte(`name: NEP-CLI-Test
+methods:
+  - name: runtimeNotify
+    parameters:
+      - name: args
+        type: Array
+    returntype: Void
+  - name: missing
+    returntype: Integer
+`), os.ModePerm))
+		e.RunWithError(t, append(stdCmd, "--standard", specPath)...)
+		// Standards are only checked for the command they're given to.
+		e.Run(t, stdCmd...)
+
+		require.NoError(t, os.WriteFile(specPath, []byte(`name: NEP-CLI-Test
+methods:
+  - name: runtimeNotify
+    parameters:
+      - name: args
+        type: Array
+    returntype: Void
+`), os.ModePerm))
+		e.Run(t, append(stdCmd, "--standard", specPath)...)
+	})
+
 	t.Run("autocomplete outputs", func(t *testing.T) {
 		cfg, err := os.ReadFile(cfgPath)
 		require.NoError(t, err)
@@ -713,6 +752,43 @@ func TestComlileAndInvokeFunction(t *testing.T) {
 	}
 	checkGetValueOut("on create|sub create")
 
+	t.Run("statediff", func(t *testing.T) {
+		neoHash := e.Chain.GoverningTokenHash()
+		to := random.Uint160()
+		cmd := []string{"neo-go", "contract", "testinvokefunction",
+			"--rpc-endpoint", "http://" + e.RPC.Addresses()[0], "--statediff",
+			neoHash.StringLE(), "transfer",
+			testcli.ValidatorAddr, "hash160:" + to.StringLE(), "1", "0",
+			"--", testcli.ValidatorAddr}
+		t.Run("historic", func(t *testing.T) {
+			e.RunWithError(t, append(cmd[:3:3], append([]string{"--historic", "1"}, cmd[3:]...)...)...)
+		})
+		e.Run(t, cmd...)
+		res := new(result.Invoke)
+		require.NoError(t, json.Unmarshal(e.Out.Bytes(), res))
+		require.Equal(t, vmstate.Halt.String(), res.State, res.FaultException)
+		require.NotNil(t, res.StateDiff)
+		// NEO for both accounts and GAS distributed to the sender.
+		require.Equal(t, 3, len(res.StateDiff.Balances))
+		for _, b := range res.StateDiff.Balances {
+			switch {
+			case b.Account == to:
+				require.Equal(t, neoHash, b.Asset)
+				require.Equal(t, "0", b.OldAmount)
+				require.Equal(t, "1", b.NewAmount)
+			case b.Asset == neoHash:
+				require.Equal(t, testcli.ValidatorHash, b.Account)
+			default:
+				require.Equal(t, e.Chain.UtilityTokenHash(), b.Asset)
+				require.Equal(t, testcli.ValidatorHash, b.Account)
+			}
+		}
+		require.Equal(t, 0, len(res.StateDiff.Contracts))
+		// The chain is not changed.
+		b, _ := e.Chain.GetGoverningTokenBalance(to)
+		require.Equal(t, 0, b.Sign())
+	})
+
 	// deploy verification contract
 	hVerify := deployVerifyContract(t, e)
 
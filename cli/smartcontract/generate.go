@@ -35,32 +35,59 @@ var generatorFlags = []cli.Flag{
 }
 
 var generateWrapperCmd = cli.Command{
-	Name:        "generate-wrapper",
-	Usage:       "generate wrapper to use in other contracts",
-	UsageText:   "neo-go contract generate-wrapper --manifest <file.json> --out <file.go> --hash <hash> [--config <config>]",
-	Description: ``,
-	Action:      contractGenerateWrapper,
-	Flags:       generatorFlags,
+	Name:      "generate-wrapper",
+	Usage:     "generate wrapper to use in other contracts",
+	UsageText: "neo-go contract generate-wrapper --manifest <file.json> --out <file.go> [--hash <hash>] [--config <config>]",
+	Description: `Generates Go wrapper that can be used by other contracts to call methods
+   of the contract described by the given manifest. If the contract hash is
+   provided, package-level functions using method tokens are generated,
+   otherwise the wrapper contains Contract type that can be used for any
+   deployed instance of this contract (like NEP-17 tokens).
+`,
+	Action: contractGenerateWrapper,
+	Flags:  generatorFlags,
 }
 
 var generateRPCWrapperCmd = cli.Command{
 	Name:      "generate-rpcwrapper",
 	Usage:     "generate RPC wrapper to use for data reads",
-	UsageText: "neo-go contract generate-rpcwrapper --manifest <file.json> --out <file.go> --hash <hash> [--config <config>]",
-	Action:    contractGenerateRPCWrapper,
-	Flags:     generatorFlags,
+	UsageText: "neo-go contract generate-rpcwrapper --manifest <file.json> --out <file> [--hash <hash>] [--config <config>] [--lang <go|ts|py>]",
+	Description: `Generates RPC wrapper for the contract described by the given manifest.
+   By default Go code is generated, --lang flag allows to generate TypeScript
+   ("ts" or "typescript") or Python ("py" or "python") module instead, the
+   same configuration file (with extended types) is used for all languages.
+`,
+	Action: contractGenerateRPCWrapper,
+	Flags: append([]cli.Flag{
+		cli.StringFlag{
+			Name:  "lang, l",
+			Value: "go",
+			Usage: "Language of the generated wrapper (go, ts/typescript or py/python)",
+		},
+	}, generatorFlags...),
 }
 
 func contractGenerateWrapper(ctx *cli.Context) error {
-	return contractGenerateSomething(ctx, binding.Generate, false)
+	return contractGenerateSomething(ctx, binding.Generate)
 }
 
 func contractGenerateRPCWrapper(ctx *cli.Context) error {
-	return contractGenerateSomething(ctx, rpcbinding.Generate, true)
+	var gen func(binding.Config) error
+	switch strings.ToLower(ctx.String("lang")) {
+	case "", "go":
+		gen = rpcbinding.Generate
+	case "ts", "typescript":
+		gen = rpcbinding.GenerateTypeScript
+	case "py", "python":
+		gen = rpcbinding.GeneratePython
+	default:
+		return cli.NewExitError(fmt.Errorf("unsupported wrapper language: %s", ctx.String("lang")), 1)
+	}
+	return contractGenerateSomething(ctx, gen)
 }
 
 // contractGenerateSomething reads generator parameters and calls the given callback.
-func contractGenerateSomething(ctx *cli.Context, cb func(binding.Config) error, allowEmptyHash bool) error {
+func contractGenerateSomething(ctx *cli.Context, cb func(binding.Config) error) error {
 	if err := cmdargs.EnsureNone(ctx); err != nil {
 		return err
 	}
@@ -73,8 +100,6 @@ func contractGenerateSomething(ctx *cli.Context, cb func(binding.Config) error,
 		if err != nil {
 			return cli.NewExitError(fmt.Errorf("invalid contract hash: %w", err), 1)
 		}
-	} else if !allowEmptyHash {
-		return cli.NewExitError("contract hash must be provided via --hash flag", 1)
 	}
 	m, _, err := readManifest(ctx.String("manifest"), h)
 	if err != nil {
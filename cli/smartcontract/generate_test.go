@@ -2,16 +2,26 @@ package smartcontract
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/gorilla/websocket"
+	"github.com/nspcc-dev/neo-go/cli/smartcontract/testdata/verifyrpc"
+	verifydynamic "github.com/nspcc-dev/neo-go/cli/smartcontract/testdata/verifyrpc/dynamic"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
 	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
 	"github.com/stretchr/testify/require"
 	"github.com/urfave/cli"
 )
@@ -326,6 +336,114 @@ func (c *ContractReader) Get() (*big.Int, error) {
 `, string(data))
 }
 
+func TestGenerateDynamicHash(t *testing.T) {
+	m := manifest.NewManifest("Dynamic")
+	m.ABI.Methods = append(m.ABI.Methods,
+		manifest.Method{
+			Name: "getPoint",
+			Parameters: []manifest.Parameter{
+				manifest.NewParameter("id", smartcontract.IntegerType),
+			},
+			ReturnType: smartcontract.ArrayType,
+			Safe:       true,
+		},
+		manifest.Method{
+			Name: "setOwners",
+			Parameters: []manifest.Parameter{
+				manifest.NewParameter("owners", smartcontract.ArrayType),
+			},
+			ReturnType: smartcontract.VoidType,
+		},
+		manifest.Method{
+			Name:       "list",
+			ReturnType: smartcontract.InteropInterfaceType,
+			Safe:       true,
+		},
+	)
+
+	tmpDir := t.TempDir()
+	manifestFile := filepath.Join(tmpDir, "manifest.json")
+	configFile := filepath.Join(tmpDir, "config.yml")
+	outFile := filepath.Join(tmpDir, "out.go")
+
+	rawManifest, err := json.Marshal(m)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(manifestFile, rawManifest, os.ModePerm))
+	require.NoError(t, os.WriteFile(configFile, []byte(`namedtypes:
+  geo.Point:
+    base: Struct
+    name: geo.Point
+    fields:
+      - field: x
+        base: Integer
+      - field: owner
+        base: Hash160
+types:
+  getPoint:
+    base: Struct
+    name: geo.Point
+  setOwners.owners:
+    base: Array
+    value:
+      base: Hash160
+  list:
+    base: InteropInterface
+    interface: iterator
+`), os.ModePerm))
+
+	app := cli.NewApp()
+	app.Commands = []cli.Command{generateWrapperCmd}
+	require.NoError(t, app.Run([]string{"", "generate-wrapper",
+		"--manifest", manifestFile,
+		"--config", configFile,
+		"--out", outFile,
+	}))
+
+	data, err := os.ReadFile(outFile)
+	require.NoError(t, err)
+	require.Equal(t, `// Package dynamic contains wrappers for Dynamic contract.
+package dynamic
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/interop"
+	"github.com/nspcc-dev/neo-go/pkg/interop/contract"
+	"github.com/nspcc-dev/neo-go/pkg/interop/iterator"
+)
+
+// Contract is a wrapper for Dynamic contract deployed with the
+// specified hash.
+type Contract struct {
+	Hash interop.Hash160
+}
+
+// New creates a wrapper for Dynamic contract with the given hash.
+func New(hash interop.Hash160) Contract {
+	return Contract{Hash: hash}
+}
+
+// GetPoint invokes `+"`getPoint`"+` method of contract.
+func (c Contract) GetPoint(id int) *GeoPoint {
+	return contract.Call(c.Hash, "getPoint", contract.ReadOnly, id).(*GeoPoint)
+}
+
+// SetOwners invokes `+"`setOwners`"+` method of contract.
+func (c Contract) SetOwners(owners []interop.Hash160) {
+	contract.Call(c.Hash, "setOwners", contract.All, owners)
+}
+
+// List invokes `+"`list`"+` method of contract.
+func (c Contract) List() iterator.Iterator {
+	return contract.Call(c.Hash, "list", contract.ReadOnly).(iterator.Iterator)
+}
+
+// GeoPoint is a contract-specific geo.Point type used by its methods.
+type GeoPoint struct {
+	X int
+	Owner interop.Hash160
+}
+`, string(data))
+}
+
 // rewriteExpectedOutputs denotes whether expected output files should be rewritten
 // for TestGenerateRPCBindings and TestAssistedRPCBindings.
 const rewriteExpectedOutputs = false
@@ -335,20 +453,26 @@ func TestGenerateRPCBindings(t *testing.T) {
 	app := cli.NewApp()
 	app.Commands = []cli.Command{generateWrapperCmd, generateRPCWrapperCmd}
 
-	var checkBinding = func(manifest string, hash string, good string) {
-		t.Run(manifest, func(t *testing.T) {
+	var checkBinding = func(manifest string, hash string, good string, lang ...string) {
+		t.Run(manifest+" "+strings.Join(lang, ""), func(t *testing.T) {
 			outFile := filepath.Join(tmpDir, "out.go")
-			require.NoError(t, app.Run([]string{"", "generate-rpcwrapper",
+			cmd := []string{"", "generate-rpcwrapper",
 				"--manifest", manifest,
 				"--out", outFile,
-				"--hash", hash,
-			}))
+			}
+			if hash != "" {
+				cmd = append(cmd, "--hash", hash)
+			}
+			if len(lang) != 0 {
+				cmd = append(cmd, "--lang", lang[0])
+			}
+			require.NoError(t, app.Run(cmd))
 
 			data, err := os.ReadFile(outFile)
 			require.NoError(t, err)
 			data = bytes.ReplaceAll(data, []byte("\r"), []byte{}) // Windows.
 			if rewriteExpectedOutputs {
-				require.NoError(t, os.WriteFile(good, data, os.ModePerm))
+				require.NoError(t, os.WriteFile(good, data, 0644))
 			} else {
 				expected, err := os.ReadFile(good)
 				require.NoError(t, err)
@@ -370,9 +494,30 @@ func TestGenerateRPCBindings(t *testing.T) {
 	checkBinding(filepath.Join("testdata", "verifyrpc", "verify.manifest.json"),
 		"0x00112233445566778899aabbccddeeff00112233",
 		filepath.Join("testdata", "verifyrpc", "verify.go"))
+	checkBinding(filepath.Join("testdata", "verifyrpc", "verify.manifest.json"),
+		"",
+		filepath.Join("testdata", "verifyrpc", "dynamic", "verify.go"))
 	checkBinding(filepath.Join("testdata", "nonepiter", "iter.manifest.json"),
 		"0x00112233445566778899aabbccddeeff00112233",
 		filepath.Join("testdata", "nonepiter", "iter.go"))
+	for _, lang := range []string{"ts", "py"} {
+		checkBinding(filepath.Join("testdata", "nex", "nex.manifest.json"),
+			"0xa2a67f09e8cf22c6bfd5cea24adc0f4bf0a11aa8",
+			filepath.Join("testdata", "nex", "nex."+lang), lang)
+		checkBinding(filepath.Join("testdata", "nameservice", "nns.manifest.json"),
+			"0x50ac1c37690cc2cfc594472833cf57505d5f46de",
+			filepath.Join("testdata", "nameservice", "nns."+lang), lang)
+	}
+
+	t.Run("unknown language", func(t *testing.T) {
+		app.ExitErrHandler = func(*cli.Context, error) {}
+		err := app.Run([]string{"", "generate-rpcwrapper",
+			"--manifest", filepath.Join("testdata", "nex", "nex.manifest.json"),
+			"--out", filepath.Join(tmpDir, "out.rs"),
+			"--lang", "rust",
+		})
+		require.ErrorContains(t, err, "unsupported wrapper language")
+	})
 
 	require.False(t, rewriteExpectedOutputs)
 }
@@ -426,7 +571,7 @@ func TestAssistedRPCBindings(t *testing.T) {
 			require.NoError(t, err)
 			data = bytes.ReplaceAll(data, []byte("\r"), []byte{}) // Windows.
 			if rewriteExpectedOutputs {
-				require.NoError(t, os.WriteFile(expectedFile, data, os.ModePerm))
+				require.NoError(t, os.WriteFile(expectedFile, data, 0644))
 			} else {
 				expected, err := os.ReadFile(expectedFile)
 				require.NoError(t, err)
@@ -562,3 +707,120 @@ func TestCompile_GuessEventTypes(t *testing.T) {
 		check(t, filepath.Join("testdata", "invalid9"), "configured declared named type intersects with the contract's one: `invalid9.NamedStruct`")
 	})
 }
+
+func TestRPCBindingEvents(t *testing.T) {
+	var (
+		other = util.Uint160{1, 2, 3}
+		good  = stackitem.NewArray([]stackitem.Item{stackitem.NewArray([]stackitem.Item{stackitem.Make("hello")})})
+		bad   = stackitem.NewArray([]stackitem.Item{})
+	)
+	t.Run("parse", func(t *testing.T) {
+		aer := &state.AppExecResult{
+			Execution: state.Execution{
+				Events: []state.NotificationEvent{
+					{ScriptHash: other, Name: "Hello world!", Item: bad},
+					{ScriptHash: verify.Hash, Name: "Hello world!", Item: good},
+					{ScriptHash: verify.Hash, Name: "Goodbye world!", Item: bad},
+				},
+			},
+		}
+		res, err := verify.ParseHelloWorldEvents(aer)
+		require.NoError(t, err)
+		require.Equal(t, []*verify.HelloWorldEvent{{Args: []any{[]byte("hello")}}}, res)
+
+		dres, err := verifydynamic.ParseHelloWorldEvents(aer, verify.Hash)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(dres))
+
+		_, err = verifydynamic.ParseHelloWorldEvents(aer, other)
+		require.Error(t, err)
+
+		dres, err = verifydynamic.ParseHelloWorldEvents(aer, util.Uint160{})
+		require.NoError(t, err)
+		require.Equal(t, 0, len(dres))
+
+		_, err = verify.ParseHelloWorldEvents(nil)
+		require.Error(t, err)
+	})
+	ntf := func(it stackitem.Item) string {
+		data, err := json.Marshal(&state.ContainedNotificationEvent{
+			NotificationEvent: state.NotificationEvent{ScriptHash: other, Name: "Hello world!", Item: it.(*stackitem.Array)},
+		})
+		require.NoError(t, err)
+		return `{"jsonrpc":"2.0","method":"notification_from_execution","params":[` + string(data) + `]}`
+	}
+	// subscribe starts a WS server sending the given messages after the
+	// subscription and handling the unsubscription, it returns the client
+	// subscribed with a channel for events and the filter received by the
+	// server.
+	subscribe := func(t *testing.T, msgs ...string) (func() error, chan *verifydynamic.HelloWorldEvent, *neorpc.NotificationFilter) {
+		var (
+			filter     = make(chan *neorpc.NotificationFilter, 1)
+			subscribed = make(chan struct{})
+		)
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			var upgrader = websocket.Upgrader{}
+			ws, err := upgrader.Upgrade(w, req, nil)
+			if err != nil {
+				return
+			}
+			defer ws.Close()
+			var r struct {
+				ID     json.RawMessage   `json:"id"`
+				Method string            `json:"method"`
+				Params []json.RawMessage `json:"params"`
+			}
+			if ws.ReadJSON(&r) != nil || len(r.Params) != 2 {
+				return
+			}
+			var flt = new(neorpc.NotificationFilter)
+			if json.Unmarshal(r.Params[1], flt) == nil {
+				filter <- flt
+			}
+			if ws.WriteMessage(websocket.TextMessage, []byte(`{"jsonrpc":"2.0","id":`+string(r.ID)+`,"result":"0"}`)) != nil {
+				return
+			}
+			<-subscribed // Events sent before subscription is registered are dropped.
+			for _, msg := range msgs {
+				if ws.WriteMessage(websocket.TextMessage, []byte(msg)) != nil {
+					return
+				}
+			}
+			if ws.ReadJSON(&r) != nil || r.Method != "unsubscribe" {
+				return
+			}
+			_ = ws.WriteMessage(websocket.TextMessage, []byte(`{"jsonrpc":"2.0","id":`+string(r.ID)+`,"result":true}`))
+			_ = ws.ReadJSON(&r) // Wait for the client to disconnect.
+		}))
+		t.Cleanup(srv.Close)
+
+		wsc, err := rpcclient.NewWS(context.Background(), "ws"+strings.TrimPrefix(srv.URL, "http")+"/ws", rpcclient.WSOptions{})
+		require.NoError(t, err)
+		t.Cleanup(wsc.Close)
+
+		ch := make(chan *verifydynamic.HelloWorldEvent)
+		unsubscribe, err := verifydynamic.ReceiveHelloWorldEvents(wsc, other, ch)
+		require.NoError(t, err)
+		close(subscribed)
+		return unsubscribe, ch, <-filter
+	}
+	t.Run("receive", func(t *testing.T) {
+		_, ch, flt := subscribe(t, ntf(bad), ntf(good), `{"jsonrpc":"2.0","method":"event_missed","params":[]}`)
+		require.Equal(t, other, *flt.Contract)
+		require.Equal(t, "Hello world!", *flt.Name)
+
+		var events []*verifydynamic.HelloWorldEvent
+		for e := range ch { // Closed after event_missed.
+			events = append(events, e)
+		}
+		require.Equal(t, []*verifydynamic.HelloWorldEvent{{Args: []any{[]byte("hello")}}}, events)
+	})
+	t.Run("unsubscribe", func(t *testing.T) {
+		unsubscribe, ch, _ := subscribe(t, ntf(good))
+		require.Equal(t, &verifydynamic.HelloWorldEvent{Args: []any{[]byte("hello")}}, <-ch)
+		require.NoError(t, unsubscribe())
+		_, ok := <-ch
+		require.False(t, ok)
+		require.Error(t, unsubscribe())
+	})
+}
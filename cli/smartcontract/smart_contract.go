@@ -26,6 +26,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/binding"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest/standard"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/nef"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm"
@@ -97,7 +98,13 @@ func NewCommands() []cli.Command {
 		options.Historic,
 	}
 	testInvokeScriptFlags = append(testInvokeScriptFlags, options.RPC...)
-	testInvokeFunctionFlags := []cli.Flag{options.Historic}
+	testInvokeFunctionFlags := []cli.Flag{
+		options.Historic,
+		cli.BoolFlag{
+			Name:  "statediff",
+			Usage: "Return the state diff (storage, balance and contract changes) of the invocation",
+		},
+	}
 	testInvokeFunctionFlags = append(testInvokeFunctionFlags, options.RPC...)
 	invokeFunctionFlags := []cli.Flag{
 		walletFlag,
@@ -126,14 +133,17 @@ func NewCommands() []cli.Command {
 			{
 				Name:      "compile",
 				Usage:     "compile a smart contract to a .nef file",
-				UsageText: "neo-go contract compile -i path [-o nef] [-v] [-d] [-m manifest] [-c yaml] [--bindings file] [--no-standards] [--no-events] [--no-permissions] [--guess-eventtypes]",
+				UsageText: "neo-go contract compile -i path [-o nef] [-v] [-d] [-m manifest] [-c yaml] [--bindings file] [--standard spec.yml] [--no-standards] [--no-events] [--no-permissions] [--guess-eventtypes]",
 				Description: `Compiles given smart contract to a .nef file and emits other associated
    information (manifest, bindings configuration, debug information files) if
    asked to. If none of --out, --manifest, --config, --bindings flags are specified,
    then the output filenames for these flags will be guessed using the contract
    name or path provided via --in option by trimming/adding corresponding suffixes
    to the common part of the path. In the latter case the configuration filepath
-   will be guessed from the --in option using the same rule."`,
+   will be guessed from the --in option using the same rule. Custom standards
+   specifications can be provided in YAML format via --standard flag, they're
+   used to check compliance with SupportedStandards from the configuration
+   along with the built-in ones."`,
 				Action: contractCompile,
 				Flags: []cli.Flag{
 					cli.StringFlag{
@@ -160,6 +170,10 @@ func NewCommands() []cli.Command {
 						Name:  "config, c",
 						Usage: "Configuration input file (*.yml)",
 					},
+					cli.StringSliceFlag{
+						Name:  "standard",
+						Usage: "Custom standard specification file (*.yml), can be specified multiple times",
+					},
 					cli.BoolFlag{
 						Name:  "no-standards",
 						Usage: "do not check compliance with supported standards",
@@ -212,7 +226,7 @@ func NewCommands() []cli.Command {
 			{
 				Name:      "testinvokefunction",
 				Usage:     "invoke deployed contract on the blockchain (test mode)",
-				UsageText: "neo-go contract testinvokefunction -r endpoint [--historic index/hash] scripthash [method] [arguments...] [--] [signers...]",
+				UsageText: "neo-go contract testinvokefunction -r endpoint [--historic index/hash] [--statediff] scripthash [method] [arguments...] [--] [signers...]",
 				Description: `Executes given (as a script hash) deployed script with the given method,
    arguments and signers (sender is not included by default). If no method is given
    "" is passed to the script, if no arguments are given, an empty array is 
@@ -220,7 +234,9 @@ func NewCommands() []cli.Command {
    the first one of them is treated as a sender. All of the given arguments are 
    encapsulated into array before invoking the script. The script thus should 
    follow the regular convention of smart contract arguments (method string and 
-   an array of other arguments).
+   an array of other arguments). With --statediff the result also contains
+   storage, NEP-17/NEP-11 balance and contract changes made by the invocation,
+   it can't be used with --historic.
 
 ` + cmdargs.ParamsParsingDoc + `
 
@@ -444,6 +460,16 @@ func contractCompile(ctx *cli.Context) error {
 		bindings = root + ".bindings.yml"
 	}
 
+	var standards *standard.Registry
+	if specs := ctx.StringSlice("standard"); len(specs) != 0 {
+		standards = standard.NewRegistry()
+		for _, spec := range specs {
+			if err := standards.RegisterSpecFile(spec); err != nil {
+				return cli.NewExitError(fmt.Errorf("can't register standard from %s: %w", spec, err), 1)
+			}
+		}
+	}
+
 	o := &compiler.Options{
 		Outfile: out,
 
@@ -452,6 +478,7 @@ func contractCompile(ctx *cli.Context) error {
 		BindingsFile: bindings,
 
 		NoStandardCheck:    ctx.Bool("no-standards"),
+		Standards:          standards,
 		NoEventsCheck:      ctx.Bool("no-events"),
 		NoPermissionsCheck: ctx.Bool("no-permissions"),
 
@@ -626,7 +653,14 @@ func invokeWithArgs(ctx *cli.Context, acc *wallet.Account, wall *wallet.Wallet,
 		}
 	}
 	out := ctx.String("out")
-	resp, err = inv.Call(script, operation, params...)
+	if ctx.Bool("statediff") {
+		if ctx.IsSet(options.Historic.Name) {
+			return cli.NewExitError(errors.New("--statediff can't be used with --historic"), 1)
+		}
+		resp, err = inv.CallWithStateDiff(script, operation, params...)
+	} else {
+		resp, err = inv.Call(script, operation, params...)
+	}
 	if err != nil {
 		return cli.NewExitError(err, 1)
 	}
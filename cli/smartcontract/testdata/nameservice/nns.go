@@ -5,8 +5,11 @@ import (
 	"errors"
 	"fmt"
 	"github.com/google/uuid"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc"
 	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient"
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient/nep11"
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient/unwrap"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
@@ -367,6 +370,81 @@ func SetAdminEventsFromApplicationLog(log *result.ApplicationLog) ([]*SetAdminEv
 	return res, nil
 }
 
+// ParseSetAdminEvents retrieves a set of all events with "SetAdmin"
+// name emitted by the contract from the provided [state.AppExecResult]. Events
+// with the same name emitted by other contracts are ignored.
+func ParseSetAdminEvents(aer *state.AppExecResult) ([]*SetAdminEvent, error) {
+	if aer == nil {
+		return nil, errors.New("nil execution result")
+	}
+
+	var res []*SetAdminEvent
+	for i, e := range aer.Events {
+		if e.Name != "SetAdmin" || !e.ScriptHash.Equals(Hash) {
+			continue
+		}
+		event := new(SetAdminEvent)
+		err := event.FromStackItem(e.Item)
+		if err != nil {
+			return nil, fmt.Errorf("failed to deserialize SetAdminEvent from stackitem (event #%d): %w", i, err)
+		}
+		res = append(res, event)
+	}
+
+	return res, nil
+}
+
+// ReceiveSetAdminEvents subscribes to "SetAdmin" events emitted by the
+// contract via the given [rpcclient.WSClient] and sends them to the provided
+// channel. Events that can't be deserialized are skipped. The channel must be
+// read from continuously to avoid blocking the client (including the time the
+// unsubscription is performed), it's closed when the subscription ends (see
+// [rpcclient.WSClient] for details) or when the function returned is called to
+// unsubscribe.
+func ReceiveSetAdminEvents(ws *rpcclient.WSClient, ch chan<- *SetAdminEvent) (func() error, error) {
+	var (
+		hash = Hash
+		name = "SetAdmin"
+		rcvr = make(chan *state.ContainedNotificationEvent)
+		done = make(chan struct{})
+	)
+	id, err := ws.ReceiveExecutionNotifications(&neorpc.NotificationFilter{Contract: &hash, Name: &name}, rcvr)
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		defer close(ch)
+		for {
+			select {
+			case <-done:
+				return
+			case e, ok := <-rcvr:
+				if !ok {
+					return
+				}
+				event := new(SetAdminEvent)
+				if event.FromStackItem(e.Item) != nil {
+					continue
+				}
+				select {
+				case <-done:
+					return
+				case ch <- event:
+				}
+			}
+		}
+	}()
+	return func() error {
+		// The client doesn't send anything to rcvr after successful
+		// unsubscription, it can't succeed twice.
+		err := ws.Unsubscribe(id)
+		if err == nil {
+			close(done)
+		}
+		return err
+	}, nil
+}
+
 // FromStackItem converts provided [stackitem.Array] to SetAdminEvent or
 // returns an error if it's not possible to do to so.
 func (e *SetAdminEvent) FromStackItem(item *stackitem.Array) error {
@@ -460,6 +538,81 @@ func RenewEventsFromApplicationLog(log *result.ApplicationLog) ([]*RenewEvent, e
 	return res, nil
 }
 
+// ParseRenewEvents retrieves a set of all events with "Renew"
+// name emitted by the contract from the provided [state.AppExecResult]. Events
+// with the same name emitted by other contracts are ignored.
+func ParseRenewEvents(aer *state.AppExecResult) ([]*RenewEvent, error) {
+	if aer == nil {
+		return nil, errors.New("nil execution result")
+	}
+
+	var res []*RenewEvent
+	for i, e := range aer.Events {
+		if e.Name != "Renew" || !e.ScriptHash.Equals(Hash) {
+			continue
+		}
+		event := new(RenewEvent)
+		err := event.FromStackItem(e.Item)
+		if err != nil {
+			return nil, fmt.Errorf("failed to deserialize RenewEvent from stackitem (event #%d): %w", i, err)
+		}
+		res = append(res, event)
+	}
+
+	return res, nil
+}
+
+// ReceiveRenewEvents subscribes to "Renew" events emitted by the
+// contract via the given [rpcclient.WSClient] and sends them to the provided
+// channel. Events that can't be deserialized are skipped. The channel must be
+// read from continuously to avoid blocking the client (including the time the
+// unsubscription is performed), it's closed when the subscription ends (see
+// [rpcclient.WSClient] for details) or when the function returned is called to
+// unsubscribe.
+func ReceiveRenewEvents(ws *rpcclient.WSClient, ch chan<- *RenewEvent) (func() error, error) {
+	var (
+		hash = Hash
+		name = "Renew"
+		rcvr = make(chan *state.ContainedNotificationEvent)
+		done = make(chan struct{})
+	)
+	id, err := ws.ReceiveExecutionNotifications(&neorpc.NotificationFilter{Contract: &hash, Name: &name}, rcvr)
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		defer close(ch)
+		for {
+			select {
+			case <-done:
+				return
+			case e, ok := <-rcvr:
+				if !ok {
+					return
+				}
+				event := new(RenewEvent)
+				if event.FromStackItem(e.Item) != nil {
+					continue
+				}
+				select {
+				case <-done:
+					return
+				case ch <- event:
+				}
+			}
+		}
+	}()
+	return func() error {
+		// The client doesn't send anything to rcvr after successful
+		// unsubscription, it can't succeed twice.
+		err := ws.Unsubscribe(id)
+		if err == nil {
+			close(done)
+		}
+		return err
+	}, nil
+}
+
 // FromStackItem converts provided [stackitem.Array] to RenewEvent or
 // returns an error if it's not possible to do to so.
 func (e *RenewEvent) FromStackItem(item *stackitem.Array) error {
@@ -4,9 +4,12 @@ package nextoken
 import (
 	"errors"
 	"fmt"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc"
 	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient"
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient/nep17"
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient/unwrap"
 	"github.com/nspcc-dev/neo-go/pkg/util"
@@ -271,6 +274,81 @@ func OnMintEventsFromApplicationLog(log *result.ApplicationLog) ([]*OnMintEvent,
 	return res, nil
 }
 
+// ParseOnMintEvents retrieves a set of all events with "OnMint"
+// name emitted by the contract from the provided [state.AppExecResult]. Events
+// with the same name emitted by other contracts are ignored.
+func ParseOnMintEvents(aer *state.AppExecResult) ([]*OnMintEvent, error) {
+	if aer == nil {
+		return nil, errors.New("nil execution result")
+	}
+
+	var res []*OnMintEvent
+	for i, e := range aer.Events {
+		if e.Name != "OnMint" || !e.ScriptHash.Equals(Hash) {
+			continue
+		}
+		event := new(OnMintEvent)
+		err := event.FromStackItem(e.Item)
+		if err != nil {
+			return nil, fmt.Errorf("failed to deserialize OnMintEvent from stackitem (event #%d): %w", i, err)
+		}
+		res = append(res, event)
+	}
+
+	return res, nil
+}
+
+// ReceiveOnMintEvents subscribes to "OnMint" events emitted by the
+// contract via the given [rpcclient.WSClient] and sends them to the provided
+// channel. Events that can't be deserialized are skipped. The channel must be
+// read from continuously to avoid blocking the client (including the time the
+// unsubscription is performed), it's closed when the subscription ends (see
+// [rpcclient.WSClient] for details) or when the function returned is called to
+// unsubscribe.
+func ReceiveOnMintEvents(ws *rpcclient.WSClient, ch chan<- *OnMintEvent) (func() error, error) {
+	var (
+		hash = Hash
+		name = "OnMint"
+		rcvr = make(chan *state.ContainedNotificationEvent)
+		done = make(chan struct{})
+	)
+	id, err := ws.ReceiveExecutionNotifications(&neorpc.NotificationFilter{Contract: &hash, Name: &name}, rcvr)
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		defer close(ch)
+		for {
+			select {
+			case <-done:
+				return
+			case e, ok := <-rcvr:
+				if !ok {
+					return
+				}
+				event := new(OnMintEvent)
+				if event.FromStackItem(e.Item) != nil {
+					continue
+				}
+				select {
+				case <-done:
+					return
+				case ch <- event:
+				}
+			}
+		}
+	}()
+	return func() error {
+		// The client doesn't send anything to rcvr after successful
+		// unsubscription, it can't succeed twice.
+		err := ws.Unsubscribe(id)
+		if err == nil {
+			close(done)
+		}
+		return err
+	}, nil
+}
+
 // FromStackItem converts provided [stackitem.Array] to OnMintEvent or
 // returns an error if it's not possible to do to so.
 func (e *OnMintEvent) FromStackItem(item *stackitem.Array) error {
@@ -4,8 +4,11 @@ package verify
 import (
 	"errors"
 	"fmt"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc"
 	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
@@ -104,6 +107,81 @@ func HelloWorldEventsFromApplicationLog(log *result.ApplicationLog) ([]*HelloWor
 	return res, nil
 }
 
+// ParseHelloWorldEvents retrieves a set of all events with "Hello world!"
+// name emitted by the contract from the provided [state.AppExecResult]. Events
+// with the same name emitted by other contracts are ignored.
+func ParseHelloWorldEvents(aer *state.AppExecResult) ([]*HelloWorldEvent, error) {
+	if aer == nil {
+		return nil, errors.New("nil execution result")
+	}
+
+	var res []*HelloWorldEvent
+	for i, e := range aer.Events {
+		if e.Name != "Hello world!" || !e.ScriptHash.Equals(Hash) {
+			continue
+		}
+		event := new(HelloWorldEvent)
+		err := event.FromStackItem(e.Item)
+		if err != nil {
+			return nil, fmt.Errorf("failed to deserialize HelloWorldEvent from stackitem (event #%d): %w", i, err)
+		}
+		res = append(res, event)
+	}
+
+	return res, nil
+}
+
+// ReceiveHelloWorldEvents subscribes to "Hello world!" events emitted by the
+// contract via the given [rpcclient.WSClient] and sends them to the provided
+// channel. Events that can't be deserialized are skipped. The channel must be
+// read from continuously to avoid blocking the client (including the time the
+// unsubscription is performed), it's closed when the subscription ends (see
+// [rpcclient.WSClient] for details) or when the function returned is called to
+// unsubscribe.
+func ReceiveHelloWorldEvents(ws *rpcclient.WSClient, ch chan<- *HelloWorldEvent) (func() error, error) {
+	var (
+		hash = Hash
+		name = "Hello world!"
+		rcvr = make(chan *state.ContainedNotificationEvent)
+		done = make(chan struct{})
+	)
+	id, err := ws.ReceiveExecutionNotifications(&neorpc.NotificationFilter{Contract: &hash, Name: &name}, rcvr)
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		defer close(ch)
+		for {
+			select {
+			case <-done:
+				return
+			case e, ok := <-rcvr:
+				if !ok {
+					return
+				}
+				event := new(HelloWorldEvent)
+				if event.FromStackItem(e.Item) != nil {
+					continue
+				}
+				select {
+				case <-done:
+					return
+				case ch <- event:
+				}
+			}
+		}
+	}()
+	return func() error {
+		// The client doesn't send anything to rcvr after successful
+		// unsubscription, it can't succeed twice.
+		err := ws.Unsubscribe(id)
+		if err == nil {
+			close(done)
+		}
+		return err
+	}, nil
+}
+
 // FromStackItem converts provided [stackitem.Array] to HelloWorldEvent or
 // returns an error if it's not possible to do to so.
 func (e *HelloWorldEvent) FromStackItem(item *stackitem.Array) error {
@@ -1,14 +1,35 @@
 package wallet
 
 import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"text/tabwriter"
 
 	"github.com/nspcc-dev/neo-go/cli/cmdargs"
 	"github.com/nspcc-dev/neo-go/cli/flags"
+	"github.com/nspcc-dev/neo-go/cli/input"
 	"github.com/nspcc-dev/neo-go/cli/options"
 	"github.com/nspcc-dev/neo-go/cli/paramcontext"
+	"github.com/nspcc-dev/neo-go/cli/txctx"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/fixedn"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/actor"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/notary"
+	"github.com/nspcc-dev/neo-go/pkg/services/sigserver"
+	sccontext "github.com/nspcc-dev/neo-go/pkg/smartcontract/context"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
 	"github.com/urfave/cli"
 )
 
@@ -17,19 +38,51 @@ func signStoredTransaction(ctx *cli.Context) error {
 		out      = ctx.String("out")
 		rpcNode  = ctx.String(options.RPCEndpointFlag)
 		addrFlag = ctx.Generic("address").(*flags.Address)
+		server   = ctx.String("server")
+		importQR = ctx.StringSlice("import-qr")
+		exportQR = ctx.String("export-qr")
+		sigSrv   *sigserver.Client
+		pc       *sccontext.ParameterContext
 	)
 	if err := cmdargs.EnsureNone(ctx); err != nil {
 		return err
 	}
+	if ctx.String("hash") != "" && (server == "" || ctx.String("in") != "") {
+		return cli.NewExitError("--hash can only be used with --server and without --in", 1)
+	}
+	if len(importQR) != 0 && (ctx.String("in") != "" || ctx.String("hash") != "") {
+		return cli.NewExitError("--import-qr can't be used with --in or --hash", 1)
+	}
 	wall, pass, err := readWallet(ctx)
 	if err != nil {
 		return cli.NewExitError(err, 1)
 	}
 	defer wall.Close()
 
-	pc, err := paramcontext.Read(ctx.String("in"))
-	if err != nil {
-		return cli.NewExitError(err, 1)
+	if server != "" {
+		gctx, cancel := options.GetTimeoutContext(ctx)
+		defer cancel()
+		sigSrv = sigserver.NewClient(gctx, server, ctx.String("server-token"))
+	}
+	if h := ctx.String("hash"); h != "" {
+		txHash, err := util.Uint256DecodeStringLE(strings.TrimPrefix(h, "0x"))
+		if err != nil {
+			return cli.NewExitError(fmt.Errorf("invalid transaction hash: %w", err), 1)
+		}
+		pc, err = sigSrv.GetContext(txHash)
+		if err != nil {
+			return cli.NewExitError(fmt.Errorf("can't get context from the signing server: %w", err), 1)
+		}
+	} else if len(importQR) != 0 {
+		pc, err = paramcontext.ReadQR(importQR)
+		if err != nil {
+			return cli.NewExitError(err, 1)
+		}
+	} else {
+		pc, err = paramcontext.Read(ctx.String("in"))
+		if err != nil {
+			return cli.NewExitError(err, 1)
+		}
 	}
 
 	if !addrFlag.IsSet {
@@ -63,11 +116,26 @@ func signStoredTransaction(ctx *cli.Context) error {
 		if err := pc.AddSignature(ch, acc.Contract, acc.PublicKey(), sign); err != nil {
 			return cli.NewExitError(fmt.Errorf("can't add signature: %w", err), 1)
 		}
-	} else if rpcNode == "" {
+	} else if rpcNode == "" && sigSrv == nil && exportQR == "" {
 		return cli.NewExitError(fmt.Errorf("can't sign transactions with the given account and no RPC endpoing given to send anything signed"), 1)
 	}
+	if sigSrv != nil {
+		st, err := sigSrv.Submit(pc)
+		if err != nil {
+			return cli.NewExitError(fmt.Errorf("can't submit context to the signing server: %w", err), 1)
+		}
+		// Get signatures added by others.
+		pc, err = sigSrv.GetContext(st.Hash)
+		if err != nil {
+			return cli.NewExitError(fmt.Errorf("can't get context from the signing server: %w", err), 1)
+		}
+		if out == "" && rpcNode == "" && exportQR == "" {
+			printContextState(ctx.App.Writer, st)
+			return nil
+		}
+	}
 	// Not saving and not sending, print.
-	if out == "" && rpcNode == "" {
+	if out == "" && rpcNode == "" && exportQR == "" {
 		txt, err := json.MarshalIndent(pc, " ", "     ")
 		if err != nil {
 			return cli.NewExitError(fmt.Errorf("can't display resulting context: %w", err), 1)
@@ -80,6 +148,11 @@ func signStoredTransaction(ctx *cli.Context) error {
 			return cli.NewExitError(fmt.Errorf("can't save resulting context: %w", err), 1)
 		}
 	}
+	if exportQR != "" {
+		if err := exportContextQR(ctx, pc, exportQR); err != nil {
+			return cli.NewExitError(fmt.Errorf("can't export resulting context: %w", err), 1)
+		}
+	}
 	if rpcNode != "" {
 		tx, err = pc.GetCompleteTransaction()
 		if err != nil {
@@ -105,3 +178,392 @@ func signStoredTransaction(ctx *cli.Context) error {
 	fmt.Fprintln(ctx.App.Writer, tx.Hash().StringLE())
 	return nil
 }
+
+func newMultisigCommands() []cli.Command {
+	walletFlags := []cli.Flag{
+		walletPathFlag,
+		walletConfigFlag,
+		flags.AddressFlag{
+			Name:  "address, a",
+			Usage: "Multisignature account address to sign with",
+		},
+		flags.AddressFlag{
+			Name:  "sender",
+			Usage: "Account paying for the notary request (uses the multisignature account key by default)",
+		},
+		cli.BoolFlag{
+			Name:  "await",
+			Usage: "Wait for all signatures and the resulting transaction (requires WebSocket RPC)",
+		},
+		txctx.ForceFlag,
+	}
+	walletFlags = append(walletFlags, options.RPC...)
+	proposeFlags := append([]cli.Flag{
+		cli.StringFlag{
+			Name:  "scope",
+			Value: transaction.CalledByEntry.String(),
+			Usage: "Witness scope of the multisignature account signer",
+		},
+	}, walletFlags...)
+	statusFlags := append([]cli.Flag{
+		cli.BoolFlag{
+			Name:  "verbose, v",
+			Usage: "Output transaction script",
+		},
+	}, options.RPC...)
+	return []cli.Command{
+		{
+			Name:      "propose",
+			Usage:     "propose a transaction signed by the multisignature account",
+			UsageText: "propose -w wallet [--wallet-config path] -r endpoint --address <address> [--sender <address>] [--scope scope] [--await] [--force] <contract> <method> [<arg>...]",
+			Description: `Creates a transaction invoking the given method of the given contract with
+   the given arguments (see 'contract invokefunction' for the format) signed by
+   the given M out of N multisignature account, signs it with the account key
+   and sends it as a P2P notary request. The main transaction hash printed is
+   to be passed to other key owners for approval ('wallet multisig approve'),
+   once enough of them approve it the notary service completes and sends the
+   transaction. The request sender (that pays for the fallback transaction
+   and must have a GAS deposit in the Notary contract) is a simple signature
+   account with the multisignature account key by default, it can be changed
+   with --sender. With --await the command waits for approvals and the
+   resulting transaction (a WebSocket connection is used for that, so the RPC
+   node must have it enabled).
+`,
+			Action: proposeMultisig,
+			Flags:  proposeFlags,
+		},
+		{
+			Name:      "approve",
+			Usage:     "approve a transaction proposed for the multisignature account",
+			UsageText: "approve -w wallet [--wallet-config path] -r endpoint --address <address> [--sender <address>] [--await] [--force] <hash>",
+			Description: `Fetches the proposal with the given main transaction hash from the notary
+   request pool of the RPC node, shows it and (after confirmation or with
+   --force) signs it with the given multisignature account key sending the
+   signature as a P2P notary request. See 'wallet multisig propose' for
+   --sender and --await details.
+`,
+			Action: approveMultisig,
+			Flags:  walletFlags,
+		},
+		{
+			Name:      "status",
+			Usage:     "show the proposal state",
+			UsageText: "status -r endpoint [-v] <hash>",
+			Description: `Shows keys that have and haven't yet approved the proposal with the given
+   main transaction hash. Only requests available in the notary request pool
+   of the RPC node are taken into account.
+`,
+			Action: multisigStatus,
+			Flags:  statusFlags,
+		},
+	}
+}
+
+func proposeMultisig(ctx *cli.Context) error {
+	args := ctx.Args()
+	if !args.Present() {
+		return cli.NewExitError("no contract hash specified", 1)
+	}
+	contract, err := flags.ParseAddress(args[0])
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("incorrect contract hash: %w", err), 1)
+	}
+	if len(args) < 2 {
+		return cli.NewExitError("no method specified", 1)
+	}
+	var params []any
+	if len(args) > 2 {
+		n, scParams, err := cmdargs.ParseParams(args[2:], true)
+		if err != nil {
+			return cli.NewExitError(err, 1)
+		}
+		if n != len(args[2:]) {
+			return cli.NewExitError("signers are not supported, use --address and --scope", 1)
+		}
+		params = make([]any, len(scParams))
+		for i := range scParams {
+			params[i] = scParams[i]
+		}
+	}
+	scope, err := transaction.ScopesFromString(ctx.String("scope"))
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("invalid scope: %w", err), 1)
+	}
+
+	wall, pass, err := readWallet(ctx)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	defer wall.Close()
+
+	gctx, cancel := options.GetTimeoutContext(ctx)
+	defer cancel()
+
+	c, m, err := getMultisigActor(gctx, ctx, wall, pass, scope)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	defer c.Close()
+
+	tx, err := m.MakeUnsignedCall(contract, args[1], nil, params...)
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("failed to create transaction: %w", err), 1)
+	}
+	if !ctx.Bool("force") {
+		if err := input.ConfirmTx(ctx.App.Writer, tx); err != nil {
+			return cli.NewExitError(err, 1)
+		}
+	}
+	// Main transaction must live long enough for approvals to be collected,
+	// the notary service requires it to be not longer than MaxNVBDelta.
+	nvbDelta, err := notary.NewReader(&m.Invoker).GetMaxNotValidBeforeDelta()
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("can't get MaxNVBDelta: %w", err), 1)
+	}
+	tx.ValidUntilBlock, err = m.CalculateValidUntilBlock()
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	tx.ValidUntilBlock += nvbDelta
+	err = m.Sign(tx)
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("failed to sign transaction: %w", err), 1)
+	}
+	p, err := m.Propose(tx, nil)
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("failed to send notary request: %w", err), 1)
+	}
+	fmt.Fprintln(ctx.App.Writer, tx.Hash().StringLE())
+	if ctx.Bool("await") {
+		return awaitMultisig(gctx, ctx, c, m, p, p.Fallbacks[0])
+	}
+	return nil
+}
+
+func approveMultisig(ctx *cli.Context) error {
+	mainHash, err := getProposalHash(ctx)
+	if err != nil {
+		return err
+	}
+
+	wall, pass, err := readWallet(ctx)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	defer wall.Close()
+
+	gctx, cancel := options.GetTimeoutContext(ctx)
+	defer cancel()
+
+	c, m, err := getMultisigActor(gctx, ctx, wall, pass, transaction.None)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	defer c.Close()
+
+	p, err := m.GetProposal(mainHash)
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("failed to get proposal: %w", err), 1)
+	}
+	if !ctx.Bool("force") {
+		printProposal(ctx, p, true)
+		if err := input.ConfirmTx(ctx.App.Writer, p.Tx); err != nil {
+			return cli.NewExitError(err, 1)
+		}
+	}
+	_, fbHash, _, err := m.Approve(p)
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("failed to approve: %w", err), 1)
+	}
+	fmt.Fprintln(ctx.App.Writer, mainHash.StringLE())
+	if ctx.Bool("await") {
+		return awaitMultisig(gctx, ctx, c, m, p, fbHash)
+	}
+	return nil
+}
+
+func multisigStatus(ctx *cli.Context) error {
+	mainHash, err := getProposalHash(ctx)
+	if err != nil {
+		return err
+	}
+
+	gctx, cancel := options.GetTimeoutContext(ctx)
+	defer cancel()
+
+	c, exitErr := options.GetRPCClient(gctx, ctx)
+	if exitErr != nil {
+		return exitErr
+	}
+	defer c.Close()
+
+	net, err := c.GetNetwork()
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	p, err := notary.GetProposal(c, net, mainHash, nil)
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("failed to get proposal: %w", err), 1)
+	}
+	printProposal(ctx, p, ctx.Bool("verbose"))
+	return nil
+}
+
+func getProposalHash(ctx *cli.Context) (util.Uint256, error) {
+	args := ctx.Args()
+	if len(args) != 1 {
+		return util.Uint256{}, cli.NewExitError("main transaction hash is expected", 1)
+	}
+	h, err := util.Uint256DecodeStringLE(strings.TrimPrefix(args[0], "0x"))
+	if err != nil {
+		return util.Uint256{}, cli.NewExitError(fmt.Errorf("invalid main transaction hash: %w", err), 1)
+	}
+	return h, nil
+}
+
+// getMultisigActor creates an RPC client (a WebSocket one if --await is set)
+// and a multisig actor for the wallet accounts given via --address and
+// --sender.
+func getMultisigActor(gctx context.Context, ctx *cli.Context, wall *wallet.Wallet, pass *string, scope transaction.WitnessScope) (multisigClient, *notary.MultisigActor, error) {
+	addrFlag := ctx.Generic("address").(*flags.Address)
+	if !addrFlag.IsSet {
+		return nil, nil, errors.New("address was not provided")
+	}
+	acc, err := getDecryptedAccount(wall, addrFlag.Uint160(), pass)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !acc.CanSign() {
+		return nil, nil, errors.New("no key to sign with for the multisignature account")
+	}
+	var sender *wallet.Account
+	if senderFlag := ctx.Generic("sender").(*flags.Address); senderFlag.IsSet {
+		sender, err = getDecryptedAccount(wall, senderFlag.Uint160(), pass)
+		if err != nil {
+			return nil, nil, err
+		}
+	} else {
+		sender = wallet.NewAccountFromPrivateKey(acc.PrivateKey())
+	}
+
+	var c multisigClient
+	if ctx.Bool("await") {
+		c, err = getWSClient(gctx, ctx)
+	} else {
+		c, err = options.GetRPCClient(gctx, ctx)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	m, err := notary.NewMultisigActor(c, actor.SignerAccount{
+		Signer: transaction.Signer{
+			Account: acc.ScriptHash(),
+			Scopes:  scope,
+		},
+		Account: acc,
+	}, sender)
+	if err != nil {
+		c.Close()
+		return nil, nil, fmt.Errorf("failed to create notary actor: %w", err)
+	}
+	return c, m, nil
+}
+
+// multisigClient is an RPC client usable for multisig actor.
+type multisigClient interface {
+	notary.MultisigRPC
+	Close()
+}
+
+// getWSClient creates a WebSocket RPC client for the RPC endpoint given, http
+// and https endpoints are converted to the default WebSocket ones.
+func getWSClient(gctx context.Context, ctx *cli.Context) (*rpcclient.WSClient, error) {
+	endpoint := ctx.String(options.RPCEndpointFlag)
+	if len(endpoint) == 0 {
+		return nil, fmt.Errorf("no RPC endpoint specified, use option '--%s' or '-r'", options.RPCEndpointFlag)
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid RPC endpoint: %w", err)
+	}
+	switch u.Scheme {
+	case "http":
+		u.Scheme = "ws"
+	case "https":
+		u.Scheme = "wss"
+	}
+	if u.Path == "" || u.Path == "/" {
+		u.Path = "/ws"
+	}
+	c, err := rpcclient.NewWS(gctx, u.String(), rpcclient.WSOptions{})
+	if err != nil {
+		return nil, err
+	}
+	err = c.Init()
+	if err != nil {
+		c.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+// awaitMultisig waits for the proposal to get all signatures and then for the
+// main or fallback transaction to be accepted.
+func awaitMultisig(gctx context.Context, ctx *cli.Context, c multisigClient, m *notary.MultisigActor, p *notary.Proposal, fbHash util.Uint256) error {
+	ws, ok := c.(notary.ProposalWatcher)
+	if !ok {
+		return cli.NewExitError("WebSocket RPC client is required", 1)
+	}
+	approvals := len(p.Approvals)
+	err := notary.WaitProposal(gctx, ws, p, func(p *notary.Proposal) {
+		for _, pub := range p.Approvals[approvals:] {
+			fmt.Fprintf(ctx.App.Writer, "Approved by %s (%d of %d)\n", hex.EncodeToString(pub.Bytes()), len(p.Approvals), p.Required)
+		}
+		approvals = len(p.Approvals)
+	})
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("failed to wait for approvals: %w", err), 1)
+	}
+	res, err := m.Wait(p.Tx.Hash(), fbHash, p.Tx.ValidUntilBlock, nil)
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("failed to wait for transaction: %w", err), 1)
+	}
+	if res.Container.Equals(p.Tx.Hash()) {
+		fmt.Fprintf(ctx.App.Writer, "Transaction %s accepted: %s\n", res.Container.StringLE(), res.VMState)
+	} else {
+		fmt.Fprintf(ctx.App.Writer, "Fallback %s accepted: %s\n", res.Container.StringLE(), res.VMState)
+	}
+	return nil
+}
+
+func printProposal(ctx *cli.Context, p *notary.Proposal, verbose bool) {
+	buf := bytes.NewBuffer(nil)
+	tw := tabwriter.NewWriter(buf, 0, 4, 4, '\t', 0)
+	_, _ = tw.Write([]byte("Hash:\t" + p.Tx.Hash().StringLE() + "\n"))
+	_, _ = tw.Write([]byte("Account:\t" + address.Uint160ToString(p.Account) + "\n"))
+	_, _ = tw.Write([]byte(fmt.Sprintf("Approvals:\t%d of %d\n", len(p.Approvals), p.Required)))
+	_, _ = tw.Write([]byte(fmt.Sprintf("Completed:\t%t\n", p.Completed())))
+	_, _ = tw.Write([]byte(fmt.Sprintf("Requests:\t%d\n", len(p.Fallbacks))))
+	_, _ = tw.Write([]byte("ValidUntil:\t" + strconv.FormatUint(uint64(p.Tx.ValidUntilBlock), 10) + "\n"))
+	for _, pub := range p.Approvals {
+		_, _ = tw.Write([]byte("Approved:\t" + hex.EncodeToString(pub.Bytes()) + "\n"))
+	}
+	for _, pub := range p.Missing() {
+		_, _ = tw.Write([]byte("Missing:\t" + hex.EncodeToString(pub.Bytes()) + "\n"))
+	}
+	if verbose {
+		for _, sig := range p.Tx.Signers {
+			_, _ = tw.Write([]byte(fmt.Sprintf("Signer:\t%s (%s)",
+				address.Uint160ToString(sig.Account),
+				sig.Scopes) + "\n"))
+		}
+		_, _ = tw.Write([]byte("SystemFee:\t" + fixedn.Fixed8(p.Tx.SystemFee).String() + " GAS\n"))
+		_, _ = tw.Write([]byte("NetworkFee:\t" + fixedn.Fixed8(p.Tx.NetworkFee).String() + " GAS\n"))
+		_, _ = tw.Write([]byte("Script:\t" + base64.StdEncoding.EncodeToString(p.Tx.Script) + "\n"))
+		v := vm.New()
+		v.Load(p.Tx.Script)
+		v.PrintOps(tw)
+	}
+	_ = tw.Flush()
+	fmt.Fprint(ctx.App.Writer, buf.String())
+}
@@ -7,9 +7,11 @@ import (
 	"math/big"
 	"os"
 	"path/filepath"
+	"strconv"
 	"testing"
 
 	"github.com/nspcc-dev/neo-go/internal/testcli"
+	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
@@ -265,3 +267,114 @@ func TestSignMultisigTx(t *testing.T) {
 func deployVerifyContract(t *testing.T, e *testcli.Executor) util.Uint160 {
 	return testcli.DeployContract(t, e, "../smartcontract/testdata/verify.go", "../smartcontract/testdata/verify.yml", testcli.ValidatorWallet, testcli.ValidatorAddr, testcli.ValidatorPass)
 }
+
+func TestMultisigProposal(t *testing.T) {
+	e := testcli.NewExecutor(t, true)
+
+	privs, pubs := testcli.GenerateKeys(t, 3)
+	script, err := smartcontract.CreateMultiSigRedeemScript(2, pubs)
+	require.NoError(t, err)
+	multisigHash := hash.Hash160(script)
+	multisigAddr := address.Uint160ToString(multisigHash)
+
+	tmpDir := t.TempDir()
+	wallets := make([]string, 2)
+	for i := range wallets {
+		wallets[i] = filepath.Join(tmpDir, fmt.Sprintf("multiWallet%d.json", i))
+		e.Run(t, "neo-go", "wallet", "init", "--wallet", wallets[i])
+		e.In.WriteString("acc\rpass\rpass\r")
+		e.Run(t, "neo-go", "wallet", "import-multisig",
+			"--wallet", wallets[i],
+			"--wif", privs[i].WIF(),
+			"--min", "2",
+			hex.EncodeToString(pubs[0].Bytes()),
+			hex.EncodeToString(pubs[1].Bytes()),
+			hex.EncodeToString(pubs[2].Bytes()))
+	}
+
+	// Fund the multisig and make notary deposits for both key owners.
+	e.In.WriteString("one\r")
+	e.Run(t, "neo-go", "wallet", "nep17", "multitransfer",
+		"--rpc-endpoint", "http://"+e.RPC.Addresses()[0],
+		"--wallet", testcli.ValidatorWallet,
+		"--from", testcli.ValidatorAddr,
+		"--force",
+		"NEO:"+multisigAddr+":4",
+		"GAS:"+multisigAddr+":1")
+	e.CheckTxPersisted(t)
+	till := strconv.Itoa(int(e.Chain.BlockHeight() + 1000))
+	for i := range wallets {
+		e.In.WriteString("one\r")
+		e.Run(t, "neo-go", "wallet", "nep17", "transfer",
+			"--rpc-endpoint", "http://"+e.RPC.Addresses()[0],
+			"--wallet", testcli.ValidatorWallet,
+			"--from", testcli.ValidatorAddr,
+			"--to", address.Uint160ToString(e.Chain.GetNotaryContractScriptHash()),
+			"--token", "GAS",
+			"--amount", "1",
+			"--force",
+			"[", privs[i].Address(), till, "]")
+		e.CheckTxPersisted(t)
+	}
+
+	neoHash, err := e.Chain.GetNativeContractScriptHash(nativenames.Neo)
+	require.NoError(t, err)
+	proposeArgs := []string{"neo-go", "wallet", "multisig", "propose",
+		"--rpc-endpoint", "http://" + e.RPC.Addresses()[0],
+		"--wallet", wallets[0], "--address", multisigAddr, "--force",
+		neoHash.StringLE(), "transfer",
+		"hash160:" + multisigAddr, "hash160:" + privs[2].Address(), "int:1", "any:"}
+
+	t.Run("missing address", func(t *testing.T) {
+		e.In.WriteString("pass\r")
+		e.RunWithError(t, append(append([]string{}, proposeArgs[:6]...), proposeArgs[8:]...)...)
+	})
+	t.Run("no method", func(t *testing.T) {
+		e.In.WriteString("pass\r")
+		e.RunWithError(t, proposeArgs[:10]...)
+	})
+	t.Run("unknown proposal", func(t *testing.T) {
+		e.RunWithError(t, "neo-go", "wallet", "multisig", "status",
+			"--rpc-endpoint", "http://"+e.RPC.Addresses()[0],
+			util.Uint256{1, 2, 3}.StringLE())
+	})
+
+	e.In.WriteString("pass\r")
+	e.Run(t, proposeArgs...)
+	mainHash, err := util.Uint256DecodeStringLE(e.GetNextLine(t))
+	require.NoError(t, err)
+
+	status := func(t *testing.T, approvals int) {
+		e.Run(t, "neo-go", "wallet", "multisig", "status",
+			"--rpc-endpoint", "http://"+e.RPC.Addresses()[0],
+			mainHash.StringLE())
+		e.CheckNextLine(t, `^Hash:\s+`+mainHash.StringLE())
+		e.CheckNextLine(t, `^Account:\s+`+multisigAddr)
+		e.CheckNextLine(t, fmt.Sprintf(`^Approvals:\s+%d of 2`, approvals))
+		e.CheckNextLine(t, fmt.Sprintf(`^Completed:\s+%t`, approvals == 2))
+		e.CheckNextLine(t, fmt.Sprintf(`^Requests:\s+%d`, approvals))
+		e.CheckNextLine(t, `^ValidUntil:\s+\d+`)
+		for i := 0; i < 3; i++ {
+			e.CheckNextLine(t, `^(Approved|Missing):\s+`)
+		}
+		e.CheckEOF(t)
+	}
+	status(t, 1)
+
+	t.Run("already approved", func(t *testing.T) {
+		e.In.WriteString("pass\r")
+		e.RunWithError(t, "neo-go", "wallet", "multisig", "approve",
+			"--rpc-endpoint", "http://"+e.RPC.Addresses()[0],
+			"--wallet", wallets[0], "--address", multisigAddr, "--force",
+			mainHash.StringLE())
+	})
+
+	e.In.WriteString("pass\r")
+	e.Run(t, "neo-go", "wallet", "multisig", "approve",
+		"--rpc-endpoint", "http://"+e.RPC.Addresses()[0],
+		"--wallet", wallets[1], "--address", multisigAddr, "--force",
+		mainHash.StringLE())
+	e.CheckNextLine(t, mainHash.StringLE())
+	e.CheckEOF(t)
+	status(t, 2)
+}
@@ -0,0 +1,73 @@
+package wallet
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/cli/paramcontext"
+	sccontext "github.com/nspcc-dev/neo-go/pkg/smartcontract/context"
+	"github.com/urfave/cli"
+)
+
+// defaultQRInterval is the default interval between QR codes shown in the
+// terminal.
+const defaultQRInterval = 500 * time.Millisecond
+
+// exportContextQR saves the context as QR codes into PNG files or shows them
+// in the terminal if "-" is given.
+func exportContextQR(ctx *cli.Context, pc *sccontext.ParameterContext, filename string) error {
+	var (
+		fragmentSize = ctx.Int("qr-fragment-size")
+		frames       = ctx.Int("qr-frames")
+	)
+	if frames < 0 {
+		return fmt.Errorf("invalid number of QR frames: %d", frames)
+	}
+	if filename != "-" {
+		names, err := paramcontext.SaveQR(pc, filename, fragmentSize, frames)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(ctx.App.Writer, "QR codes saved: %s\n", strings.Join(names, ", "))
+		return nil
+	}
+	e, err := paramcontext.NewQREncoder(pc, fragmentSize)
+	if err != nil {
+		return err
+	}
+	if frames == 0 && e.Frames() == 1 {
+		frames = 1
+	}
+	if frames != 1 {
+		fmt.Fprintln(ctx.App.Writer, "Showing QR codes, press Ctrl+C to stop")
+	}
+	sctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+	var lines int
+	for i := 0; frames == 0 || i < frames; i++ {
+		code, err := e.Next()
+		if err != nil {
+			return fmt.Errorf("can't create QR code: %w", err)
+		}
+		if lines != 0 {
+			// Move the cursor up and clear the previous code.
+			fmt.Fprintf(ctx.App.Writer, "\x1b[%dA\x1b[J", lines)
+		}
+		s := code.String()
+		fmt.Fprint(ctx.App.Writer, s)
+		lines = strings.Count(s, "\n")
+		if i == frames-1 {
+			break
+		}
+		select {
+		case <-sctx.Done():
+			return nil
+		case <-time.After(ctx.Duration("qr-interval")):
+		}
+	}
+	return nil
+}
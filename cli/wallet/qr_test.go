@@ -0,0 +1,123 @@
+package wallet_test
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/cli/paramcontext"
+	"github.com/nspcc-dev/neo-go/internal/testcli"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignWithQR(t *testing.T) {
+	e := testcli.NewExecutor(t, true)
+
+	privs, pubs := testcli.GenerateKeys(t, 3)
+	script, err := smartcontract.CreateMultiSigRedeemScript(2, pubs)
+	require.NoError(t, err)
+	multisigAddr := address.Uint160ToString(hash.Hash160(script))
+
+	tmpDir := t.TempDir()
+	wallets := make([]string, 3)
+	for i := range wallets {
+		wallets[i] = filepath.Join(tmpDir, fmt.Sprintf("multiWallet%d.json", i))
+		e.Run(t, "neo-go", "wallet", "init", "--wallet", wallets[i])
+		e.In.WriteString("acc\rpass\rpass\r")
+		e.Run(t, "neo-go", "wallet", "import-multisig",
+			"--wallet", wallets[i],
+			"--wif", privs[i].WIF(),
+			"--min", "2",
+			hex.EncodeToString(pubs[0].Bytes()),
+			hex.EncodeToString(pubs[1].Bytes()),
+			hex.EncodeToString(pubs[2].Bytes()))
+	}
+
+	e.In.WriteString("one\r")
+	e.Run(t, "neo-go", "wallet", "nep17", "multitransfer",
+		"--rpc-endpoint", "http://"+e.RPC.Addresses()[0],
+		"--wallet", testcli.ValidatorWallet,
+		"--from", testcli.ValidatorAddr,
+		"--force",
+		"NEO:"+multisigAddr+":4",
+		"GAS:"+multisigAddr+":1")
+	e.CheckTxPersisted(t)
+
+	priv, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+	txPath := filepath.Join(tmpDir, "multisigtx.json")
+	e.In.WriteString("pass\r")
+	e.Run(t, "neo-go", "wallet", "nep17", "transfer",
+		"--rpc-endpoint", "http://"+e.RPC.Addresses()[0],
+		"--wallet", wallets[0], "--from", multisigAddr,
+		"--to", priv.Address(), "--token", "NEO", "--amount", "1",
+		"--out", txPath)
+	pc, err := paramcontext.Read(txPath)
+	require.NoError(t, err)
+	txHash := pc.Verifiable.Hash()
+
+	// Context with the first signature goes to the air-gapped machine.
+	inPath := filepath.Join(tmpDir, "tx.png")
+	names, err := paramcontext.SaveQR(pc, inPath, 100, 0)
+	require.NoError(t, err)
+	require.True(t, len(names) > 1)
+
+	t.Run("import with input file", func(t *testing.T) {
+		e.RunWithError(t, "neo-go", "wallet", "sign",
+			"--wallet", wallets[1], "--address", multisigAddr,
+			"--in", txPath, "--import-qr", inPath)
+	})
+	t.Run("missing image", func(t *testing.T) {
+		e.RunWithError(t, "neo-go", "wallet", "sign",
+			"--wallet", wallets[1], "--address", multisigAddr,
+			"--import-qr", filepath.Join(tmpDir, "unknown.png"))
+	})
+	t.Run("not enough images", func(t *testing.T) {
+		e.RunWithError(t, "neo-go", "wallet", "sign",
+			"--wallet", wallets[1], "--address", multisigAddr,
+			"--import-qr", names[0])
+	})
+	t.Run("terminal", func(t *testing.T) {
+		e.In.WriteString("pass\r")
+		e.Run(t, "neo-go", "wallet", "sign",
+			"--wallet", wallets[1], "--address", multisigAddr,
+			"--import-qr", filepath.Join(tmpDir, "tx-*.png"),
+			"--export-qr=-", "--qr-frames", "2", "--qr-interval", "0")
+		out := e.Out.String()
+		require.Contains(t, out, "Showing QR codes")
+		require.Contains(t, out, "\x1b[")
+		require.Contains(t, out, txHash.StringLE())
+		e.Out.Reset()
+	})
+
+	// Sign and export the context back.
+	outPath := filepath.Join(tmpDir, "signed.png")
+	e.In.WriteString("pass\r")
+	e.Run(t, "neo-go", "wallet", "sign",
+		"--wallet", wallets[1], "--address", multisigAddr,
+		"--import-qr", filepath.Join(tmpDir, "tx-*.png"),
+		"--export-qr", outPath, "--qr-fragment-size", "100")
+	line := e.GetNextLine(t)
+	require.True(t, strings.HasPrefix(line, "QR codes saved: "))
+	signed := strings.Split(strings.TrimPrefix(line, "QR codes saved: "), ", ")
+	require.True(t, len(signed) > 2)
+	e.CheckNextLine(t, txHash.StringLE())
+	e.CheckEOF(t)
+
+	e.In.WriteString("pass\r")
+	e.Run(t, "neo-go", "wallet", "sign",
+		"--rpc-endpoint", "http://"+e.RPC.Addresses()[0],
+		"--wallet", wallets[2], "--address", multisigAddr,
+		"--import-qr", filepath.Join(tmpDir, "signed-*.png"))
+	e.CheckTxPersisted(t)
+
+	b, _ := e.Chain.GetGoverningTokenBalance(priv.GetScriptHash())
+	require.Equal(t, big.NewInt(1), b)
+}
@@ -0,0 +1,148 @@
+package wallet
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+	"text/tabwriter"
+
+	"github.com/nspcc-dev/neo-go/cli/cmdargs"
+	"github.com/nspcc-dev/neo-go/cli/options"
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient"
+	"github.com/nspcc-dev/neo-go/pkg/services/sigserver"
+	"github.com/urfave/cli"
+)
+
+func newSigningServerCommand() cli.Command {
+	return cli.Command{
+		Name:      "signing-server",
+		Usage:     "start a server coordinating transaction signing",
+		UsageText: "neo-go wallet signing-server [--listen address] [--store-dir dir] [--network magic] [--max-contexts num] [--completed-ttl duration] [--token token] [-r endpoint [-s timeout]] [-d]",
+		Description: `Starts an HTTP server storing transaction signing contexts (the ones
+   used by 'wallet sign'), so that they don't have to be passed between signers
+   as files. Signers can upload contexts, fetch them, add their signatures (see
+   'wallet sign --server') and download completed transactions. Every signature
+   is checked before being stored and only transaction signers are accepted,
+   new contexts must have at least one signature.
+
+   If --token is given clients must provide it (see 'wallet sign
+   --server-token') to access the server, any client is accepted otherwise.
+
+   Contexts are kept in memory unless --store-dir is given, in which case they
+   survive restarts. --network restricts contexts accepted to the given network
+   magic (any network is accepted by default).
+
+   Contexts can be removed with the DELETE request if --token is set (it's
+   not allowed otherwise), completed ones are removed automatically after
+   --completed-ttl. If an RPC node is given with --rpc-endpoint contexts of transactions that are no longer valid (with
+   ValidUntilBlock not greater than the current chain height) are removed too.
+`,
+		Action: startSigningServer,
+		Flags: append([]cli.Flag{
+			cli.StringFlag{
+				Name:  "listen",
+				Value: "localhost:10340",
+				Usage: "address to listen on",
+			},
+			cli.StringFlag{
+				Name:  "store-dir",
+				Usage: "directory to store contexts in",
+			},
+			cli.UintFlag{
+				Name:  "network",
+				Usage: "network magic to accept contexts for",
+			},
+			cli.IntFlag{
+				Name:  "max-contexts",
+				Value: sigserver.DefaultMaxContexts,
+				Usage: "maximum number of contexts stored",
+			},
+			cli.DurationFlag{
+				Name:  "completed-ttl",
+				Value: sigserver.DefaultCompletedTTL,
+				Usage: "time to keep completed contexts for",
+			},
+			cli.StringFlag{
+				Name:  "token",
+				Usage: "shared token clients must provide to access the server",
+			},
+			options.Debug,
+		}, options.RPC...),
+	}
+}
+
+func startSigningServer(ctx *cli.Context) error {
+	if err := cmdargs.EnsureNone(ctx); err != nil {
+		return err
+	}
+	if ctx.Int("max-contexts") <= 0 {
+		return cli.NewExitError("invalid number of contexts", 1)
+	}
+	if ctx.Duration("completed-ttl") <= 0 {
+		return cli.NewExitError("invalid completed contexts TTL", 1)
+	}
+	log, _, logCloser, err := options.HandleLoggingParams(ctx.Bool("debug"), config.ApplicationConfiguration{})
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	if logCloser != nil {
+		defer func() { _ = logCloser() }()
+	}
+	cfg := sigserver.Config{
+		Address:      ctx.String("listen"),
+		Network:      netmode.Magic(ctx.Uint("network")),
+		Dir:          ctx.String("store-dir"),
+		MaxContexts:  ctx.Int("max-contexts"),
+		CompletedTTL: ctx.Duration("completed-ttl"),
+		Token:        ctx.String("token"),
+	}
+	if endpoint := ctx.String(options.RPCEndpointFlag); endpoint != "" {
+		// The client is used for the whole server lifetime, so the timeout
+		// is applied to every request instead of the context.
+		c, err := rpcclient.New(context.Background(), endpoint, rpcclient.Options{
+			RequestTimeout: ctx.Duration("timeout"),
+		})
+		if err != nil {
+			return cli.NewExitError(err, 1)
+		}
+		if err := c.Init(); err != nil {
+			return cli.NewExitError(err, 1)
+		}
+		defer c.Close()
+		cfg.Height = func() (uint32, error) {
+			count, err := c.GetBlockCount()
+			if err != nil {
+				return 0, err
+			}
+			return count - 1, nil
+		}
+	}
+	srv, err := sigserver.New(cfg, log)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	if err := srv.Start(); err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	gctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	<-gctx.Done()
+	srv.Shutdown()
+	return nil
+}
+
+func printContextState(w io.Writer, st *sigserver.ContextState) {
+	tw := tabwriter.NewWriter(w, 0, 4, 1, ' ', 0)
+	fmt.Fprintf(tw, "Hash:\t%s\n", st.Hash.StringLE())
+	fmt.Fprintf(tw, "Complete:\t%t\n", st.Complete)
+	for i, s := range st.Signers {
+		fmt.Fprintf(tw, "Signer #%d:\t%s (%s)\n", i, s.Signer.Account.StringLE(), s.Signer.Scopes)
+		fmt.Fprintf(tw, "\tSignatures: %d/%d, complete: %t\n", len(s.Keys), s.Required, s.Complete)
+	}
+	_ = tw.Flush()
+}
@@ -0,0 +1,150 @@
+package wallet_test
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/cli/paramcontext"
+	"github.com/nspcc-dev/neo-go/internal/testcli"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
+	"github.com/nspcc-dev/neo-go/pkg/services/sigserver"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestSigningServer(t *testing.T) {
+	e := testcli.NewExecutor(t, false)
+
+	t.Run("excessive parameters", func(t *testing.T) {
+		e.RunWithError(t, "neo-go", "wallet", "signing-server", "something")
+	})
+	t.Run("invalid max contexts", func(t *testing.T) {
+		e.RunWithError(t, "neo-go", "wallet", "signing-server", "--max-contexts", "0")
+	})
+	t.Run("invalid completed TTL", func(t *testing.T) {
+		e.RunWithError(t, "neo-go", "wallet", "signing-server", "--completed-ttl", "0")
+	})
+	t.Run("invalid RPC endpoint", func(t *testing.T) {
+		e.RunWithError(t, "neo-go", "wallet", "signing-server", "--listen", "localhost:0", "-r", "http://localhost:1")
+	})
+	t.Run("invalid address", func(t *testing.T) {
+		e.RunWithError(t, "neo-go", "wallet", "signing-server", "--listen", "not-an-address")
+	})
+}
+
+func TestSignWithSigningServer(t *testing.T) {
+	e := testcli.NewExecutor(t, true)
+
+	const token = "secret"
+	srv, err := sigserver.New(sigserver.Config{Token: token}, zaptest.NewLogger(t))
+	require.NoError(t, err)
+	ts := httptest.NewServer(srv)
+	t.Cleanup(ts.Close)
+
+	privs, pubs := testcli.GenerateKeys(t, 3)
+	script, err := smartcontract.CreateMultiSigRedeemScript(2, pubs)
+	require.NoError(t, err)
+	multisigHash := hash.Hash160(script)
+	multisigAddr := address.Uint160ToString(multisigHash)
+
+	tmpDir := t.TempDir()
+	wallets := make([]string, 3)
+	for i := range wallets {
+		wallets[i] = filepath.Join(tmpDir, fmt.Sprintf("multiWallet%d.json", i))
+		e.Run(t, "neo-go", "wallet", "init", "--wallet", wallets[i])
+		e.In.WriteString("acc\rpass\rpass\r")
+		e.Run(t, "neo-go", "wallet", "import-multisig",
+			"--wallet", wallets[i],
+			"--wif", privs[i].WIF(),
+			"--min", "2",
+			hex.EncodeToString(pubs[0].Bytes()),
+			hex.EncodeToString(pubs[1].Bytes()),
+			hex.EncodeToString(pubs[2].Bytes()))
+	}
+
+	e.In.WriteString("one\r")
+	e.Run(t, "neo-go", "wallet", "nep17", "multitransfer",
+		"--rpc-endpoint", "http://"+e.RPC.Addresses()[0],
+		"--wallet", testcli.ValidatorWallet,
+		"--from", testcli.ValidatorAddr,
+		"--force",
+		"NEO:"+multisigAddr+":4",
+		"GAS:"+multisigAddr+":1")
+	e.CheckTxPersisted(t)
+
+	priv, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+	txPath := filepath.Join(tmpDir, "multisigtx.json")
+	e.In.WriteString("pass\r")
+	e.Run(t, "neo-go", "wallet", "nep17", "transfer",
+		"--rpc-endpoint", "http://"+e.RPC.Addresses()[0],
+		"--wallet", wallets[0], "--from", multisigAddr,
+		"--to", priv.Address(), "--token", "NEO", "--amount", "1",
+		"--out", txPath)
+	pc, err := paramcontext.Read(txPath)
+	require.NoError(t, err)
+	txHash := pc.Verifiable.Hash()
+
+	t.Run("hash without server", func(t *testing.T) {
+		e.RunWithError(t, "neo-go", "wallet", "sign",
+			"--wallet", wallets[1], "--address", multisigAddr,
+			"--hash", txHash.StringLE())
+	})
+	t.Run("hash with input file", func(t *testing.T) {
+		e.RunWithError(t, "neo-go", "wallet", "sign",
+			"--wallet", wallets[1], "--address", multisigAddr,
+			"--server", ts.URL, "--in", txPath, "--hash", txHash.StringLE())
+	})
+	t.Run("invalid hash", func(t *testing.T) {
+		e.RunWithError(t, "neo-go", "wallet", "sign",
+			"--wallet", wallets[1], "--address", multisigAddr,
+			"--server", ts.URL, "--hash", "notahash")
+	})
+	t.Run("unknown context", func(t *testing.T) {
+		e.RunWithError(t, "neo-go", "wallet", "sign",
+			"--wallet", wallets[1], "--address", multisigAddr,
+			"--server", ts.URL, "--server-token", token, "--hash", util.Uint256{}.StringLE())
+	})
+
+	t.Run("no token", func(t *testing.T) {
+		e.In.WriteString("pass\r")
+		e.RunWithError(t, "neo-go", "wallet", "sign",
+			"--wallet", wallets[1], "--address", multisigAddr,
+			"--in", txPath, "--server", ts.URL)
+	})
+
+	// Upload the context signed by the first key and add the second signature.
+	e.In.WriteString("pass\r")
+	e.Run(t, "neo-go", "wallet", "sign",
+		"--wallet", wallets[1], "--address", multisigAddr,
+		"--in", txPath, "--server", ts.URL, "--server-token", token)
+	e.CheckNextLine(t, `^Hash:\s+`+txHash.StringLE())
+	e.CheckNextLine(t, `^Complete:\s+true`)
+	e.CheckNextLine(t, `^Signer #0:\s+`+multisigHash.StringLE())
+	e.CheckNextLine(t, `^\s+Signatures: 2/2, complete: true`)
+	e.CheckEOF(t)
+
+	// Fetch the context, add one more signature and send the transaction.
+	e.In.WriteString("pass\r")
+	e.Run(t, "neo-go", "wallet", "sign",
+		"--rpc-endpoint", "http://"+e.RPC.Addresses()[0],
+		"--wallet", wallets[2], "--address", multisigAddr,
+		"--server", ts.URL, "--server-token", token, "--hash", txHash.StringLE())
+	e.CheckTxPersisted(t)
+
+	st, err := sigserver.NewClient(context.Background(), ts.URL, token).GetState(txHash)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(st.Signers[0].Keys))
+
+	b, _ := e.Chain.GetGoverningTokenBalance(priv.GetScriptHash())
+	require.Equal(t, big.NewInt(1), b)
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/nspcc-dev/neo-go/cli/flags"
 	"github.com/nspcc-dev/neo-go/cli/input"
 	"github.com/nspcc-dev/neo-go/cli/options"
+	"github.com/nspcc-dev/neo-go/cli/paramcontext"
 	"github.com/nspcc-dev/neo-go/cli/txctx"
 	"github.com/nspcc-dev/neo-go/pkg/config"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
@@ -103,6 +104,40 @@ func NewCommands() []cli.Command {
 			Name:  "address, a",
 			Usage: "Address to use",
 		},
+		cli.StringFlag{
+			Name:  "server",
+			Usage: "Signing server endpoint to exchange the context with",
+		},
+		cli.StringFlag{
+			Name:  "server-token",
+			Usage: "Signing server access token (if it's required by the server)",
+		},
+		cli.StringFlag{
+			Name:  "hash",
+			Usage: "Hash of the transaction to fetch from the signing server (instead of --in)",
+		},
+		cli.StringSliceFlag{
+			Name:  "import-qr",
+			Usage: "QR code image files (or patterns) to read the context from (instead of --in)",
+		},
+		cli.StringFlag{
+			Name:  "export-qr",
+			Usage: "PNG file to save the resulting context to as QR codes (numbered if there are several), '-' to show them in the terminal",
+		},
+		cli.IntFlag{
+			Name:  "qr-fragment-size",
+			Value: paramcontext.DefaultQRFragmentSize,
+			Usage: "Maximum number of context bytes per QR code",
+		},
+		cli.IntFlag{
+			Name:  "qr-frames",
+			Usage: "Number of QR codes to export (by default it's enough to restore the context with some codes lost, terminal output is shown until interrupted)",
+		},
+		cli.DurationFlag{
+			Name:  "qr-interval",
+			Value: defaultQRInterval,
+			Usage: "Interval between QR codes shown in the terminal",
+		},
 	}
 	signFlags = append(signFlags, options.RPC...)
 	return []cli.Command{{
@@ -290,17 +325,38 @@ func NewCommands() []cli.Command {
 			{
 				Name:      "sign",
 				Usage:     "cosign transaction with multisig/contract/additional account",
-				UsageText: "sign -w wallet [--wallet-config path] --address <address> --in <file.in> [--out <file.out>] [-r <endpoint>]",
+				UsageText: "sign -w wallet [--wallet-config path] --address <address> {--in <file.in> | --server <url> --hash <hash> | --import-qr <image> [--import-qr <image> ...]} [--server <url> [--server-token <token>]] [--out <file.out>] [--export-qr {<file.png> | -} [--qr-fragment-size <n>] [--qr-frames <n>] [--qr-interval <duration>]] [-r <endpoint>]",
 				Description: `Signs the given (in file.in) context (which must be a transaction
    signing context) for the given address using the given wallet. This command can
    output the resulting JSON (with additional signature added) right to the console
    (if no file.out and no RPC endpoint specified) or into a file (which can be the
    same as input one). If an RPC endpoint is given it'll also try to construct a
    complete transaction and send it via RPC (printing its hash if everything is OK).
+
+   If a signing server (see 'wallet signing-server') is given with --server the
+   context is sent there after signing (and its state is printed instead of
+   JSON), signatures already collected by the server are merged into the
+   resulting context. The context can be fetched from the server by the
+   transaction hash (--hash) instead of reading it from file.in. The account
+   doesn't have to be able to sign in this case, so contexts already having
+   some signatures can also be uploaded to the server using any signer address
+   (the server doesn't accept new contexts without signatures). --server-token
+   is passed to the server if it requires a token.
+
+   For air-gapped signing the context can be transferred as a sequence of QR
+   codes. --export-qr saves the resulting context into PNG files (file.png
+   for a single code, file-1.png, file-2.png and so on otherwise) or shows an
+   animated sequence of codes in the terminal with --export-qr=-. --import-qr
+   reads the context from image files (PNG, JPEG or GIF, patterns like
+   'ctx-*.png' are accepted) instead of file.in. Codes are generated with
+   fountain code, so not every one of them is needed to restore the context.
+   The account doesn't have to be able to sign when exporting, so a
+   watch-only wallet can be used on the online machine.
 `,
 				Action: signStoredTransaction,
 				Flags:  signFlags,
 			},
+			newSigningServerCommand(),
 			{
 				Name:      "strip-keys",
 				Usage:     "remove private keys for all accounts",
@@ -333,6 +389,11 @@ func NewCommands() []cli.Command {
 				Usage:       "work with candidates",
 				Subcommands: newValidatorCommands(),
 			},
+			{
+				Name:        "multisig",
+				Usage:       "collect multisignature account signatures via P2P notary requests",
+				Subcommands: newMultisigCommands(),
+			},
 		},
 	}}
 }
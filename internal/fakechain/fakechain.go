@@ -27,8 +27,9 @@ type FakeChain struct {
 	*mempool.Pool
 	blocksCh                 []chan *block.Block
 	Blockheight              uint32
+	Headerheight             uint32
 	PoolTxF                  func(*transaction.Transaction) error
-	poolTxWithData           func(*transaction.Transaction, any, *mempool.Pool) error
+	PoolTxWithDataF          func(*transaction.Transaction, any, *mempool.Pool) error
 	blocks                   map[util.Uint256]*block.Block
 	hdrHashes                map[uint32]util.Uint256
 	txs                      map[util.Uint256]*transaction.Transaction
@@ -62,13 +63,13 @@ func NewFakeChainWithCustomCfg(protocolCfg func(c *config.Blockchain)) *FakeChai
 		protocolCfg(&cfg)
 	}
 	return &FakeChain{
-		Pool:           mempool.New(10, 0, false, nil),
-		PoolTxF:        func(*transaction.Transaction) error { return nil },
-		poolTxWithData: func(*transaction.Transaction, any, *mempool.Pool) error { return nil },
-		blocks:         make(map[util.Uint256]*block.Block),
-		hdrHashes:      make(map[uint32]util.Uint256),
-		txs:            make(map[util.Uint256]*transaction.Transaction),
-		Blockchain:     cfg,
+		Pool:            mempool.New(10, 0, false, nil),
+		PoolTxF:         func(*transaction.Transaction) error { return nil },
+		PoolTxWithDataF: func(*transaction.Transaction, any, *mempool.Pool) error { return nil },
+		blocks:          make(map[util.Uint256]*block.Block),
+		hdrHashes:       make(map[uint32]util.Uint256),
+		txs:             make(map[util.Uint256]*transaction.Transaction),
+		Blockchain:      cfg,
 	}
 }
 
@@ -150,7 +151,7 @@ func (chain *FakeChain) GetMaxVerificationGAS() int64 {
 
 // PoolTxWithData implements the Blockchainer interface.
 func (chain *FakeChain) PoolTxWithData(t *transaction.Transaction, data any, mp *mempool.Pool, feer mempool.Feer, verificationFunction func(t *transaction.Transaction, data any) error) error {
-	return chain.poolTxWithData(t, data, mp)
+	return chain.PoolTxWithDataF(t, data, mp)
 }
 
 // RegisterPostBlock implements the Blockchainer interface.
@@ -158,6 +159,10 @@ func (chain *FakeChain) RegisterPostBlock(f func(func(*transaction.Transaction,
 	chain.PostBlock = append(chain.PostBlock, f)
 }
 
+// SetFutureTxCallback implements the Blockchainer interface.
+func (chain *FakeChain) SetFutureTxCallback(func(*transaction.Transaction)) {
+}
+
 // GetConfig implements the Blockchainer interface.
 func (chain *FakeChain) GetConfig() config.Blockchain {
 	return chain.Blockchain
@@ -179,8 +184,15 @@ func (chain *FakeChain) P2PSigExtensionsEnabled() bool {
 }
 
 // AddHeaders implements the Blockchainer interface.
-func (chain *FakeChain) AddHeaders(...*block.Header) error {
-	panic("TODO")
+func (chain *FakeChain) AddHeaders(hdrs ...*block.Header) error {
+	for _, h := range hdrs {
+		if h.Index != chain.HeaderHeight()+1 {
+			continue
+		}
+		chain.hdrHashes[h.Index] = h.Hash()
+		atomic.StoreUint32(&chain.Headerheight, h.Index)
+	}
+	return nil
 }
 
 // AddBlock implements the Blockchainer interface.
@@ -198,6 +210,9 @@ func (chain *FakeChain) BlockHeight() uint32 {
 
 // HeaderHeight implements the Blockchainer interface.
 func (chain *FakeChain) HeaderHeight() uint32 {
+	if h := atomic.LoadUint32(&chain.Headerheight); h > atomic.LoadUint32(&chain.Blockheight) {
+		return h
+	}
 	return atomic.LoadUint32(&chain.Blockheight)
 }
 
@@ -77,6 +77,11 @@ type Options struct {
 	// The list of standards supported by the contract.
 	ContractSupportedStandards []string
 
+	// Standards contains custom standards to check the contract against in
+	// addition to built-in ones. The default registry (see standard.Register)
+	// is used if it's nil.
+	Standards *standard.Registry
+
 	// SafeMethods contains a list of methods which will be marked as safe in manifest.
 	SafeMethods []string
 
@@ -452,7 +457,11 @@ func CreateManifest(di *DebugInfo, o *Options) (*manifest.Manifest, error) {
 		return m, fmt.Errorf("manifest is invalid: %w", err)
 	}
 	if !o.NoStandardCheck {
-		if err := standard.CheckABI(m, o.ContractSupportedStandards...); err != nil {
+		var checkABI = standard.CheckABI
+		if o.Standards != nil {
+			checkABI = o.Standards.CheckABI
+		}
+		if err := checkABI(m, o.ContractSupportedStandards...); err != nil {
 			return m, err
 		}
 		if m.ABI.GetMethod(manifest.MethodOnNEP11Payment, -1) != nil {
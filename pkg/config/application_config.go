@@ -26,9 +26,10 @@ type ApplicationConfiguration struct {
 	BroadcastFactor int                      `yaml:"BroadcastFactor"`
 	DBConfiguration dbconfig.DBConfiguration `yaml:"DBConfiguration"`
 	// Deprecated: this option is moved to the P2P section.
-	DialTimeout int64  `yaml:"DialTimeout"`
-	LogLevel    string `yaml:"LogLevel"`
-	LogPath     string `yaml:"LogPath"`
+	DialTimeout int64   `yaml:"DialTimeout"`
+	LogLevel    string  `yaml:"LogLevel"`
+	LogPath     string  `yaml:"LogPath"`
+	Mempool     Mempool `yaml:"Mempool"`
 	// Deprecated: this option is moved to the P2P section.
 	MaxPeers int `yaml:"MaxPeers"`
 	// Deprecated: this option is moved to the P2P section.
@@ -87,6 +88,7 @@ func (a *ApplicationConfiguration) EqualsButServices(o *ApplicationConfiguration
 		a.ExtensiblePoolSize != o.ExtensiblePoolSize || //nolint:staticcheck // SA1019: a.ExtensiblePoolSize is deprecated
 		a.P2P.ExtensiblePoolSize != o.P2P.ExtensiblePoolSize ||
 		a.LogPath != o.LogPath ||
+		a.Mempool != o.Mempool ||
 		a.MaxPeers != o.MaxPeers || //nolint:staticcheck // SA1019: a.MaxPeers is deprecated
 		a.P2P.MaxPeers != o.P2P.MaxPeers ||
 		a.MinPeers != o.MinPeers || //nolint:staticcheck // SA1019: a.MinPeers is deprecated
@@ -19,6 +19,9 @@ const (
 	// DefaultMaxIteratorResultItems is the default upper bound of traversed
 	// iterator items per JSON-RPC response.
 	DefaultMaxIteratorResultItems = 100
+	// DefaultMaxTraceSteps is the default upper bound of execution trace steps
+	// per JSON-RPC response.
+	DefaultMaxTraceSteps = 100000
 )
 
 // Version is the version of the node, set at the build time.
@@ -74,6 +77,7 @@ func LoadFile(configPath string) (Config, error) {
 				MaxIteratorResultItems: DefaultMaxIteratorResultItems,
 				MaxFindResultItems:     100,
 				MaxNEP11Tokens:         100,
+				MaxTraceSteps:          DefaultMaxTraceSteps,
 			},
 		},
 	}
@@ -1,8 +1,10 @@
 package config
 
 // InternalService stores configuration for internal services that don't have
-// any network configuration, but use a wallet and can be enabled/disabled.
+// any network configuration, but use a wallet (or a remote signer) and can be
+// enabled/disabled.
 type InternalService struct {
-	Enabled      bool   `yaml:"Enabled"`
-	UnlockWallet Wallet `yaml:"UnlockWallet"`
+	Enabled      bool         `yaml:"Enabled"`
+	UnlockWallet Wallet       `yaml:"UnlockWallet"`
+	RemoteSigner RemoteSigner `yaml:"RemoteSigner"`
 }
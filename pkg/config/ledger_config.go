@@ -4,6 +4,13 @@ package config
 // a part of the ProtocolConfiguration (which is common for every node on the
 // network).
 type Ledger struct {
+	// FutureMemPoolSize is the maximum number of transactions with
+	// NotValidBefore attribute set to some future height that are kept by
+	// the node until they become valid. Zero value disables this queue.
+	FutureMemPoolSize int `yaml:"FutureMemPoolSize"`
+	// FutureMemPoolSenderLimit is the maximum number of queued future
+	// transactions from a single sender.
+	FutureMemPoolSenderLimit int `yaml:"FutureMemPoolSenderLimit"`
 	// GarbageCollectionPeriod sets the number of blocks to wait before
 	// starting the next MPT garbage collection cycle when RemoveUntraceableBlocks
 	// option is used.
@@ -12,6 +19,16 @@ type Ledger struct {
 	// If true, DB size will be smaller, but older roots won't be accessible.
 	// This value should remain the same for the same database.
 	KeepOnlyLatestState bool `yaml:"KeepOnlyLatestState"`
+	// ReplaceByFee enables replace-by-fee memory pool policy: a transaction
+	// with the same signers and nonce range as some pooled one replaces it if
+	// it pays more per byte.
+	ReplaceByFee bool `yaml:"ReplaceByFee"`
+	// ReplaceByFeeMinBump is the minimum fee per byte increase (in percents)
+	// required to replace a pooled transaction.
+	ReplaceByFeeMinBump uint32 `yaml:"ReplaceByFeeMinBump"`
+	// ReplaceByFeeNonceRange is the size of nonce ranges used to match
+	// replacements, zero means exact nonce match.
+	ReplaceByFeeNonceRange uint32 `yaml:"ReplaceByFeeNonceRange"`
 	// RemoveUntraceableBlocks specifies if old data should be removed.
 	RemoveUntraceableBlocks bool `yaml:"RemoveUntraceableBlocks"`
 	// SaveStorageBatch enables storage batch saving before every persist.
@@ -0,0 +1,15 @@
+package config
+
+import "time"
+
+// Mempool contains node-specific memory pool settings.
+type Mempool struct {
+	// PersistFile is the path to the file used to save verified transactions
+	// (and P2P notary requests) from memory pools on node shutdown, they're
+	// verified and added back to pools on node start. Persistence is
+	// disabled if this field is empty.
+	PersistFile string `yaml:"PersistFile"`
+	// PersistInterval is the interval between periodic pool dumps, zero
+	// value means that pools are only saved on shutdown.
+	PersistInterval time.Duration `yaml:"PersistInterval"`
+}
@@ -15,6 +15,33 @@ type OracleConfiguration struct {
 	RequestTimeout        time.Duration      `yaml:"RequestTimeout"`
 	ResponseTimeout       time.Duration      `yaml:"ResponseTimeout"`
 	UnlockWallet          Wallet             `yaml:"UnlockWallet"`
+	RemoteSigner          RemoteSigner       `yaml:"RemoteSigner"`
+	// Protocols contains per-scheme protocol handler configurations.
+	Protocols map[string]OracleProtocol `yaml:"Protocols"`
+	// AuditLog is the request audit trail configuration.
+	AuditLog OracleAuditLog `yaml:"AuditLog"`
+}
+
+// OracleAuditLog is a config for the oracle request audit trail.
+type OracleAuditLog struct {
+	// Path is the file audit records are saved to, they're only kept in
+	// memory if it's not set.
+	Path string `yaml:"Path"`
+	// MaxRecords is the maximum number of completed request records kept.
+	MaxRecords int `yaml:"MaxRecords"`
+}
+
+// OracleProtocol is a config for the oracle protocol handler serving some URI
+// scheme.
+type OracleProtocol struct {
+	// Disabled turns the handler off, it can be used for built-in https and
+	// neofs handlers that are enabled by default.
+	Disabled bool `yaml:"Disabled"`
+	// Timeout is the request timeout, RequestTimeout is used if not set
+	// (NeoFS.Timeout for neofs).
+	Timeout time.Duration `yaml:"Timeout"`
+	// Parameters contains handler-specific settings.
+	Parameters map[string]string `yaml:"Parameters"`
 }
 
 // NeoFSConfiguration is a config for the NeoFS service.
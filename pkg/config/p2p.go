@@ -16,4 +16,45 @@ type P2P struct {
 	PingInterval       time.Duration `yaml:"PingInterval"`
 	PingTimeout        time.Duration `yaml:"PingTimeout"`
 	ProtoTickInterval  time.Duration `yaml:"ProtoTickInterval"`
+	// StaticPeers is a list of "host:port" addresses the node always keeps
+	// connections to, they're reconnected whenever the connection is lost.
+	StaticPeers []string `yaml:"StaticPeers"`
+	// Denylist is a list of IP addresses and CIDR networks the node never
+	// connects to and never accepts connections from.
+	Denylist []string `yaml:"Denylist"`
+	// Allowlist is a list of IP addresses and CIDR networks. If it's not
+	// empty, the node only connects to and accepts connections from the
+	// listed addresses (allowlist-only mode).
+	Allowlist []string `yaml:"Allowlist"`
+	// BanScore is the (negative) peer score threshold, peers with lower score
+	// are temporarily banned. Peers are disconnected when their score drops
+	// below the half of this value.
+	BanScore int `yaml:"BanScore"`
+	// BanDuration is the duration of temporary misbehaviour bans.
+	BanDuration time.Duration `yaml:"BanDuration"`
+	// BansFile is the file temporary bans are saved to, so that they're
+	// restored after node restart. Bans are not saved if it's empty.
+	BansFile string `yaml:"BansFile"`
+	// Encryption contains encrypted transport settings.
+	Encryption P2PEncryption `yaml:"Encryption"`
+}
+
+// P2PEncryption holds encrypted P2P transport settings. Encrypted connections
+// use TLS 1.3 with self-signed certificates, peers are identified by their
+// secp256r1 node keys.
+type P2PEncryption struct {
+	// Enabled turns encryption on. Encryption is negotiated per connection,
+	// peers not supporting it are connected to via plain TCP unless Required
+	// is set.
+	Enabled bool `yaml:"Enabled"`
+	// KeyFile is the PEM-encoded secp256r1 node key file. An ephemeral key is
+	// generated on every start if it's empty.
+	KeyFile string `yaml:"KeyFile"`
+	// Required disables plain TCP fallback, peers not supporting encryption
+	// are not connected to and not accepted.
+	Required bool `yaml:"Required"`
+	// TrustedKeys is a list of hex-encoded compressed public keys of the
+	// peers allowed to establish encrypted connections. Any key is accepted
+	// if it's empty, non-empty list makes encryption required.
+	TrustedKeys []string `yaml:"TrustedKeys"`
 }
@@ -0,0 +1,12 @@
+package config
+
+import "time"
+
+// RemoteSigner is a configuration of the external signer used by services
+// instead of an unlocked wallet.
+type RemoteSigner struct {
+	// Socket is the path to the Unix socket of the signer.
+	Socket string `yaml:"Socket"`
+	// Timeout is the timeout for a single signer request.
+	Timeout time.Duration `yaml:"Timeout"`
+}
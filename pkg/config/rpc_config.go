@@ -7,7 +7,10 @@ import (
 type (
 	// RPC is an RPC service configuration information.
 	RPC struct {
-		BasicService         `yaml:",inline"`
+		BasicService `yaml:",inline"`
+		// AdminEnabled enables node administration methods (addpeer,
+		// removepeer, banpeer and unbanpeer).
+		AdminEnabled         bool `yaml:"AdminEnabled"`
 		EnableCORSWorkaround bool `yaml:"EnableCORSWorkaround"`
 		// MaxGasInvoke is the maximum amount of GAS which
 		// can be spent during an RPC call.
@@ -15,6 +18,7 @@ type (
 		MaxIteratorResultItems int           `yaml:"MaxIteratorResultItems"`
 		MaxFindResultItems     int           `yaml:"MaxFindResultItems"`
 		MaxNEP11Tokens         int           `yaml:"MaxNEP11Tokens"`
+		MaxTraceSteps          int           `yaml:"MaxTraceSteps"`
 		MaxWebSocketClients    int           `yaml:"MaxWebSocketClients"`
 		SessionEnabled         bool          `yaml:"SessionEnabled"`
 		SessionExpirationTime  int           `yaml:"SessionExpirationTime"`
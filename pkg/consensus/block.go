@@ -8,6 +8,8 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
 	coreb "github.com/nspcc-dev/neo-go/pkg/core/block"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
+	"github.com/nspcc-dev/neo-go/pkg/services/signer"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 )
 
@@ -16,16 +18,29 @@ import (
 type neoBlock struct {
 	coreb.Block
 
-	network   netmode.Magic
+	network netmode.Magic
+	// view is the view number the block is proposed in.
+	view      byte
 	signature []byte
 }
 
 var _ block.Block = (*neoBlock)(nil)
 
-// Sign implements the block.Block interface.
+// Sign implements the block.Block interface. Block signatures are protected
+// from double signing, only one block can be signed for the given height and
+// view.
 func (n *neoBlock) Sign(key crypto.PrivateKey) error {
 	k := key.(*privateKey)
-	sig := k.PrivateKey.SignHashable(uint32(n.network), &n.Block)
+	sig, err := k.Account.Sign(signer.Request{
+		Hash:      hash.NetSha256(uint32(n.network), &n.Block),
+		Domain:    signer.DomainConsensus,
+		Height:    n.Block.Index,
+		View:      n.view,
+		Protected: true,
+	})
+	if err != nil {
+		return err
+	}
 	n.signature = sig
 	return nil
 }
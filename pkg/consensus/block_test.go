@@ -6,6 +6,7 @@ import (
 	"github.com/nspcc-dev/dbft/block"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/services/signer"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
 	"github.com/stretchr/testify/require"
@@ -13,10 +14,21 @@ import (
 
 func TestNeoBlock_Sign(t *testing.T) {
 	b := new(neoBlock)
+	b.Block.Index = 1
 	priv, _ := keys.NewPrivateKey()
 
-	require.NoError(t, b.Sign(&privateKey{PrivateKey: priv}))
+	key := newTestPrivateKey(priv)
+	require.NoError(t, b.Sign(key))
 	require.NoError(t, b.Verify(&publicKey{PublicKey: priv.PublicKey()}, b.Signature()))
+
+	// Another block can't be signed for the same height and view.
+	other := new(neoBlock)
+	other.Block.Index = 1
+	other.Block.Nonce = 1
+	require.ErrorIs(t, other.Sign(key), signer.ErrDoubleSign)
+	require.NoError(t, b.Sign(key))
+	other.view = 1
+	require.NoError(t, other.Sign(key))
 }
 
 func TestNeoBlock_Setters(t *testing.T) {
@@ -19,13 +19,13 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
-	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
 	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
 	npayload "github.com/nspcc-dev/neo-go/pkg/network/payload"
+	"github.com/nspcc-dev/neo-go/pkg/services/signer"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
-	"github.com/nspcc-dev/neo-go/pkg/wallet"
 	"go.uber.org/atomic"
 	"go.uber.org/zap"
 )
@@ -79,6 +79,14 @@ type Service interface {
 	OnPayload(p *npayload.Extensible) error
 	// OnTransaction is a callback to notify the Service about a newly received transaction.
 	OnTransaction(tx *transaction.Transaction)
+
+	// GetState returns the timeline of the current consensus round along
+	// with per-validator statistics for the recent rounds.
+	GetState() result.ConsensusState
+	// GetHistory returns timelines of up to n latest finished rounds, the
+	// latest one first. All of the rounds kept are returned if n is not
+	// positive.
+	GetHistory(n int) []result.ConsensusTimeline
 }
 
 type service struct {
@@ -97,7 +105,7 @@ type service struct {
 	// on block addition under the high load.
 	blockEvents  chan *coreb.Block
 	lastProposal []util.Uint256
-	wallet       *wallet.Wallet
+	signer       signer.Signer
 	// started is a flag set with Start method that runs an event handling
 	// goroutine.
 	started  *atomic.Bool
@@ -108,6 +116,15 @@ type service struct {
 	// before the block is accepted. So, in case of change view, it will contain
 	// an updated value.
 	lastTimestamp uint64
+	// timeline records consensus rounds for monitoring.
+	timeline *timeline
+	// roundStarted is set when dBFT (re)initializes its context for a new
+	// round or view, the round is recorded into the timeline after the
+	// initialization is finished.
+	roundStarted bool
+	// nonce is used to generate block nonces instead of the random ones
+	// if set (see consensustest.Simulator).
+	nonce func() uint64
 }
 
 // Config is a configuration for consensus services.
@@ -131,13 +148,27 @@ type Config struct {
 	StopTxFlow func()
 	// TimePerBlock is minimal time that should pass before the next block is accepted.
 	TimePerBlock time.Duration
-	// Wallet is a local-node wallet configuration. If the path is empty, then
-	// no wallet will be initialized and the service will be in watch-only mode.
+	// Wallet is a local-node wallet configuration. If the path is empty (and
+	// no RemoteSigner is configured), then no wallet will be initialized and
+	// the service will be in watch-only mode.
 	Wallet config.Wallet
+	// RemoteSigner is a remote signer configuration, it's used instead of
+	// the Wallet if the socket is specified.
+	RemoteSigner config.RemoteSigner
 }
 
 // NewService returns a new consensus.Service instance.
 func NewService(cfg Config) (Service, error) {
+	srv, err := newService(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return srv, nil
+}
+
+// newService creates a service with additional dBFT options (overriding the
+// default ones).
+func newService(cfg Config, opts ...dbft.Option) (*service, error) {
 	if cfg.TimePerBlock <= 0 {
 		cfg.TimePerBlock = defaultTimePerBlock
 	}
@@ -158,35 +189,21 @@ func NewService(cfg Config) (Service, error) {
 		started:      atomic.NewBool(false),
 		quit:         make(chan struct{}),
 		finished:     make(chan struct{}),
+		timeline:     newTimeline(),
 	}
 
 	var err error
 
-	if len(cfg.Wallet.Path) > 0 {
-		if srv.wallet, err = wallet.NewWalletFromFile(cfg.Wallet.Path); err != nil {
-			return nil, err
-		}
-
-		// Check that the wallet password is correct for at least one account.
-		var ok bool
-		for _, acc := range srv.wallet.Accounts {
-			err := acc.Decrypt(srv.Config.Wallet.Password, srv.wallet.Scrypt)
-			if err == nil {
-				ok = true
-				break
-			}
-		}
-		if !ok {
-			return nil, errors.New("no account with provided password was found")
-		}
+	if srv.signer, err = signer.New(cfg.Wallet, cfg.RemoteSigner, signer.DomainConsensus, signer.DomainConsensusMessage); err != nil {
+		return nil, err
 	}
 
-	srv.dbft = dbft.New(
+	srv.dbft = dbft.New(append([]dbft.Option{
 		dbft.WithLogger(srv.log),
 		dbft.WithSecondsPerBlock(cfg.TimePerBlock),
 		dbft.WithGetKeyPair(srv.getKeyPair),
 		dbft.WithRequestTx(cfg.RequestTx),
-		dbft.WithStopTxFlow(cfg.StopTxFlow),
+		dbft.WithStopTxFlow(srv.stopTxFlow),
 		dbft.WithGetTx(srv.getTx),
 		dbft.WithGetVerified(srv.getVerifiedTx),
 		dbft.WithBroadcast(srv.broadcast),
@@ -211,7 +228,7 @@ func NewService(cfg Config) (Service, error) {
 		}),
 		dbft.WithVerifyPrepareRequest(srv.verifyRequest),
 		dbft.WithVerifyPrepareResponse(func(_ payload.ConsensusPayload) error { return nil }),
-	)
+	}, opts...)...)
 
 	if srv.dbft == nil {
 		return nil, errors.New("can't initialize dBFT")
@@ -247,6 +264,10 @@ func (s *service) newPayload(c *dbft.Context, t payload.MessageType, msg any) pa
 	if pr, ok := msg.(*prepareRequest); ok {
 		pr.SetPrevHash(s.dbft.PrevHash)
 		pr.SetVersion(s.dbft.Version)
+		if s.nonce != nil {
+			c.Nonce = s.nonce()
+			pr.SetNonce(c.Nonce)
+		}
 	}
 	cp.SetPayload(msg)
 
@@ -278,22 +299,27 @@ func (s *service) Name() string {
 func (s *service) Start() {
 	if s.started.CompareAndSwap(false, true) {
 		s.log.Info("starting consensus service")
-		b, _ := s.Chain.GetBlock(s.Chain.CurrentBlockHash()) // Can't fail, we have some current block!
-		s.lastTimestamp = b.Timestamp
-		s.dbft.Start(s.lastTimestamp * nsInMs)
+		s.initialize()
 		s.Chain.SubscribeForBlocks(s.blockEvents)
 		go s.eventLoop()
 	}
 }
 
+// initialize starts dBFT for the current chain height.
+func (s *service) initialize() {
+	b, _ := s.Chain.GetBlock(s.Chain.CurrentBlockHash()) // Can't fail, we have some current block!
+	s.lastTimestamp = b.Timestamp
+	s.dbft.Start(s.lastTimestamp * nsInMs)
+}
+
 // Shutdown implements the Service interface.
 func (s *service) Shutdown() {
 	if s.started.CompareAndSwap(true, false) {
 		s.log.Info("stopping consensus service")
 		close(s.quit)
 		<-s.finished
-		if s.wallet != nil {
-			s.wallet.Close()
+		if s.signer != nil {
+			s.signer.Close()
 		}
 	}
 }
@@ -307,37 +333,9 @@ events:
 			s.Chain.UnsubscribeFromBlocks(s.blockEvents)
 			break events
 		case <-s.dbft.Timer.C():
-			hv := s.dbft.Timer.HV()
-			s.log.Debug("timer fired",
-				zap.Uint32("height", hv.Height),
-				zap.Uint("view", uint(hv.View)))
-			s.dbft.OnTimeout(hv)
+			s.onTimeout()
 		case msg := <-s.messages:
-			fields := []zap.Field{
-				zap.Uint8("from", msg.message.ValidatorIndex),
-				zap.Stringer("type", msg.Type()),
-			}
-
-			if msg.Type() == payload.RecoveryMessageType {
-				rec := msg.GetRecoveryMessage().(*recoveryMessage)
-				if rec.preparationHash == nil {
-					req := rec.GetPrepareRequest(&msg, s.dbft.Validators, uint16(s.dbft.PrimaryIndex))
-					if req != nil {
-						h := req.Hash()
-						rec.preparationHash = &h
-					}
-				}
-
-				fields = append(fields,
-					zap.Int("#preparation", len(rec.preparationPayloads)),
-					zap.Int("#commit", len(rec.commitPayloads)),
-					zap.Int("#changeview", len(rec.changeViewPayloads)),
-					zap.Bool("#request", rec.prepareRequest != nil),
-					zap.Bool("#hash", rec.preparationHash != nil))
-			}
-
-			s.log.Debug("received message", fields...)
-			s.dbft.OnReceive(&msg)
+			s.onMessage(msg)
 		case tx := <-s.transactions:
 			s.dbft.OnTransaction(tx)
 		case b := <-s.blockEvents:
@@ -374,6 +372,45 @@ drainLoop:
 	close(s.finished)
 }
 
+// onTimeout handles dBFT timer event.
+func (s *service) onTimeout() {
+	hv := s.dbft.Timer.HV()
+	s.log.Debug("timer fired",
+		zap.Uint32("height", hv.Height),
+		zap.Uint("view", uint(hv.View)))
+	s.dbft.OnTimeout(hv)
+}
+
+// onMessage handles consensus message received from the network.
+func (s *service) onMessage(msg Payload) {
+	fields := []zap.Field{
+		zap.Uint8("from", msg.message.ValidatorIndex),
+		zap.Stringer("type", msg.Type()),
+	}
+
+	if msg.Type() == payload.RecoveryMessageType {
+		rec := msg.GetRecoveryMessage().(*recoveryMessage)
+		if rec.preparationHash == nil {
+			req := rec.GetPrepareRequest(&msg, s.dbft.Validators, uint16(s.dbft.PrimaryIndex))
+			if req != nil {
+				h := req.Hash()
+				rec.preparationHash = &h
+			}
+		}
+
+		fields = append(fields,
+			zap.Int("#preparation", len(rec.preparationPayloads)),
+			zap.Int("#commit", len(rec.commitPayloads)),
+			zap.Int("#changeview", len(rec.changeViewPayloads)),
+			zap.Bool("#request", rec.prepareRequest != nil),
+			zap.Bool("#hash", rec.preparationHash != nil))
+	}
+
+	s.log.Debug("received message", fields...)
+	s.timeline.message(&msg, s.dbft.Timer.Now())
+	s.dbft.OnReceive(&msg)
+}
+
 func (s *service) handleChainBlock(b *coreb.Block) {
 	// We can get our own block here, so check for index.
 	if b.Index >= s.dbft.BlockIndex {
@@ -396,24 +433,18 @@ func (s *service) validatePayload(p *Payload) bool {
 	return p.Sender == h
 }
 
+// getKeyPair is a dBFT callback that is called exactly once per round (or
+// view) initialization when the context is reset, so it also marks the round
+// start.
 func (s *service) getKeyPair(pubs []crypto.PublicKey) (int, crypto.PrivateKey, crypto.PublicKey) {
-	if s.wallet != nil {
-		for i := range pubs {
-			sh := pubs[i].(*publicKey).GetScriptHash()
-			acc := s.wallet.GetAccount(sh)
-			if acc == nil {
-				continue
-			}
-
-			if !acc.CanSign() {
-				err := acc.Decrypt(s.Config.Wallet.Password, s.wallet.Scrypt)
-				if err != nil {
-					s.log.Fatal("can't unlock account", zap.String("address", address.Uint160ToString(sh)))
-					break
-				}
-			}
-
-			return i, &privateKey{PrivateKey: acc.PrivateKey()}, &publicKey{PublicKey: acc.PublicKey()}
+	s.roundStarted = true
+	if s.signer != nil {
+		i, acc, err := signer.Find(s.signer, convertKeys(pubs))
+		if err != nil {
+			s.log.Error("can't get signer keys", zap.Error(err))
+		}
+		if acc != nil {
+			return i, &privateKey{Account: acc}, &publicKey{PublicKey: acc.PublicKey()}
 		}
 	}
 	return -1, nil, nil
@@ -459,14 +490,48 @@ func (s *service) OnTransaction(tx *transaction.Transaction) {
 }
 
 func (s *service) broadcast(p payload.ConsensusPayload) {
+	if p.Type() == payload.CommitType && len(p.GetCommit().Signature()) == 0 {
+		// Block signing was rejected by the signer (double signing attempt
+		// or signer failure), such commit is useless for other nodes.
+		s.log.Error("can't sign block, commit is not sent",
+			zap.Uint32("height", p.Height()), zap.Uint("view", uint(p.ViewNumber())))
+		return
+	}
 	if err := p.(*Payload).Sign(s.dbft.Priv.(*privateKey)); err != nil {
 		s.log.Warn("can't sign consensus payload", zap.Error(err))
+		return
 	}
 
+	s.timeline.message(p.(*Payload), s.dbft.Timer.Now())
 	ep := &p.(*Payload).Extensible
 	s.Config.Broadcast(ep)
 }
 
+// stopTxFlow is called by dBFT on every (re)initialization right after the
+// context reset (and also when the node no longer needs transactions for the
+// current round). It records the round started by the initialization (if
+// any) when the context is complete.
+func (s *service) stopTxFlow() {
+	if s.roundStarted {
+		s.roundStarted = false
+		s.timeline.initialize(s.dbft.BlockIndex, s.dbft.ViewNumber, uint16(s.dbft.PrimaryIndex),
+			s.dbft.MyIndex, convertKeys(s.dbft.Validators), s.dbft.Timer.Now())
+	}
+	if s.StopTxFlow != nil {
+		s.StopTxFlow()
+	}
+}
+
+// GetState implements the Service interface.
+func (s *service) GetState() result.ConsensusState {
+	return s.timeline.state()
+}
+
+// GetHistory implements the Service interface.
+func (s *service) GetHistory(n int) []result.ConsensusTimeline {
+	return s.timeline.getHistory(n)
+}
+
 func (s *service) getTx(h util.Uint256) block.Transaction {
 	if tx := s.txx.Get(h); tx != nil {
 		return tx.(*transaction.Transaction)
@@ -590,6 +655,7 @@ func (s *service) processBlock(b block.Block) {
 }
 
 func (s *service) postBlock(b *coreb.Block) {
+	s.timeline.blockAccepted(b.Index, b.Hash(), s.dbft.Timer.Now())
 	if s.lastTimestamp < b.Timestamp {
 		s.lastTimestamp = b.Timestamp
 	}
@@ -712,6 +778,7 @@ func (s *service) newBlockFromContext(ctx *dbft.Context) block.Block {
 	block.Block.Timestamp = ctx.Timestamp / nsInMs
 	block.Block.Nonce = ctx.Nonce
 	block.Block.Index = ctx.BlockIndex
+	block.view = ctx.ViewNumber
 	if s.ProtocolConfiguration.StateRootInHeader {
 		sr, err := s.Chain.GetStateRoot(ctx.BlockIndex - 1)
 		if err != nil {
@@ -20,7 +20,9 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
 	npayload "github.com/nspcc-dev/neo-go/pkg/network/payload"
+	"github.com/nspcc-dev/neo-go/pkg/services/signer"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
@@ -193,6 +195,57 @@ func TestService_NextConsensus(t *testing.T) {
 	*/
 }
 
+func TestService_Timeline(t *testing.T) {
+	bc := newSingleTestChain(t)
+	srv := newTestServiceWithChain(t, bc)
+	checkRound := func(t *testing.T, r result.ConsensusTimeline) {
+		var types []string
+		for _, m := range r.Messages {
+			types = append(types, m.Type)
+		}
+		require.Equal(t, []string{"PrepareRequest", "Commit"}, types)
+		h := bc.GetHeaderHash(r.Height)
+		require.Equal(t, &h, r.Block)
+	}
+
+	// Single validator produces a block immediately.
+	srv.dbft.Start(0)
+	t.Cleanup(srv.dbft.Timer.Stop)
+	st := srv.GetState()
+	require.Equal(t, 0, st.Index)
+	require.Equal(t, uint32(1), st.Round.Height)
+	checkRound(t, *st.Round)
+	require.Empty(t, srv.GetHistory(0))
+
+	header, err := bc.GetHeader(bc.CurrentBlockHash())
+	require.NoError(t, err)
+	srv.dbft.InitializeConsensus(0, header.Timestamp*nsInMs)
+	collectBlock(t, bc, srv)
+
+	hist := srv.GetHistory(0)
+	require.Len(t, hist, 2)
+	require.Equal(t, uint32(2), hist[0].Height)
+	require.Equal(t, uint32(1), hist[1].Height)
+	checkRound(t, hist[0])
+	checkRound(t, hist[1])
+
+	st = srv.GetState()
+	require.Equal(t, uint32(3), st.Round.Height)
+	require.Len(t, st.Validators, 1)
+	require.Equal(t, 3, st.Validators[0].Rounds)
+	require.Equal(t, 2, st.Validators[0].Preparations)
+	require.Equal(t, 2, st.Validators[0].Commits)
+	require.Equal(t, 0, st.Validators[0].MissedCommits)
+	require.Equal(t, hist[0].Messages[1].Time, st.Validators[0].LastSeen)
+
+	// StopTxFlow outside of the round initialization (like the one after
+	// PrepareResponse or ChangeView sending) doesn't affect the timeline.
+	srv.dbft.ViewNumber = 1
+	srv.stopTxFlow()
+	srv.dbft.ViewNumber = 0
+	require.Equal(t, st, srv.GetState())
+}
+
 func TestService_GetVerified(t *testing.T) {
 	srv := newTestService(t)
 	srv.dbft.Start(0)
@@ -211,7 +264,7 @@ func TestService_GetVerified(t *testing.T) {
 
 	// Everyone sends a message.
 	for i := 0; i < 4; i++ {
-		p := new(Payload)
+		p := NewPayload(netmode.UnitTestNet, false)
 		// One PrepareRequest and three ChangeViews.
 		if i == 1 {
 			p.SetType(payload.PrepareRequestType)
@@ -253,13 +306,18 @@ func TestService_GetVerified(t *testing.T) {
 func TestService_ValidatePayload(t *testing.T) {
 	srv := newTestService(t)
 	priv, _ := getTestValidator(1)
-	p := new(Payload)
-	p.Sender = priv.GetScriptHash()
-	p.SetPayload(&prepareRequest{})
+	// Payload hash is cached, so every case needs a new one.
+	newSignedPayload := func(t *testing.T, index uint16, sender util.Uint160) *Payload {
+		p := NewPayload(netmode.UnitTestNet, false)
+		p.Sender = sender
+		p.SetPayload(&prepareRequest{})
+		p.SetValidatorIndex(index)
+		require.NoError(t, p.Sign(priv))
+		return p
+	}
 
 	t.Run("invalid validator index", func(t *testing.T) {
-		p.SetValidatorIndex(11)
-		require.NoError(t, p.Sign(priv))
+		p := newSignedPayload(t, 11, priv.ScriptHash())
 
 		var ok bool
 		require.NotPanics(t, func() { ok = srv.validatePayload(p) })
@@ -267,23 +325,15 @@ func TestService_ValidatePayload(t *testing.T) {
 	})
 
 	t.Run("wrong validator index", func(t *testing.T) {
-		p.SetValidatorIndex(2)
-		require.NoError(t, p.Sign(priv))
-		require.False(t, srv.validatePayload(p))
+		require.False(t, srv.validatePayload(newSignedPayload(t, 2, priv.ScriptHash())))
 	})
 
 	t.Run("invalid sender", func(t *testing.T) {
-		p.SetValidatorIndex(1)
-		p.Sender = util.Uint160{}
-		require.NoError(t, p.Sign(priv))
-		require.False(t, srv.validatePayload(p))
+		require.False(t, srv.validatePayload(newSignedPayload(t, 1, util.Uint160{})))
 	})
 
 	t.Run("normal case", func(t *testing.T) {
-		p.SetValidatorIndex(1)
-		p.Sender = priv.GetScriptHash()
-		require.NoError(t, p.Sign(priv))
-		require.True(t, srv.validatePayload(p))
+		require.True(t, srv.validatePayload(newSignedPayload(t, 1, priv.ScriptHash())))
 	})
 }
 
@@ -329,7 +379,7 @@ func TestService_PrepareRequest(t *testing.T) {
 	t.Cleanup(srv.dbft.Timer.Stop)
 
 	priv, _ := getTestValidator(1)
-	p := new(Payload)
+	p := NewPayload(netmode.UnitTestNet, false)
 	p.SetValidatorIndex(1)
 
 	prevHash := srv.Chain.CurrentBlockHash()
@@ -376,7 +426,7 @@ func TestService_OnPayload(t *testing.T) {
 	srv.started.Store(true)
 
 	priv, _ := getTestValidator(1)
-	p := new(Payload)
+	p := NewPayload(netmode.UnitTestNet, false)
 	p.SetValidatorIndex(1)
 	p.SetPayload(&prepareRequest{})
 	p.encodeData()
@@ -385,9 +435,9 @@ func TestService_OnPayload(t *testing.T) {
 	require.NoError(t, srv.OnPayload(&p.Extensible))
 	shouldNotReceive(t, srv.messages)
 
-	p = new(Payload)
+	p = NewPayload(netmode.UnitTestNet, false)
 	p.SetValidatorIndex(1)
-	p.Sender = priv.GetScriptHash()
+	p.Sender = priv.ScriptHash()
 	p.SetPayload(&prepareRequest{})
 	require.NoError(t, p.Sign(priv))
 	require.NoError(t, srv.OnPayload(&p.Extensible))
@@ -536,7 +586,12 @@ func (bq testBlockQueuer) PutBlock(b *coreb.Block) error {
 
 func getTestValidator(i int) (*privateKey, *publicKey) {
 	key := testchain.PrivateKey(i)
-	return &privateKey{PrivateKey: key}, &publicKey{PublicKey: key.PublicKey()}
+	return newTestPrivateKey(key), &publicKey{PublicKey: key.PublicKey()}
+}
+
+// newTestPrivateKey returns privateKey backed by a local signer holding the key.
+func newTestPrivateKey(key *keys.PrivateKey) *privateKey {
+	return &privateKey{Account: signer.NewAccount(signer.NewLocal(key), key.PublicKey())}
 }
 
 func newSingleTestChain(t *testing.T) *core.Blockchain {
@@ -0,0 +1,708 @@
+/*
+Package consensustest provides a deterministic multi-node consensus simulator
+for tests.
+*/
+package consensustest
+
+import (
+	"container/heap"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/nspcc-dev/dbft/payload"
+	"github.com/nspcc-dev/dbft/timer"
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/consensus"
+	"github.com/nspcc-dev/neo-go/pkg/consensus/internal/driver"
+	"github.com/nspcc-dev/neo-go/pkg/core"
+	coreb "github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	npayload "github.com/nspcc-dev/neo-go/pkg/network/payload"
+	"github.com/nspcc-dev/neo-go/pkg/services/signer"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
+	"go.uber.org/zap"
+)
+
+// defaultTimePerBlock is the block interval used if it's not configured.
+const defaultTimePerBlock = 15 * time.Second
+
+// Behavior is a behavior of the simulated consensus node.
+type Behavior byte
+
+// Simulated node behaviors.
+const (
+	// Honest node follows the protocol.
+	Honest Behavior = iota
+	// Silent node receives messages, but never sends anything.
+	Silent
+	// Equivocating node sends PrepareRequests for different blocks (with
+	// different nonces) to different halves of the other nodes when it's
+	// the primary, recovery messages are only sent to the first half then.
+	Equivocating
+)
+
+// SimulatorConfig is a configuration of the consensus Simulator.
+type SimulatorConfig struct {
+	// Validators is the number of consensus nodes, 4 by default. It's
+	// ignored if Keys are specified.
+	Validators int
+	// Keys are private keys of the validators. They're derived from the Seed
+	// if not specified. Nodes are ordered by their public keys (the same way
+	// validators are), so node indexes match validator indexes.
+	Keys []*keys.PrivateKey
+	// TimePerBlock is the block interval, 15s by default.
+	TimePerBlock time.Duration
+	// Seed initializes the random source used for keys, nonces, delays and
+	// drops, runs with the same configuration and seed are identical.
+	Seed int64
+	// MinDelay and MaxDelay bound the (uniformly distributed) message
+	// delivery delay.
+	MinDelay time.Duration
+	MaxDelay time.Duration
+	// DropRate is the probability for a consensus message to be lost
+	// (blocks are never lost, they're only delayed).
+	DropRate float64
+	// Logger is used by nodes and their chains, nothing is logged if it's
+	// not set.
+	Logger *zap.Logger
+}
+
+// Filter is a callback deciding whether the consensus message from one node
+// should be delivered to another. The payload must not be changed.
+type Filter func(from, to int, p *consensus.Payload) bool
+
+// Simulator runs a set of consensus services over an in-memory network
+// using a virtual clock. Each node has its own in-memory Blockchain, all
+// events (message deliveries, timer firings, block and transaction relays)
+// are processed sequentially in a single goroutine, so the simulation is
+// deterministic for the given configuration and seed. Simulator is intended
+// to be used in tests and it's not safe for concurrent use.
+type Simulator struct {
+	cfg    SimulatorConfig
+	rng    *rand.Rand
+	now    time.Time
+	seq    uint64
+	events eventQueue
+	nodes  []*simNode
+	filter Filter
+	// txs contains all transactions added to the simulation, they're used to
+	// serve RequestTx callbacks.
+	txs map[util.Uint256]*transaction.Transaction
+}
+
+type simNode struct {
+	index    int
+	group    int
+	behavior Behavior
+	log      *zap.Logger
+	key      *keys.PrivateKey
+	signer   *signer.Local
+	chain    *core.Blockchain
+	timer    *simTimer
+	// crashed is set by Crash and cleared by Restart.
+	crashed bool
+	// srv is nil while the node is not running.
+	srv driver.Node
+}
+
+// NewSimulator creates a new simulator, nodes are started with Start.
+func NewSimulator(cfg SimulatorConfig) (*Simulator, error) {
+	if cfg.TimePerBlock <= 0 {
+		cfg.TimePerBlock = defaultTimePerBlock
+	}
+	if cfg.MaxDelay < cfg.MinDelay {
+		return nil, errors.New("MaxDelay is less than MinDelay")
+	}
+	if cfg.DropRate < 0 || cfg.DropRate >= 1 {
+		return nil, errors.New("DropRate must be in [0, 1) range")
+	}
+	if cfg.Logger == nil {
+		cfg.Logger = zap.NewNop()
+	}
+	s := &Simulator{
+		cfg: cfg,
+		rng: rand.New(rand.NewSource(cfg.Seed)),
+		txs: make(map[util.Uint256]*transaction.Transaction),
+	}
+	privs := append([]*keys.PrivateKey{}, cfg.Keys...)
+	if len(privs) == 0 {
+		if cfg.Validators == 0 {
+			cfg.Validators = 4
+		}
+		for len(privs) < cfg.Validators {
+			b := make([]byte, 32)
+			s.rng.Read(b)
+			p, err := keys.NewPrivateKeyFromBytes(b)
+			if err != nil {
+				continue // Out of curve order, very unlikely.
+			}
+			privs = append(privs, p)
+		}
+	}
+	sort.Slice(privs, func(i, j int) bool {
+		return privs[i].PublicKey().Cmp(privs[j].PublicKey()) < 0
+	})
+	committee := make([]string, len(privs))
+	for i, p := range privs {
+		committee[i] = hex.EncodeToString(p.PublicKey().Bytes())
+	}
+	bcfg := config.Blockchain{
+		ProtocolConfiguration: config.ProtocolConfiguration{
+			Magic:              netmode.UnitTestNet,
+			StandbyCommittee:   committee,
+			TimePerBlock:       cfg.TimePerBlock,
+			ValidatorsCount:    uint32(len(privs)),
+			VerifyTransactions: true,
+		},
+	}
+	for i, p := range privs {
+		log := cfg.Logger.With(zap.Int("node", i))
+		chain, err := core.NewBlockchain(storage.NewMemoryStore(), bcfg, log)
+		if err != nil {
+			s.Close()
+			return nil, fmt.Errorf("node %d: %w", i, err)
+		}
+		go chain.Run()
+		n := &simNode{
+			index:  i,
+			log:    log,
+			key:    p,
+			signer: signer.NewLocal(p),
+			chain:  chain,
+		}
+		n.timer = &simTimer{sim: s, node: n}
+		s.nodes = append(s.nodes, n)
+	}
+	genesis, err := s.nodes[0].chain.GetBlock(s.nodes[0].chain.GetHeaderHash(0))
+	if err != nil {
+		s.Close()
+		return nil, err
+	}
+	s.now = time.UnixMilli(int64(genesis.Timestamp))
+	return s, nil
+}
+
+// Start starts consensus on all nodes except the ones crashed before.
+func (s *Simulator) Start() error {
+	for i, n := range s.nodes {
+		if n.crashed {
+			continue
+		}
+		if err := s.Restart(i); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close stops all nodes and closes their chains.
+func (s *Simulator) Close() {
+	for _, n := range s.nodes {
+		n.timer.Stop()
+		n.srv = nil
+		n.chain.Close()
+	}
+	s.events = nil
+}
+
+// Now returns the current virtual time.
+func (s *Simulator) Now() time.Time {
+	return s.now
+}
+
+// Size returns the number of nodes.
+func (s *Simulator) Size() int {
+	return len(s.nodes)
+}
+
+// Chain returns the Blockchain of the i-th node.
+func (s *Simulator) Chain(i int) *core.Blockchain {
+	return s.nodes[i].chain
+}
+
+// PrivateKey returns the private key of the i-th node.
+func (s *Simulator) PrivateKey(i int) *keys.PrivateKey {
+	return s.nodes[i].key
+}
+
+// State returns the consensus state of the i-th node, it's empty for crashed
+// nodes.
+func (s *Simulator) State(i int) result.ConsensusState {
+	if s.nodes[i].srv == nil {
+		return result.ConsensusState{Index: -1}
+	}
+	return s.nodes[i].srv.GetState()
+}
+
+// SetBehavior changes the behavior of the i-th node.
+func (s *Simulator) SetBehavior(i int, b Behavior) {
+	s.nodes[i].behavior = b
+}
+
+// SetFilter sets the message filter applied after partitions and drops, nil
+// removes it.
+func (s *Simulator) SetFilter(f Filter) {
+	s.filter = f
+}
+
+// Partition splits the network into the given groups of nodes, nodes from
+// different groups can't communicate. Nodes not mentioned form one more
+// group.
+func (s *Simulator) Partition(groups ...[]int) {
+	for _, n := range s.nodes {
+		n.group = 0
+	}
+	for g, nodes := range groups {
+		for _, i := range nodes {
+			s.nodes[i].group = g + 1
+		}
+	}
+}
+
+// Heal removes all partitions, nodes that are behind synchronize their chains
+// with the other ones.
+func (s *Simulator) Heal() {
+	for _, n := range s.nodes {
+		n.group = 0
+	}
+	for i := range s.nodes {
+		s.sync(i)
+	}
+}
+
+// Crash stops the i-th node, it loses all consensus state (but not the chain)
+// and misses all messages until restarted. Messages it has already sent are
+// still delivered.
+func (s *Simulator) Crash(i int) {
+	n := s.nodes[i]
+	n.timer.Stop()
+	n.crashed = true
+	n.srv = nil
+}
+
+// Restart starts a new consensus service instance for the i-th node (it can
+// be used for running nodes too, their state is lost then). Double signing
+// protection of the node is kept.
+func (s *Simulator) Restart(i int) error {
+	n := s.nodes[i]
+	n.timer.Stop()
+	srv, err := driver.NewNode(consensus.Config{
+		Logger:                n.log,
+		Broadcast:             func(p *npayload.Extensible) { s.broadcast(n, p) },
+		Chain:                 n.chain,
+		BlockQueue:            simBlockQueue{sim: s, node: n},
+		ProtocolConfiguration: n.chain.GetConfig().ProtocolConfiguration,
+		RequestTx:             func(h ...util.Uint256) { s.requestTx(n, h) },
+		TimePerBlock:          s.cfg.TimePerBlock,
+	}, driver.Options{
+		Signer: n.signer,
+		Timer:  n.timer,
+		Nonce:  s.rng.Uint64,
+	})
+	if err != nil {
+		return fmt.Errorf("node %d: %w", i, err)
+	}
+	n.crashed = false
+	n.srv = srv
+	srv.Initialize()
+	s.sync(i)
+	return nil
+}
+
+// AddTransaction relays the transaction to all nodes (with the usual message
+// delays). It's checked against the first node's chain.
+func (s *Simulator) AddTransaction(tx *transaction.Transaction) error {
+	if err := s.nodes[0].chain.VerifyTx(tx); err != nil {
+		return err
+	}
+	s.txs[tx.Hash()] = tx
+	for _, n := range s.nodes {
+		s.sendTx(n, tx)
+	}
+	return nil
+}
+
+// Run processes events for the given period of virtual time.
+func (s *Simulator) Run(d time.Duration) {
+	end := s.now.Add(d)
+	for len(s.events) > 0 && !s.events[0].at.After(end) {
+		s.step()
+	}
+	s.now = end
+}
+
+// RunUntilHeight processes events until all running nodes have the block at
+// the given height, an error is returned if it doesn't happen within the
+// given period of virtual time.
+func (s *Simulator) RunUntilHeight(h uint32, limit time.Duration) error {
+	end := s.now.Add(limit)
+	for !s.reached(h) {
+		if len(s.events) == 0 || s.events[0].at.After(end) {
+			s.now = end
+			return fmt.Errorf("height %d is not reached in %s", h, limit)
+		}
+		s.step()
+	}
+	return nil
+}
+
+// CheckConsistency checks that the chains of all nodes have the same blocks
+// at the same heights.
+func (s *Simulator) CheckConsistency() error {
+	for _, n := range s.nodes[1:] {
+		h := n.chain.BlockHeight()
+		if top := s.nodes[0].chain.BlockHeight(); top < h {
+			h = top
+		}
+		for ; h > 0; h-- {
+			a, b := s.nodes[0].chain.GetHeaderHash(h), n.chain.GetHeaderHash(h)
+			if a != b {
+				return fmt.Errorf("nodes 0 and %d have different blocks at height %d: %s and %s",
+					n.index, h, a.StringLE(), b.StringLE())
+			}
+		}
+	}
+	return nil
+}
+
+func (s *Simulator) reached(h uint32) bool {
+	for _, n := range s.nodes {
+		if n.srv != nil && n.chain.BlockHeight() < h {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *Simulator) step() {
+	ev := heap.Pop(&s.events).(*simEvent)
+	s.now = ev.at
+	ev.run()
+}
+
+// schedule adds the event to be processed after the given delay.
+func (s *Simulator) schedule(d time.Duration, f func()) {
+	s.seq++
+	heap.Push(&s.events, &simEvent{at: s.now.Add(d), seq: s.seq, run: f})
+}
+
+func (s *Simulator) delay() time.Duration {
+	d := s.cfg.MinDelay
+	if spread := s.cfg.MaxDelay - s.cfg.MinDelay; spread > 0 {
+		d += time.Duration(s.rng.Int63n(int64(spread) + 1))
+	}
+	return d
+}
+
+func (s *Simulator) connected(a, b *simNode) bool {
+	return a.group == b.group
+}
+
+func (s *Simulator) broadcast(from *simNode, ep *npayload.Extensible) {
+	if from.behavior == Silent {
+		return
+	}
+	data, err := encodeExtensible(ep)
+	if err != nil {
+		from.log.Error("can't encode consensus payload", zap.Error(err))
+		return
+	}
+	var k int
+	for _, to := range s.nodes {
+		if to == from {
+			continue
+		}
+		msg := data
+		if from.behavior == Equivocating && k%2 == 0 {
+			if msg = s.equivocate(from, data); msg == nil {
+				k++
+				continue
+			}
+		}
+		k++
+		if !s.connected(from, to) || s.rng.Float64() < s.cfg.DropRate {
+			continue
+		}
+		to := to
+		s.schedule(s.delay(), func() { s.deliver(from, to, msg) })
+	}
+}
+
+// equivocate returns the message the equivocating node sends to the other
+// half of nodes: a conflicting version of the PrepareRequest, nothing
+// instead of recovery messages (which contain the original request) and the
+// same message for all other types.
+func (s *Simulator) equivocate(n *simNode, data []byte) []byte {
+	p, err := decodePayload(n, data)
+	if err != nil {
+		return data
+	}
+	switch p.Type() {
+	case payload.RecoveryMessageType:
+		return nil
+	case payload.PrepareRequestType:
+	default:
+		return data
+	}
+	p.GetPrepareRequest().SetNonce(s.rng.Uint64())
+	alt, err := sign(n, p)
+	if err != nil {
+		return data
+	}
+	return alt
+}
+
+// sign encodes the changed payload message and signs the payload the same way
+// consensus.Payload.Sign does, it returns the encoded Extensible.
+func sign(n *simNode, p *consensus.Payload) ([]byte, error) {
+	p.Extensible.Data = nil
+	w := io.NewBufBinWriter()
+	p.EncodeBinary(w.BinWriter) // Fills the Extensible data in.
+	if w.Err != nil {
+		return nil, w.Err
+	}
+	acc := signer.NewAccount(n.signer, n.key.PublicKey())
+	sig, err := acc.SignExtensible(uint32(netmode.UnitTestNet), &p.Extensible, signer.DomainConsensusMessage)
+	if err != nil {
+		return nil, err
+	}
+	w.Reset()
+	emit.Bytes(w.BinWriter, sig)
+	p.Witness.InvocationScript = w.Bytes()
+	p.Witness.VerificationScript = n.key.PublicKey().GetVerificationScript()
+	return encodeExtensible(&p.Extensible)
+}
+
+func (s *Simulator) deliver(from, to *simNode, data []byte) {
+	srv := to.srv
+	if srv == nil || !s.connected(from, to) {
+		return
+	}
+	ep := new(npayload.Extensible)
+	if err := decodeExtensible(ep, data); err != nil || !srv.Check(ep) {
+		return
+	}
+	if s.filter != nil {
+		p, err := decodePayload(to, data)
+		if err != nil || !s.filter(from.index, to.index, p) {
+			return
+		}
+	}
+	srv.Process(ep)
+}
+
+func (s *Simulator) sendTx(to *simNode, tx *transaction.Transaction) {
+	s.schedule(s.delay(), func() {
+		err := to.chain.PoolTx(tx)
+		if err != nil && !errors.Is(err, core.ErrAlreadyExists) {
+			return
+		}
+		if to.srv != nil {
+			to.srv.OnTransaction(tx)
+		}
+	})
+}
+
+func (s *Simulator) requestTx(n *simNode, hashes []util.Uint256) {
+	for _, h := range hashes {
+		if tx, ok := s.txs[h]; ok {
+			s.sendTx(n, tx)
+		}
+	}
+}
+
+// onBlock is called after the block is added to the node's chain.
+func (s *Simulator) onBlock(n *simNode, b *coreb.Block) {
+	s.schedule(0, func() {
+		if n.srv != nil {
+			n.srv.OnBlock(b)
+		}
+	})
+	for _, to := range s.nodes {
+		if to != n {
+			s.relayBlocks(n, to)
+		}
+	}
+}
+
+// sync makes the i-th node fetch missing blocks from the best reachable one.
+func (s *Simulator) sync(i int) {
+	to := s.nodes[i]
+	var best *simNode
+	for _, n := range s.nodes {
+		if n != to && s.connected(n, to) && n.chain.BlockHeight() > to.chain.BlockHeight() &&
+			(best == nil || n.chain.BlockHeight() > best.chain.BlockHeight()) {
+			best = n
+		}
+	}
+	if best != nil {
+		s.relayBlocks(best, to)
+	}
+}
+
+// relayBlocks schedules the delivery of all blocks the receiver lacks.
+func (s *Simulator) relayBlocks(from, to *simNode) {
+	s.schedule(s.delay(), func() {
+		if !s.connected(from, to) {
+			return
+		}
+		var last *coreb.Block
+		for h := to.chain.BlockHeight() + 1; h <= from.chain.BlockHeight(); h++ {
+			b, err := from.chain.GetBlock(from.chain.GetHeaderHash(h))
+			if err != nil {
+				break
+			}
+			if err := to.chain.AddBlock(b); err != nil {
+				to.log.Warn("can't add relayed block", zap.Uint32("index", b.Index), zap.Error(err))
+				break
+			}
+			last = b
+		}
+		if last != nil && to.srv != nil {
+			to.srv.OnBlock(last)
+		}
+	})
+}
+
+// simBlockQueue is a BlockQueuer adding blocks to the node's chain directly.
+type simBlockQueue struct {
+	sim  *Simulator
+	node *simNode
+}
+
+// PutBlock implements the BlockQueuer interface.
+func (q simBlockQueue) PutBlock(b *coreb.Block) error {
+	if err := q.node.chain.AddBlock(b); err != nil {
+		return err
+	}
+	q.sim.onBlock(q.node, b)
+	return nil
+}
+
+// simTimer is a dBFT timer working with the simulator's virtual clock. Every
+// Reset, Extend and Stop invalidates previously scheduled firings.
+type simTimer struct {
+	sim   *Simulator
+	node  *simNode
+	hv    timer.HV
+	start time.Time
+	d     time.Duration
+	gen   uint64
+}
+
+var _ timer.Timer = (*simTimer)(nil)
+
+// Now implements the timer.Timer interface.
+func (t *simTimer) Now() time.Time {
+	return t.sim.now
+}
+
+// Reset implements the timer.Timer interface.
+func (t *simTimer) Reset(hv timer.HV, d time.Duration) {
+	t.hv = hv
+	t.start = t.sim.now
+	t.d = d
+	t.schedule(d)
+}
+
+// Sleep implements the timer.Timer interface. It does nothing, the virtual
+// clock is only moved by the simulator.
+func (t *simTimer) Sleep(time.Duration) {}
+
+// Extend implements the timer.Timer interface.
+func (t *simTimer) Extend(d time.Duration) {
+	t.d += d
+	if elapsed := t.sim.now.Sub(t.start); t.d > elapsed {
+		t.schedule(t.d - elapsed)
+	}
+}
+
+// Stop implements the timer.Timer interface.
+func (t *simTimer) Stop() {
+	t.gen++
+}
+
+// HV implements the timer.Timer interface.
+func (t *simTimer) HV() timer.HV {
+	return t.hv
+}
+
+// C implements the timer.Timer interface. Simulated timers fire via the
+// simulator's event queue, so the channel is never ready.
+func (t *simTimer) C() <-chan time.Time {
+	return nil
+}
+
+func (t *simTimer) schedule(d time.Duration) {
+	t.gen++
+	gen := t.gen
+	t.sim.schedule(d, func() {
+		if t.gen != gen || t.node.srv == nil {
+			return
+		}
+		t.gen++
+		t.node.srv.OnTimeout()
+	})
+}
+
+type simEvent struct {
+	at  time.Time
+	seq uint64
+	run func()
+}
+
+// eventQueue is a heap of events ordered by time and then by the order they
+// were scheduled in.
+type eventQueue []*simEvent
+
+func (q eventQueue) Len() int { return len(q) }
+
+func (q eventQueue) Less(i, j int) bool {
+	if !q[i].at.Equal(q[j].at) {
+		return q[i].at.Before(q[j].at)
+	}
+	return q[i].seq < q[j].seq
+}
+
+func (q eventQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *eventQueue) Push(x any) { *q = append(*q, x.(*simEvent)) }
+
+func (q *eventQueue) Pop() any {
+	old := *q
+	ev := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return ev
+}
+
+func encodeExtensible(ep *npayload.Extensible) ([]byte, error) {
+	w := io.NewBufBinWriter()
+	ep.EncodeBinary(w.BinWriter)
+	if w.Err != nil {
+		return nil, w.Err
+	}
+	return w.Bytes(), nil
+}
+
+func decodeExtensible(ep *npayload.Extensible, data []byte) error {
+	r := io.NewBinReaderFromBuf(data)
+	ep.DecodeBinary(r)
+	return r.Err
+}
+
+func decodePayload(n *simNode, data []byte) (*consensus.Payload, error) {
+	p := consensus.NewPayload(netmode.UnitTestNet, n.chain.GetConfig().StateRootInHeader)
+	r := io.NewBinReaderFromBuf(data)
+	p.DecodeBinary(r)
+	return p, r.Err
+}
@@ -0,0 +1,185 @@
+package consensustest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/dbft/payload"
+	"github.com/nspcc-dev/neo-go/internal/testchain"
+	"github.com/nspcc-dev/neo-go/pkg/consensus"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSimulator(t *testing.T, cfg SimulatorConfig) *Simulator {
+	if cfg.MaxDelay == 0 {
+		cfg.MinDelay, cfg.MaxDelay = 10*time.Millisecond, 200*time.Millisecond
+	}
+	sim, err := NewSimulator(cfg)
+	require.NoError(t, err)
+	t.Cleanup(sim.Close)
+	return sim
+}
+
+func blockHashes(sim *Simulator, i int) []util.Uint256 {
+	bc := sim.Chain(i)
+	hs := make([]util.Uint256, bc.BlockHeight()+1)
+	for h := range hs {
+		hs[h] = bc.GetHeaderHash(uint32(h))
+	}
+	return hs
+}
+
+func primaryOf(t *testing.T, sim *Simulator, h uint32) byte {
+	b, err := sim.Chain(0).GetBlock(sim.Chain(0).GetHeaderHash(h))
+	require.NoError(t, err)
+	return b.PrimaryIndex
+}
+
+func TestNewSimulator(t *testing.T) {
+	_, err := NewSimulator(SimulatorConfig{MinDelay: time.Second})
+	require.Error(t, err)
+	_, err = NewSimulator(SimulatorConfig{DropRate: 1})
+	require.Error(t, err)
+}
+
+func TestSimulator(t *testing.T) {
+	sim := newTestSimulator(t, SimulatorConfig{Seed: 1})
+	require.NoError(t, sim.Start())
+	start := sim.Now()
+	require.NoError(t, sim.RunUntilHeight(5, 2*time.Minute))
+	require.NoError(t, sim.CheckConsistency())
+	for h := uint32(1); h <= 5; h++ {
+		require.Equal(t, byte(h%4), primaryOf(t, sim, h))
+	}
+	// Blocks are produced every TimePerBlock in virtual time.
+	require.Less(t, sim.Now().Sub(start), 5*defaultTimePerBlock+5*time.Second)
+
+	st := sim.State(0)
+	require.Equal(t, 0, st.Index)
+	require.NotNil(t, st.Round)
+	require.Equal(t, uint32(6), st.Round.Height)
+
+	t.Run("deterministic", func(t *testing.T) {
+		other := newTestSimulator(t, SimulatorConfig{Seed: 1})
+		require.NoError(t, other.Start())
+		require.NoError(t, other.RunUntilHeight(5, 2*time.Minute))
+		require.Equal(t, sim.Now(), other.Now())
+		require.Equal(t, blockHashes(sim, 0)[:6], blockHashes(other, 0)[:6])
+	})
+}
+
+func TestSimulator_Drops(t *testing.T) {
+	sim := newTestSimulator(t, SimulatorConfig{Seed: 2, DropRate: 0.2})
+	require.NoError(t, sim.Start())
+	require.NoError(t, sim.RunUntilHeight(5, 10*time.Minute))
+	require.NoError(t, sim.CheckConsistency())
+}
+
+func TestSimulator_CrashedPrimary(t *testing.T) {
+	sim := newTestSimulator(t, SimulatorConfig{Seed: 3})
+	sim.Crash(1) // Primary for the first block.
+	require.NoError(t, sim.Start())
+	require.NoError(t, sim.RunUntilHeight(1, 2*time.Minute))
+	require.NotEqual(t, byte(1), primaryOf(t, sim, 1))
+	require.Equal(t, uint32(0), sim.Chain(1).BlockHeight())
+	require.Equal(t, -1, sim.State(1).Index)
+
+	require.NoError(t, sim.Restart(1))
+	require.NoError(t, sim.RunUntilHeight(4, 2*time.Minute))
+	require.NoError(t, sim.CheckConsistency())
+}
+
+func TestSimulator_SilentPrimary(t *testing.T) {
+	sim := newTestSimulator(t, SimulatorConfig{Seed: 4})
+	sim.SetBehavior(1, Silent)
+	require.NoError(t, sim.Start())
+	require.NoError(t, sim.RunUntilHeight(1, 2*time.Minute))
+	require.NotEqual(t, byte(1), primaryOf(t, sim, 1))
+	require.NoError(t, sim.CheckConsistency())
+}
+
+func TestSimulator_Partition(t *testing.T) {
+	sim := newTestSimulator(t, SimulatorConfig{Seed: 5})
+	require.NoError(t, sim.Start())
+	sim.Partition([]int{0, 1}, []int{2, 3})
+	sim.Run(2 * time.Minute)
+	for i := 0; i < sim.Size(); i++ {
+		require.Equal(t, uint32(0), sim.Chain(i).BlockHeight())
+	}
+
+	sim.Heal()
+	require.NoError(t, sim.RunUntilHeight(3, 5*time.Minute))
+	require.NoError(t, sim.CheckConsistency())
+
+	t.Run("minority", func(t *testing.T) {
+		sim.Partition([]int{3})
+		h := sim.Chain(3).BlockHeight()
+		sim.Run(time.Minute)
+		require.Less(t, h+2, sim.Chain(0).BlockHeight())
+		require.Equal(t, h, sim.Chain(3).BlockHeight())
+
+		sim.Heal()
+		require.NoError(t, sim.RunUntilHeight(h+5, 5*time.Minute))
+		require.NoError(t, sim.CheckConsistency())
+	})
+}
+
+func TestSimulator_Equivocation(t *testing.T) {
+	sim := newTestSimulator(t, SimulatorConfig{Seed: 6})
+	sim.SetBehavior(1, Equivocating)
+	require.NoError(t, sim.Start())
+	require.NoError(t, sim.RunUntilHeight(5, 5*time.Minute))
+	require.NoError(t, sim.CheckConsistency())
+	require.NotEqual(t, byte(1), primaryOf(t, sim, 1))
+	require.NotEqual(t, byte(1), primaryOf(t, sim, 5))
+}
+
+func TestSimulator_Filter(t *testing.T) {
+	sim := newTestSimulator(t, SimulatorConfig{Seed: 7})
+	require.NoError(t, sim.Start())
+	var commits int
+	sim.SetFilter(func(from, to int, p *consensus.Payload) bool {
+		switch p.Type() {
+		case payload.CommitType:
+			commits++
+			return false
+		case payload.RecoveryMessageType: // Commits are also relayed via recovery messages.
+			return false
+		}
+		return true
+	})
+	sim.Run(time.Minute)
+	require.NotZero(t, commits)
+	require.Equal(t, uint32(0), sim.Chain(0).BlockHeight())
+
+	sim.SetFilter(nil)
+	require.NoError(t, sim.RunUntilHeight(1, 2*time.Minute))
+}
+
+func TestSimulator_Transactions(t *testing.T) {
+	privs := make([]*keys.PrivateKey, testchain.Size())
+	for i := range privs {
+		privs[i] = testchain.PrivateKey(i)
+	}
+	sim := newTestSimulator(t, SimulatorConfig{Seed: 8, Keys: privs})
+	require.NoError(t, sim.Start())
+
+	tx := transaction.New([]byte{byte(opcode.PUSH1)}, 0)
+	tx.ValidUntilBlock = 10
+	tx.Signers = []transaction.Signer{{Account: testchain.MultisigScriptHash()}}
+	require.NoError(t, testchain.SignTx(sim.Chain(0), tx))
+	require.NoError(t, sim.AddTransaction(tx))
+
+	// The first block is proposed right after the start, so the transaction
+	// gets into the second one.
+	require.NoError(t, sim.RunUntilHeight(2, time.Minute))
+	for i := 0; i < sim.Size(); i++ {
+		_, h, err := sim.Chain(i).GetTransaction(tx.Hash())
+		require.NoError(t, err)
+		require.Equal(t, uint32(2), h)
+	}
+}
@@ -5,17 +5,20 @@ import (
 	"errors"
 
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/services/signer"
 )
 
-// privateKey is a wrapper around keys.PrivateKey
+// privateKey is a wrapper around signer.Account
 // which implements the crypto.PrivateKey interface.
 type privateKey struct {
-	*keys.PrivateKey
+	*signer.Account
 }
 
-// Sign implements the dbft's crypto.PrivateKey interface.
+// Sign implements the dbft's crypto.PrivateKey interface. It's not used by
+// dBFT for blocks and payloads (they're signed via signer requests with
+// double-signing protection) and signing arbitrary data is not allowed.
 func (p *privateKey) Sign(data []byte) ([]byte, error) {
-	return p.PrivateKey.Sign(data), nil
+	return nil, errors.New("arbitrary data signing is not supported")
 }
 
 // publicKey is a wrapper around keys.PublicKey
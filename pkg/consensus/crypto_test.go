@@ -11,7 +11,7 @@ func TestCrypt(t *testing.T) {
 	key, err := keys.NewPrivateKey()
 	require.NoError(t, err)
 
-	priv := privateKey{key}
+	priv := newTestPrivateKey(key)
 
 	key1, err := keys.NewPrivateKey()
 	require.NoError(t, err)
@@ -27,8 +27,10 @@ func TestCrypt(t *testing.T) {
 
 	data = []byte{1, 2, 3, 4}
 
-	sign, err := priv.Sign(data)
-	require.NoError(t, err)
+	_, err = priv.Sign(data)
+	require.Error(t, err)
+
+	sign := key.Sign(data)
 	require.NoError(t, pub.Verify(data, sign))
 
 	sign[0] = ^sign[0]
@@ -0,0 +1,68 @@
+package consensus
+
+import (
+	"github.com/nspcc-dev/dbft"
+	"github.com/nspcc-dev/neo-go/pkg/consensus/internal/driver"
+	coreb "github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	npayload "github.com/nspcc-dev/neo-go/pkg/network/payload"
+)
+
+func init() {
+	driver.NewNode = newDriverNode
+}
+
+// driverNode is a service driven synchronously via the driver.Node interface.
+type driverNode struct {
+	srv *service
+}
+
+func newDriverNode(cfg any, o driver.Options) (driver.Node, error) {
+	srv, err := newService(cfg.(Config), dbft.WithTimer(o.Timer))
+	if err != nil {
+		return nil, err
+	}
+	srv.signer = o.Signer
+	srv.nonce = o.Nonce
+	return driverNode{srv: srv}, nil
+}
+
+// Initialize implements the driver.Node interface.
+func (n driverNode) Initialize() {
+	n.srv.initialize()
+}
+
+// GetState implements the driver.Node interface.
+func (n driverNode) GetState() result.ConsensusState {
+	return n.srv.GetState()
+}
+
+// Check implements the driver.Node interface.
+func (n driverNode) Check(ep *npayload.Extensible) bool {
+	p := n.srv.payloadFromExtensible(ep)
+	return p.decodeData() == nil && n.srv.validatePayload(p)
+}
+
+// Process implements the driver.Node interface.
+func (n driverNode) Process(ep *npayload.Extensible) {
+	p := n.srv.payloadFromExtensible(ep)
+	if p.decodeData() == nil {
+		n.srv.onMessage(*p)
+	}
+}
+
+// OnTransaction implements the driver.Node interface.
+func (n driverNode) OnTransaction(tx *transaction.Transaction) {
+	n.srv.dbft.OnTransaction(tx)
+}
+
+// OnBlock implements the driver.Node interface.
+func (n driverNode) OnBlock(b *coreb.Block) {
+	n.srv.handleChainBlock(b)
+}
+
+// OnTimeout implements the driver.Node interface.
+func (n driverNode) OnTimeout() {
+	n.srv.onTimeout()
+}
@@ -0,0 +1,49 @@
+/*
+Package driver provides access to the consensus service internals for the
+consensustest package. It allows to run the service without its event loop,
+every event is handled synchronously by the caller.
+*/
+package driver
+
+import (
+	"github.com/nspcc-dev/dbft/timer"
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/network/payload"
+	"github.com/nspcc-dev/neo-go/pkg/services/signer"
+)
+
+// Options are the service parameters not available via the consensus.Config.
+type Options struct {
+	// Signer is used instead of the Wallet and RemoteSigner.
+	Signer signer.Signer
+	// Timer replaces the default dBFT timer.
+	Timer timer.Timer
+	// Nonce generates block nonces instead of the random source if set.
+	Nonce func() uint64
+}
+
+// Node is a consensus service driven by the caller, it's not safe for
+// concurrent use.
+type Node interface {
+	// Initialize starts dBFT for the current chain height.
+	Initialize()
+	// GetState returns the current consensus state.
+	GetState() result.ConsensusState
+	// Check decodes the consensus payload and checks that it's sent by one
+	// of the validators.
+	Check(ep *payload.Extensible) bool
+	// Process handles the consensus payload that has passed the Check.
+	Process(ep *payload.Extensible)
+	// OnTransaction handles the transaction added to the pool.
+	OnTransaction(tx *transaction.Transaction)
+	// OnBlock handles the block added to the chain.
+	OnBlock(b *block.Block)
+	// OnTimeout handles the dBFT timer event.
+	OnTimeout()
+}
+
+// NewNode creates a new Node, cfg must be a consensus.Config. It's set by the
+// consensus package.
+var NewNode func(cfg any, o Options) (Node, error)
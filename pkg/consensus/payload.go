@@ -7,6 +7,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
 	"github.com/nspcc-dev/neo-go/pkg/io"
 	npayload "github.com/nspcc-dev/neo-go/pkg/network/payload"
+	"github.com/nspcc-dev/neo-go/pkg/services/signer"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
 )
@@ -130,7 +131,10 @@ func (p *Payload) EncodeBinary(w *io.BinWriter) {
 // It also sets corresponding verification and invocation scripts.
 func (p *Payload) Sign(key *privateKey) error {
 	p.encodeData()
-	sig := key.PrivateKey.SignHashable(uint32(p.network), &p.Extensible)
+	sig, err := key.SignExtensible(uint32(p.network), &p.Extensible, signer.DomainConsensusMessage)
+	if err != nil {
+		return err
+	}
 
 	buf := io.NewBufBinWriter()
 	emit.Bytes(buf.BinWriter, sig)
@@ -158,6 +158,7 @@ func randomPayload(t *testing.T, mt messageType) *Payload {
 			payload:        randomMessage(t, mt),
 		},
 		Extensible: npayload.Extensible{
+			Category: npayload.ConsensusCategory,
 			Witness: transaction.Witness{
 				InvocationScript:   random.Bytes(3),
 				VerificationScript: []byte{byte(opcode.PUSH0)},
@@ -257,7 +258,7 @@ func TestPayload_Sign(t *testing.T) {
 	key, err := keys.NewPrivateKey()
 	require.NoError(t, err)
 
-	priv := &privateKey{key}
+	priv := newTestPrivateKey(key)
 
 	p := randomPayload(t, prepareRequestType)
 	h := priv.PublicKey().GetScriptHash()
@@ -0,0 +1,118 @@
+package consensus
+
+import (
+	"encoding/hex"
+	"time"
+
+	"github.com/nspcc-dev/dbft/payload"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Metrics for monitoring service.
+var (
+	// consensusHeight prometheus metric.
+	consensusHeight = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Help:      "Height of the current consensus round",
+			Name:      "consensus_height",
+			Namespace: "neogo",
+		},
+	)
+	// consensusView prometheus metric.
+	consensusView = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Help:      "View number of the current consensus round",
+			Name:      "consensus_view",
+			Namespace: "neogo",
+		},
+	)
+	// consensusViewChanges prometheus metric.
+	consensusViewChanges = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Help:      "Number of view changes by reason",
+			Name:      "consensus_view_changes_total",
+			Namespace: "neogo",
+		},
+		[]string{"reason"},
+	)
+	// consensusMessages prometheus metric.
+	consensusMessages = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Help:      "Number of consensus messages sent and received by type",
+			Name:      "consensus_messages_total",
+			Namespace: "neogo",
+		},
+		[]string{"type"},
+	)
+	// consensusRoundDuration prometheus metric.
+	consensusRoundDuration = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Help:      "Consensus round duration",
+			Name:      "consensus_round_duration_seconds",
+			Namespace: "neogo",
+			Buckets:   []float64{1, 2, 5, 10, 15, 20, 30, 60, 120, 300},
+		},
+	)
+	// consensusCommitDelay prometheus metric.
+	consensusCommitDelay = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Help:      "Time from the round start to Commit for the last finished round by validator",
+			Name:      "consensus_validator_commit_delay_seconds",
+			Namespace: "neogo",
+		},
+		[]string{"validator"},
+	)
+	// consensusMissedCommits prometheus metric.
+	consensusMissedCommits = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Help:      "Number of finished rounds without Commit from validator",
+			Name:      "consensus_validator_missed_commits_total",
+			Namespace: "neogo",
+		},
+		[]string{"validator"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(
+		consensusHeight,
+		consensusView,
+		consensusViewChanges,
+		consensusMessages,
+		consensusRoundDuration,
+		consensusCommitDelay,
+		consensusMissedCommits,
+	)
+}
+
+func updateConsensusStateMetrics(height uint32, view byte) {
+	consensusHeight.Set(float64(height))
+	consensusView.Set(float64(view))
+}
+
+func addViewChangeMetric(reason string) {
+	if len(reason) == 0 {
+		reason = payload.CVUnknown.String()
+	}
+	consensusViewChanges.WithLabelValues(reason).Inc()
+}
+
+func addConsensusMessageMetric(typ string) {
+	consensusMessages.WithLabelValues(typ).Inc()
+}
+
+// updateRoundMetrics updates round duration and per-validator metrics for
+// the finished round.
+func updateRoundMetrics(r *result.ConsensusTimeline) {
+	consensusRoundDuration.Observe(float64(r.End-r.Start) / float64(time.Second/time.Millisecond))
+	activity := roundActivity(r)
+	for i, pub := range r.Validators {
+		label := hex.EncodeToString(pub.Bytes())
+		if a := activity[uint16(i)]; a.commit != 0 {
+			consensusCommitDelay.WithLabelValues(label).Set(float64(a.commit-r.Start) / float64(time.Second/time.Millisecond))
+		} else {
+			consensusMissedCommits.WithLabelValues(label).Inc()
+		}
+	}
+}
@@ -48,7 +48,7 @@ func testRecoveryMessageSetters(t *testing.T, enableStateRoot bool) {
 	p1.SetHeight(msgHeight)
 	p1.SetPayload(req)
 	p1.SetValidatorIndex(0)
-	p1.Sender = privs[0].GetScriptHash()
+	p1.Sender = privs[0].ScriptHash()
 	require.NoError(t, p1.Sign(privs[0]))
 
 	t.Run("prepare response is added", func(t *testing.T) {
@@ -59,7 +59,7 @@ func testRecoveryMessageSetters(t *testing.T, enableStateRoot bool) {
 			preparationHash: p1.Hash(),
 		})
 		p2.SetValidatorIndex(1)
-		p2.Sender = privs[1].GetScriptHash()
+		p2.Sender = privs[1].ScriptHash()
 		require.NoError(t, p2.Sign(privs[1]))
 
 		r.AddPayload(p2)
@@ -98,7 +98,7 @@ func testRecoveryMessageSetters(t *testing.T, enableStateRoot bool) {
 			timestamp:     12345,
 		})
 		p3.SetValidatorIndex(3)
-		p3.Sender = privs[3].GetScriptHash()
+		p3.Sender = privs[3].ScriptHash()
 		require.NoError(t, p3.Sign(privs[3]))
 
 		r.AddPayload(p3)
@@ -119,7 +119,7 @@ func testRecoveryMessageSetters(t *testing.T, enableStateRoot bool) {
 		p4.SetHeight(msgHeight)
 		p4.SetPayload(randomMessage(t, commitType))
 		p4.SetValidatorIndex(3)
-		p4.Sender = privs[3].GetScriptHash()
+		p4.Sender = privs[3].ScriptHash()
 		require.NoError(t, p4.Sign(privs[3]))
 
 		r.AddPayload(p4)
@@ -0,0 +1,259 @@
+package consensus
+
+import (
+	"sync"
+	"time"
+
+	"github.com/nspcc-dev/dbft/payload"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// timelineHistorySize is the number of finished rounds kept by the service.
+const timelineHistorySize = 100
+
+// timeline records consensus events. Events are added from the consensus
+// event loop and read via RPC, so it's safe for concurrent use.
+type timeline struct {
+	lock    sync.RWMutex
+	index   int
+	current *result.ConsensusTimeline
+	// history contains finished rounds, the oldest one first.
+	history []*result.ConsensusTimeline
+}
+
+func newTimeline() *timeline {
+	return &timeline{index: -1}
+}
+
+func toMillis(t time.Time) uint64 {
+	return uint64(t.UnixNano() / int64(time.Millisecond))
+}
+
+// initialize handles dBFT (re)initialization at the given height and view,
+// it starts a new round or records a view change.
+func (t *timeline) initialize(height uint32, view byte, primary uint16, index int, validators keys.PublicKeys, now time.Time) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.index = index
+	updateConsensusStateMetrics(height, view)
+	if t.current != nil && t.current.Height == height {
+		if view <= t.current.View {
+			return
+		}
+		vc := result.ConsensusViewChange{
+			View:   view,
+			Time:   toMillis(now),
+			Reason: changeViewReason(t.current.Messages, t.current.View),
+		}
+		t.current.ViewChanges = append(t.current.ViewChanges, vc)
+		t.current.View = view
+		t.current.Primary = primary
+		addViewChangeMetric(vc.Reason)
+		return
+	}
+	t.finish(now)
+	t.current = &result.ConsensusTimeline{
+		Height:      height,
+		View:        view,
+		Primary:     primary,
+		Validators:  validators,
+		Start:       toMillis(now),
+		Messages:    []result.ConsensusMessage{},
+		ViewChanges: []result.ConsensusViewChange{},
+	}
+}
+
+// changeViewReason returns the most common reason of ChangeView messages
+// sent in the given view.
+func changeViewReason(msgs []result.ConsensusMessage, view byte) string {
+	var (
+		counts = make(map[string]int)
+		res    string
+	)
+	for _, m := range msgs {
+		if m.Type != payload.ChangeViewType.String() || m.View != view {
+			continue
+		}
+		counts[m.Reason]++
+		if c := counts[m.Reason]; c > counts[res] || (c == counts[res] && m.Reason < res) {
+			res = m.Reason
+		}
+	}
+	return res
+}
+
+// message records the consensus message sent or received by the node.
+// Messages for other heights are ignored.
+func (t *timeline) message(p *Payload, now time.Time) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if t.current == nil || p.Height() != t.current.Height {
+		return
+	}
+	addConsensusMessageMetric(p.Type().String())
+	switch p.Type() {
+	case payload.RecoveryRequestType:
+		t.current.RecoveryRequests++
+	case payload.RecoveryMessageType:
+		t.current.RecoveryMessages++
+	}
+	ev := result.ConsensusMessage{
+		Type:      p.Type().String(),
+		Validator: p.ValidatorIndex(),
+		View:      p.ViewNumber(),
+		Time:      toMillis(now),
+	}
+	if p.Type() == payload.ChangeViewType {
+		ev.Reason = p.GetChangeView().Reason().String()
+	}
+	t.current.Messages = append(t.current.Messages, ev)
+}
+
+// blockAccepted marks the round at the given height as finished with the
+// given block.
+func (t *timeline) blockAccepted(height uint32, h util.Uint256, now time.Time) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if t.current == nil || t.current.Height != height || t.current.End != 0 {
+		return
+	}
+	t.current.End = toMillis(now)
+	t.current.Block = &h
+}
+
+// finish moves the current round to the history. It must be called with
+// the lock held.
+func (t *timeline) finish(now time.Time) {
+	r := t.current
+	if r == nil {
+		return
+	}
+	if r.End == 0 {
+		r.End = toMillis(now)
+	}
+	updateRoundMetrics(r)
+	if len(t.history) == timelineHistorySize {
+		copy(t.history, t.history[1:])
+		t.history = t.history[:len(t.history)-1]
+	}
+	t.history = append(t.history, r)
+	t.current = nil
+}
+
+// state returns the current state and statistics.
+func (t *timeline) state() result.ConsensusState {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	var st = result.ConsensusState{Index: t.index}
+	if t.current != nil {
+		r := copyTimeline(t.current)
+		st.Round = &r
+	}
+	var (
+		stats = make(map[string]*result.ConsensusValidatorStats)
+		order []string
+		delay = make(map[string]uint64)
+	)
+	rounds := t.history
+	if t.current != nil {
+		rounds = append(rounds[:len(rounds):len(rounds)], t.current)
+	}
+	for _, r := range rounds {
+		activity := roundActivity(r)
+		for i, pub := range r.Validators {
+			k := string(pub.Bytes())
+			vs, ok := stats[k]
+			if !ok {
+				vs = &result.ConsensusValidatorStats{PublicKey: pub}
+				stats[k] = vs
+				order = append(order, k)
+			}
+			a := activity[uint16(i)]
+			vs.Rounds++
+			if a.prepared {
+				vs.Preparations++
+			}
+			if a.commit != 0 {
+				vs.Commits++
+				delay[k] += a.commit - r.Start
+			} else if r != t.current {
+				vs.MissedCommits++
+			}
+			if a.changedView {
+				vs.ChangeViews++
+			}
+			if a.lastSeen != 0 {
+				vs.LastSeen = a.lastSeen
+			}
+		}
+	}
+	st.Validators = make([]result.ConsensusValidatorStats, 0, len(order))
+	for _, k := range order {
+		vs := stats[k]
+		if vs.Commits != 0 {
+			vs.CommitDelay = delay[k] / uint64(vs.Commits)
+		}
+		st.Validators = append(st.Validators, *vs)
+	}
+	return st
+}
+
+// validatorActivity is a summary of messages from a single validator in
+// a round.
+type validatorActivity struct {
+	prepared    bool
+	changedView bool
+	// commit is the time of the first Commit message (0 if there is none).
+	commit   uint64
+	lastSeen uint64
+}
+
+// roundActivity summarizes messages of the round per validator index.
+func roundActivity(r *result.ConsensusTimeline) map[uint16]validatorActivity {
+	var res = make(map[uint16]validatorActivity)
+	for _, m := range r.Messages {
+		a := res[m.Validator]
+		switch m.Type {
+		case payload.PrepareRequestType.String(), payload.PrepareResponseType.String():
+			a.prepared = true
+		case payload.ChangeViewType.String():
+			a.changedView = true
+		case payload.CommitType.String():
+			if a.commit == 0 {
+				a.commit = m.Time
+			}
+		}
+		a.lastSeen = m.Time
+		res[m.Validator] = a
+	}
+	return res
+}
+
+// getHistory returns up to n latest finished rounds, the latest one first.
+// All of them are returned if n is not positive.
+func (t *timeline) getHistory(n int) []result.ConsensusTimeline {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	if n <= 0 || n > len(t.history) {
+		n = len(t.history)
+	}
+	res := make([]result.ConsensusTimeline, 0, n)
+	for i := len(t.history) - 1; i >= len(t.history)-n; i-- {
+		res = append(res, copyTimeline(t.history[i]))
+	}
+	return res
+}
+
+func copyTimeline(r *result.ConsensusTimeline) result.ConsensusTimeline {
+	res := *r
+	res.Messages = append([]result.ConsensusMessage{}, r.Messages...)
+	res.ViewChanges = append([]result.ConsensusViewChange{}, r.ViewChanges...)
+	return res
+}
@@ -0,0 +1,116 @@
+package consensus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/dbft/payload"
+	"github.com/nspcc-dev/neo-go/internal/random"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/stretchr/testify/require"
+)
+
+func newTimelinePayload(height uint32, view byte, from uint16, t payload.MessageType, msg any) *Payload {
+	p := NewPayload(0, false)
+	p.SetHeight(height)
+	p.SetViewNumber(view)
+	p.SetValidatorIndex(from)
+	p.SetType(t)
+	p.SetPayload(msg)
+	return p
+}
+
+func TestTimeline(t *testing.T) {
+	var (
+		tl    = newTimeline()
+		start = time.Unix(1000, 0)
+		pubs  keys.PublicKeys
+	)
+	for i := 0; i < 4; i++ {
+		_, pub := getTestValidator(i)
+		pubs = append(pubs, pub.PublicKey)
+	}
+	at := func(ms int) time.Time { return start.Add(time.Duration(ms) * time.Millisecond) }
+
+	require.Nil(t, tl.state().Round)
+	require.Empty(t, tl.getHistory(0))
+
+	tl.initialize(10, 0, 1, 2, pubs, at(0))
+	// Repeated initialization for the same round is ignored.
+	tl.initialize(10, 0, 1, 2, pubs, at(50))
+	tl.message(newTimelinePayload(10, 0, 1, payload.PrepareRequestType, &prepareRequest{}), at(100))
+	tl.message(newTimelinePayload(10, 0, 3, payload.ChangeViewType, &changeView{newViewNumber: 1, reason: payload.CVTxNotFound}), at(150))
+	tl.message(newTimelinePayload(10, 0, 0, payload.ChangeViewType, &changeView{newViewNumber: 1, reason: payload.CVTimeout}), at(200))
+	tl.message(newTimelinePayload(10, 0, 2, payload.ChangeViewType, &changeView{newViewNumber: 1, reason: payload.CVTimeout}), at(200))
+	// Messages for other heights are ignored.
+	tl.message(newTimelinePayload(11, 0, 0, payload.CommitType, &commit{}), at(210))
+
+	tl.initialize(10, 1, 2, 2, pubs, at(300))
+	tl.message(newTimelinePayload(10, 1, 2, payload.PrepareRequestType, &prepareRequest{}), at(400))
+	tl.message(newTimelinePayload(10, 1, 0, payload.PrepareResponseType, &prepareResponse{}), at(500))
+	tl.message(newTimelinePayload(10, 1, 1, payload.RecoveryRequestType, &recoveryRequest{}), at(550))
+	for _, i := range []uint16{0, 1, 2} {
+		tl.message(newTimelinePayload(10, 1, i, payload.CommitType, &commit{}), at(600+100*int(i)))
+	}
+
+	st := tl.state()
+	require.Equal(t, 2, st.Index)
+	require.Equal(t, uint32(10), st.Round.Height)
+	require.Equal(t, byte(1), st.Round.View)
+	require.Equal(t, uint16(2), st.Round.Primary)
+	require.Equal(t, toMillis(start), st.Round.Start)
+	require.Zero(t, st.Round.End)
+	require.Equal(t, []result.ConsensusViewChange{{View: 1, Time: toMillis(at(300)), Reason: payload.CVTimeout.String()}}, st.Round.ViewChanges)
+	require.Equal(t, 1, st.Round.RecoveryRequests)
+	require.Len(t, st.Round.Messages, 10)
+	require.Equal(t, result.ConsensusMessage{Type: "ChangeView", Validator: 3, View: 0, Time: toMillis(at(150)), Reason: "TxNotFound"}, st.Round.Messages[1])
+	require.Len(t, st.Validators, 4)
+	require.Equal(t, result.ConsensusValidatorStats{
+		PublicKey:    pubs[0],
+		Rounds:       1,
+		Preparations: 1,
+		Commits:      1,
+		ChangeViews:  1,
+		CommitDelay:  600,
+		LastSeen:     toMillis(at(600)),
+	}, st.Validators[0])
+	// Commits are not missed until the round is finished.
+	require.Equal(t, result.ConsensusValidatorStats{
+		PublicKey:   pubs[3],
+		Rounds:      1,
+		ChangeViews: 1,
+		LastSeen:    toMillis(at(150)),
+	}, st.Validators[3])
+
+	h := random.Uint256()
+	tl.blockAccepted(9, random.Uint256(), at(800))
+	tl.blockAccepted(10, h, at(900))
+	tl.blockAccepted(10, random.Uint256(), at(950))
+	tl.initialize(11, 0, 2, 2, pubs, at(1000))
+
+	hist := tl.getHistory(0)
+	require.Len(t, hist, 1)
+	require.Equal(t, &h, hist[0].Block)
+	require.Equal(t, toMillis(at(900)), hist[0].End)
+	st = tl.state()
+	require.Equal(t, uint32(11), st.Round.Height)
+	require.Equal(t, 2, st.Validators[3].Rounds)
+	require.Equal(t, 1, st.Validators[3].MissedCommits)
+	require.Equal(t, 0, st.Validators[0].MissedCommits)
+
+	t.Run("history", func(t *testing.T) {
+		for i := uint32(12); i < 12+timelineHistorySize; i++ {
+			tl.initialize(i, 0, 0, -1, pubs, at(int(i)*1000))
+		}
+		hist := tl.getHistory(0)
+		require.Len(t, hist, timelineHistorySize)
+		require.Equal(t, uint32(10+timelineHistorySize), hist[0].Height)
+		require.Equal(t, uint32(11), hist[len(hist)-1].Height)
+		hist = tl.getHistory(2)
+		require.Len(t, hist, 2)
+		require.Equal(t, uint32(10+timelineHistorySize), hist[0].Height)
+		require.Equal(t, uint32(9+timelineHistorySize), hist[1].Height)
+		require.Equal(t, -1, tl.state().Index)
+	})
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/interop"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop/contract"
 	"github.com/nspcc-dev/neo-go/pkg/core/mempool"
+	"github.com/nspcc-dev/neo-go/pkg/core/mempoolevent"
 	"github.com/nspcc-dev/neo-go/pkg/core/mpt"
 	"github.com/nspcc-dev/neo-go/pkg/core/native"
 	"github.com/nspcc-dev/neo-go/pkg/core/native/noderoles"
@@ -48,6 +49,7 @@ const (
 	version = "0.2.9"
 
 	defaultInitialGAS                      = 52000000_00000000
+	defaultFutureMemPoolSenderLimit        = 16
 	defaultGCPeriod                        = 10000
 	defaultMemPoolSize                     = 50000
 	defaultP2PNotaryRequestPayloadPoolSize = 1000
@@ -101,6 +103,9 @@ var (
 	// ErrInvalidBlockIndex is returned when trying to add block with index
 	// other than expected height of the blockchain.
 	ErrInvalidBlockIndex = errors.New("invalid block index")
+	// ErrBlockHeaderMismatch is returned when trying to add block that
+	// doesn't match the header already stored for its index.
+	ErrBlockHeaderMismatch = errors.New("block doesn't match the stored header")
 	// ErrHasConflicts is returned when trying to add some transaction which
 	// conflicts with other transaction in the chain or pool according to
 	// Conflicts attribute.
@@ -159,6 +164,15 @@ type Blockchain struct {
 	isRunning atomic.Value
 
 	memPool *mempool.Pool
+	// mempoolSubs contains memPool event subscribers, memPool event
+	// subscriptions are only running when there is at least one of them.
+	mempoolSubsLock sync.Mutex
+	mempoolSubs     map[chan<- mempoolevent.Event]struct{}
+	// futurePool contains not yet valid transactions (it's nil if disabled).
+	futurePool *mempool.Future
+	// futureCb is called for every transaction moved from futurePool to
+	// memPool, it's protected by the lock.
+	futureCb func(*transaction.Transaction)
 
 	// postBlock is a set of callback methods which should be run under the Blockchain lock after new block is persisted.
 	// Block's transactions are passed via mempool.
@@ -302,6 +316,10 @@ func NewBlockchain(s storage.Store, cfg config.Blockchain, log *zap.Logger) (*Bl
 		cfg.Ledger.GarbageCollectionPeriod = defaultGCPeriod
 		log.Info("GarbageCollectionPeriod is not set or wrong, using default value", zap.Uint32("GarbageCollectionPeriod", cfg.Ledger.GarbageCollectionPeriod))
 	}
+	if cfg.Ledger.FutureMemPoolSize > 0 && cfg.Ledger.FutureMemPoolSenderLimit <= 0 {
+		cfg.Ledger.FutureMemPoolSenderLimit = defaultFutureMemPoolSenderLimit
+		log.Info("FutureMemPoolSenderLimit is not set or wrong, using default value", zap.Int("FutureMemPoolSenderLimit", cfg.Ledger.FutureMemPoolSenderLimit))
+	}
 	bc := &Blockchain{
 		config:      cfg,
 		dao:         dao.NewSimple(s, cfg.StateRootInHeader, cfg.P2PSigExtensions),
@@ -317,6 +335,13 @@ func NewBlockchain(s storage.Store, cfg config.Blockchain, log *zap.Logger) (*Bl
 		contracts:   *native.NewContracts(cfg.ProtocolConfiguration),
 	}
 
+	if cfg.Ledger.ReplaceByFee {
+		bc.memPool.SetReplaceByFee(cfg.Ledger.ReplaceByFeeMinBump, cfg.Ledger.ReplaceByFeeNonceRange)
+	}
+	if cfg.Ledger.FutureMemPoolSize > 0 && cfg.P2PSigExtensions {
+		bc.futurePool = mempool.NewFuture(cfg.Ledger.FutureMemPoolSize, cfg.Ledger.FutureMemPoolSenderLimit, updateFutureTxMetrics)
+	}
+
 	bc.stateRoot = stateroot.NewModule(cfg, bc.VerifyWitness, bc.log, bc.dao.Store)
 	bc.contracts.Designate.StateRootService = bc.stateRoot
 
@@ -1038,6 +1063,12 @@ func (bc *Blockchain) Run() {
 		if err := bc.dao.Store.Close(); err != nil {
 			bc.log.Warn("failed to close db", zap.Error(err))
 		}
+		bc.mempoolSubsLock.Lock()
+		if len(bc.mempoolSubs) != 0 {
+			bc.memPool.DisableSubscriptions()
+			bc.mempoolSubs = nil
+		}
+		bc.mempoolSubsLock.Unlock()
 		bc.isRunning.Store(false)
 		close(bc.runToExitCh)
 	}()
@@ -1434,6 +1465,9 @@ func (bc *Blockchain) AddBlock(block *block.Block) error {
 		if err != nil {
 			return err
 		}
+	} else if h := bc.GetHeaderHash(block.Index); !h.Equals(block.Hash()) {
+		// Header is already known (and verified), so the block must match it.
+		return fmt.Errorf("%w: expected %s, got %s", ErrBlockHeaderMismatch, h.StringLE(), block.Hash().StringLE())
 	}
 	if !bc.config.SkipBlockVerification {
 		merkle := block.ComputeMerkleRoot()
@@ -1732,6 +1766,7 @@ func (bc *Blockchain) storeBlock(block *block.Block, txpool *mempool.Pool) error
 	bc.topBlock.Store(block)
 	atomic.StoreUint32(&bc.blockHeight, block.Index)
 	bc.memPool.RemoveStale(func(tx *transaction.Transaction) bool { return bc.IsTxStillRelevant(tx, txpool, false) }, bc)
+	promoted := bc.promoteFutureTxs(block.Index)
 	for _, f := range bc.postBlock {
 		f(bc.IsTxStillRelevant, txpool, block)
 	}
@@ -1739,8 +1774,15 @@ func (bc *Blockchain) storeBlock(block *block.Block, txpool *mempool.Pool) error
 		bc.lock.Unlock()
 		return err
 	}
+	futureCb := bc.futureCb
 	bc.lock.Unlock()
 
+	if futureCb != nil {
+		for _, tx := range promoted {
+			futureCb(tx)
+		}
+	}
+
 	updateBlockHeightMetric(block.Index)
 	// Genesis block is stored when Blockchain is not yet running, so there
 	// is no one to read this event. And it doesn't make much sense as event
@@ -2280,6 +2322,43 @@ func (bc *Blockchain) SubscribeForExecutions(ch chan *state.AppExecResult) {
 	bc.subCh <- ch
 }
 
+// SubscribeForMempoolEvents adds the given channel to memory pool event
+// broadcasting, so when a transaction is added to the memory pool or removed
+// from it (including replace-by-fee evictions) you'll receive it via this
+// channel. Memory pool event dispatching is started for the first subscriber
+// and stopped when the last one unsubscribes. Make sure it's read from regularly as not reading these events
+// might block the memory pool. Make sure you're not changing the received
+// events, as it may affect the functionality of other subscribers.
+func (bc *Blockchain) SubscribeForMempoolEvents(ch chan<- mempoolevent.Event) {
+	bc.mempoolSubsLock.Lock()
+	defer bc.mempoolSubsLock.Unlock()
+	if _, ok := bc.mempoolSubs[ch]; ok {
+		return
+	}
+	if len(bc.mempoolSubs) == 0 {
+		bc.mempoolSubs = make(map[chan<- mempoolevent.Event]struct{})
+		bc.memPool.EnableSubscriptions()
+	}
+	bc.mempoolSubs[ch] = struct{}{}
+	bc.memPool.SubscribeForTransactions(ch)
+}
+
+// UnsubscribeFromMempoolEvents unsubscribes the given channel from memory pool
+// events, you can close it afterwards. Passing non-subscribed channel is a
+// no-op.
+func (bc *Blockchain) UnsubscribeFromMempoolEvents(ch chan<- mempoolevent.Event) {
+	bc.mempoolSubsLock.Lock()
+	defer bc.mempoolSubsLock.Unlock()
+	if _, ok := bc.mempoolSubs[ch]; !ok {
+		return
+	}
+	bc.memPool.UnsubscribeFromTransactions(ch)
+	delete(bc.mempoolSubs, ch)
+	if len(bc.mempoolSubs) == 0 {
+		bc.memPool.DisableSubscriptions()
+	}
+}
+
 // UnsubscribeFromBlocks unsubscribes given channel from new block notifications,
 // you can close it afterwards. Passing non-subscribed channel is a no-op, but
 // the method can read from this channel (discarding any read data).
@@ -2435,6 +2514,13 @@ var (
 	ErrMemPoolConflict   = errors.New("invalid transaction due to conflicts with the memory pool")
 	ErrInvalidScript     = errors.New("invalid script")
 	ErrInvalidAttribute  = errors.New("invalid attribute")
+	// ErrReplaceUnderpriced is returned when replace-by-fee policy is enabled
+	// and the transaction doesn't pay enough to replace the pooled one with
+	// the same signers and nonce.
+	ErrReplaceUnderpriced = errors.New("replacement transaction underpriced")
+	// ErrTxNotYetValid is returned for transactions with NotValidBefore
+	// attribute set to some future height, it wraps ErrInvalidAttribute.
+	ErrTxNotYetValid = fmt.Errorf("%w: transaction is not yet valid", ErrInvalidAttribute)
 )
 
 // verifyAndPoolTx verifies whether a transaction is bonafide or not and tries
@@ -2507,6 +2593,8 @@ func (bc *Blockchain) verifyAndPoolTx(t *transaction.Transaction, pool *mempool.
 			return ErrOOM
 		case errors.Is(err, mempool.ErrConflictsAttribute):
 			return fmt.Errorf("mempool: %w: %s", ErrHasConflicts, err) //nolint:errorlint // errorlint: non-wrapping format verb for fmt.Errorf. Use `%w` to format errors
+		case errors.Is(err, mempool.ErrReplaceUnderpriced):
+			return fmt.Errorf("mempool: %w: %s", ErrReplaceUnderpriced, err) //nolint:errorlint // errorlint: non-wrapping format verb for fmt.Errorf. Use `%w` to format errors
 		default:
 			return err
 		}
@@ -2516,6 +2604,9 @@ func (bc *Blockchain) verifyAndPoolTx(t *transaction.Transaction, pool *mempool.
 }
 
 func (bc *Blockchain) verifyTxAttributes(d *dao.Simple, tx *transaction.Transaction, isPartialTx bool) error {
+	// ErrTxNotYetValid is returned only if all other attributes are valid,
+	// such transactions can be queued for the future.
+	var notYetValid error
 	for i := range tx.Attributes {
 		switch attrType := tx.Attributes[i].Type; attrType {
 		case transaction.HighPriority:
@@ -2570,7 +2661,7 @@ func (bc *Blockchain) verifyTxAttributes(d *dao.Simple, tx *transaction.Transact
 				}
 			} else {
 				if curHeight < nvb {
-					return fmt.Errorf("%w: transaction is not yet valid: NotValidBefore = %d, current height = %d", ErrInvalidAttribute, nvb, curHeight)
+					notYetValid = fmt.Errorf("%w: NotValidBefore = %d, current height = %d", ErrTxNotYetValid, nvb, curHeight)
 				}
 			}
 		case transaction.ConflictsT:
@@ -2596,7 +2687,7 @@ func (bc *Blockchain) verifyTxAttributes(d *dao.Simple, tx *transaction.Transact
 			}
 		}
 	}
-	return nil
+	return notYetValid
 }
 
 // IsTxStillRelevant is a callback for mempool transaction filtering after the
@@ -2658,7 +2749,73 @@ func (bc *Blockchain) PoolTx(t *transaction.Transaction, pools ...*mempool.Pool)
 	if len(pools) == 1 {
 		pool = pools[0]
 	}
-	return bc.verifyAndPoolTx(t, pool, bc)
+	err := bc.verifyAndPoolTx(t, pool, bc)
+	if errors.Is(err, ErrTxNotYetValid) && pool == bc.memPool && bc.futurePool != nil {
+		return bc.queueFutureTx(t)
+	}
+	return err
+}
+
+// queueFutureTx adds the given transaction to the future transactions queue.
+// It's only called for transactions that have failed verifyAndPoolTx with
+// ErrTxNotYetValid which is returned after all other stateless, witness and
+// attribute checks, so this function only checks that the sender is able to
+// pay for it (taking into account both mempool and the future queue).
+func (bc *Blockchain) queueFutureTx(t *transaction.Transaction) error {
+	var nvb = t.GetAttributes(transaction.NotValidBeforeT)[0].Value.(*transaction.NotValidBefore).Height
+	if bc.memPool.ContainsKey(t.Hash()) {
+		return fmt.Errorf("mempool: %w", ErrAlreadyExists)
+	}
+	if !bc.memPool.Verify(t, bc) {
+		return fmt.Errorf("future queue: %w", ErrInsufficientFunds)
+	}
+	err := bc.futurePool.Add(t, nvb, bc)
+	switch {
+	case errors.Is(err, mempool.ErrDup):
+		return fmt.Errorf("future queue: %w", ErrAlreadyExists)
+	case errors.Is(err, mempool.ErrInsufficientFunds):
+		return fmt.Errorf("future queue: %w", ErrInsufficientFunds)
+	case errors.Is(err, mempool.ErrOOM), errors.Is(err, mempool.ErrSenderLimit):
+		return fmt.Errorf("%w: future queue: %s", ErrOOM, err) //nolint:errorlint // errorlint: non-wrapping format verb for fmt.Errorf. Use `%w` to format errors
+	}
+	return err
+}
+
+// promoteFutureTxs verifies transactions from the future queue that have
+// become valid at the given height and adds them to the mempool. It must be
+// called under the Blockchain lock, transactions that were successfully
+// added to the mempool are returned.
+func (bc *Blockchain) promoteFutureTxs(height uint32) []*transaction.Transaction {
+	if bc.futurePool == nil {
+		return nil
+	}
+	var promoted []*transaction.Transaction
+	for _, tx := range bc.futurePool.Promote(height) {
+		if err := bc.verifyAndPoolTx(tx, bc.memPool, bc); err != nil {
+			bc.log.Debug("future transaction dropped",
+				zap.Stringer("hash", tx.Hash()), zap.Error(err))
+			continue
+		}
+		promoted = append(promoted, tx)
+	}
+	mempoolFutureTxPromoted.Add(float64(len(promoted)))
+	return promoted
+}
+
+// GetFuturePool returns the queue of not yet valid transactions, it's nil if
+// the queue is disabled (FutureMemPoolSize is not set or P2PSigExtensions
+// are disabled).
+func (bc *Blockchain) GetFuturePool() *mempool.Future {
+	return bc.futurePool
+}
+
+// SetFutureTxCallback sets the function that is called for every transaction
+// moved from the future queue to the mempool after it's added there. It's
+// used by the network server to relay such transactions.
+func (bc *Blockchain) SetFutureTxCallback(f func(*transaction.Transaction)) {
+	bc.lock.Lock()
+	bc.futureCb = f
+	bc.lock.Unlock()
 }
 
 // PoolTxWithData verifies and tries to add given transaction with additional data into the mempool.
@@ -2714,13 +2871,72 @@ func (bc *Blockchain) GetTestVM(t trigger.Type, tx *transaction.Transaction, b *
 
 // GetTestHistoricVM returns an interop context with VM set up for a test run.
 func (bc *Blockchain) GetTestHistoricVM(t trigger.Type, tx *transaction.Transaction, nextBlockHeight uint32) (*interop.Context, error) {
-	if bc.config.Ledger.KeepOnlyLatestState {
-		return nil, errors.New("only latest state is supported")
-	}
 	b, err := bc.getFakeNextBlock(nextBlockHeight)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create fake block for height %d: %w", nextBlockHeight, err)
 	}
+	dTrie, err := bc.getHistoricDAO(b)
+	if err != nil {
+		return nil, err
+	}
+	systemInterop := bc.newInteropContext(t, dTrie, b, tx)
+	_ = systemInterop.SpawnVM() // All the other code suppose that the VM is ready.
+	return systemInterop, nil
+}
+
+// ReplayBlock executes the block with the given index once again using the
+// historic state of the previous block, the chain itself is not changed. For
+// every script executed during block processing (OnPersist, transactions and
+// PostPersist) run is called with an interop context having VM loaded and
+// ready. It's supposed to execute the script (and can do it step by step) and
+// may stop the replay by returning an error, this error is returned from
+// ReplayBlock then. Changes made by successful executions are visible to the
+// subsequent ones the same way they are during regular block processing.
+func (bc *Blockchain) ReplayBlock(index uint32, run func(ic *interop.Context) error) error {
+	b, err := bc.GetBlock(bc.GetHeaderHash(index))
+	if err != nil {
+		return fmt.Errorf("failed to get block %d: %w", index, err)
+	}
+	d, err := bc.getHistoricDAO(b)
+	if err != nil {
+		return err
+	}
+	exec := func(trig trigger.Type, tx *transaction.Transaction, script []byte, gas int64) error {
+		ic := bc.newInteropContext(trig, d, b, tx)
+		defer ic.Finalize()
+		v := ic.SpawnVM()
+		v.LoadScriptWithFlags(script, callflag.All)
+		v.GasLimit = gas
+		if err := run(ic); err != nil {
+			return err
+		}
+		if v.HasFailed() {
+			if trig != trigger.Application {
+				return fmt.Errorf("%s script has failed", trig)
+			}
+			return nil
+		}
+		_, err := ic.DAO.Persist()
+		return err
+	}
+	if err := exec(trigger.OnPersist, nil, bc.contracts.GetPersistScript(), -1); err != nil {
+		return err
+	}
+	for _, tx := range b.Transactions {
+		if err := exec(trigger.Application, tx, tx.Script, tx.SystemFee); err != nil {
+			return err
+		}
+	}
+	return exec(trigger.PostPersist, nil, bc.contracts.GetPostPersistScript(), -1)
+}
+
+// getHistoricDAO returns DAO backed by the MPT state of the block preceding
+// the given one (the one b is to be processed with) with initialized native
+// cache.
+func (bc *Blockchain) getHistoricDAO(b *block.Block) (*dao.Simple, error) {
+	if bc.config.Ledger.KeepOnlyLatestState {
+		return nil, errors.New("only latest state is supported")
+	}
 	var mode = mpt.ModeAll
 	if bc.config.Ledger.RemoveUntraceableBlocks {
 		if b.Index < bc.BlockHeight()-bc.config.MaxTraceableBlocks {
@@ -2745,9 +2961,7 @@ func (bc *Blockchain) GetTestHistoricVM(t trigger.Type, tx *transaction.Transact
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize native cache backed by historic DAO: %w", err)
 	}
-	systemInterop := bc.newInteropContext(t, dTrie, b, tx)
-	_ = systemInterop.SpawnVM() // All the other code suppose that the VM is ready.
-	return systemInterop, nil
+	return dTrie, nil
 }
 
 // getFakeNextBlock returns fake block with the specified index and pre-filled Timestamp field.
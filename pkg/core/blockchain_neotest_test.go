@@ -20,8 +20,10 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
 	"github.com/nspcc-dev/neo-go/pkg/core/dao"
 	"github.com/nspcc-dev/neo-go/pkg/core/fee"
+	"github.com/nspcc-dev/neo-go/pkg/core/interop"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop/interopnames"
 	"github.com/nspcc-dev/neo-go/pkg/core/mempool"
+	"github.com/nspcc-dev/neo-go/pkg/core/mempoolevent"
 	"github.com/nspcc-dev/neo-go/pkg/core/native"
 	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
 	"github.com/nspcc-dev/neo-go/pkg/core/native/nativeprices"
@@ -472,6 +474,22 @@ func TestBlockchain_AddHeadersStateRoot(t *testing.T) {
 	require.NoError(t, bc.AddHeaders(&b.Header))
 }
 
+func TestBlockchain_AddBlockKnownHeader(t *testing.T) {
+	bc, acc := chain.NewSingle(t)
+	e := neotest.NewExecutor(t, bc, acc, acc)
+
+	b := e.NewUnsignedBlock(t)
+	e.SignBlock(b)
+	require.NoError(t, bc.AddHeaders(&b.Header))
+
+	other := e.NewUnsignedBlock(t)
+	other.Timestamp = b.Timestamp + 1
+	e.SignBlock(other)
+	require.ErrorIs(t, bc.AddBlock(other), core.ErrBlockHeaderMismatch)
+	require.NoError(t, bc.AddBlock(b))
+	require.Equal(t, b.Index, bc.BlockHeight())
+}
+
 func TestBlockchain_AddBadBlock(t *testing.T) {
 	check := func(t *testing.T, b *block.Block, cfg func(c *config.Blockchain)) {
 		bc, _ := chain.NewSingleWithCustomConfig(t, cfg)
@@ -800,6 +818,68 @@ func TestBlockchain_GetTransaction(t *testing.T) {
 	assert.Equal(t, b.Transactions[0], tx)
 }
 
+func TestBlockchain_ReplayBlock(t *testing.T) {
+	bc, acc := chain.NewSingle(t)
+	e := neotest.NewExecutor(t, bc, acc, acc)
+	neoInvoker := e.CommitteeInvoker(e.NativeHash(t, nativenames.Neo))
+
+	to := random.Uint160()
+	tx1 := neoInvoker.PrepareInvoke(t, "transfer", acc.ScriptHash(), to, 1, nil)
+	tx2 := neoInvoker.PrepareInvoke(t, "balanceOf", to)
+	tx3 := e.PrepareInvocation(t, []byte{byte(opcode.ABORT)}, []neotest.Signer{acc})
+	b := e.AddNewBlock(t, tx1, tx2, tx3)
+	e.AddNewBlock(t)
+
+	var (
+		triggers []trigger.Type
+		txs      []*transaction.Transaction
+	)
+	require.NoError(t, bc.ReplayBlock(b.Index, func(ic *interop.Context) error {
+		require.Equal(t, b.Index, ic.Block.Index)
+		triggers = append(triggers, ic.Trigger)
+		err := ic.VM.Run()
+		if ic.Tx == nil {
+			require.NoError(t, err)
+			return nil
+		}
+		txs = append(txs, ic.Tx)
+		aer := e.GetTxExecResult(t, ic.Tx.Hash())
+		require.Equal(t, aer.VMState, ic.VM.State())
+		require.Equal(t, aer.GasConsumed, ic.VM.GasConsumed())
+		if ic.Tx.Hash() == tx2.Hash() {
+			// The state is changed by the previous transaction.
+			require.Equal(t, 1, ic.VM.Estack().Len())
+			require.Equal(t, big.NewInt(1), ic.VM.Estack().Pop().BigInt())
+		}
+		return nil
+	}))
+	require.Equal(t, []trigger.Type{trigger.OnPersist, trigger.Application, trigger.Application, trigger.Application, trigger.PostPersist}, triggers)
+	require.Equal(t, b.Transactions, txs)
+
+	t.Run("stop", func(t *testing.T) {
+		errStop := errors.New("stop")
+		var calls int
+		require.ErrorIs(t, bc.ReplayBlock(b.Index, func(ic *interop.Context) error {
+			calls++
+			if ic.Tx != nil {
+				return errStop
+			}
+			return ic.VM.Run()
+		}), errStop)
+		require.Equal(t, 2, calls)
+	})
+	t.Run("unknown block", func(t *testing.T) {
+		require.Error(t, bc.ReplayBlock(bc.BlockHeight()+1, func(ic *interop.Context) error {
+			return ic.VM.Run()
+		}))
+	})
+	t.Run("genesis", func(t *testing.T) {
+		require.Error(t, bc.ReplayBlock(0, func(ic *interop.Context) error {
+			return ic.VM.Run()
+		}))
+	})
+}
+
 func TestBlockchain_GetClaimable(t *testing.T) {
 	bc, acc := chain.NewSingle(t)
 
@@ -938,6 +1018,53 @@ func TestBlockchain_Subscriptions(t *testing.T) {
 	e.GenerateNewBlocks(t, 2*chBufSize)
 }
 
+func TestBlockchain_MempoolSubscriptions(t *testing.T) {
+	bc, acc := chain.NewSingle(t)
+	e := neotest.NewExecutor(t, bc, acc, acc)
+	ch1 := make(chan mempoolevent.Event, 4)
+	ch2 := make(chan mempoolevent.Event, 4)
+	poolTx := func() *transaction.Transaction {
+		tx := e.PrepareInvocation(t, []byte{byte(opcode.PUSH1)}, []neotest.Signer{acc})
+		require.NoError(t, bc.PoolTx(tx))
+		return tx
+	}
+	checkAdded := func(ch chan mempoolevent.Event, tx *transaction.Transaction) {
+		select {
+		case ev := <-ch:
+			require.Equal(t, mempoolevent.Event{Type: mempoolevent.TransactionAdded, Tx: tx}, ev)
+		case <-time.After(time.Second):
+			t.Fatal("timeout waiting for event")
+		}
+	}
+
+	// No subscribers, no events.
+	poolTx()
+
+	bc.SubscribeForMempoolEvents(ch1)
+	bc.SubscribeForMempoolEvents(ch1) // No-op.
+	bc.SubscribeForMempoolEvents(ch2)
+	tx := poolTx()
+	checkAdded(ch1, tx)
+	checkAdded(ch2, tx)
+
+	bc.UnsubscribeFromMempoolEvents(ch1)
+	tx = poolTx()
+	checkAdded(ch2, tx)
+	require.Empty(t, ch1)
+
+	// The last one leaves, dispatching stops.
+	bc.UnsubscribeFromMempoolEvents(ch2)
+	bc.UnsubscribeFromMempoolEvents(ch2) // No-op.
+	poolTx()
+	require.Never(t, func() bool { return len(ch1) != 0 || len(ch2) != 0 }, 100*time.Millisecond, 10*time.Millisecond)
+
+	// And restarts for the new subscriber.
+	bc.SubscribeForMempoolEvents(ch1)
+	tx = poolTx()
+	checkAdded(ch1, tx)
+	bc.UnsubscribeFromMempoolEvents(ch1)
+}
+
 func TestBlockchain_RemoveUntraceable(t *testing.T) {
 	neoCommitteeKey := []byte{0xfb, 0xff, 0xff, 0xff, 0x0e}
 	check := func(t *testing.T, bc *core.Blockchain, tHash, bHash, sHash util.Uint256, errorExpected bool) {
@@ -2209,6 +2336,145 @@ func TestBlockchain_VerifyTx(t *testing.T) {
 	})
 }
 
+func TestBlockchain_FuturePool(t *testing.T) {
+	newSignedNVBTx := func(t *testing.T, e *neotest.Executor, signer neotest.Signer, nvb uint32, extraFee int64, attrs ...transaction.Attribute) *transaction.Transaction {
+		tx := transaction.New([]byte{byte(opcode.PUSH1)}, 1_000_000)
+		tx.Nonce = neotest.Nonce()
+		tx.ValidUntilBlock = e.Chain.BlockHeight() + 10
+		tx.Attributes = append([]transaction.Attribute{{Type: transaction.NotValidBeforeT, Value: &transaction.NotValidBefore{Height: nvb}}}, attrs...)
+		tx.Signers = []transaction.Signer{{
+			Account: signer.ScriptHash(),
+			Scopes:  transaction.None,
+		}}
+		rawScript := signer.Script()
+		netFee, sizeDelta := fee.Calculate(e.Chain.GetBaseExecFee(), rawScript)
+		tx.NetworkFee = netFee + int64(io.GetVarSize(tx)+sizeDelta)*e.Chain.FeePerByte() + extraFee
+		tx.Scripts = []transaction.Witness{{
+			InvocationScript:   signer.SignHashable(uint32(netmode.UnitTestNet), tx),
+			VerificationScript: rawScript,
+		}}
+		return tx
+	}
+	newNVBTx := func(t *testing.T, e *neotest.Executor, nvb uint32) *transaction.Transaction {
+		return newSignedNVBTx(t, e, e.Validator, nvb, 0)
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		bc, validator, committee := chain.NewMultiWithCustomConfig(t, func(c *config.Blockchain) {
+			c.P2PSigExtensions = true
+		})
+		e := neotest.NewExecutor(t, bc, validator, committee)
+		require.Nil(t, bc.GetFuturePool())
+
+		tx := newNVBTx(t, e, bc.BlockHeight()+2)
+		err := bc.PoolTx(tx)
+		require.ErrorIs(t, err, core.ErrTxNotYetValid)
+		require.ErrorIs(t, err, core.ErrInvalidAttribute)
+	})
+	t.Run("attribute precedence", func(t *testing.T) {
+		bc, validator, committee := chain.NewMultiWithCustomConfig(t, func(c *config.Blockchain) {
+			c.P2PSigExtensions = true
+		})
+		e := neotest.NewExecutor(t, bc, validator, committee)
+		nvb := bc.BlockHeight() + 2
+		highPriority := transaction.Attribute{Type: transaction.HighPriority}
+
+		// Other attributes are checked irrespective of their position.
+		err := bc.PoolTx(newSignedNVBTx(t, e, e.Validator, nvb, 0, highPriority))
+		require.ErrorIs(t, err, core.ErrInvalidAttribute)
+		require.NotErrorIs(t, err, core.ErrTxNotYetValid)
+
+		tx := newSignedNVBTx(t, e, e.Validator, nvb, 0, highPriority)
+		tx.Attributes[0], tx.Attributes[1] = tx.Attributes[1], tx.Attributes[0]
+		tx, err = transaction.NewTransactionFromBytes(tx.Bytes()) // Rehash.
+		require.NoError(t, err)
+		tx.Scripts[0].InvocationScript = e.Validator.SignHashable(uint32(netmode.UnitTestNet), tx)
+		err = bc.PoolTx(tx)
+		require.ErrorIs(t, err, core.ErrInvalidAttribute)
+		require.NotErrorIs(t, err, core.ErrTxNotYetValid)
+
+		// Valid attributes following NotValidBefore don't hide it.
+		err = bc.PoolTx(newSignedNVBTx(t, e, e.Validator, nvb, 0, transaction.Attribute{
+			Type:  transaction.ConflictsT,
+			Value: &transaction.Conflicts{Hash: util.Uint256{1, 2, 3}},
+		}))
+		require.ErrorIs(t, err, core.ErrTxNotYetValid)
+	})
+
+	bc, validator, committee := chain.NewMultiWithCustomConfig(t, func(c *config.Blockchain) {
+		c.P2PSigExtensions = true
+		c.FutureMemPoolSize = 2
+	})
+	e := neotest.NewExecutor(t, bc, validator, committee)
+	fp := bc.GetFuturePool()
+	require.NotNil(t, fp)
+
+	var relayed []*transaction.Transaction
+	bc.SetFutureTxCallback(func(tx *transaction.Transaction) {
+		relayed = append(relayed, tx)
+	})
+
+	tx1 := newNVBTx(t, e, bc.BlockHeight()+2)
+	require.NoError(t, bc.PoolTx(tx1))
+	require.True(t, fp.ContainsKey(tx1.Hash()))
+	require.False(t, bc.GetMemPool().ContainsKey(tx1.Hash()))
+	require.ErrorIs(t, bc.PoolTx(tx1), core.ErrAlreadyExists)
+
+	tx2 := newNVBTx(t, e, bc.BlockHeight()+3)
+	require.NoError(t, bc.PoolTx(tx2))
+	require.ErrorIs(t, bc.PoolTx(newNVBTx(t, e, bc.BlockHeight()+3)), core.ErrOOM)
+
+	// Only the default pool is backed by the future queue.
+	err := bc.PoolTx(newNVBTx(t, e, bc.BlockHeight()+3), mempool.New(10, 0, false, nil))
+	require.ErrorIs(t, err, core.ErrTxNotYetValid)
+
+	e.AddNewBlock(t)
+	require.Equal(t, 2, fp.Count())
+	require.Equal(t, 0, len(relayed))
+
+	e.AddNewBlock(t)
+	require.False(t, fp.ContainsKey(tx1.Hash()))
+	require.True(t, bc.GetMemPool().ContainsKey(tx1.Hash()))
+	require.Equal(t, []*transaction.Transaction{tx1}, relayed)
+
+	e.AddNewBlock(t, tx1)
+	require.Equal(t, 0, fp.Count())
+	require.True(t, bc.GetMemPool().ContainsKey(tx2.Hash()))
+	require.Equal(t, []*transaction.Transaction{tx1, tx2}, relayed)
+
+	acc, err := wallet.NewAccount()
+	require.NoError(t, err)
+	unfunded := neotest.NewSingleSigner(acc)
+
+	t.Run("invalid attribute", func(t *testing.T) {
+		tx := newSignedNVBTx(t, e, unfunded, bc.BlockHeight()+2, 0, transaction.Attribute{Type: transaction.HighPriority})
+		err := bc.PoolTx(tx)
+		require.ErrorIs(t, err, core.ErrInvalidAttribute)
+		require.NotErrorIs(t, err, core.ErrTxNotYetValid)
+		require.False(t, fp.ContainsKey(tx.Hash()))
+	})
+	t.Run("insufficient funds", func(t *testing.T) {
+		tx := newSignedNVBTx(t, e, unfunded, bc.BlockHeight()+2, 0)
+		require.ErrorIs(t, bc.PoolTx(tx), core.ErrInsufficientFunds)
+		require.False(t, fp.ContainsKey(tx.Hash()))
+	})
+	t.Run("eviction", func(t *testing.T) {
+		var (
+			cheap = newSignedNVBTx(t, e, e.Validator, bc.BlockHeight()+2, 0)
+			mid   = newSignedNVBTx(t, e, e.Validator, bc.BlockHeight()+2, 100_000)
+			rich  = newSignedNVBTx(t, e, e.Validator, bc.BlockHeight()+2, 200_000)
+		)
+		require.NoError(t, bc.PoolTx(cheap))
+		require.NoError(t, bc.PoolTx(mid))
+		require.ErrorIs(t, bc.PoolTx(newSignedNVBTx(t, e, e.Validator, bc.BlockHeight()+2, 0)), core.ErrOOM)
+		require.NoError(t, bc.PoolTx(rich))
+		require.Equal(t, 2, fp.Count())
+		require.False(t, fp.ContainsKey(cheap.Hash()))
+		require.True(t, fp.ContainsKey(mid.Hash()))
+		require.True(t, fp.ContainsKey(rich.Hash()))
+	})
+}
+
 func TestBlockchain_Bug1728(t *testing.T) {
 	bc, acc := chain.NewSingle(t)
 	e := neotest.NewExecutor(t, bc, acc, acc)
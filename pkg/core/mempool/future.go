@@ -0,0 +1,265 @@
+package mempool
+
+import (
+	"errors"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// ErrSenderLimit is returned when the future transactions queue already
+// contains the maximum allowed number of transactions from the sender of
+// the transaction being added.
+var ErrSenderLimit = errors.New("too many future transactions from the sender")
+
+// futureItem is a transaction waiting for its NotValidBefore height.
+type futureItem struct {
+	txn *transaction.Transaction
+	nvb uint32
+}
+
+// CompareTo returns the difference between two futureItems, fee per byte is
+// compared first and network fee is used for items with equal fee per byte.
+// difference < 0 implies p < otherP.
+// difference = 0 implies p = otherP.
+// difference > 0 implies p > otherP.
+func (p futureItem) CompareTo(otherP futureItem) int {
+	if ret := int(p.txn.FeePerByte() - otherP.txn.FeePerByte()); ret != 0 {
+		return ret
+	}
+	return int(p.txn.NetworkFee - otherP.txn.NetworkFee)
+}
+
+// futureSender contains the number of queued transactions of a sender and
+// the sum of their fees.
+type futureSender struct {
+	count  int
+	feeSum int64
+}
+
+// Future is a bounded queue of transactions that are not yet valid because
+// of their NotValidBefore attribute. Transactions are kept there until the
+// chain reaches the required height and then they're supposed to be
+// verified again and moved to the Pool. It's safe for concurrent use.
+type Future struct {
+	lock  sync.RWMutex
+	items map[util.Uint256]futureItem
+	// sorted contains the same items as the map, but ordered by fee
+	// (descending), so the last one is the first to be evicted.
+	sorted    []futureItem
+	senders   map[util.Uint160]futureSender
+	capacity  int
+	perSender int
+
+	updateMetricsCb func(int)
+}
+
+// NewFuture returns a new future transactions queue able to hold up to
+// capacity transactions with no more than perSender transactions from a
+// single sender.
+func NewFuture(capacity int, perSender int, updateMetricsCb func(int)) *Future {
+	return &Future{
+		items:           make(map[util.Uint256]futureItem),
+		sorted:          make([]futureItem, 0, capacity),
+		senders:         make(map[util.Uint160]futureSender),
+		capacity:        capacity,
+		perSender:       perSender,
+		updateMetricsCb: updateMetricsCb,
+	}
+}
+
+// Add adds the given transaction with the specified NotValidBefore height to
+// the queue. If the queue is full the transaction with the lowest fee per byte
+// is to be evicted to make room for the new one, ErrOOM is returned if the new
+// transaction doesn't pay more than it. Sender limits are checked after that
+// taking the eviction into account: ErrSenderLimit is returned if the sender
+// has too many queued transactions and ErrInsufficientFunds if the sender's
+// GAS balance (as returned by feer) can't cover fees of all of its queued
+// transactions. ErrDup is returned if the transaction is already queued.
+func (f *Future) Add(t *transaction.Transaction, nvb uint32, feer Feer) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	h := t.Hash()
+	if _, ok := f.items[h]; ok {
+		return ErrDup
+	}
+	var (
+		itm     = futureItem{txn: t, nvb: nvb}
+		evicted *transaction.Transaction
+	)
+	if len(f.sorted) >= f.capacity {
+		// Less prioritized than the least prioritized we already have, won't fit.
+		if len(f.sorted) == 0 || itm.CompareTo(f.sorted[len(f.sorted)-1]) <= 0 {
+			return ErrOOM
+		}
+		evicted = f.sorted[len(f.sorted)-1].txn
+	}
+	sender := t.Sender()
+	fs := f.senders[sender]
+	if evicted != nil && evicted.Sender().Equals(sender) {
+		fs.count--
+		fs.feeSum -= evicted.SystemFee + evicted.NetworkFee
+	}
+	if fs.count >= f.perSender {
+		return ErrSenderLimit
+	}
+	need := big.NewInt(fs.feeSum + t.SystemFee + t.NetworkFee)
+	if feer.GetUtilityTokenBalance(sender).Cmp(need) < 0 {
+		return ErrInsufficientFunds
+	}
+	if evicted != nil {
+		f.remove(evicted.Hash())
+	}
+	n := sort.Search(len(f.sorted), func(n int) bool {
+		return itm.CompareTo(f.sorted[n]) > 0
+	})
+	f.sorted = append(f.sorted, futureItem{})
+	copy(f.sorted[n+1:], f.sorted[n:])
+	f.sorted[n] = itm
+	f.items[h] = itm
+	fs.count++
+	fs.feeSum += t.SystemFee + t.NetworkFee
+	f.senders[sender] = fs
+	f.updateMetrics()
+	return nil
+}
+
+// remove is an unlocked internal item removal function.
+func (f *Future) remove(h util.Uint256) {
+	itm, ok := f.items[h]
+	if !ok {
+		return
+	}
+	delete(f.items, h)
+	n := sort.Search(len(f.sorted), func(n int) bool {
+		return itm.CompareTo(f.sorted[n]) >= 0
+	})
+	for i := n; i < len(f.sorted); i++ { // Items may have equal priority, `n` is the left bound of them.
+		if f.sorted[i].txn.Hash() == h {
+			copy(f.sorted[i:], f.sorted[i+1:])
+			f.sorted[len(f.sorted)-1] = futureItem{}
+			f.sorted = f.sorted[:len(f.sorted)-1]
+			break
+		}
+	}
+	f.dropSender(itm.txn)
+}
+
+// dropSender updates sender's counters for the removed transaction.
+func (f *Future) dropSender(t *transaction.Transaction) {
+	sender := t.Sender()
+	fs := f.senders[sender]
+	if fs.count <= 1 {
+		delete(f.senders, sender)
+		return
+	}
+	fs.count--
+	fs.feeSum -= t.SystemFee + t.NetworkFee
+	f.senders[sender] = fs
+}
+
+// Remove removes the transaction with the given hash from the queue.
+func (f *Future) Remove(h util.Uint256) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.remove(h)
+	f.updateMetrics()
+}
+
+// Promote removes and returns transactions that are valid at the given
+// height (their NotValidBefore is not greater than it) sorted by
+// NotValidBefore. Expired transactions (the ones with ValidUntilBlock not
+// greater than height) are dropped.
+func (f *Future) Promote(height uint32) []*transaction.Transaction {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	var (
+		ready []futureItem
+		kept  = f.sorted[:0]
+	)
+	for _, itm := range f.sorted {
+		switch {
+		case itm.txn.ValidUntilBlock <= height:
+			f.dropSender(itm.txn)
+		case itm.nvb <= height:
+			ready = append(ready, itm)
+			f.dropSender(itm.txn)
+		default:
+			kept = append(kept, itm)
+			continue
+		}
+		delete(f.items, itm.txn.Hash())
+	}
+	for i := len(kept); i < len(f.sorted); i++ {
+		f.sorted[i] = futureItem{}
+	}
+	f.sorted = kept
+	f.updateMetrics()
+	// Ready items are already ordered by fee.
+	sort.SliceStable(ready, func(i, j int) bool {
+		return ready[i].nvb < ready[j].nvb
+	})
+	var res = make([]*transaction.Transaction, len(ready))
+	for i := range ready {
+		res[i] = ready[i].txn
+	}
+	return res
+}
+
+// ContainsKey checks if the transaction with the given hash is in the queue.
+func (f *Future) ContainsKey(h util.Uint256) bool {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+	_, ok := f.items[h]
+	return ok
+}
+
+// TryGetValue returns the transaction with the given hash if it's in the
+// queue.
+func (f *Future) TryGetValue(h util.Uint256) (*transaction.Transaction, bool) {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+	itm, ok := f.items[h]
+	return itm.txn, ok
+}
+
+// Count returns the number of queued transactions.
+func (f *Future) Count() int {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+	return len(f.items)
+}
+
+// GetTransactions returns all queued transactions sorted by NotValidBefore
+// height.
+func (f *Future) GetTransactions() []*transaction.Transaction {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+
+	var itms = make([]futureItem, 0, len(f.items))
+	for _, itm := range f.items {
+		itms = append(itms, itm)
+	}
+	sort.Slice(itms, func(i, j int) bool {
+		if itms[i].nvb != itms[j].nvb {
+			return itms[i].nvb < itms[j].nvb
+		}
+		return itms[i].txn.Hash().CompareTo(itms[j].txn.Hash()) < 0
+	})
+	var res = make([]*transaction.Transaction, len(itms))
+	for i := range itms {
+		res[i] = itms[i].txn
+	}
+	return res
+}
+
+func (f *Future) updateMetrics() {
+	if f.updateMetricsCb != nil {
+		f.updateMetricsCb(len(f.items))
+	}
+}
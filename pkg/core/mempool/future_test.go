@@ -0,0 +1,162 @@
+package mempool
+
+import (
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/stretchr/testify/require"
+)
+
+func newFutureTx(nonce uint32, sender util.Uint160, vub uint32, netFee int64) *transaction.Transaction {
+	tx := transaction.New([]byte{byte(opcode.PUSH1)}, 0)
+	tx.Nonce = nonce
+	tx.ValidUntilBlock = vub
+	tx.NetworkFee = netFee
+	tx.Signers = []transaction.Signer{{Account: sender}}
+	tx.Scripts = []transaction.Witness{{}}
+	return tx
+}
+
+func TestFutureAddRemove(t *testing.T) {
+	var (
+		count int
+		fs    = &FeerStub{balance: 100}
+	)
+	f := NewFuture(3, 2, func(n int) { count = n })
+	tx1 := newFutureTx(1, util.Uint160{1}, 100, 0)
+	tx2 := newFutureTx(2, util.Uint160{1}, 100, 0)
+	tx3 := newFutureTx(3, util.Uint160{1}, 100, 0)
+	tx4 := newFutureTx(4, util.Uint160{2}, 100, 0)
+	tx5 := newFutureTx(5, util.Uint160{3}, 100, 0)
+
+	require.NoError(t, f.Add(tx1, 10, fs))
+	require.ErrorIs(t, f.Add(tx1, 10, fs), ErrDup)
+	require.NoError(t, f.Add(tx2, 5, fs))
+	require.ErrorIs(t, f.Add(tx3, 5, fs), ErrSenderLimit)
+	require.NoError(t, f.Add(tx4, 5, fs))
+	require.ErrorIs(t, f.Add(tx5, 5, fs), ErrOOM)
+	require.Equal(t, 3, f.Count())
+	require.Equal(t, 3, count)
+
+	require.True(t, f.ContainsKey(tx1.Hash()))
+	actual, ok := f.TryGetValue(tx1.Hash())
+	require.True(t, ok)
+	require.Equal(t, tx1, actual)
+
+	txs := f.GetTransactions()
+	require.Equal(t, 3, len(txs))
+	require.Equal(t, tx1, txs[2])
+
+	f.Remove(tx1.Hash())
+	require.False(t, f.ContainsKey(tx1.Hash()))
+	_, ok = f.TryGetValue(tx1.Hash())
+	require.False(t, ok)
+	require.Equal(t, 2, count)
+
+	// Sender slot is released.
+	require.NoError(t, f.Add(tx3, 5, fs))
+	require.Equal(t, 3, f.Count())
+}
+
+func TestFutureEviction(t *testing.T) {
+	var (
+		fs    = &FeerStub{balance: 100000}
+		f     = NewFuture(2, 2, nil)
+		cheap = newFutureTx(1, util.Uint160{1}, 100, 1000)
+		mid   = newFutureTx(2, util.Uint160{2}, 100, 2000)
+		same  = newFutureTx(3, util.Uint160{3}, 100, 1000)
+		rich  = newFutureTx(4, util.Uint160{3}, 100, 3000)
+	)
+	require.NoError(t, f.Add(cheap, 5, fs))
+	require.NoError(t, f.Add(mid, 5, fs))
+	// Not paying more than the cheapest queued transaction.
+	require.ErrorIs(t, f.Add(same, 5, fs), ErrOOM)
+	require.NoError(t, f.Add(rich, 5, fs))
+	require.Equal(t, 2, f.Count())
+	require.False(t, f.ContainsKey(cheap.Hash()))
+	require.True(t, f.ContainsKey(mid.Hash()))
+	require.True(t, f.ContainsKey(rich.Hash()))
+}
+
+func TestFutureInsufficientFunds(t *testing.T) {
+	var (
+		fs     = &FeerStub{balance: 25}
+		f      = NewFuture(10, 10, nil)
+		sender = util.Uint160{1}
+		tx1    = newFutureTx(1, sender, 100, 10)
+		tx2    = newFutureTx(2, sender, 100, 10)
+		tx3    = newFutureTx(3, sender, 100, 10)
+	)
+	require.NoError(t, f.Add(tx1, 5, fs))
+	require.NoError(t, f.Add(tx2, 5, fs))
+	// Fees of all queued transactions are taken into account.
+	require.ErrorIs(t, f.Add(tx3, 5, fs), ErrInsufficientFunds)
+
+	// Removal releases the funds.
+	f.Remove(tx1.Hash())
+	require.NoError(t, f.Add(tx3, 5, fs))
+}
+
+func TestFuturePromote(t *testing.T) {
+	fs := &FeerStub{balance: 1000}
+	f := NewFuture(10, 10, nil)
+	var (
+		sender  = util.Uint160{1}
+		cheap   = newFutureTx(1, sender, 100, 1)
+		rich    = newFutureTx(2, sender, 100, 100)
+		later   = newFutureTx(3, sender, 100, 1)
+		expired = newFutureTx(4, sender, 7, 1)
+	)
+	require.NoError(t, f.Add(later, 8, fs))
+	require.NoError(t, f.Add(cheap, 5, fs))
+	require.NoError(t, f.Add(rich, 5, fs))
+	require.NoError(t, f.Add(expired, 10, fs))
+
+	require.Equal(t, 0, len(f.Promote(4)))
+	require.Equal(t, 4, f.Count())
+
+	// Expired transaction is dropped, the others are ordered by fee.
+	require.Equal(t, []*transaction.Transaction{rich, cheap}, f.Promote(7))
+	require.Equal(t, 1, f.Count())
+	require.False(t, f.ContainsKey(expired.Hash()))
+
+	require.Equal(t, []*transaction.Transaction{later}, f.Promote(8))
+	require.Equal(t, 0, f.Count())
+}
+
+func TestFutureEvictionSenderChecks(t *testing.T) {
+	var sender = util.Uint160{1}
+	t.Run("own transaction evicted", func(t *testing.T) {
+		var (
+			fs    = &FeerStub{balance: 5000}
+			f     = NewFuture(2, 2, nil)
+			cheap = newFutureTx(1, sender, 100, 1000)
+			mid   = newFutureTx(2, sender, 100, 2000)
+			rich  = newFutureTx(3, sender, 100, 3000)
+		)
+		require.NoError(t, f.Add(cheap, 5, fs))
+		require.NoError(t, f.Add(mid, 5, fs))
+		// Sender is at its limit and can't pay for three transactions,
+		// but its cheapest one is to be evicted.
+		require.NoError(t, f.Add(rich, 5, fs))
+		require.False(t, f.ContainsKey(cheap.Hash()))
+		require.Equal(t, []*transaction.Transaction{rich, mid}, f.Promote(5))
+	})
+	t.Run("no eviction on failure", func(t *testing.T) {
+		var (
+			fs    = &FeerStub{balance: 5000}
+			f     = NewFuture(2, 1, nil)
+			cheap = newFutureTx(1, util.Uint160{2}, 100, 1000)
+			mid   = newFutureTx(2, sender, 100, 2000)
+			rich  = newFutureTx(3, sender, 100, 3000)
+		)
+		require.NoError(t, f.Add(cheap, 5, fs))
+		require.NoError(t, f.Add(mid, 5, fs))
+		require.ErrorIs(t, f.Add(rich, 5, fs), ErrSenderLimit)
+		require.True(t, f.ContainsKey(cheap.Hash()))
+		require.True(t, f.ContainsKey(mid.Hash()))
+		require.Equal(t, 2, f.Count())
+	})
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/holiman/uint256"
 	"github.com/nspcc-dev/neo-go/pkg/core/mempoolevent"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/io"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"go.uber.org/atomic"
 )
@@ -35,6 +36,10 @@ var (
 	// ErrOracleResponse is returned when the mempool already contains a transaction
 	// with the same oracle response ID and higher network fee.
 	ErrOracleResponse = errors.New("conflicts with memory pool due to OracleResponse attribute")
+	// ErrReplaceUnderpriced is returned when replace-by-fee policy is enabled
+	// and the mempool already contains a transaction with the same signers and
+	// nonce, but the new one doesn't pay enough to replace it.
+	ErrReplaceUnderpriced = errors.New("replacement transaction underpriced")
 )
 
 // item represents a transaction in the the Memory pool.
@@ -64,6 +69,16 @@ type Pool struct {
 	conflicts map[util.Uint256][]util.Uint256
 	// oracleResp contains the ids of oracle responses for the tx in the pool.
 	oracleResp map[uint64]util.Uint256
+	// replaceable maps signers and nonce range of the pooled transactions to
+	// their hashes, it's only maintained when replace-by-fee is enabled.
+	replaceable map[string]util.Uint256
+
+	// rbfEnabled enables replace-by-fee policy with the minimum fee-per-byte
+	// increase (in percents) specified by rbfMinBump and the nonce range
+	// size specified by rbfNonceRange.
+	rbfEnabled    bool
+	rbfMinBump    uint32
+	rbfNonceRange uint32
 
 	capacity        int
 	feePerByte      int64
@@ -74,12 +89,14 @@ type Pool struct {
 	resendFunc      func(*transaction.Transaction, any)
 
 	// subscriptions for mempool events
+	subsLock             sync.Mutex
 	subscriptionsEnabled bool
-	subscriptionsOn      atomic.Bool
-	stopCh               chan struct{}
-	events               chan mempoolevent.Event
-	subCh                chan chan<- mempoolevent.Event // there are no other events in mempool except Event, so no need in generic subscribers type
-	unsubCh              chan chan<- mempoolevent.Event
+	// stopCh is the stop channel of the running events dispatcher, it's nil
+	// if subscriptions are not running.
+	stopCh  atomic.Pointer[chan struct{}]
+	events  chan mempoolevent.Event
+	subCh   chan chan<- mempoolevent.Event // there are no other events in mempool except Event, so no need in generic subscribers type
+	unsubCh chan chan<- mempoolevent.Event
 }
 
 func (p items) Len() int           { return len(p) }
@@ -211,7 +228,7 @@ func (mp *Pool) Add(t *transaction.Transaction, fee Feer, data ...any) error {
 		mp.lock.Unlock()
 		return ErrDup
 	}
-	conflictsToBeRemoved, err := mp.checkTxConflicts(t, fee)
+	conflictsToBeRemoved, replaced, err := mp.checkTxConflicts(t, fee)
 	if err != nil {
 		mp.lock.Unlock()
 		return err
@@ -224,7 +241,7 @@ func (mp *Pool) Add(t *transaction.Transaction, fee Feer, data ...any) error {
 				mp.lock.Unlock()
 				return ErrOracleResponse
 			}
-			mp.removeInternal(h, fee)
+			mp.removeInternal(h, fee, nil)
 		}
 		mp.oracleResp[id] = t.Hash()
 	}
@@ -232,9 +249,12 @@ func (mp *Pool) Add(t *transaction.Transaction, fee Feer, data ...any) error {
 	if fee.P2PSigExtensionsEnabled() {
 		// Remove conflicting transactions.
 		for _, conflictingTx := range conflictsToBeRemoved {
-			mp.removeInternal(conflictingTx.Hash(), fee)
+			mp.removeInternal(conflictingTx.Hash(), fee, nil)
 		}
 	}
+	if replaced != nil {
+		mp.removeInternal(replaced.Hash(), fee, t)
+	}
 	// Insert into a sorted array (from max to min, that could also be done
 	// using sort.Sort(sort.Reverse()), but it incurs more overhead. Notice
 	// also that we're searching for a position that is strictly more
@@ -261,14 +281,15 @@ func (mp *Pool) Add(t *transaction.Transaction, fee Feer, data ...any) error {
 		if attrs := unlucky.txn.GetAttributes(transaction.OracleResponseT); len(attrs) != 0 {
 			delete(mp.oracleResp, attrs[0].Value.(*transaction.OracleResponse).ID)
 		}
-		mp.verifiedTxes[len(mp.verifiedTxes)-1] = pItem
-		if mp.subscriptionsOn.Load() {
-			mp.events <- mempoolevent.Event{
-				Type: mempoolevent.TransactionRemoved,
-				Tx:   unlucky.txn,
-				Data: unlucky.data,
-			}
+		if mp.rbfEnabled {
+			delete(mp.replaceable, mp.replacementKey(unlucky.txn))
 		}
+		mp.verifiedTxes[len(mp.verifiedTxes)-1] = pItem
+		mp.sendEvent(mempoolevent.Event{
+			Type: mempoolevent.TransactionRemoved,
+			Tx:   unlucky.txn,
+			Data: unlucky.data,
+		})
 	} else {
 		mp.verifiedTxes = append(mp.verifiedTxes, pItem)
 	}
@@ -284,6 +305,9 @@ func (mp *Pool) Add(t *transaction.Transaction, fee Feer, data ...any) error {
 			mp.conflicts[hash] = append(mp.conflicts[hash], t.Hash())
 		}
 	}
+	if mp.rbfEnabled {
+		mp.replaceable[mp.replacementKey(t)] = t.Hash()
+	}
 	// we already checked balance in checkTxConflicts, so don't need to check again
 	mp.tryAddSendersFee(pItem.txn, fee, false)
 
@@ -292,13 +316,11 @@ func (mp *Pool) Add(t *transaction.Transaction, fee Feer, data ...any) error {
 	}
 	mp.lock.Unlock()
 
-	if mp.subscriptionsOn.Load() {
-		mp.events <- mempoolevent.Event{
-			Type: mempoolevent.TransactionAdded,
-			Tx:   pItem.txn,
-			Data: pItem.data,
-		}
-	}
+	mp.sendEvent(mempoolevent.Event{
+		Type: mempoolevent.TransactionAdded,
+		Tx:   pItem.txn,
+		Data: pItem.data,
+	})
 	return nil
 }
 
@@ -306,12 +328,14 @@ func (mp *Pool) Add(t *transaction.Transaction, fee Feer, data ...any) error {
 // nothing if it doesn't).
 func (mp *Pool) Remove(hash util.Uint256, feer Feer) {
 	mp.lock.Lock()
-	mp.removeInternal(hash, feer)
+	mp.removeInternal(hash, feer, nil)
 	mp.lock.Unlock()
 }
 
-// removeInternal is an internal unlocked representation of Remove.
-func (mp *Pool) removeInternal(hash util.Uint256, feer Feer) {
+// removeInternal is an internal unlocked representation of Remove. If
+// replacedBy is not nil, then the transaction is being replaced by it
+// according to replace-by-fee policy.
+func (mp *Pool) removeInternal(hash util.Uint256, feer Feer, replacedBy *transaction.Transaction) {
 	if tx, ok := mp.verifiedMap[hash]; ok {
 		var num int
 		delete(mp.verifiedMap, hash)
@@ -337,13 +361,22 @@ func (mp *Pool) removeInternal(hash util.Uint256, feer Feer) {
 		if attrs := tx.GetAttributes(transaction.OracleResponseT); len(attrs) != 0 {
 			delete(mp.oracleResp, attrs[0].Value.(*transaction.OracleResponse).ID)
 		}
-		if mp.subscriptionsOn.Load() {
-			mp.events <- mempoolevent.Event{
-				Type: mempoolevent.TransactionRemoved,
-				Tx:   itm.txn,
-				Data: itm.data,
+		if mp.rbfEnabled {
+			key := mp.replacementKey(tx)
+			if mp.replaceable[key] == hash {
+				delete(mp.replaceable, key)
 			}
 		}
+		var e = mempoolevent.Event{
+			Type: mempoolevent.TransactionRemoved,
+			Tx:   itm.txn,
+			Data: itm.data,
+		}
+		if replacedBy != nil {
+			e.Type = mempoolevent.TransactionReplaced
+			e.ReplacedBy = replacedBy
+		}
+		mp.sendEvent(e)
 	}
 	if mp.updateMetricsCb != nil {
 		mp.updateMetricsCb(len(mp.verifiedTxes))
@@ -363,6 +396,9 @@ func (mp *Pool) RemoveStale(isOK func(*transaction.Transaction) bool, feer Feer)
 	if feer.P2PSigExtensionsEnabled() {
 		mp.conflicts = make(map[util.Uint256][]util.Uint256)
 	}
+	if mp.rbfEnabled {
+		mp.replaceable = make(map[string]util.Uint256)
+	}
 	height := feer.BlockHeight()
 	var (
 		staleItems []item
@@ -376,6 +412,9 @@ func (mp *Pool) RemoveStale(isOK func(*transaction.Transaction) bool, feer Feer)
 					mp.conflicts[hash] = append(mp.conflicts[hash], itm.txn.Hash())
 				}
 			}
+			if mp.rbfEnabled {
+				mp.replaceable[mp.replacementKey(itm.txn)] = itm.txn.Hash()
+			}
 			if mp.resendThreshold != 0 {
 				// item is resent at resendThreshold, 2*resendThreshold, 4*resendThreshold ...
 				// so quotient must be a power of two.
@@ -389,13 +428,11 @@ func (mp *Pool) RemoveStale(isOK func(*transaction.Transaction) bool, feer Feer)
 			if attrs := itm.txn.GetAttributes(transaction.OracleResponseT); len(attrs) != 0 {
 				delete(mp.oracleResp, attrs[0].Value.(*transaction.OracleResponse).ID)
 			}
-			if mp.subscriptionsOn.Load() {
-				mp.events <- mempoolevent.Event{
-					Type: mempoolevent.TransactionRemoved,
-					Tx:   itm.txn,
-					Data: itm.data,
-				}
-			}
+			mp.sendEvent(mempoolevent.Event{
+				Type: mempoolevent.TransactionRemoved,
+				Tx:   itm.txn,
+				Data: itm.data,
+			})
 		}
 	}
 	if len(staleItems) != 0 {
@@ -434,14 +471,13 @@ func New(capacity int, payerIndex int, enableSubscriptions bool, updateMetricsCb
 		fees:                 make(map[util.Uint160]utilityBalanceAndFees),
 		conflicts:            make(map[util.Uint256][]util.Uint256),
 		oracleResp:           make(map[uint64]util.Uint256),
+		replaceable:          make(map[string]util.Uint256),
 		subscriptionsEnabled: enableSubscriptions,
-		stopCh:               make(chan struct{}),
 		events:               make(chan mempoolevent.Event),
 		subCh:                make(chan chan<- mempoolevent.Event),
 		unsubCh:              make(chan chan<- mempoolevent.Event),
 		updateMetricsCb:      updateMetricsCb,
 	}
-	mp.subscriptionsOn.Store(false)
 	return mp
 }
 
@@ -454,6 +490,69 @@ func (mp *Pool) SetResendThreshold(h uint32, f func(*transaction.Transaction, an
 	mp.resendFunc = f
 }
 
+// SetReplaceByFee enables replace-by-fee policy for the pool. With this
+// policy a transaction having the same set of signers (in the same order) and
+// the nonce from the same range as some pooled one is treated as a
+// replacement for it. Nonces are split into consecutive ranges of nonceRange
+// size starting from zero (so with nonceRange of 10 nonces 0-9 form one range,
+// 10-19 another one, etc.), zero or one nonceRange requires exactly the same
+// nonce. A replacement evicts the pooled transaction if its fee per byte is
+// higher by at least minFeeBump percents (and strictly higher in any case),
+// otherwise it's rejected with ErrReplaceUnderpriced.
+func (mp *Pool) SetReplaceByFee(minFeeBump uint32, nonceRange uint32) {
+	mp.lock.Lock()
+	defer mp.lock.Unlock()
+	mp.rbfEnabled = true
+	mp.rbfMinBump = minFeeBump
+	mp.rbfNonceRange = nonceRange
+	mp.replaceable = make(map[string]util.Uint256, len(mp.verifiedTxes))
+	for _, itm := range mp.verifiedTxes {
+		mp.replaceable[mp.replacementKey(itm.txn)] = itm.txn.Hash()
+	}
+}
+
+// replacementKey returns a key identifying the transaction for replace-by-fee
+// purposes, it consists of the nonce range and signers. Signers are compared
+// as a whole (accounts, scopes, allowed contracts/groups and rules), so a
+// transaction with the same accounts but different witness scopes is not a
+// replacement.
+func (mp *Pool) replacementKey(tx *transaction.Transaction) string {
+	var (
+		bw    = io.NewBufBinWriter()
+		nonce = tx.Nonce
+	)
+	if mp.rbfNonceRange > 1 {
+		nonce /= mp.rbfNonceRange
+	}
+	bw.WriteU32LE(nonce)
+	bw.WriteArray(tx.Signers)
+	return string(bw.Bytes())
+}
+
+// getReplaced returns the pooled transaction that is to be replaced by the
+// given one according to replace-by-fee policy (if any). An error is
+// returned if the new transaction doesn't pay enough for the replacement.
+func (mp *Pool) getReplaced(tx *transaction.Transaction) (*transaction.Transaction, error) {
+	if !mp.rbfEnabled {
+		return nil, nil
+	}
+	h, ok := mp.replaceable[mp.replacementKey(tx)]
+	if !ok {
+		return nil, nil
+	}
+	old := mp.verifiedMap[h]
+	var oldFee, newFee uint256.Int
+	oldFee.SetUint64(uint64(old.FeePerByte()))
+	oldFee.Mul(&oldFee, uint256.NewInt(100+uint64(mp.rbfMinBump)))
+	newFee.SetUint64(uint64(tx.FeePerByte()))
+	newFee.Mul(&newFee, uint256.NewInt(100))
+	if tx.FeePerByte() <= old.FeePerByte() || newFee.Lt(&oldFee) {
+		return nil, fmt.Errorf("%w: %s fee per byte is %d, new one has %d (minimum bump is %d%%)",
+			ErrReplaceUnderpriced, h.StringLE(), old.FeePerByte(), tx.FeePerByte(), mp.rbfMinBump)
+	}
+	return old, nil
+}
+
 func (mp *Pool) resendStaleItems(items []item) {
 	for i := range items {
 		mp.resendFunc(items[i].txn, items[i].data)
@@ -510,13 +609,18 @@ func (mp *Pool) GetVerifiedTransactions() []*transaction.Transaction {
 }
 
 // checkTxConflicts is an internal unprotected version of Verify. It takes into
-// consideration conflicting transactions which are about to be removed from mempool.
-func (mp *Pool) checkTxConflicts(tx *transaction.Transaction, fee Feer) ([]*transaction.Transaction, error) {
+// consideration conflicting transactions which are about to be removed from mempool
+// as well as the transaction replaced by tx according to replace-by-fee policy.
+func (mp *Pool) checkTxConflicts(tx *transaction.Transaction, fee Feer) ([]*transaction.Transaction, *transaction.Transaction, error) {
 	payer := tx.Signers[mp.payerIndex].Account
 	actualSenderFee, ok := mp.fees[payer]
 	if !ok {
 		actualSenderFee.balance.SetFromBig(fee.GetUtilityTokenBalance(payer))
 	}
+	replaced, err := mp.getReplaced(tx)
+	if err != nil {
+		return nil, nil, err
+	}
 
 	var expectedSenderFee utilityBalanceAndFees
 	// Check Conflicts attributes.
@@ -556,18 +660,21 @@ func (mp *Pool) checkTxConflicts(tx *transaction.Transaction, fee Feer) ([]*tran
 					}
 				}
 				if !signerOK {
-					return nil, fmt.Errorf("%w: not signed by a signer of conflicting transaction %s", ErrConflictsAttribute, existingTx.Hash().StringBE())
+					return nil, nil, fmt.Errorf("%w: not signed by a signer of conflicting transaction %s", ErrConflictsAttribute, existingTx.Hash().StringBE())
 				}
 				conflictingFee += existingTx.NetworkFee
 				conflictsToBeRemoved = append(conflictsToBeRemoved, existingTx)
 			}
 		}
 		if conflictingFee != 0 && tx.NetworkFee <= conflictingFee {
-			return nil, fmt.Errorf("%w: conflicting transactions have bigger or equal network fee: %d vs %d", ErrConflictsAttribute, tx.NetworkFee, conflictingFee)
+			return nil, nil, fmt.Errorf("%w: conflicting transactions have bigger or equal network fee: %d vs %d", ErrConflictsAttribute, tx.NetworkFee, conflictingFee)
 		}
 		// Step 3: take into account sender's conflicting transactions before balance check.
 		expectedSenderFee = actualSenderFee
 		for _, conflictingTx := range conflictsToBeRemoved {
+			if replaced != nil && conflictingTx.Hash() == replaced.Hash() {
+				replaced = nil // It's removed anyway.
+			}
 			if conflictingTx.Signers[mp.payerIndex].Account.Equals(payer) {
 				expectedSenderFee.feeSum.SubUint64(&expectedSenderFee.feeSum, uint64(conflictingTx.SystemFee+conflictingTx.NetworkFee))
 			}
@@ -575,8 +682,12 @@ func (mp *Pool) checkTxConflicts(tx *transaction.Transaction, fee Feer) ([]*tran
 	} else {
 		expectedSenderFee = actualSenderFee
 	}
-	_, err := checkBalance(tx, expectedSenderFee)
-	return conflictsToBeRemoved, err
+	if replaced != nil {
+		// Signers are the same, so the payer is the same as well.
+		expectedSenderFee.feeSum.SubUint64(&expectedSenderFee.feeSum, uint64(replaced.SystemFee+replaced.NetworkFee))
+	}
+	_, err = checkBalance(tx, expectedSenderFee)
+	return conflictsToBeRemoved, replaced, err
 }
 
 // Verify checks if the Sender of the tx is able to pay for it (and all the other
@@ -586,7 +697,7 @@ func (mp *Pool) checkTxConflicts(tx *transaction.Transaction, fee Feer) ([]*tran
 func (mp *Pool) Verify(tx *transaction.Transaction, feer Feer) bool {
 	mp.lock.RLock()
 	defer mp.lock.RUnlock()
-	_, err := mp.checkTxConflicts(tx, feer)
+	_, _, err := mp.checkTxConflicts(tx, feer)
 	return err == nil
 }
 
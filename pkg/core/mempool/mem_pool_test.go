@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/holiman/uint256"
+	"github.com/nspcc-dev/neo-go/pkg/core/mempoolevent"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/network/payload"
 	"github.com/nspcc-dev/neo-go/pkg/util"
@@ -737,3 +738,95 @@ func TestMempoolAddWithDataGetData(t *testing.T) {
 	_, ok = mp.TryGetData(r7.FallbackTransaction.Hash())
 	require.False(t, ok)
 }
+
+func TestMempoolReplaceByFee(t *testing.T) {
+	var (
+		acc1 = util.Uint160{1, 2, 3}
+		acc2 = util.Uint160{4, 5, 6}
+	)
+	newTx := func(nonce uint32, netFee int64, signers ...util.Uint160) *transaction.Transaction {
+		tx := transaction.New([]byte{byte(opcode.PUSH1)}, 0)
+		tx.Nonce = nonce
+		tx.NetworkFee = netFee
+		for _, s := range signers {
+			tx.Signers = append(tx.Signers, transaction.Signer{Account: s})
+		}
+		tx.Scripts = make([]transaction.Witness, len(signers))
+		return tx
+	}
+	// Sender can only pay for the most expensive transaction.
+	fs := &FeerStub{balance: 120000}
+	mp := New(10, 0, true, nil)
+	mp.SetReplaceByFee(10, 0)
+	mp.RunSubscriptions()
+	t.Cleanup(mp.StopSubscriptions)
+	events := make(chan mempoolevent.Event, 10)
+	mp.SubscribeForTransactions(events)
+
+	tx1 := newTx(1, 100000, acc1, acc2)
+	require.NoError(t, mp.Add(tx1, fs))
+	<-events
+
+	// Equal fee.
+	sameFee := newTx(1, 100000, acc1, acc2)
+	sameFee.Script = []byte{byte(opcode.PUSH2)}
+	require.ErrorIs(t, mp.Add(sameFee, fs), ErrReplaceUnderpriced)
+	// Not enough of a bump.
+	tx2 := newTx(1, 105000, acc1, acc2)
+	require.Greater(t, tx2.FeePerByte(), tx1.FeePerByte())
+	require.ErrorIs(t, mp.Add(tx2, fs), ErrReplaceUnderpriced)
+	// Different nonce or signers are not replacements, sender can't pay for them.
+	require.ErrorIs(t, mp.Add(newTx(2, 120000, acc1, acc2), fs), ErrConflict)
+	require.ErrorIs(t, mp.Add(newTx(1, 120000, acc1), fs), ErrConflict)
+	// Same accounts with different scopes are not replacements either.
+	wideScope := newTx(1, 120000, acc1, acc2)
+	wideScope.Signers[0].Scopes = transaction.Global
+	require.ErrorIs(t, mp.Add(wideScope, fs), ErrConflict)
+
+	tx3 := newTx(1, 120000, acc1, acc2)
+	require.NoError(t, mp.Add(tx3, fs))
+	require.False(t, mp.ContainsKey(tx1.Hash()))
+	require.True(t, mp.ContainsKey(tx3.Hash()))
+	require.Equal(t, 1, mp.Count())
+	require.Equal(t, mempoolevent.Event{Type: mempoolevent.TransactionReplaced, Tx: tx1, ReplacedBy: tx3}, <-events)
+	require.Equal(t, mempoolevent.Event{Type: mempoolevent.TransactionAdded, Tx: tx3}, <-events)
+
+	// Index survives RemoveStale.
+	mp.RemoveStale(func(*transaction.Transaction) bool { return true }, fs)
+	require.ErrorIs(t, mp.Add(newTx(1, 110000, acc1, acc2), fs), ErrReplaceUnderpriced)
+
+	// And is cleaned up on removal.
+	mp.Remove(tx3.Hash(), fs)
+	<-events
+	require.NoError(t, mp.Add(tx1, fs))
+}
+
+func TestMempoolReplaceByFeeNonceRange(t *testing.T) {
+	var acc = util.Uint160{1, 2, 3}
+	newTx := func(nonce uint32, netFee int64) *transaction.Transaction {
+		tx := transaction.New([]byte{byte(opcode.PUSH1)}, 0)
+		tx.Nonce = nonce
+		tx.NetworkFee = netFee
+		tx.Signers = []transaction.Signer{{Account: acc}}
+		tx.Scripts = []transaction.Witness{{}}
+		return tx
+	}
+	fs := &FeerStub{balance: 200000}
+	mp := New(10, 0, false, nil)
+	mp.SetReplaceByFee(0, 10)
+
+	tx1 := newTx(12, 100000)
+	require.NoError(t, mp.Add(tx1, fs))
+	// Same range, but doesn't pay more.
+	require.ErrorIs(t, mp.Add(newTx(19, 100000), fs), ErrReplaceUnderpriced)
+	// Another range.
+	tx2 := newTx(20, 50000)
+	require.NoError(t, mp.Add(tx2, fs))
+	require.Equal(t, 2, mp.Count())
+
+	tx3 := newTx(10, 110000)
+	require.NoError(t, mp.Add(tx3, fs))
+	require.False(t, mp.ContainsKey(tx1.Hash()))
+	require.True(t, mp.ContainsKey(tx2.Hash()))
+	require.True(t, mp.ContainsKey(tx3.Hash()))
+}
@@ -5,23 +5,72 @@ import "github.com/nspcc-dev/neo-go/pkg/core/mempoolevent"
 // RunSubscriptions runs subscriptions goroutine if mempool subscriptions are enabled.
 // You should manually free the resources by calling StopSubscriptions on mempool shutdown.
 func (mp *Pool) RunSubscriptions() {
+	mp.subsLock.Lock()
+	defer mp.subsLock.Unlock()
 	if !mp.subscriptionsEnabled {
 		panic("subscriptions are disabled")
 	}
-	if !mp.subscriptionsOn.Load() {
-		mp.subscriptionsOn.Store(true)
-		go mp.notificationDispatcher()
-	}
+	mp.runSubscriptions()
 }
 
 // StopSubscriptions stops mempool events loop.
 func (mp *Pool) StopSubscriptions() {
+	mp.subsLock.Lock()
+	defer mp.subsLock.Unlock()
 	if !mp.subscriptionsEnabled {
 		panic("subscriptions are disabled")
 	}
-	if mp.subscriptionsOn.Load() {
-		mp.subscriptionsOn.Store(false)
-		close(mp.stopCh)
+	mp.stopSubscriptions()
+}
+
+// EnableSubscriptions enables subscriptions for the mempool created without
+// them and runs subscriptions goroutine (it's a no-op if they're running
+// already). It allows to only dispatch events when there are subscribers,
+// subscriptions can be disabled with DisableSubscriptions after the last
+// subscriber leaves.
+func (mp *Pool) EnableSubscriptions() {
+	mp.subsLock.Lock()
+	defer mp.subsLock.Unlock()
+	mp.subscriptionsEnabled = true
+	mp.runSubscriptions()
+}
+
+// DisableSubscriptions stops mempool events loop and disables subscriptions,
+// all subscribers are dropped. Subscriptions can be enabled again with
+// EnableSubscriptions.
+func (mp *Pool) DisableSubscriptions() {
+	mp.subsLock.Lock()
+	defer mp.subsLock.Unlock()
+	mp.stopSubscriptions()
+	mp.subscriptionsEnabled = false
+}
+
+// runSubscriptions starts events dispatcher if it's not running, it must be
+// called with subsLock held.
+func (mp *Pool) runSubscriptions() {
+	if mp.stopCh.Load() == nil {
+		stop := make(chan struct{})
+		mp.stopCh.Store(&stop)
+		go mp.notificationDispatcher(stop)
+	}
+}
+
+// stopSubscriptions stops events dispatcher if it's running, it must be
+// called with subsLock held. Events sent concurrently are dropped.
+func (mp *Pool) stopSubscriptions() {
+	if stop := mp.stopCh.Load(); stop != nil {
+		mp.stopCh.Store(nil)
+		close(*stop)
+	}
+}
+
+// sendEvent passes the event to the dispatcher if subscriptions are running.
+func (mp *Pool) sendEvent(e mempoolevent.Event) {
+	if stop := mp.stopCh.Load(); stop != nil {
+		select {
+		case mp.events <- e:
+		case <-*stop:
+		}
 	}
 }
 
@@ -30,21 +79,31 @@ func (mp *Pool) StopSubscriptions() {
 // the mempool, you'll receive it via this channel. Make sure you're not changing the received
 // mempool events, as it may affect the functionality of other subscribers.
 func (mp *Pool) SubscribeForTransactions(ch chan<- mempoolevent.Event) {
-	if mp.subscriptionsOn.Load() {
-		mp.subCh <- ch
+	mp.subsLock.Lock()
+	defer mp.subsLock.Unlock()
+	if stop := mp.stopCh.Load(); stop != nil {
+		select {
+		case mp.subCh <- ch:
+		case <-*stop:
+		}
 	}
 }
 
 // UnsubscribeFromTransactions unsubscribes the given channel from new mempool notifications,
 // you can close it afterwards. Passing non-subscribed channel is a no-op.
 func (mp *Pool) UnsubscribeFromTransactions(ch chan<- mempoolevent.Event) {
-	if mp.subscriptionsOn.Load() {
-		mp.unsubCh <- ch
+	mp.subsLock.Lock()
+	defer mp.subsLock.Unlock()
+	if stop := mp.stopCh.Load(); stop != nil {
+		select {
+		case mp.unsubCh <- ch:
+		case <-*stop:
+		}
 	}
 }
 
 // notificationDispatcher manages subscription to events and broadcasts new events.
-func (mp *Pool) notificationDispatcher() {
+func (mp *Pool) notificationDispatcher(stop <-chan struct{}) {
 	var (
 		// These are just sets of subscribers, though modelled as maps
 		// for ease of management (not a lot of subscriptions is really
@@ -53,7 +112,7 @@ func (mp *Pool) notificationDispatcher() {
 	)
 	for {
 		select {
-		case <-mp.stopCh:
+		case <-stop:
 			return
 		case sub := <-mp.subCh:
 			txFeed[sub] = true
@@ -22,6 +22,36 @@ func TestSubscriptions(t *testing.T) {
 		})
 	})
 
+	t.Run("enable/disable", func(t *testing.T) {
+		fs := &FeerStub{balance: 100}
+		mp := New(5, 0, false, nil)
+		newTx := func(nonce uint32) *transaction.Transaction {
+			tx := transaction.New([]byte{byte(opcode.PUSH1)}, 0)
+			tx.Nonce = nonce
+			tx.Signers = []transaction.Signer{{Account: util.Uint160{1, 2, 3}}}
+			return tx
+		}
+		subChan := make(chan mempoolevent.Event, 3)
+
+		// Not running, nothing is sent.
+		mp.SubscribeForTransactions(subChan)
+		require.NoError(t, mp.Add(newTx(0), fs))
+
+		mp.EnableSubscriptions()
+		mp.EnableSubscriptions() // No-op.
+		mp.SubscribeForTransactions(subChan)
+		tx := newTx(1)
+		require.NoError(t, mp.Add(tx, fs))
+		require.Equal(t, mempoolevent.Event{Type: mempoolevent.TransactionAdded, Tx: tx}, <-subChan)
+		require.NotPanics(t, mp.StopSubscriptions)
+		require.NotPanics(t, mp.RunSubscriptions)
+
+		mp.DisableSubscriptions()
+		require.NoError(t, mp.Add(newTx(2), fs))
+		require.Never(t, func() bool { return len(subChan) != 0 }, 100*time.Millisecond, 10*time.Millisecond)
+		require.Panics(t, mp.RunSubscriptions)
+	})
+
 	t.Run("enabled subscriptions", func(t *testing.T) {
 		fs := &FeerStub{balance: 100}
 		mp := New(2, 0, true, nil)
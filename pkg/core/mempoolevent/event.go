@@ -15,6 +15,13 @@ const (
 	TransactionAdded Type = 0x01
 	// TransactionRemoved marks transaction removal mempool event.
 	TransactionRemoved Type = 0x02
+	// TransactionReplaced marks transaction removal mempool event caused by
+	// another transaction replacing this one according to replace-by-fee
+	// policy.
+	TransactionReplaced Type = 0x03
+	// TransactionUpdated marks an update of the pooled item processing state,
+	// it's only used for notary request state change events.
+	TransactionUpdated Type = 0x04
 )
 
 // Event represents one of mempool events: transaction was added or removed from the mempool.
@@ -22,6 +29,9 @@ type Event struct {
 	Type Type
 	Tx   *transaction.Transaction
 	Data any
+	// ReplacedBy is the transaction replacing Tx, it's only set for
+	// TransactionReplaced events.
+	ReplacedBy *transaction.Transaction
 }
 
 // String is a Stringer implementation.
@@ -31,6 +41,10 @@ func (e Type) String() string {
 		return "added"
 	case TransactionRemoved:
 		return "removed"
+	case TransactionReplaced:
+		return "replaced"
+	case TransactionUpdated:
+		return "updated"
 	default:
 		return "unknown"
 	}
@@ -43,6 +57,10 @@ func GetEventTypeFromString(s string) (Type, error) {
 		return TransactionAdded, nil
 	case "removed":
 		return TransactionRemoved, nil
+	case "replaced":
+		return TransactionReplaced, nil
+	case "updated":
+		return TransactionUpdated, nil
 	default:
 		return 0, errors.New("invalid event type name")
 	}
@@ -38,6 +38,22 @@ var (
 			Namespace: "neogo",
 		},
 	)
+	// mempoolFutureTx prometheus metric.
+	mempoolFutureTx = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Help:      "Not yet valid transactions waiting for their NotValidBefore height",
+			Name:      "mempool_future_tx",
+			Namespace: "neogo",
+		},
+	)
+	// mempoolFutureTxPromoted prometheus metric.
+	mempoolFutureTxPromoted = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Help:      "Number of future transactions moved to the mempool",
+			Name:      "mempool_future_tx_promoted_total",
+			Namespace: "neogo",
+		},
+	)
 )
 
 func init() {
@@ -46,6 +62,8 @@ func init() {
 		persistedHeight,
 		headerHeight,
 		mempoolUnsortedTx,
+		mempoolFutureTx,
+		mempoolFutureTxPromoted,
 	)
 }
 
@@ -65,3 +83,9 @@ func updateBlockHeightMetric(bHeight uint32) {
 func updateMempoolMetrics(unsortedTxnLen int) {
 	mempoolUnsortedTx.Set(float64(unsortedTxnLen))
 }
+
+// updateFutureTxMetrics updates metric of the number of transactions in the
+// future transactions queue.
+func updateFutureTxMetrics(n int) {
+	mempoolFutureTx.Set(float64(n))
+}
@@ -0,0 +1,11 @@
+/*
+Package fountain implements a rateless (fountain) code splitting messages into
+an unlimited sequence of parts. Any sufficiently large subset of parts
+(usually a bit more than the number of fragments) allows to restore the
+message, which makes it suitable for animated QR codes where some frames can
+be missed. First SeqLen parts contain message fragments as is, subsequent ones
+are XORs of pseudo-randomly selected fragments (the selection only depends on
+the part sequence number and message checksum), the scheme is similar to the
+one used by Uniform Resources (UR).
+*/
+package fountain
@@ -0,0 +1,323 @@
+package fountain
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"math/bits"
+	"sort"
+
+	"github.com/nspcc-dev/neo-go/pkg/io"
+)
+
+// MaxMessageLen is the maximum supported message length.
+const MaxMessageLen = 1 << 20
+
+// ErrIncomplete is returned when there are not enough parts to restore the
+// message.
+var ErrIncomplete = errors.New("message is incomplete")
+
+type (
+	// Part is a single part of the encoded message.
+	Part struct {
+		// SeqNum is the part number starting from 1.
+		SeqNum uint32
+		// SeqLen is the number of message fragments.
+		SeqLen uint32
+		// MessageLen is the length of the message.
+		MessageLen uint32
+		// Checksum is CRC-32 (IEEE) of the message.
+		Checksum uint32
+		// Data is a fragment or a XOR of several fragments.
+		Data []byte
+	}
+
+	// Encoder generates parts for the message.
+	Encoder struct {
+		fragments [][]byte
+		msgLen    uint32
+		checksum  uint32
+		seqNum    uint32
+	}
+
+	// Decoder restores the message from parts.
+	Decoder struct {
+		started   bool
+		seqLen    uint32
+		msgLen    uint32
+		checksum  uint32
+		fragLen   int
+		fragments [][]byte
+		known     int
+		mixed     []*mixedPart
+	}
+
+	mixedPart struct {
+		indexes []int
+		data    []byte
+	}
+
+	// xoshiro is xoshiro256** PRNG.
+	xoshiro [4]uint64
+)
+
+// fragmentLen returns the length of fragments for the message.
+func fragmentLen(msgLen, seqLen uint32) int {
+	return int((msgLen + seqLen - 1) / seqLen)
+}
+
+// EncodeBinary implements the io.Serializable interface.
+func (p *Part) EncodeBinary(w *io.BinWriter) {
+	w.WriteVarUint(uint64(p.SeqNum))
+	w.WriteVarUint(uint64(p.SeqLen))
+	w.WriteVarUint(uint64(p.MessageLen))
+	w.WriteU32LE(p.Checksum)
+	w.WriteVarBytes(p.Data)
+}
+
+// DecodeBinary implements the io.Serializable interface.
+func (p *Part) DecodeBinary(r *io.BinReader) {
+	seqNum := r.ReadVarUint()
+	seqLen := r.ReadVarUint()
+	msgLen := r.ReadVarUint()
+	p.Checksum = r.ReadU32LE()
+	p.Data = r.ReadVarBytes(MaxMessageLen)
+	if r.Err != nil {
+		return
+	}
+	if seqNum == 0 || seqNum > 1<<32-1 || seqLen == 0 || msgLen == 0 || msgLen > MaxMessageLen || seqLen > msgLen {
+		r.Err = errors.New("invalid part header")
+		return
+	}
+	p.SeqNum, p.SeqLen, p.MessageLen = uint32(seqNum), uint32(seqLen), uint32(msgLen)
+	if len(p.Data) != fragmentLen(p.MessageLen, p.SeqLen) {
+		r.Err = errors.New("invalid part data length")
+	}
+}
+
+// NewEncoder creates an encoder for the message splitting it into fragments
+// of at most maxFragmentLen bytes.
+func NewEncoder(msg []byte, maxFragmentLen int) (*Encoder, error) {
+	if len(msg) == 0 || len(msg) > MaxMessageLen {
+		return nil, fmt.Errorf("invalid message length %d", len(msg))
+	}
+	if maxFragmentLen < 1 {
+		return nil, fmt.Errorf("invalid fragment length %d", maxFragmentLen)
+	}
+	var (
+		seqLen  = uint32((len(msg) + maxFragmentLen - 1) / maxFragmentLen)
+		fragLen = fragmentLen(uint32(len(msg)), seqLen)
+		padded  = make([]byte, int(seqLen)*fragLen)
+		e       = &Encoder{
+			fragments: make([][]byte, seqLen),
+			msgLen:    uint32(len(msg)),
+			checksum:  crc32.ChecksumIEEE(msg),
+		}
+	)
+	copy(padded, msg)
+	for i := range e.fragments {
+		e.fragments[i] = padded[i*fragLen : (i+1)*fragLen]
+	}
+	return e, nil
+}
+
+// SeqLen returns the number of fragments.
+func (e *Encoder) SeqLen() int {
+	return len(e.fragments)
+}
+
+// NextPart returns the next part of the message, the sequence is infinite.
+func (e *Encoder) NextPart() *Part {
+	e.seqNum++
+	var (
+		seqLen = uint32(len(e.fragments))
+		data   = make([]byte, len(e.fragments[0]))
+	)
+	for _, i := range chooseFragments(e.seqNum, seqLen, e.checksum) {
+		xor(data, e.fragments[i])
+	}
+	return &Part{
+		SeqNum:     e.seqNum,
+		SeqLen:     seqLen,
+		MessageLen: e.msgLen,
+		Checksum:   e.checksum,
+		Data:       data,
+	}
+}
+
+// NewDecoder creates a decoder.
+func NewDecoder() *Decoder {
+	return new(Decoder)
+}
+
+// Add adds the part to the decoder. It returns an error if the part belongs to
+// some other message.
+func (d *Decoder) Add(p *Part) error {
+	if !d.started {
+		d.started = true
+		d.seqLen, d.msgLen, d.checksum = p.SeqLen, p.MessageLen, p.Checksum
+		d.fragLen = fragmentLen(p.MessageLen, p.SeqLen)
+		d.fragments = make([][]byte, p.SeqLen)
+	} else if p.SeqLen != d.seqLen || p.MessageLen != d.msgLen || p.Checksum != d.checksum {
+		return errors.New("part belongs to a different message")
+	}
+	if p.SeqNum == 0 || len(p.Data) != d.fragLen {
+		return errors.New("invalid part")
+	}
+	if d.Complete() {
+		return nil
+	}
+	var (
+		data    = append([]byte{}, p.Data...)
+		indexes []int
+	)
+	for _, i := range chooseFragments(p.SeqNum, d.seqLen, d.checksum) {
+		if d.fragments[i] != nil {
+			xor(data, d.fragments[i])
+		} else {
+			indexes = append(indexes, i)
+		}
+	}
+	switch len(indexes) {
+	case 0:
+	case 1:
+		d.solve(indexes[0], data)
+	default:
+		d.mixed = append(d.mixed, &mixedPart{indexes: indexes, data: data})
+	}
+	return nil
+}
+
+// solve stores the fragment and reduces mixed parts with it, which can make
+// more fragments known.
+func (d *Decoder) solve(idx int, data []byte) {
+	type fragment struct {
+		idx  int
+		data []byte
+	}
+	queue := []fragment{{idx, data}}
+	for len(queue) != 0 {
+		f := queue[0]
+		queue = queue[1:]
+		if d.fragments[f.idx] != nil {
+			continue
+		}
+		d.fragments[f.idx] = f.data
+		d.known++
+		var mixed = d.mixed[:0]
+		for _, m := range d.mixed {
+			for j, i := range m.indexes {
+				if i == f.idx {
+					xor(m.data, f.data)
+					m.indexes = append(m.indexes[:j], m.indexes[j+1:]...)
+					break
+				}
+			}
+			if len(m.indexes) == 1 {
+				queue = append(queue, fragment{m.indexes[0], m.data})
+			} else if len(m.indexes) > 1 {
+				mixed = append(mixed, m)
+			}
+		}
+		d.mixed = mixed
+	}
+}
+
+// Progress returns the number of known fragments and the total number of
+// fragments (it's zero until the first part is added).
+func (d *Decoder) Progress() (int, int) {
+	return d.known, int(d.seqLen)
+}
+
+// Complete returns true if the message can be restored.
+func (d *Decoder) Complete() bool {
+	return d.started && d.known == int(d.seqLen)
+}
+
+// Message returns the message restored from parts.
+func (d *Decoder) Message() ([]byte, error) {
+	if !d.Complete() {
+		return nil, ErrIncomplete
+	}
+	msg := make([]byte, 0, int(d.seqLen)*d.fragLen)
+	for _, f := range d.fragments {
+		msg = append(msg, f...)
+	}
+	msg = msg[:d.msgLen]
+	if crc32.ChecksumIEEE(msg) != d.checksum {
+		return nil, errors.New("checksum mismatch")
+	}
+	return msg, nil
+}
+
+// chooseFragments returns indexes of fragments used for the part.
+func chooseFragments(seqNum, seqLen, checksum uint32) []int {
+	if seqNum <= seqLen {
+		return []int{int(seqNum - 1)}
+	}
+	var seed [8]byte
+	binary.BigEndian.PutUint32(seed[:], seqNum)
+	binary.BigEndian.PutUint32(seed[4:], checksum)
+	rng := newXoshiro(sha256.Sum256(seed[:]))
+
+	// Degree distribution is 1/i.
+	var total float64
+	for i := 1; i <= int(seqLen); i++ {
+		total += 1 / float64(i)
+	}
+	var (
+		r      = rng.float() * total
+		degree = int(seqLen)
+	)
+	for i := 1; i <= int(seqLen); i++ {
+		r -= 1 / float64(i)
+		if r < 0 {
+			degree = i
+			break
+		}
+	}
+	indexes := make([]int, seqLen)
+	for i := range indexes {
+		indexes[i] = i
+	}
+	for i := len(indexes) - 1; i > 0; i-- {
+		j := int(rng.float() * float64(i+1))
+		indexes[i], indexes[j] = indexes[j], indexes[i]
+	}
+	indexes = indexes[:degree]
+	sort.Ints(indexes)
+	return indexes
+}
+
+func newXoshiro(seed [32]byte) *xoshiro {
+	var x xoshiro
+	for i := range x {
+		x[i] = binary.BigEndian.Uint64(seed[i*8:])
+	}
+	return &x
+}
+
+func (x *xoshiro) next() uint64 {
+	res := bits.RotateLeft64(x[1]*5, 7) * 9
+	t := x[1] << 17
+	x[2] ^= x[0]
+	x[3] ^= x[1]
+	x[1] ^= x[2]
+	x[0] ^= x[3]
+	x[2] ^= t
+	x[3] = bits.RotateLeft64(x[3], 45)
+	return res
+}
+
+// float returns a number in [0, 1) range.
+func (x *xoshiro) float() float64 {
+	return float64(x.next()>>11) / (1 << 53)
+}
+
+func xor(dst, src []byte) {
+	for i := range dst {
+		dst[i] ^= src[i]
+	}
+}
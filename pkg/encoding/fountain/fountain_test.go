@@ -0,0 +1,130 @@
+package fountain
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/internal/testserdes"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewEncoder(t *testing.T) {
+	_, err := NewEncoder(nil, 10)
+	require.Error(t, err)
+	_, err = NewEncoder(make([]byte, MaxMessageLen+1), 10)
+	require.Error(t, err)
+	_, err = NewEncoder([]byte{1}, 0)
+	require.Error(t, err)
+
+	e, err := NewEncoder(make([]byte, 101), 10)
+	require.NoError(t, err)
+	require.Equal(t, 11, e.SeqLen())
+	require.Equal(t, 10, len(e.NextPart().Data)) // Balanced.
+}
+
+func TestEncodeDecode(t *testing.T) {
+	for _, n := range []int{1, 10, 99, 1000, 5000} {
+		msg := make([]byte, n)
+		rand.Read(msg)
+		e, err := NewEncoder(msg, 100)
+		require.NoError(t, err)
+
+		// Pure fragments are enough.
+		d := NewDecoder()
+		for i := 0; i < e.SeqLen(); i++ {
+			require.False(t, d.Complete())
+			p := e.NextPart()
+			require.Equal(t, uint32(i+1), p.SeqNum)
+			require.NoError(t, d.Add(p))
+		}
+		require.True(t, d.Complete())
+		res, err := d.Message()
+		require.NoError(t, err)
+		require.Equal(t, msg, res)
+	}
+}
+
+func TestLostParts(t *testing.T) {
+	msg := make([]byte, 3000)
+	rand.Read(msg)
+	e, err := NewEncoder(msg, 100)
+	require.NoError(t, err)
+
+	d := NewDecoder()
+	_, err = d.Message()
+	require.ErrorIs(t, err, ErrIncomplete)
+	var received int
+	for i := 0; !d.Complete(); i++ {
+		require.Less(t, i, 10*e.SeqLen(), "too many parts needed")
+		p := e.NextPart()
+		if rand.Intn(3) == 0 {
+			continue // Lost.
+		}
+		require.NoError(t, d.Add(p))
+		received++
+	}
+	known, total := d.Progress()
+	require.Equal(t, e.SeqLen(), known)
+	require.Equal(t, e.SeqLen(), total)
+	res, err := d.Message()
+	require.NoError(t, err)
+	require.Equal(t, msg, res)
+	// Mixed parts are useful, so the overhead is limited.
+	require.Less(t, received, 3*e.SeqLen())
+}
+
+func TestDecoderAdd(t *testing.T) {
+	e1, err := NewEncoder([]byte("first message"), 5)
+	require.NoError(t, err)
+	e2, err := NewEncoder([]byte("other message"), 5)
+	require.NoError(t, err)
+
+	d := NewDecoder()
+	require.NoError(t, d.Add(e1.NextPart()))
+	require.Error(t, d.Add(e2.NextPart()))
+
+	p := e1.NextPart()
+	require.Error(t, d.Add(&Part{SeqNum: p.SeqNum, SeqLen: p.SeqLen, MessageLen: p.MessageLen, Checksum: p.Checksum, Data: p.Data[1:]}))
+	require.NoError(t, d.Add(p))
+	require.False(t, d.Complete())
+
+	p = e1.NextPart()
+	p.Data[0] ^= 0xFF // Corrupted.
+	require.NoError(t, d.Add(p))
+	require.True(t, d.Complete())
+	_, err = d.Message()
+	require.Error(t, err)
+}
+
+func TestChooseFragments(t *testing.T) {
+	for i := uint32(1); i <= 5; i++ {
+		require.Equal(t, []int{int(i - 1)}, chooseFragments(i, 5, 42))
+	}
+	for i := uint32(6); i < 100; i++ {
+		idx := chooseFragments(i, 5, 42)
+		require.Equal(t, idx, chooseFragments(i, 5, 42))
+		require.True(t, len(idx) >= 1 && len(idx) <= 5)
+	}
+}
+
+func TestPartSerializable(t *testing.T) {
+	e, err := NewEncoder(make([]byte, 300), 100)
+	require.NoError(t, err)
+	p := e.NextPart()
+	testserdes.EncodeDecodeBinary(t, p, new(Part))
+
+	for _, bad := range []Part{
+		{SeqNum: 0, SeqLen: 3, MessageLen: 300, Data: p.Data},
+		{SeqNum: 1, SeqLen: 0, MessageLen: 300, Data: p.Data},
+		{SeqNum: 1, SeqLen: 3, MessageLen: MaxMessageLen + 1, Data: p.Data},
+		{SeqNum: 1, SeqLen: 301, MessageLen: 300, Data: p.Data},
+		{SeqNum: 1, SeqLen: 3, MessageLen: 300, Data: p.Data[1:]},
+	} {
+		data, err := testserdes.EncodeBinary(&bad)
+		require.NoError(t, err)
+		r := io.NewBinReaderFromBuf(data)
+		new(Part).DecodeBinary(r)
+		require.Error(t, r.Err)
+	}
+}
@@ -0,0 +1,746 @@
+package qr
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"math"
+	"math/bits"
+	"sort"
+)
+
+// ErrNotFound is returned when there is no QR code in the image.
+var ErrNotFound = errors.New("QR code not found")
+
+const alphanumericChars = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ $%*+-./:"
+
+type (
+	// bitmap is a binarized image, true is dark.
+	bitmap struct {
+		w, h int
+		bits []bool
+	}
+
+	// finder is a finder pattern candidate.
+	finder struct {
+		x, y   float64
+		module float64
+		count  int
+	}
+
+	// perspective is a projective transformation.
+	perspective struct {
+		a11, a12, a13, a21, a22, a23, a31, a32, a33 float64
+	}
+)
+
+// Decode finds a QR code in the image and returns its data.
+func Decode(img image.Image) ([]byte, error) {
+	var (
+		gray = luminance(img)
+		err  = ErrNotFound
+	)
+	for _, bm := range []*bitmap{globalThreshold(gray), adaptiveThreshold(gray)} {
+		for _, inverted := range []bool{false, true} {
+			if inverted {
+				bm = bm.inverted()
+			}
+			var res []byte
+			res, err = bm.decode()
+			if err == nil {
+				return res, nil
+			}
+		}
+	}
+	return nil, err
+}
+
+type grayImage struct {
+	w, h int
+	pix  []uint8
+}
+
+func luminance(img image.Image) *grayImage {
+	b := img.Bounds()
+	g := &grayImage{w: b.Dx(), h: b.Dy(), pix: make([]uint8, b.Dx()*b.Dy())}
+	if gi, ok := img.(*image.Gray); ok {
+		for y := 0; y < g.h; y++ {
+			copy(g.pix[y*g.w:(y+1)*g.w], gi.Pix[gi.PixOffset(b.Min.X, b.Min.Y+y):])
+		}
+		return g
+	}
+	for y := 0; y < g.h; y++ {
+		for x := 0; x < g.w; x++ {
+			r, gg, bb, a := img.At(b.Min.X+x, b.Min.Y+y).RGBA()
+			// Colors are alpha-premultiplied, transparent pixels are white.
+			l := (299*r+587*gg+114*bb)/1000 + (0xFFFF - a)
+			g.pix[y*g.w+x] = uint8(l >> 8)
+		}
+	}
+	return g
+}
+
+// globalThreshold binarizes the image using Otsu's method.
+func globalThreshold(g *grayImage) *bitmap {
+	var hist [256]int
+	for _, p := range g.pix {
+		hist[p]++
+	}
+	var (
+		total     = len(g.pix)
+		sum, sumB float64
+		wB        int
+		best      float64
+		threshold int
+	)
+	for i, n := range hist {
+		sum += float64(i * n)
+	}
+	for i := 0; i < 256; i++ {
+		wB += hist[i]
+		if wB == 0 {
+			continue
+		}
+		wF := total - wB
+		if wF == 0 {
+			break
+		}
+		sumB += float64(i * hist[i])
+		mB := sumB / float64(wB)
+		mF := (sum - sumB) / float64(wF)
+		between := float64(wB) * float64(wF) * (mB - mF) * (mB - mF)
+		if between > best {
+			best = between
+			threshold = i
+		}
+	}
+	bm := &bitmap{w: g.w, h: g.h, bits: make([]bool, len(g.pix))}
+	for i, p := range g.pix {
+		bm.bits[i] = int(p) <= threshold
+	}
+	return bm
+}
+
+// adaptiveThreshold binarizes the image comparing every pixel with the mean
+// of its neighbourhood, it works better for unevenly lit photos.
+func adaptiveThreshold(g *grayImage) *bitmap {
+	integral := make([]int, (g.w+1)*(g.h+1))
+	for y := 0; y < g.h; y++ {
+		var row int
+		for x := 0; x < g.w; x++ {
+			row += int(g.pix[y*g.w+x])
+			integral[(y+1)*(g.w+1)+x+1] = integral[y*(g.w+1)+x+1] + row
+		}
+	}
+	r := max(g.w, g.h) / 16
+	if r < 7 {
+		r = 7
+	}
+	bm := &bitmap{w: g.w, h: g.h, bits: make([]bool, len(g.pix))}
+	for y := 0; y < g.h; y++ {
+		y0, y1 := max(0, y-r), min(g.h, y+r+1)
+		for x := 0; x < g.w; x++ {
+			x0, x1 := max(0, x-r), min(g.w, x+r+1)
+			sum := integral[y1*(g.w+1)+x1] - integral[y0*(g.w+1)+x1] - integral[y1*(g.w+1)+x0] + integral[y0*(g.w+1)+x0]
+			area := (x1 - x0) * (y1 - y0)
+			bm.bits[y*g.w+x] = int(g.pix[y*g.w+x])*area*10 < sum*9
+		}
+	}
+	return bm
+}
+
+func (bm *bitmap) inverted() *bitmap {
+	res := &bitmap{w: bm.w, h: bm.h, bits: make([]bool, len(bm.bits))}
+	for i := range bm.bits {
+		res.bits[i] = !bm.bits[i]
+	}
+	return res
+}
+
+func (bm *bitmap) black(x, y int) bool {
+	return x >= 0 && y >= 0 && x < bm.w && y < bm.h && bm.bits[y*bm.w+x]
+}
+
+func (bm *bitmap) decode() ([]byte, error) {
+	b, a, c, err := bm.findFinders()
+	if err != nil {
+		return nil, err
+	}
+	module := (bm.moduleSize(b, a) + bm.moduleSize(b, c)) / 2
+	if module < 1 {
+		return nil, ErrNotFound
+	}
+	est := (math.Hypot(a.x-b.x, a.y-b.y)+math.Hypot(c.x-b.x, c.y-b.y))/(2*module) + 7
+	dim := int(math.Round((est-1)/4))*4 + 1
+
+	var lastErr = ErrNotFound
+	for _, d := range []int{dim, dim - 4, dim + 4, dim - 8, dim + 8} {
+		if d < 21 || d > maxVersion*4+17 {
+			continue
+		}
+		grid := bm.sample(a, b, c, d)
+		if ver, ok := readVersion(grid, d); ok && ver*4+17 != d {
+			d = ver*4 + 17
+			grid = bm.sample(a, b, c, d)
+		}
+		res, err := decodeGrid(grid, d)
+		if err == nil {
+			return res, nil
+		}
+		// Mirrored image.
+		res, err = decodeGrid(transpose(grid, d), d)
+		if err == nil {
+			return res, nil
+		}
+		lastErr = err
+	}
+	return nil, lastErr
+}
+
+// findFinders returns top-left, top-right and bottom-left finder patterns.
+func (bm *bitmap) findFinders() (*finder, *finder, *finder, error) {
+	var cands []*finder
+	for y := 0; y < bm.h; y++ {
+		var (
+			counts [5]int
+			state  int
+		)
+		for x := 0; x <= bm.w; x++ {
+			if x < bm.w && bm.black(x, y) {
+				if state&1 == 1 {
+					state++
+				}
+				counts[state]++
+				continue
+			}
+			if state&1 == 1 {
+				counts[state]++
+				continue
+			}
+			if state < 4 {
+				state++
+				counts[state]++
+				continue
+			}
+			if isFinderRun(counts) {
+				cands = bm.handleCenter(cands, counts, x, y)
+			}
+			counts = [5]int{counts[2], counts[3], counts[4], 1, 0}
+			state = 3
+		}
+	}
+	// Real patterns are confirmed by every row crossing their center (about
+	// three modules), random matches are not.
+	var confirmed = cands[:0]
+	for _, f := range cands {
+		if float64(f.count) >= f.module {
+			confirmed = append(confirmed, f)
+		}
+	}
+	cands = confirmed
+	sort.Slice(cands, func(i, j int) bool { return cands[i].count > cands[j].count })
+	if len(cands) > 12 {
+		cands = cands[:12]
+	}
+	var (
+		best    = math.Inf(1)
+		b, a, c *finder
+	)
+	for i := 0; i < len(cands); i++ {
+		for j := i + 1; j < len(cands); j++ {
+			for k := j + 1; k < len(cands); k++ {
+				tb, ta, tc, score := orderFinders(cands[i], cands[j], cands[k])
+				if score < best {
+					best, b, a, c = score, tb, ta, tc
+				}
+			}
+		}
+	}
+	if b == nil || best > 0.5 {
+		return nil, nil, nil, ErrNotFound
+	}
+	return b, a, c, nil
+}
+
+// orderFinders returns patterns ordered as top-left, top-right and
+// bottom-left with a score showing how bad they are as a QR code corners.
+func orderFinders(p1, p2, p3 *finder) (*finder, *finder, *finder, float64) {
+	dist := func(a, b *finder) float64 { return math.Hypot(a.x-b.x, a.y-b.y) }
+	var (
+		d12, d13, d23 = dist(p1, p2), dist(p1, p3), dist(p2, p3)
+		b, a, c       = p1, p2, p3
+		hyp, s1, s2   = d23, d12, d13
+	)
+	// Right angle is opposite to the longest side.
+	if d12 >= d13 && d12 >= d23 {
+		b, a, c, hyp, s1, s2 = p3, p1, p2, d12, d13, d23
+	} else if d13 >= d12 && d13 >= d23 {
+		b, a, c, hyp, s1, s2 = p2, p1, p3, d13, d12, d23
+	}
+	if (a.x-b.x)*(c.y-b.y)-(a.y-b.y)*(c.x-b.x) < 0 {
+		a, c = c, a
+	}
+	var (
+		minM   = math.Min(b.module, math.Min(a.module, c.module))
+		maxM   = math.Max(b.module, math.Max(a.module, c.module))
+		score  = (maxM - minM) / maxM
+		minLen = 10 * maxM // 14 for 21 modules, but rotation makes modules look bigger.
+	)
+	if s1 < minLen || s2 < minLen {
+		return b, a, c, math.Inf(1)
+	}
+	score += math.Abs(s1-s2) / math.Max(s1, s2)
+	score += math.Abs(hyp*hyp-s1*s1-s2*s2) / (hyp * hyp)
+	return b, a, c, score
+}
+
+func isFinderRun(counts [5]int) bool {
+	var total int
+	for _, c := range counts {
+		if c == 0 {
+			return false
+		}
+		total += c
+	}
+	if total < 7 {
+		return false
+	}
+	module := float64(total) / 7
+	variance := module / 2
+	return math.Abs(module-float64(counts[0])) < variance &&
+		math.Abs(module-float64(counts[1])) < variance &&
+		math.Abs(3*module-float64(counts[2])) < 3*variance &&
+		math.Abs(module-float64(counts[3])) < variance &&
+		math.Abs(module-float64(counts[4])) < variance
+}
+
+func centerFromEnd(counts [5]int, end int) float64 {
+	return float64(end-counts[4]-counts[3]) - float64(counts[2])/2
+}
+
+func (bm *bitmap) handleCenter(cands []*finder, counts [5]int, endX, y int) []*finder {
+	total := counts[0] + counts[1] + counts[2] + counts[3] + counts[4]
+	cx := centerFromEnd(counts, endX)
+	cy, ok := bm.crossCheck(int(cx), y, false, counts[2], total)
+	if !ok {
+		return cands
+	}
+	cx, ok = bm.crossCheck(int(cx), int(cy), true, counts[2], total)
+	if !ok {
+		return cands
+	}
+	module := float64(total) / 7
+	for _, f := range cands {
+		if math.Abs(f.x-cx) <= module && math.Abs(f.y-cy) <= module &&
+			math.Abs(f.module-module) <= math.Max(1, f.module) {
+			n := float64(f.count)
+			f.x = (f.x*n + cx) / (n + 1)
+			f.y = (f.y*n + cy) / (n + 1)
+			f.module = (f.module*n + module) / (n + 1)
+			f.count++
+			return cands
+		}
+	}
+	return append(cands, &finder{x: cx, y: cy, module: module, count: 1})
+}
+
+// crossCheck checks the finder pattern in the perpendicular direction
+// (vertical by default) returning the refined center coordinate.
+func (bm *bitmap) crossCheck(x, y int, horizontal bool, maxCount, origTotal int) (float64, bool) {
+	get := func(i int) bool { return bm.black(x, i) }
+	start, limit := y, bm.h
+	if horizontal {
+		get = func(i int) bool { return bm.black(i, y) }
+		start, limit = x, bm.w
+	}
+	var counts [5]int
+	i := start
+	for ; i >= 0 && get(i); i-- {
+		counts[2]++
+	}
+	for ; i >= 0 && !get(i) && counts[1] <= maxCount; i-- {
+		counts[1]++
+	}
+	for ; i >= 0 && get(i) && counts[0] <= maxCount; i-- {
+		counts[0]++
+	}
+	if i < 0 || counts[1] > maxCount || counts[0] > maxCount {
+		return 0, false
+	}
+	for i = start + 1; i < limit && get(i); i++ {
+		counts[2]++
+	}
+	for ; i < limit && !get(i) && counts[3] <= maxCount; i++ {
+		counts[3]++
+	}
+	for ; i < limit && get(i) && counts[4] <= maxCount; i++ {
+		counts[4]++
+	}
+	if i >= limit || counts[3] > maxCount || counts[4] > maxCount {
+		return 0, false
+	}
+	total := counts[0] + counts[1] + counts[2] + counts[3] + counts[4]
+	if 5*abs(total-origTotal) >= 2*origTotal || !isFinderRun(counts) {
+		return 0, false
+	}
+	return centerFromEnd(counts, i), true
+}
+
+// moduleSize estimates the module size walking from the center of the
+// finder pattern towards the other one until its outer dark ring ends, this
+// distance is 3.5 modules.
+func (bm *bitmap) moduleSize(from, to *finder) float64 {
+	var (
+		dx, dy = to.x - from.x, to.y - from.y
+		l      = math.Hypot(dx, dy)
+		state  int
+	)
+	dx, dy = dx/l, dy/l
+	for t := 0.0; t < l; t++ {
+		dark := bm.black(int(math.Floor(from.x+dx*t)), int(math.Floor(from.y+dy*t)))
+		if dark == (state%2 == 1) {
+			state++
+			if state == 3 {
+				// The edge is somewhere in the last step.
+				return (t - 0.5) / 3.5
+			}
+		}
+	}
+	return from.module
+}
+
+// sample reads modules of the code with the given dimension.
+func (bm *bitmap) sample(a, b, c *finder, dim int) []bool {
+	var (
+		d      = float64(dim)
+		brX    = a.x - b.x + c.x
+		brY    = a.y - b.y + c.y
+		corner = d - 3.5
+	)
+	if dim > 21 {
+		// Bottom-right alignment pattern.
+		if x, y, ok := bm.findAlignment(a, b, c, d); ok {
+			brX, brY, corner = x, y, d-6.5
+		}
+	}
+	t := quadToQuad(
+		3.5, 3.5, d-3.5, 3.5, corner, corner, 3.5, d-3.5,
+		b.x, b.y, a.x, a.y, brX, brY, c.x, c.y)
+	grid := make([]bool, dim*dim)
+	for y := 0; y < dim; y++ {
+		for x := 0; x < dim; x++ {
+			px, py := t.transform(float64(x)+0.5, float64(y)+0.5)
+			grid[y*dim+x] = bm.black(int(math.Floor(px)), int(math.Floor(py)))
+		}
+	}
+	return grid
+}
+
+// findAlignment searches for the bottom-right alignment pattern around its
+// estimated position extending the search area if it's not found.
+func (bm *bitmap) findAlignment(a, b, c *finder, d float64) (float64, float64, bool) {
+	var (
+		ux, uy = (a.x - b.x) / (d - 7), (a.y - b.y) / (d - 7)
+		vx, vy = (c.x - b.x) / (d - 7), (c.y - b.y) / (d - 7)
+		off    = d - 10 // From the top-left finder center.
+		ex     = b.x + (ux+vx)*off
+		ey     = b.y + (uy+vy)*off
+		module = math.Max(math.Hypot(ux, uy), math.Hypot(vx, vy))
+	)
+	for _, allowance := range []float64{4, 8, 16} {
+		var (
+			r      = int(math.Ceil(allowance * module))
+			best   int
+			sx, sy float64
+			n      int
+		)
+		for y := int(ey) - r; y <= int(ey)+r; y++ {
+			for x := int(ex) - r; x <= int(ex)+r; x++ {
+				var score int
+				for j := -2; j <= 2; j++ {
+					for i := -2; i <= 2; i++ {
+						px := float64(x) + 0.5 + ux*float64(i) + vx*float64(j)
+						py := float64(y) + 0.5 + uy*float64(i) + vy*float64(j)
+						if bm.black(int(math.Floor(px)), int(math.Floor(py))) == (max(abs(i), abs(j)) != 1) {
+							score++
+						}
+					}
+				}
+				switch {
+				case score > best:
+					best, sx, sy, n = score, float64(x)+0.5, float64(y)+0.5, 1
+				case score == best:
+					sx, sy, n = sx+float64(x)+0.5, sy+float64(y)+0.5, n+1
+				}
+			}
+		}
+		if best >= 24 {
+			return sx / float64(n), sy / float64(n), true
+		}
+	}
+	return 0, 0, false
+}
+
+func squareToQuad(x0, y0, x1, y1, x2, y2, x3, y3 float64) perspective {
+	dx3 := x0 - x1 + x2 - x3
+	dy3 := y0 - y1 + y2 - y3
+	if dx3 == 0 && dy3 == 0 {
+		return perspective{x1 - x0, y1 - y0, 0, x2 - x1, y2 - y1, 0, x0, y0, 1}
+	}
+	dx1, dx2 := x1-x2, x3-x2
+	dy1, dy2 := y1-y2, y3-y2
+	den := dx1*dy2 - dx2*dy1
+	a13 := (dx3*dy2 - dx2*dy3) / den
+	a23 := (dx1*dy3 - dx3*dy1) / den
+	return perspective{
+		x1 - x0 + a13*x1, y1 - y0 + a13*y1, a13,
+		x3 - x0 + a23*x3, y3 - y0 + a23*y3, a23,
+		x0, y0, 1,
+	}
+}
+
+func (p perspective) adjoint() perspective {
+	return perspective{
+		p.a22*p.a33 - p.a23*p.a32, p.a13*p.a32 - p.a12*p.a33, p.a12*p.a23 - p.a13*p.a22,
+		p.a23*p.a31 - p.a21*p.a33, p.a11*p.a33 - p.a13*p.a31, p.a13*p.a21 - p.a11*p.a23,
+		p.a21*p.a32 - p.a22*p.a31, p.a12*p.a31 - p.a11*p.a32, p.a11*p.a22 - p.a12*p.a21,
+	}
+}
+
+func (p perspective) times(o perspective) perspective {
+	return perspective{
+		p.a11*o.a11 + p.a21*o.a12 + p.a31*o.a13, p.a12*o.a11 + p.a22*o.a12 + p.a32*o.a13, p.a13*o.a11 + p.a23*o.a12 + p.a33*o.a13,
+		p.a11*o.a21 + p.a21*o.a22 + p.a31*o.a23, p.a12*o.a21 + p.a22*o.a22 + p.a32*o.a23, p.a13*o.a21 + p.a23*o.a22 + p.a33*o.a23,
+		p.a11*o.a31 + p.a21*o.a32 + p.a31*o.a33, p.a12*o.a31 + p.a22*o.a32 + p.a32*o.a33, p.a13*o.a31 + p.a23*o.a32 + p.a33*o.a33,
+	}
+}
+
+// quadToQuad returns the transformation mapping the first quadrilateral to
+// the second one.
+func quadToQuad(x0, y0, x1, y1, x2, y2, x3, y3, x0p, y0p, x1p, y1p, x2p, y2p, x3p, y3p float64) perspective {
+	qToS := squareToQuad(x0, y0, x1, y1, x2, y2, x3, y3).adjoint()
+	return squareToQuad(x0p, y0p, x1p, y1p, x2p, y2p, x3p, y3p).times(qToS)
+}
+
+func (p perspective) transform(x, y float64) (float64, float64) {
+	den := p.a13*x + p.a23*y + p.a33
+	return (p.a11*x + p.a21*y + p.a31) / den, (p.a12*x + p.a22*y + p.a32) / den
+}
+
+func transpose(grid []bool, dim int) []bool {
+	res := make([]bool, len(grid))
+	for y := 0; y < dim; y++ {
+		for x := 0; x < dim; x++ {
+			res[x*dim+y] = grid[y*dim+x]
+		}
+	}
+	return res
+}
+
+// readVersion reads version information from the grid.
+func readVersion(grid []bool, dim int) (int, bool) {
+	if dim < 7*4+17 {
+		return 0, false
+	}
+	for _, transposed := range []bool{false, true} {
+		var val int
+		for i := 0; i < 18; i++ {
+			x, y := dim-11+i%3, i/3
+			if transposed {
+				x, y = y, x
+			}
+			if grid[y*dim+x] {
+				val |= 1 << i
+			}
+		}
+		for ver := 7; ver <= maxVersion; ver++ {
+			if bits.OnesCount(uint(val^versionInfo(ver))) <= 3 {
+				return ver, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// decodeGrid decodes the code from its modules.
+func decodeGrid(grid []bool, dim int) ([]byte, error) {
+	ver := (dim - 17) / 4
+	if ver < minVersion || ver > maxVersion || ver*4+17 != dim {
+		return nil, fmt.Errorf("invalid dimension %d", dim)
+	}
+	var (
+		first, second = formatPositions(dim)
+		bestDist      = 16
+		lvl           Level
+		mask          int
+	)
+	for _, pos := range [][15]image.Point{first, second} {
+		var val int
+		for i, p := range pos {
+			if grid[p.Y*dim+p.X] {
+				val |= 1 << i
+			}
+		}
+		for l := Low; l <= High; l++ {
+			for m := 0; m < 8; m++ {
+				if d := bits.OnesCount(uint(val ^ formatInfo(l, m))); d < bestDist {
+					bestDist, lvl, mask = d, l, m
+				}
+			}
+		}
+	}
+	if bestDist > 3 {
+		return nil, errors.New("invalid format information")
+	}
+
+	c := newCode(ver, lvl)
+	copy(c.modules, grid)
+	c.applyMask(mask)
+	codewords := make([]byte, rawDataModules(ver)/8)
+	for i, p := range c.dataPositions() {
+		if i >= len(codewords)*8 {
+			break
+		}
+		if c.modules[p.Y*dim+p.X] {
+			codewords[i/8] |= 0x80 >> (i % 8)
+		}
+	}
+
+	var (
+		numBlocks, numShort, shortLen, ecLen = blockSizes(ver, lvl)
+		blocks                               = make([][]byte, numBlocks)
+		k                                    int
+	)
+	for j := range blocks {
+		l := shortLen
+		if j >= numShort {
+			l++
+		}
+		blocks[j] = make([]byte, l+ecLen)
+	}
+	for i := 0; i <= shortLen; i++ {
+		for j := range blocks {
+			if i < len(blocks[j])-ecLen {
+				blocks[j][i] = codewords[k]
+				k++
+			}
+		}
+	}
+	for i := 0; i < ecLen; i++ {
+		for j := range blocks {
+			blocks[j][len(blocks[j])-ecLen+i] = codewords[k]
+			k++
+		}
+	}
+	var data []byte
+	for _, b := range blocks {
+		if err := rsCorrect(b, ecLen); err != nil {
+			return nil, err
+		}
+		data = append(data, b[:len(b)-ecLen]...)
+	}
+	return parseSegments(data, ver)
+}
+
+type bitReader struct {
+	data []byte
+	pos  int
+}
+
+func (r *bitReader) left() int {
+	return len(r.data)*8 - r.pos
+}
+
+func (r *bitReader) read(n int) (int, error) {
+	if n > r.left() {
+		return 0, errors.New("unexpected end of data")
+	}
+	var res int
+	for i := 0; i < n; i++ {
+		res = res<<1 | int(r.data[r.pos/8]>>(7-r.pos%8)&1)
+		r.pos++
+	}
+	return res, nil
+}
+
+// parseSegments decodes numeric, alphanumeric and byte mode segments.
+func parseSegments(data []byte, ver int) ([]byte, error) {
+	var (
+		r   = &bitReader{data: data}
+		res = []byte{}
+	)
+	countSize := func(small, medium, large int) int {
+		switch {
+		case ver < 10:
+			return small
+		case ver < 27:
+			return medium
+		default:
+			return large
+		}
+	}
+	for r.left() >= 4 {
+		mode, _ := r.read(4)
+		switch mode {
+		case 0b0000:
+			return res, nil
+		case 0b0100:
+			n, err := r.read(countSize(8, 16, 16))
+			if err != nil {
+				return nil, err
+			}
+			for i := 0; i < n; i++ {
+				b, err := r.read(8)
+				if err != nil {
+					return nil, err
+				}
+				res = append(res, byte(b))
+			}
+		case 0b0001:
+			n, err := r.read(countSize(10, 12, 14))
+			if err != nil {
+				return nil, err
+			}
+			for ; n > 0; n -= 3 {
+				digits, size := min(n, 3), [4]int{0, 4, 7, 10}[min(n, 3)]
+				v, err := r.read(size)
+				if err != nil {
+					return nil, err
+				}
+				res = append(res, []byte(fmt.Sprintf("%0*d", digits, v))...)
+			}
+		case 0b0010:
+			n, err := r.read(countSize(9, 11, 13))
+			if err != nil {
+				return nil, err
+			}
+			for ; n > 0; n -= 2 {
+				if n == 1 {
+					v, err := r.read(6)
+					if err != nil || v >= 45 {
+						return nil, errors.New("invalid alphanumeric data")
+					}
+					res = append(res, alphanumericChars[v])
+					break
+				}
+				v, err := r.read(11)
+				if err != nil || v >= 45*45 {
+					return nil, errors.New("invalid alphanumeric data")
+				}
+				res = append(res, alphanumericChars[v/45], alphanumericChars[v%45])
+			}
+		case 0b0111:
+			// ECI designator is ignored.
+			b, err := r.read(8)
+			if err == nil && b&0x80 != 0 {
+				_, err = r.read(8 * (1 + b>>6&1))
+			}
+			if err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("unsupported mode %04b", mode)
+		}
+	}
+	return res, nil
+}
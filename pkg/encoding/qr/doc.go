@@ -0,0 +1,8 @@
+/*
+Package qr implements QR code (model 2) encoding and decoding. Encoder always
+uses byte mode, it can render codes as images or as text suitable for terminal
+output. Decoder works with images of codes (like the ones produced by encoder,
+screenshots or reasonably good photos), it locates the code using finder and
+alignment patterns, so the image doesn't have to be cropped or aligned.
+*/
+package qr
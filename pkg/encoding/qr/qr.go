@@ -0,0 +1,549 @@
+package qr
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"strings"
+)
+
+// Level is the error correction level.
+type Level byte
+
+// Error correction levels, they allow to restore approximately 7%, 15%, 25%
+// and 30% of codewords respectively.
+const (
+	Low Level = iota
+	Medium
+	Quartile
+	High
+)
+
+const (
+	minVersion = 1
+	maxVersion = 40
+	// QuietZone is the width of the border (in modules) added around the
+	// code when it's rendered.
+	QuietZone = 4
+)
+
+// ErrTooBig is returned when data can't fit into a QR code.
+var ErrTooBig = errors.New("data is too big")
+
+// Error correction codewords per block, indexed by level and version.
+var ecCodewordsPerBlock = [4][maxVersion + 1]int{
+	{-1, 7, 10, 15, 20, 26, 18, 20, 24, 30, 18, 20, 24, 26, 30, 22, 24, 28, 30, 28, 28, 28, 28, 30, 30, 26, 28, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+	{-1, 10, 16, 26, 18, 24, 16, 18, 22, 22, 26, 30, 22, 22, 24, 24, 28, 28, 26, 26, 26, 26, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28},
+	{-1, 13, 22, 18, 26, 18, 24, 18, 22, 20, 24, 28, 26, 24, 20, 30, 24, 28, 28, 26, 30, 28, 30, 30, 30, 30, 28, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+	{-1, 17, 28, 22, 16, 22, 28, 26, 26, 24, 28, 24, 28, 22, 24, 24, 30, 28, 28, 26, 28, 30, 24, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+}
+
+// Number of error correction blocks, indexed by level and version.
+var ecBlocks = [4][maxVersion + 1]int{
+	{-1, 1, 1, 1, 1, 1, 2, 2, 2, 2, 4, 4, 4, 4, 4, 6, 6, 6, 6, 7, 8, 8, 9, 9, 10, 12, 12, 12, 13, 14, 15, 16, 17, 18, 19, 19, 20, 21, 22, 24, 25},
+	{-1, 1, 1, 1, 2, 2, 4, 4, 4, 5, 5, 5, 8, 9, 9, 10, 10, 11, 13, 14, 16, 17, 17, 18, 20, 21, 23, 25, 26, 28, 29, 31, 33, 35, 37, 38, 40, 43, 45, 47, 49},
+	{-1, 1, 1, 2, 2, 4, 4, 6, 6, 8, 8, 8, 10, 12, 16, 12, 17, 16, 18, 21, 20, 23, 23, 25, 27, 29, 34, 34, 35, 38, 40, 43, 45, 48, 51, 53, 56, 59, 62, 65, 68},
+	{-1, 1, 1, 2, 4, 4, 4, 5, 6, 8, 8, 11, 11, 16, 16, 18, 16, 19, 21, 25, 25, 25, 34, 30, 32, 35, 37, 40, 42, 45, 48, 51, 54, 57, 60, 63, 66, 70, 74, 77, 81},
+}
+
+// formatBits are the level bits used in format information.
+var formatBits = [4]int{Low: 1, Medium: 0, Quartile: 3, High: 2}
+
+// Code is a QR code.
+type Code struct {
+	// Size is the number of modules in each dimension.
+	Size    int
+	Version int
+	Level   Level
+	Mask    int
+
+	modules    []bool
+	isFunction []bool
+}
+
+// Encode creates the smallest QR code for the data with the given error
+// correction level using byte mode.
+func Encode(data []byte, lvl Level) (*Code, error) {
+	if lvl > High {
+		return nil, fmt.Errorf("invalid level %d", lvl)
+	}
+	var ver int
+	for ver = minVersion; ver <= maxVersion; ver++ {
+		if dataBits(len(data), ver) <= dataCodewords(ver, lvl)*8 {
+			break
+		}
+	}
+	if ver > maxVersion {
+		return nil, ErrTooBig
+	}
+
+	var bb bitBuffer
+	bb.append(0b0100, 4)
+	bb.append(len(data), countBits(ver))
+	for _, b := range data {
+		bb.append(int(b), 8)
+	}
+	capacity := dataCodewords(ver, lvl) * 8
+	bb.append(0, min(4, capacity-bb.len()))
+	bb.append(0, (8-bb.len()%8)%8)
+	for pad := 0xEC; bb.len() < capacity; pad ^= 0xEC ^ 0x11 {
+		bb.append(pad, 8)
+	}
+
+	c := newCode(ver, lvl)
+	c.drawCodewords(addErrorCorrection(bb.bytes(), ver, lvl))
+
+	var minPenalty = -1
+	for mask := 0; mask < 8; mask++ {
+		c.applyMask(mask)
+		c.drawFormat(mask)
+		p := c.penalty()
+		if minPenalty < 0 || p < minPenalty {
+			minPenalty = p
+			c.Mask = mask
+		}
+		c.applyMask(mask) // Undo.
+	}
+	c.applyMask(c.Mask)
+	c.drawFormat(c.Mask)
+	return c, nil
+}
+
+func countBits(ver int) int {
+	if ver < 10 {
+		return 8
+	}
+	return 16
+}
+
+func dataBits(n int, ver int) int {
+	return 4 + countBits(ver) + 8*n
+}
+
+// rawDataModules returns the number of modules available for data and
+// error correction codewords in the given version.
+func rawDataModules(ver int) int {
+	res := (16*ver+128)*ver + 64
+	if ver >= 2 {
+		numAlign := ver/7 + 2
+		res -= (25*numAlign-10)*numAlign - 55
+		if ver >= 7 {
+			res -= 36
+		}
+	}
+	return res
+}
+
+func dataCodewords(ver int, lvl Level) int {
+	return rawDataModules(ver)/8 - ecCodewordsPerBlock[lvl][ver]*ecBlocks[lvl][ver]
+}
+
+// blockSizes returns the number of blocks, the number of short blocks, the
+// data length of short blocks and the error correction length.
+func blockSizes(ver int, lvl Level) (int, int, int, int) {
+	var (
+		numBlocks = ecBlocks[lvl][ver]
+		ecLen     = ecCodewordsPerBlock[lvl][ver]
+		raw       = rawDataModules(ver) / 8
+	)
+	return numBlocks, numBlocks - raw%numBlocks, raw/numBlocks - ecLen, ecLen
+}
+
+// addErrorCorrection splits the data into blocks, adds error correction
+// codewords and interleaves the result.
+func addErrorCorrection(data []byte, ver int, lvl Level) []byte {
+	var (
+		numBlocks, numShort, shortLen, ecLen = blockSizes(ver, lvl)
+		blocks                               = make([][]byte, numBlocks)
+		ecs                                  = make([][]byte, numBlocks)
+	)
+	for i, k := 0, 0; i < numBlocks; i++ {
+		l := shortLen
+		if i >= numShort {
+			l++
+		}
+		blocks[i] = data[k : k+l]
+		ecs[i] = rsEncode(blocks[i], ecLen)
+		k += l
+	}
+	res := make([]byte, 0, rawDataModules(ver)/8)
+	for i := 0; i <= shortLen; i++ {
+		for j := range blocks {
+			if i < len(blocks[j]) {
+				res = append(res, blocks[j][i])
+			}
+		}
+	}
+	for i := 0; i < ecLen; i++ {
+		for j := range ecs {
+			res = append(res, ecs[j][i])
+		}
+	}
+	return res
+}
+
+// newCode creates a code with function patterns drawn and format/version
+// areas reserved.
+func newCode(ver int, lvl Level) *Code {
+	size := ver*4 + 17
+	c := &Code{
+		Size:       size,
+		Version:    ver,
+		Level:      lvl,
+		modules:    make([]bool, size*size),
+		isFunction: make([]bool, size*size),
+	}
+	for i := 0; i < size; i++ {
+		c.setFunction(6, i, i%2 == 0)
+		c.setFunction(i, 6, i%2 == 0)
+	}
+	c.drawFinder(3, 3)
+	c.drawFinder(size-4, 3)
+	c.drawFinder(3, size-4)
+	pos := alignmentPositions(ver)
+	for i := range pos {
+		for j := range pos {
+			if (i == 0 && j == 0) || (i == 0 && j == len(pos)-1) || (i == len(pos)-1 && j == 0) {
+				continue
+			}
+			c.drawAlignment(pos[i], pos[j])
+		}
+	}
+	c.drawFormat(0) // Reserve.
+	c.drawVersion()
+	return c
+}
+
+// alignmentPositions returns coordinates of alignment pattern centers.
+func alignmentPositions(ver int) []int {
+	if ver == 1 {
+		return nil
+	}
+	var (
+		numAlign = ver/7 + 2
+		size     = ver*4 + 17
+		step     int
+	)
+	if ver == 32 {
+		step = 26
+	} else {
+		step = (ver*4 + numAlign*2 + 1) / (numAlign*2 - 2) * 2
+	}
+	res := make([]int, numAlign)
+	res[0] = 6
+	for i, pos := numAlign-1, size-7; i >= 1; i, pos = i-1, pos-step {
+		res[i] = pos
+	}
+	return res
+}
+
+func (c *Code) setFunction(x, y int, dark bool) {
+	c.modules[y*c.Size+x] = dark
+	c.isFunction[y*c.Size+x] = true
+}
+
+// Black returns true if the module at the given column and row is dark.
+func (c *Code) Black(x, y int) bool {
+	return x >= 0 && y >= 0 && x < c.Size && y < c.Size && c.modules[y*c.Size+x]
+}
+
+func (c *Code) drawFinder(x, y int) {
+	for dy := -4; dy <= 4; dy++ {
+		for dx := -4; dx <= 4; dx++ {
+			xx, yy := x+dx, y+dy
+			if xx < 0 || yy < 0 || xx >= c.Size || yy >= c.Size {
+				continue
+			}
+			dist := max(abs(dx), abs(dy))
+			c.setFunction(xx, yy, dist != 2 && dist != 4)
+		}
+	}
+}
+
+func (c *Code) drawAlignment(x, y int) {
+	for dy := -2; dy <= 2; dy++ {
+		for dx := -2; dx <= 2; dx++ {
+			c.setFunction(x+dx, y+dy, max(abs(dx), abs(dy)) != 1)
+		}
+	}
+}
+
+// formatInfo returns 15 bits of format information.
+func formatInfo(lvl Level, mask int) int {
+	data := formatBits[lvl]<<3 | mask
+	rem := data
+	for i := 0; i < 10; i++ {
+		rem = (rem << 1) ^ ((rem >> 9) * 0x537)
+	}
+	return (data<<10 | rem) ^ 0x5412
+}
+
+// versionInfo returns 18 bits of version information.
+func versionInfo(ver int) int {
+	rem := ver
+	for i := 0; i < 12; i++ {
+		rem = (rem << 1) ^ ((rem >> 11) * 0x1F25)
+	}
+	return ver<<12 | rem
+}
+
+// formatPositions returns module coordinates of both format information
+// copies, bit i is stored at first[i] and second[i].
+func formatPositions(size int) ([15]image.Point, [15]image.Point) {
+	var first, second [15]image.Point
+	for i := 0; i <= 5; i++ {
+		first[i] = image.Pt(8, i)
+	}
+	first[6] = image.Pt(8, 7)
+	first[7] = image.Pt(8, 8)
+	first[8] = image.Pt(7, 8)
+	for i := 9; i < 15; i++ {
+		first[i] = image.Pt(14-i, 8)
+	}
+	for i := 0; i < 8; i++ {
+		second[i] = image.Pt(size-1-i, 8)
+	}
+	for i := 8; i < 15; i++ {
+		second[i] = image.Pt(8, size-15+i)
+	}
+	return first, second
+}
+
+func (c *Code) drawFormat(mask int) {
+	var (
+		bits          = formatInfo(c.Level, mask)
+		first, second = formatPositions(c.Size)
+	)
+	for i := 0; i < 15; i++ {
+		dark := bits>>i&1 == 1
+		c.setFunction(first[i].X, first[i].Y, dark)
+		c.setFunction(second[i].X, second[i].Y, dark)
+	}
+	c.setFunction(8, c.Size-8, true)
+}
+
+func (c *Code) drawVersion() {
+	if c.Version < 7 {
+		return
+	}
+	bits := versionInfo(c.Version)
+	for i := 0; i < 18; i++ {
+		var (
+			dark = bits>>i&1 == 1
+			a    = c.Size - 11 + i%3
+			b    = i / 3
+		)
+		c.setFunction(a, b, dark)
+		c.setFunction(b, a, dark)
+	}
+}
+
+// dataPositions returns data module coordinates in placement order.
+func (c *Code) dataPositions() []image.Point {
+	res := make([]image.Point, 0, rawDataModules(c.Version))
+	for right := c.Size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		for vert := 0; vert < c.Size; vert++ {
+			for j := 0; j < 2; j++ {
+				x := right - j
+				y := vert
+				if (right+1)&2 == 0 {
+					y = c.Size - 1 - vert
+				}
+				if !c.isFunction[y*c.Size+x] {
+					res = append(res, image.Pt(x, y))
+				}
+			}
+		}
+	}
+	return res
+}
+
+func (c *Code) drawCodewords(data []byte) {
+	for i, p := range c.dataPositions() {
+		if i >= len(data)*8 {
+			break // Remainder bits are light.
+		}
+		c.modules[p.Y*c.Size+p.X] = data[i/8]>>(7-i%8)&1 == 1
+	}
+}
+
+func maskBit(mask, x, y int) bool {
+	switch mask {
+	case 0:
+		return (x+y)%2 == 0
+	case 1:
+		return y%2 == 0
+	case 2:
+		return x%3 == 0
+	case 3:
+		return (x+y)%3 == 0
+	case 4:
+		return (x/3+y/2)%2 == 0
+	case 5:
+		return x*y%2+x*y%3 == 0
+	case 6:
+		return (x*y%2+x*y%3)%2 == 0
+	default:
+		return ((x+y)%2+x*y%3)%2 == 0
+	}
+}
+
+// applyMask XORs all non-function modules with the mask.
+func (c *Code) applyMask(mask int) {
+	for y := 0; y < c.Size; y++ {
+		for x := 0; x < c.Size; x++ {
+			if !c.isFunction[y*c.Size+x] && maskBit(mask, x, y) {
+				c.modules[y*c.Size+x] = !c.modules[y*c.Size+x]
+			}
+		}
+	}
+}
+
+// penalty calculates the mask penalty score as defined by the standard.
+func (c *Code) penalty() int {
+	var res, dark int
+	finderLike := [2][11]bool{
+		{true, false, true, true, true, false, true, false, false, false, false},
+		{false, false, false, false, true, false, true, true, true, false, true},
+	}
+	for _, transposed := range []bool{false, true} {
+		get := func(i, j int) bool {
+			if transposed {
+				return c.Black(j, i)
+			}
+			return c.Black(i, j)
+		}
+		for j := 0; j < c.Size; j++ {
+			run := 1
+			for i := 1; i <= c.Size; i++ {
+				if i < c.Size && get(i, j) == get(i-1, j) {
+					run++
+					continue
+				}
+				if run >= 5 {
+					res += 3 + run - 5
+				}
+				run = 1
+			}
+			for i := 0; i+11 <= c.Size; i++ {
+				for _, p := range finderLike {
+					match := true
+					for k := range p {
+						if get(i+k, j) != p[k] {
+							match = false
+							break
+						}
+					}
+					if match {
+						res += 40
+					}
+				}
+			}
+		}
+	}
+	for y := 0; y < c.Size; y++ {
+		for x := 0; x < c.Size; x++ {
+			b := c.Black(x, y)
+			if b {
+				dark++
+			}
+			if x+1 < c.Size && y+1 < c.Size && b == c.Black(x+1, y) && b == c.Black(x, y+1) && b == c.Black(x+1, y+1) {
+				res += 3
+			}
+		}
+	}
+	total := c.Size * c.Size
+	k := (abs(dark*20-total*10)+total-1)/total - 1
+	return res + k*10
+}
+
+// Image returns the code image with the given number of pixels per module
+// and QuietZone border.
+func (c *Code) Image(scale int) *image.Gray {
+	if scale < 1 {
+		scale = 1
+	}
+	side := (c.Size + 2*QuietZone) * scale
+	img := image.NewGray(image.Rect(0, 0, side, side))
+	for y := 0; y < side; y++ {
+		for x := 0; x < side; x++ {
+			var v uint8 = 0xFF
+			if c.Black(x/scale-QuietZone, y/scale-QuietZone) {
+				v = 0
+			}
+			img.SetGray(x, y, color.Gray{Y: v})
+		}
+	}
+	return img
+}
+
+// String renders the code as text using Unicode half block characters (two
+// rows of modules per line) with QuietZone border. Light modules are drawn
+// with characters, so it's suitable for terminals with light text on dark
+// background.
+func (c *Code) String() string {
+	var sb strings.Builder
+	for y := -QuietZone; y < c.Size+QuietZone; y += 2 {
+		for x := -QuietZone; x < c.Size+QuietZone; x++ {
+			top, bottom := !c.Black(x, y), !c.Black(x, y+1)
+			if y+1 >= c.Size+QuietZone {
+				bottom = false
+			}
+			switch {
+			case top && bottom:
+				sb.WriteRune('█')
+			case top:
+				sb.WriteRune('▀')
+			case bottom:
+				sb.WriteRune('▄')
+			default:
+				sb.WriteRune(' ')
+			}
+		}
+		sb.WriteRune('\n')
+	}
+	return sb.String()
+}
+
+type bitBuffer struct {
+	data []byte
+	n    int
+}
+
+func (b *bitBuffer) append(val int, n int) {
+	for i := n - 1; i >= 0; i-- {
+		if b.n%8 == 0 {
+			b.data = append(b.data, 0)
+		}
+		if val>>i&1 == 1 {
+			b.data[b.n/8] |= 0x80 >> (b.n % 8)
+		}
+		b.n++
+	}
+}
+
+func (b *bitBuffer) len() int {
+	return b.n
+}
+
+func (b *bitBuffer) bytes() []byte {
+	return b.data
+}
+
+func abs(a int) int {
+	if a < 0 {
+		return -a
+	}
+	return a
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,216 @@
+package qr
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRSEncode(t *testing.T) {
+	// Version 1-M "HELLO WORLD" example from the standard.
+	data := []byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17}
+	require.Equal(t, []byte{196, 35, 39, 119, 235, 215, 231, 226, 93, 23}, rsEncode(data, 10))
+}
+
+func TestRSCorrect(t *testing.T) {
+	const ecLen = 16
+	data := make([]byte, 50)
+	rand.Read(data)
+	block := append(append([]byte{}, data...), rsEncode(data, ecLen)...)
+
+	for n := 0; n <= ecLen/2; n++ {
+		corrupted := append([]byte{}, block...)
+		for _, i := range rand.Perm(len(block))[:n] {
+			corrupted[i] ^= byte(1 + rand.Intn(255))
+		}
+		require.NoError(t, rsCorrect(corrupted, ecLen), n)
+		require.Equal(t, block, corrupted, n)
+	}
+}
+
+func TestFormatVersionInfo(t *testing.T) {
+	require.Equal(t, 0b110011000101111, formatInfo(Low, 4))
+	require.Equal(t, 0b101010000010010, formatInfo(Medium, 0))
+	require.Equal(t, 0b000111110010010100, versionInfo(7))
+	require.Equal(t, 0b101000110001101001, versionInfo(40))
+}
+
+func TestCapacity(t *testing.T) {
+	// Byte mode capacities of some versions.
+	for _, tc := range []struct {
+		ver, n int
+		lvl    Level
+	}{{1, 17, Low}, {1, 14, Medium}, {10, 213, Medium}, {27, 1465, Low}, {40, 2953, Low}, {40, 1273, High}} {
+		c, err := Encode(make([]byte, tc.n), tc.lvl)
+		require.NoError(t, err)
+		require.Equal(t, tc.ver, c.Version)
+		require.Equal(t, tc.ver*4+17, c.Size)
+		if tc.ver < maxVersion {
+			c, err = Encode(make([]byte, tc.n+1), tc.lvl)
+			require.NoError(t, err)
+			require.Equal(t, tc.ver+1, c.Version)
+		}
+	}
+	_, err := Encode(make([]byte, 2954), Low)
+	require.ErrorIs(t, err, ErrTooBig)
+	_, err = Encode(nil, High+1)
+	require.Error(t, err)
+}
+
+func TestEncodeDecode(t *testing.T) {
+	for _, n := range []int{0, 1, 20, 100, 300, 1000, 2900} {
+		for _, lvl := range []Level{Low, Medium, Quartile, High} {
+			data := make([]byte, n)
+			rand.Read(data)
+			c, err := Encode(data, lvl)
+			if n > 1200 && lvl != Low {
+				continue
+			}
+			require.NoError(t, err)
+			res, err := Decode(c.Image(3))
+			require.NoError(t, err, "size %d, level %d, version %d", n, lvl, c.Version)
+			require.Equal(t, data, res)
+		}
+	}
+}
+
+// transformImage renders the code image using the transformation mapping
+// destination coordinates to the source ones.
+func transformImage(src *image.Gray, w, h int, f func(x, y float64) (float64, float64)) *image.Gray {
+	img := image.NewGray(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			sx, sy := f(float64(x), float64(y))
+			v := uint8(0xFF)
+			if p := image.Pt(int(math.Floor(sx)), int(math.Floor(sy))); p.In(src.Bounds()) {
+				v = src.GrayAt(p.X, p.Y).Y
+			}
+			img.SetGray(x, y, color.Gray{Y: v})
+		}
+	}
+	return img
+}
+
+func TestDecodeTransformed(t *testing.T) {
+	data := make([]byte, 200)
+	rand.Read(data)
+	c, err := Encode(data, Medium)
+	require.NoError(t, err)
+	src := c.Image(6)
+	side := float64(src.Bounds().Dx())
+
+	t.Run("rotated", func(t *testing.T) {
+		for _, angle := range []float64{10, 30, 45, 90, 135, 200, 290} {
+			a := angle * math.Pi / 180
+			w := int(side * 1.6)
+			img := transformImage(src, w, w, func(x, y float64) (float64, float64) {
+				x, y = x-float64(w)/2, y-float64(w)/2
+				return x*math.Cos(a) + y*math.Sin(a) + side/2, -x*math.Sin(a) + y*math.Cos(a) + side/2
+			})
+			res, err := Decode(img)
+			require.NoError(t, err, angle)
+			require.Equal(t, data, res)
+		}
+	})
+	t.Run("perspective", func(t *testing.T) {
+		w := int(side * 1.5)
+		img := transformImage(src, w, w, func(x, y float64) (float64, float64) {
+			den := 1 + 0.0004*y - 0.0002*x
+			return (1.1*x + 0.05*y - 40) / den, (0.03*x + 1.1*y - 30) / den
+		})
+		res, err := Decode(img)
+		require.NoError(t, err)
+		require.Equal(t, data, res)
+	})
+	t.Run("mirrored", func(t *testing.T) {
+		img := transformImage(src, int(side), int(side), func(x, y float64) (float64, float64) {
+			return side - 1 - x, y
+		})
+		res, err := Decode(img)
+		require.NoError(t, err)
+		require.Equal(t, data, res)
+	})
+	t.Run("inverted and noisy", func(t *testing.T) {
+		img := transformImage(src, int(side), int(side), func(x, y float64) (float64, float64) {
+			return x, y
+		})
+		for i := range img.Pix {
+			img.Pix[i] = ^img.Pix[i]
+		}
+		// Garble some modules, error correction should handle it.
+		for i := 0; i < 15; i++ {
+			x, y := (QuietZone+10+rand.Intn(c.Size-20))*6, (QuietZone+10+rand.Intn(c.Size-20))*6
+			for dy := 0; dy < 6; dy++ {
+				for dx := 0; dx < 6; dx++ {
+					img.Pix[img.PixOffset(x+dx, y+dy)] ^= 0xFF
+				}
+			}
+		}
+		res, err := Decode(img)
+		require.NoError(t, err)
+		require.Equal(t, data, res)
+	})
+	t.Run("colored", func(t *testing.T) {
+		img := image.NewNRGBA(image.Rect(0, 0, int(side), int(side)))
+		for y := 0; y < int(side); y++ {
+			for x := 0; x < int(side); x++ {
+				col := color.NRGBA{R: 0xF0, G: 0xF0, B: 0xA0, A: 0xFF}
+				if src.GrayAt(x, y).Y == 0 {
+					col = color.NRGBA{R: 0x20, G: 0x20, B: 0x60, A: 0xFF}
+				}
+				img.SetNRGBA(x, y, col)
+			}
+		}
+		res, err := Decode(img)
+		require.NoError(t, err)
+		require.Equal(t, data, res)
+	})
+}
+
+func TestDecodeNotFound(t *testing.T) {
+	_, err := Decode(image.NewGray(image.Rect(0, 0, 100, 100)))
+	require.Error(t, err)
+}
+
+func TestParseSegments(t *testing.T) {
+	var bb bitBuffer
+	bb.append(0b0001, 4) // Numeric "01234567".
+	bb.append(8, 10)
+	bb.append(12, 10)
+	bb.append(345, 10)
+	bb.append(67, 7)
+	bb.append(0b0010, 4) // Alphanumeric "AC-42".
+	bb.append(5, 9)
+	bb.append(10*45+12, 11)
+	bb.append(41*45+4, 11)
+	bb.append(2, 6)
+	bb.append(0b0111, 4) // ECI.
+	bb.append(26, 8)
+	bb.append(0b0100, 4) // Byte.
+	bb.append(1, 8)
+	bb.append('!', 8)
+	bb.append(0, 4)
+	res, err := parseSegments(bb.bytes(), 1)
+	require.NoError(t, err)
+	require.Equal(t, "01234567AC-42!", string(res))
+
+	_, err = parseSegments([]byte{0b1000_0000}, 1)
+	require.Error(t, err)
+}
+
+func TestString(t *testing.T) {
+	c, err := Encode([]byte("neo"), Low)
+	require.NoError(t, err)
+	s := c.String()
+	lines := 0
+	for _, r := range s {
+		if r == '\n' {
+			lines++
+		}
+	}
+	require.Equal(t, (c.Size+2*QuietZone+1)/2, lines)
+}
@@ -0,0 +1,195 @@
+package qr
+
+import "errors"
+
+// errTooManyErrors is returned when the block can't be corrected.
+var errTooManyErrors = errors.New("too many errors")
+
+// GF(2^8) with 0x11D polynomial used by QR codes.
+var (
+	gfExp [512]byte
+	gfLog [256]byte
+)
+
+func init() {
+	var x = 1
+	for i := 0; i < 255; i++ {
+		gfExp[i] = byte(x)
+		gfLog[x] = byte(i)
+		x <<= 1
+		if x&0x100 != 0 {
+			x ^= 0x11D
+		}
+	}
+	for i := 255; i < len(gfExp); i++ {
+		gfExp[i] = gfExp[i-255]
+	}
+}
+
+func gfMul(a, b byte) byte {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return gfExp[int(gfLog[a])+int(gfLog[b])]
+}
+
+func gfDiv(a, b byte) byte {
+	if a == 0 {
+		return 0
+	}
+	return gfExp[int(gfLog[a])+255-int(gfLog[b])]
+}
+
+// gfPow returns 2^n.
+func gfPow(n int) byte {
+	n %= 255
+	if n < 0 {
+		n += 255
+	}
+	return gfExp[n]
+}
+
+// rsGenerator returns the generator polynomial of the given degree (without
+// the leading coefficient, highest degree first).
+func rsGenerator(degree int) []byte {
+	res := make([]byte, degree)
+	res[degree-1] = 1
+	var root byte = 1
+	for i := 0; i < degree; i++ {
+		for j := range res {
+			res[j] = gfMul(res[j], root)
+			if j+1 < len(res) {
+				res[j] ^= res[j+1]
+			}
+		}
+		root = gfMul(root, 2)
+	}
+	return res
+}
+
+// rsEncode returns error correction codewords for the data.
+func rsEncode(data []byte, ecLen int) []byte {
+	var (
+		gen = rsGenerator(ecLen)
+		res = make([]byte, ecLen)
+	)
+	for _, b := range data {
+		factor := b ^ res[0]
+		copy(res, res[1:])
+		res[len(res)-1] = 0
+		for i := range res {
+			res[i] ^= gfMul(gen[i], factor)
+		}
+	}
+	return res
+}
+
+// polyEval evaluates the polynomial with coefficients stored lowest degree
+// first at x.
+func polyEval(p []byte, x byte) byte {
+	var res byte
+	for i := len(p) - 1; i >= 0; i-- {
+		res = gfMul(res, x) ^ p[i]
+	}
+	return res
+}
+
+// rsCorrect corrects errors in the block (data followed by ecLen error
+// correction codewords) in place.
+func rsCorrect(block []byte, ecLen int) error {
+	var (
+		n    = len(block)
+		synd = make([]byte, ecLen)
+		ok   = true
+	)
+	for j := range synd {
+		x := gfPow(j)
+		var s byte
+		for _, b := range block {
+			s = gfMul(s, x) ^ b
+		}
+		synd[j] = s
+		ok = ok && s == 0
+	}
+	if ok {
+		return nil
+	}
+
+	// Berlekamp-Massey, polynomials are lowest degree first.
+	var (
+		locator = []byte{1}
+		prev    = []byte{1}
+		l       int
+		m            = 1
+		b       byte = 1
+	)
+	for i := 0; i < ecLen; i++ {
+		d := synd[i]
+		for j := 1; j <= l && j < len(locator); j++ {
+			d ^= gfMul(locator[j], synd[i-j])
+		}
+		if d == 0 {
+			m++
+			continue
+		}
+		coef := gfDiv(d, b)
+		next := make([]byte, max(len(locator), len(prev)+m))
+		copy(next, locator)
+		for j := range prev {
+			next[j+m] ^= gfMul(coef, prev[j])
+		}
+		if 2*l <= i {
+			prev = locator
+			l = i + 1 - l
+			b = d
+			m = 1
+		} else {
+			m++
+		}
+		locator = next
+	}
+	if 2*l > ecLen {
+		return errTooManyErrors
+	}
+
+	// Chien search, position i corresponds to x^i term of the codeword.
+	var positions []int
+	for i := 0; i < n; i++ {
+		if polyEval(locator, gfPow(-i)) == 0 {
+			positions = append(positions, i)
+		}
+	}
+	if len(positions) != l {
+		return errTooManyErrors
+	}
+
+	// Forney, omega = synd * locator mod x^ecLen.
+	omega := make([]byte, ecLen)
+	for i := 0; i < ecLen; i++ {
+		for j := 0; j <= i && j < len(locator); j++ {
+			omega[i] ^= gfMul(synd[i-j], locator[j])
+		}
+	}
+	deriv := make([]byte, len(locator))
+	for i := 1; i < len(locator); i += 2 {
+		deriv[i-1] = locator[i]
+	}
+	for _, pos := range positions {
+		var (
+			xInv  = gfPow(-pos)
+			denom = polyEval(deriv, xInv)
+		)
+		if denom == 0 {
+			return errTooManyErrors
+		}
+		block[n-1-pos] ^= gfMul(gfPow(pos), gfDiv(polyEval(omega, xInv), denom))
+	}
+	return nil
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
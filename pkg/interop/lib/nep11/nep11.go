@@ -0,0 +1,64 @@
+// Package nep11 contains wrappers for NEP-11 non-divisible contract.
+package nep11
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/interop"
+	"github.com/nspcc-dev/neo-go/pkg/interop/contract"
+	"github.com/nspcc-dev/neo-go/pkg/interop/iterator"
+)
+
+// Contract is a wrapper for NEP-11 non-divisible contract deployed with the
+// specified hash.
+type Contract struct {
+	Hash interop.Hash160
+}
+
+// New creates a wrapper for NEP-11 non-divisible contract with the given hash.
+func New(hash interop.Hash160) Contract {
+	return Contract{Hash: hash}
+}
+
+// Decimals invokes `decimals` method of contract.
+func (c Contract) Decimals() int {
+	return contract.Call(c.Hash, "decimals", contract.ReadOnly).(int)
+}
+
+// Symbol invokes `symbol` method of contract.
+func (c Contract) Symbol() string {
+	return contract.Call(c.Hash, "symbol", contract.ReadOnly).(string)
+}
+
+// TotalSupply invokes `totalSupply` method of contract.
+func (c Contract) TotalSupply() int {
+	return contract.Call(c.Hash, "totalSupply", contract.ReadOnly).(int)
+}
+
+// BalanceOf invokes `balanceOf` method of contract.
+func (c Contract) BalanceOf(owner interop.Hash160) int {
+	return contract.Call(c.Hash, "balanceOf", contract.ReadOnly, owner).(int)
+}
+
+// TokensOf invokes `tokensOf` method of contract.
+func (c Contract) TokensOf(owner interop.Hash160) iterator.Iterator {
+	return contract.Call(c.Hash, "tokensOf", contract.ReadOnly, owner).(iterator.Iterator)
+}
+
+// Transfer invokes `transfer` method of contract.
+func (c Contract) Transfer(to interop.Hash160, tokenId []byte, data any) bool {
+	return contract.Call(c.Hash, "transfer", contract.All, to, tokenId, data).(bool)
+}
+
+// Properties invokes `properties` method of contract.
+func (c Contract) Properties(tokenId []byte) map[string]any {
+	return contract.Call(c.Hash, "properties", contract.ReadOnly, tokenId).(map[string]any)
+}
+
+// Tokens invokes `tokens` method of contract.
+func (c Contract) Tokens() iterator.Iterator {
+	return contract.Call(c.Hash, "tokens", contract.ReadOnly).(iterator.Iterator)
+}
+
+// OwnerOf invokes `ownerOf` method of contract.
+func (c Contract) OwnerOf(tokenId []byte) interop.Hash160 {
+	return contract.Call(c.Hash, "ownerOf", contract.ReadOnly, tokenId).(interop.Hash160)
+}
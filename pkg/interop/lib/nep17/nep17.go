@@ -0,0 +1,43 @@
+// Package nep17 contains wrappers for NEP-17 contract.
+package nep17
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/interop"
+	"github.com/nspcc-dev/neo-go/pkg/interop/contract"
+)
+
+// Contract is a wrapper for NEP-17 contract deployed with the
+// specified hash.
+type Contract struct {
+	Hash interop.Hash160
+}
+
+// New creates a wrapper for NEP-17 contract with the given hash.
+func New(hash interop.Hash160) Contract {
+	return Contract{Hash: hash}
+}
+
+// Decimals invokes `decimals` method of contract.
+func (c Contract) Decimals() int {
+	return contract.Call(c.Hash, "decimals", contract.ReadOnly).(int)
+}
+
+// Symbol invokes `symbol` method of contract.
+func (c Contract) Symbol() string {
+	return contract.Call(c.Hash, "symbol", contract.ReadOnly).(string)
+}
+
+// TotalSupply invokes `totalSupply` method of contract.
+func (c Contract) TotalSupply() int {
+	return contract.Call(c.Hash, "totalSupply", contract.ReadOnly).(int)
+}
+
+// BalanceOf invokes `balanceOf` method of contract.
+func (c Contract) BalanceOf(account interop.Hash160) int {
+	return contract.Call(c.Hash, "balanceOf", contract.ReadOnly, account).(int)
+}
+
+// Transfer invokes `transfer` method of contract.
+func (c Contract) Transfer(from interop.Hash160, to interop.Hash160, amount int, data any) bool {
+	return contract.Call(c.Hash, "transfer", contract.All, from, to, amount, data).(bool)
+}
@@ -0,0 +1,134 @@
+// Package nns contains wrappers for NNS contract.
+package nns
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/interop"
+	"github.com/nspcc-dev/neo-go/pkg/interop/contract"
+	"github.com/nspcc-dev/neo-go/pkg/interop/iterator"
+)
+
+// Contract is a wrapper for NNS contract deployed with the
+// specified hash.
+type Contract struct {
+	Hash interop.Hash160
+}
+
+// New creates a wrapper for NNS contract with the given hash.
+func New(hash interop.Hash160) Contract {
+	return Contract{Hash: hash}
+}
+
+// Symbol invokes `symbol` method of contract.
+func (c Contract) Symbol() string {
+	return contract.Call(c.Hash, "symbol", contract.ReadOnly).(string)
+}
+
+// Decimals invokes `decimals` method of contract.
+func (c Contract) Decimals() int {
+	return contract.Call(c.Hash, "decimals", contract.ReadOnly).(int)
+}
+
+// TotalSupply invokes `totalSupply` method of contract.
+func (c Contract) TotalSupply() int {
+	return contract.Call(c.Hash, "totalSupply", contract.ReadOnly).(int)
+}
+
+// OwnerOf invokes `ownerOf` method of contract.
+func (c Contract) OwnerOf(tokenId []byte) interop.Hash160 {
+	return contract.Call(c.Hash, "ownerOf", contract.ReadOnly, tokenId).(interop.Hash160)
+}
+
+// Properties invokes `properties` method of contract.
+func (c Contract) Properties(tokenId []byte) map[string]any {
+	return contract.Call(c.Hash, "properties", contract.ReadOnly, tokenId).(map[string]any)
+}
+
+// BalanceOf invokes `balanceOf` method of contract.
+func (c Contract) BalanceOf(owner interop.Hash160) int {
+	return contract.Call(c.Hash, "balanceOf", contract.ReadOnly, owner).(int)
+}
+
+// Tokens invokes `tokens` method of contract.
+func (c Contract) Tokens() iterator.Iterator {
+	return contract.Call(c.Hash, "tokens", contract.ReadOnly).(iterator.Iterator)
+}
+
+// TokensOf invokes `tokensOf` method of contract.
+func (c Contract) TokensOf(owner interop.Hash160) iterator.Iterator {
+	return contract.Call(c.Hash, "tokensOf", contract.ReadOnly, owner).(iterator.Iterator)
+}
+
+// Transfer invokes `transfer` method of contract.
+func (c Contract) Transfer(to interop.Hash160, tokenId []byte, data any) bool {
+	return contract.Call(c.Hash, "transfer", contract.All, to, tokenId, data).(bool)
+}
+
+// AddRoot invokes `addRoot` method of contract.
+func (c Contract) AddRoot(root string) {
+	contract.Call(c.Hash, "addRoot", contract.All, root)
+}
+
+// Roots invokes `roots` method of contract.
+func (c Contract) Roots() iterator.Iterator {
+	return contract.Call(c.Hash, "roots", contract.ReadOnly).(iterator.Iterator)
+}
+
+// SetPrice invokes `setPrice` method of contract.
+func (c Contract) SetPrice(priceList []int) {
+	contract.Call(c.Hash, "setPrice", contract.All, priceList)
+}
+
+// GetPrice invokes `getPrice` method of contract.
+func (c Contract) GetPrice(length int) int {
+	return contract.Call(c.Hash, "getPrice", contract.ReadOnly, length).(int)
+}
+
+// IsAvailable invokes `isAvailable` method of contract.
+func (c Contract) IsAvailable(name string) bool {
+	return contract.Call(c.Hash, "isAvailable", contract.ReadOnly, name).(bool)
+}
+
+// Register invokes `register` method of contract.
+func (c Contract) Register(name string, owner interop.Hash160) bool {
+	return contract.Call(c.Hash, "register", contract.All, name, owner).(bool)
+}
+
+// Renew invokes `renew` method of contract.
+func (c Contract) Renew(name string) int {
+	return contract.Call(c.Hash, "renew", contract.All, name).(int)
+}
+
+// Renew_2 invokes `renew` method of contract.
+func (c Contract) Renew_2(name string, years int) int {
+	return contract.Call(c.Hash, "renew", contract.All, name, years).(int)
+}
+
+// SetAdmin invokes `setAdmin` method of contract.
+func (c Contract) SetAdmin(name string, admin interop.Hash160) {
+	contract.Call(c.Hash, "setAdmin", contract.All, name, admin)
+}
+
+// SetRecord invokes `setRecord` method of contract.
+func (c Contract) SetRecord(name string, typev int, data string) {
+	contract.Call(c.Hash, "setRecord", contract.All, name, typev, data)
+}
+
+// GetRecord invokes `getRecord` method of contract.
+func (c Contract) GetRecord(name string, typev int) string {
+	return contract.Call(c.Hash, "getRecord", contract.ReadOnly, name, typev).(string)
+}
+
+// GetAllRecords invokes `getAllRecords` method of contract.
+func (c Contract) GetAllRecords(name string) iterator.Iterator {
+	return contract.Call(c.Hash, "getAllRecords", contract.ReadOnly, name).(iterator.Iterator)
+}
+
+// DeleteRecord invokes `deleteRecord` method of contract.
+func (c Contract) DeleteRecord(name string, typev int) {
+	contract.Call(c.Hash, "deleteRecord", contract.All, name, typev)
+}
+
+// Resolve invokes `resolve` method of contract.
+func (c Contract) Resolve(name string, typev int) string {
+	return contract.Call(c.Hash, "resolve", contract.ReadOnly, name, typev).(string)
+}
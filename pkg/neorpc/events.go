@@ -22,6 +22,8 @@ const (
 	ExecutionEventID
 	// NotaryRequestEventID is used for the `notary_request_event` event.
 	NotaryRequestEventID
+	// MempoolEventID is used for the `mempool_event` event.
+	MempoolEventID
 	// MissedEventID notifies user of missed events.
 	MissedEventID EventID = 255
 )
@@ -39,6 +41,8 @@ func (e EventID) String() string {
 		return "transaction_executed"
 	case NotaryRequestEventID:
 		return "notary_request_event"
+	case MempoolEventID:
+		return "mempool_event"
 	case MissedEventID:
 		return "event_missed"
 	default:
@@ -59,6 +63,8 @@ func GetEventIDFromString(s string) (EventID, error) {
 		return ExecutionEventID, nil
 	case "notary_request_event":
 		return NotaryRequestEventID, nil
+	case "mempool_event":
+		return MempoolEventID, nil
 	case "event_missed":
 		return MissedEventID, nil
 	default:
@@ -16,10 +16,13 @@ type (
 	}
 	// TxFilter is a wrapper structure for the transaction event filter. It
 	// allows to filter transactions by senders and/or signers. nil value treated
-	// as missing filter.
+	// as missing filter. StateChanges flag can only be used for
+	// notary_request_event subscriptions, it adds notary request state change
+	// events of the notary service (see result.NotaryRequestState) to them.
 	TxFilter struct {
-		Sender *util.Uint160 `json:"sender,omitempty"`
-		Signer *util.Uint160 `json:"signer,omitempty"`
+		Sender       *util.Uint160 `json:"sender,omitempty"`
+		Signer       *util.Uint160 `json:"signer,omitempty"`
+		StateChanges bool          `json:"statechanges,omitempty"`
 	}
 	// NotificationFilter is a wrapper structure representing a filter used for
 	// notifications generated during transaction execution. Notifications can
@@ -75,6 +78,7 @@ func (f *TxFilter) Copy() *TxFilter {
 		res.Signer = new(util.Uint160)
 		*res.Signer = *f.Signer
 	}
+	res.StateChanges = f.StateChanges
 	return res
 }
 
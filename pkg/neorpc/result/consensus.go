@@ -0,0 +1,82 @@
+package result
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+type (
+	// ConsensusState is the current consensus state along with per-validator
+	// statistics collected over the recent rounds, it's returned by
+	// `getconsensusstate` RPC call.
+	ConsensusState struct {
+		// Index is the index of the node in the current validators list, -1
+		// for non-validators.
+		Index int `json:"index"`
+		// Round is the timeline of the current round, it's nil if consensus
+		// is not started yet.
+		Round *ConsensusTimeline `json:"round,omitempty"`
+		// Validators contains statistics for all validators seen in the
+		// current and recent rounds.
+		Validators []ConsensusValidatorStats `json:"validators"`
+	}
+
+	// ConsensusTimeline contains consensus messages and events for a single
+	// height. All times are Unix timestamps in milliseconds.
+	ConsensusTimeline struct {
+		Height uint32 `json:"height"`
+		// View is the current (or final for finished rounds) view number.
+		View byte `json:"view"`
+		// Primary is the index of the primary for View.
+		Primary          uint16                `json:"primary"`
+		Validators       keys.PublicKeys       `json:"validators"`
+		Start            uint64                `json:"start"`
+		End              uint64                `json:"end,omitempty"`
+		Block            *util.Uint256         `json:"block,omitempty"`
+		Messages         []ConsensusMessage    `json:"messages"`
+		ViewChanges      []ConsensusViewChange `json:"viewchanges"`
+		RecoveryRequests int                   `json:"recoveryrequests"`
+		RecoveryMessages int                   `json:"recoverymessages"`
+	}
+
+	// ConsensusMessage is a consensus message sent or received by the node.
+	ConsensusMessage struct {
+		Type      string `json:"type"`
+		Validator uint16 `json:"validator"`
+		View      byte   `json:"view"`
+		Time      uint64 `json:"time"`
+		// Reason is only set for ChangeView messages.
+		Reason string `json:"reason,omitempty"`
+	}
+
+	// ConsensusViewChange is a view change that happened during the round.
+	ConsensusViewChange struct {
+		// View is the new view number.
+		View byte   `json:"view"`
+		Time uint64 `json:"time"`
+		// Reason is the most common reason of the ChangeView messages of the
+		// previous view (if any were seen).
+		Reason string `json:"reason,omitempty"`
+	}
+
+	// ConsensusValidatorStats contains statistics for a single validator.
+	// Rounds here are heights where the key was a validator.
+	ConsensusValidatorStats struct {
+		PublicKey *keys.PublicKey `json:"publickey"`
+		Rounds    int             `json:"rounds"`
+		// Preparations and Commits are the number of rounds with
+		// PrepareRequest or PrepareResponse and Commit messages seen from
+		// the validator.
+		Preparations int `json:"preparations"`
+		Commits      int `json:"commits"`
+		// MissedCommits is the number of finished rounds without Commit
+		// seen from the validator.
+		MissedCommits int `json:"missedcommits"`
+		ChangeViews   int `json:"changeviews"`
+		// CommitDelay is the average time between the round start and
+		// Commit message in milliseconds.
+		CommitDelay uint64 `json:"commitdelay"`
+		// LastSeen is the time of the last message from the validator.
+		LastSeen uint64 `json:"lastseen,omitempty"`
+	}
+)
@@ -23,6 +23,7 @@ type Invoke struct {
 	Notifications  []state.NotificationEvent
 	Transaction    *transaction.Transaction
 	Diagnostics    *InvokeDiag
+	StateDiff      *StateDiff
 	Session        uuid.UUID
 }
 
@@ -41,6 +42,7 @@ type invokeAux struct {
 	Notifications  []state.NotificationEvent `json:"notifications"`
 	Transaction    []byte                    `json:"tx,omitempty"`
 	Diagnostics    *InvokeDiag               `json:"diagnostics,omitempty"`
+	StateDiff      *StateDiff                `json:"statediff,omitempty"`
 	Session        string                    `json:"session,omitempty"`
 }
 
@@ -171,6 +173,7 @@ func (r Invoke) MarshalJSON() ([]byte, error) {
 		Notifications: r.Notifications,
 		Transaction:   txbytes,
 		Diagnostics:   r.Diagnostics,
+		StateDiff:     r.StateDiff,
 		Session:       sessionID,
 	}
 	if len(r.FaultException) != 0 {
@@ -230,6 +233,7 @@ func (r *Invoke) UnmarshalJSON(data []byte) error {
 	r.Notifications = aux.Notifications
 	r.Transaction = tx
 	r.Diagnostics = aux.Diagnostics
+	r.StateDiff = aux.StateDiff
 	return nil
 }
 
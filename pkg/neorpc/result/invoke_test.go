@@ -54,6 +54,35 @@ func TestInvoke_MarshalJSON(t *testing.T) {
 	require.Equal(t, result, actual)
 }
 
+func TestInvoke_MarshalJSONStateDiff(t *testing.T) {
+	result := &Invoke{
+		State:         "HALT",
+		GasConsumed:   100,
+		Script:        []byte{10},
+		Stack:         []stackitem.Item{},
+		Notifications: []state.NotificationEvent{},
+		StateDiff: &StateDiff{
+			Storage: []StorageDiff{
+				{Contract: util.Uint160{1}, State: "Added", Key: []byte{1}, NewValue: []byte{2}},
+				{Contract: util.Uint160{1}, State: "Changed", Key: []byte{2}, OldValue: []byte{3}, NewValue: []byte{4}},
+				{Contract: util.Uint160{2}, State: "Deleted", Key: []byte{3}, OldValue: []byte{5}},
+			},
+			Balances: []BalanceDiff{
+				{Asset: util.Uint160{1}, Standard: "NEP-17", Account: util.Uint160{3}, OldAmount: "10", NewAmount: "5"},
+				{Asset: util.Uint160{2}, Standard: "NEP-11", Account: util.Uint160{3}, ID: "01", OldAmount: "0", NewAmount: "1"},
+			},
+			Contracts: []ContractDiff{{Hash: util.Uint160{2}, State: "Deployed", Name: "contract"}},
+		},
+	}
+	data, err := json.Marshal(result)
+	require.NoError(t, err)
+	require.Contains(t, string(data), `"statediff":{"storage":[{"contract":"0x0000000000000000000000000000000000000001","state":"Added","key":"AQ==","newvalue":"Ag=="}`)
+
+	actual := new(Invoke)
+	require.NoError(t, json.Unmarshal(data, actual))
+	require.Equal(t, result, actual)
+}
+
 func TestAppExecToInvocation(t *testing.T) {
 	// With error.
 	someErr := errors.New("some err")
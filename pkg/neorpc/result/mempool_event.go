@@ -0,0 +1,18 @@
+package result
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/core/mempoolevent"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// MempoolEvent represents a transaction either added to or removed (replaced)
+// from the memory pool. It's sent to transaction_added subscribers that have
+// Mempool flag set in their filter.
+type MempoolEvent struct {
+	Type        mempoolevent.Type        `json:"type"`
+	Transaction *transaction.Transaction `json:"transaction"`
+	// ReplacedBy is the hash of the transaction that has replaced this one
+	// according to replace-by-fee policy, it's only set for "replaced" events.
+	ReplacedBy *util.Uint256 `json:"replacedby,omitempty"`
+}
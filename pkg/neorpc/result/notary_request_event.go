@@ -2,12 +2,108 @@ package result
 
 import (
 	"github.com/nspcc-dev/neo-go/pkg/core/mempoolevent"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/network/payload"
+	"github.com/nspcc-dev/neo-go/pkg/util"
 )
 
 // NotaryRequestEvent represents a P2PNotaryRequest event either added or removed
-// from the notary payload pool.
+// from the notary payload pool or a notary request state change ("updated"
+// event, it doesn't contain the payload).
 type NotaryRequestEvent struct {
 	Type          mempoolevent.Type         `json:"type"`
-	NotaryRequest *payload.P2PNotaryRequest `json:"notaryrequest"`
+	NotaryRequest *payload.P2PNotaryRequest `json:"notaryrequest,omitempty"`
+	// State is the state of the main transaction processing by the notary
+	// service, it's only available on nodes running it.
+	State *NotaryRequestState `json:"state,omitempty"`
 }
+
+// Notary request statuses.
+const (
+	// NotaryRequestPending is used for requests with main transaction
+	// witnesses being collected.
+	NotaryRequestPending = "pending"
+	// NotaryRequestCompleted is used for requests with all main transaction
+	// witnesses collected, the main transaction is being sent.
+	NotaryRequestCompleted = "completed"
+	// NotaryRequestSent is used for requests with the main transaction sent
+	// to the network.
+	NotaryRequestSent = "sent"
+	// NotaryRequestFallback is used for requests with some fallback
+	// transaction sent to the network.
+	NotaryRequestFallback = "fallback"
+	// NotaryRequestRemoved is used for requests with all fallbacks removed
+	// from the pool before any transaction was sent by the node.
+	NotaryRequestRemoved = "removed"
+)
+
+// Reasons of fallback transaction sending.
+const (
+	// FallbackInvalid means that the main transaction is not valid for the
+	// notary service.
+	FallbackInvalid = "invalid"
+	// FallbackIncomplete means that not all main transaction witnesses were
+	// collected before the fallback height.
+	FallbackIncomplete = "incomplete"
+	// FallbackExpired means that the main transaction was completed, but it
+	// wasn't accepted before the fallback height.
+	FallbackExpired = "expired"
+)
+
+type (
+	// NotaryRequestState is the state of the main transaction processing by
+	// the notary service. Times are Unix timestamps in milliseconds.
+	NotaryRequestState struct {
+		// Hash is the main transaction hash.
+		Hash     util.Uint256 `json:"hash"`
+		Status   string       `json:"status"`
+		Received uint64       `json:"received"`
+		Updated  uint64       `json:"updated"`
+		// ValidUntilBlock is the main transaction ValidUntilBlock.
+		ValidUntilBlock uint32 `json:"validuntilblock"`
+		// FallbackHeight is the minimum NotValidBefore value of fallbacks,
+		// the main transaction can't be sent since this height.
+		FallbackHeight uint32 `json:"fallbackheight"`
+		// Witnesses contains main transaction witness states, it's empty if
+		// the main transaction is invalid.
+		Witnesses []NotaryWitnessState `json:"witnesses"`
+		// Fallbacks contains fallbacks that are not yet sent.
+		Fallbacks []NotaryFallbackState `json:"fallbacks"`
+		// SentHash is the hash of the last transaction (main or fallback)
+		// sent by the node.
+		SentHash *util.Uint256 `json:"senthash,omitempty"`
+		// FallbackReason is the reason of the last fallback sending.
+		FallbackReason string `json:"fallbackreason,omitempty"`
+		// Error is the main transaction verification or sending error.
+		Error string `json:"error,omitempty"`
+	}
+
+	// NotaryWitnessState is the state of the main transaction witness
+	// collection.
+	NotaryWitnessState struct {
+		Account util.Uint160 `json:"account"`
+		// Type is "signature", "multisignature" or "contract".
+		Type string `json:"type"`
+		// Required is the number of signatures required.
+		Required  int             `json:"required"`
+		Collected keys.PublicKeys `json:"collected"`
+		Missing   keys.PublicKeys `json:"missing"`
+		Completed bool            `json:"completed"`
+	}
+
+	// NotaryFallbackState is the state of a fallback transaction.
+	NotaryFallbackState struct {
+		Hash           util.Uint256 `json:"hash"`
+		NotValidBefore uint32       `json:"notvalidbefore"`
+		// Sender is the notary request sender paying for the fallback.
+		Sender util.Uint160 `json:"sender"`
+		// Deposit is the sender's deposit in the Notary contract.
+		Deposit NotaryDeposit `json:"deposit"`
+	}
+
+	// NotaryDeposit is a deposit in the Notary contract.
+	NotaryDeposit struct {
+		Amount int64  `json:"amount,string"`
+		Till   uint32 `json:"till"`
+	}
+)
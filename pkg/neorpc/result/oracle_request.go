@@ -0,0 +1,65 @@
+package result
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// OracleRequestStatus is the processing status of an oracle request.
+type OracleRequestStatus string
+
+// Oracle request statuses.
+const (
+	// OracleRequestPending is used for requests that are not yet processed.
+	OracleRequestPending OracleRequestStatus = "pending"
+	// OracleRequestSigning is used for requests with the response created by
+	// the node, signatures of other oracle nodes are being collected for it.
+	OracleRequestSigning OracleRequestStatus = "signing"
+	// OracleRequestSent is used for requests with the response transaction
+	// (main or backup one) signed by enough oracle nodes and sent to the
+	// network.
+	OracleRequestSent OracleRequestStatus = "sent"
+	// OracleRequestFinished is used for requests removed from the Oracle
+	// contract, which happens when the response is persisted.
+	OracleRequestFinished OracleRequestStatus = "finished"
+	// OracleRequestExpired is used for requests dropped by the node after
+	// MaxTaskTimeout.
+	OracleRequestExpired OracleRequestStatus = "expired"
+)
+
+// OracleRequestRecord is an audit record of an oracle request processing,
+// it's returned by `getoraclerequests` and `getoracleresponse` RPC calls. All
+// times are Unix timestamps in milliseconds.
+type OracleRequestRecord struct {
+	ID           uint64              `json:"id"`
+	URL          string              `json:"url,omitempty"`
+	Filter       *string             `json:"filter,omitempty"`
+	OriginalTxID *util.Uint256       `json:"originaltxid,omitempty"`
+	Status       OracleRequestStatus `json:"status"`
+	// Received is the time the request (or some response for it) was
+	// first seen by the node.
+	Received uint64 `json:"received"`
+	Updated  uint64 `json:"updated"`
+	// Attempts is the number of times the request was processed.
+	Attempts int `json:"attempts"`
+	// Code is the response code, it's only set for processed requests.
+	Code *transaction.OracleResponseCode `json:"code,omitempty"`
+	// FetchedSize is the size of data fetched, ResultSize is the size of the
+	// response result (after filtering).
+	FetchedSize int `json:"fetchedsize"`
+	ResultSize  int `json:"resultsize"`
+	// Error is the reason of data fetching or filtering failure.
+	Error string `json:"error,omitempty"`
+	// Signatures and BackupSignatures contain keys of oracle nodes whose
+	// valid signatures for the response and backup transactions were
+	// collected. Required is the number of signatures needed.
+	Signatures       keys.PublicKeys `json:"signatures"`
+	BackupSignatures keys.PublicKeys `json:"backupsignatures"`
+	Required         int             `json:"required"`
+	TxHash           *util.Uint256   `json:"txhash,omitempty"`
+	BackupTxHash     *util.Uint256   `json:"backuptxhash,omitempty"`
+	// SentTxHash is the hash of the transaction (either main or backup)
+	// sent to the network.
+	SentTxHash *util.Uint256 `json:"senttxhash,omitempty"`
+}
@@ -12,6 +12,10 @@ type (
 		Unconnected Peers `json:"unconnected"`
 		Connected   Peers `json:"connected"`
 		Bad         Peers `json:"bad"`
+		// Static is a list of peers the node always keeps connections to.
+		Static Peers `json:"static,omitempty"`
+		// Banned is a list of denied IP addresses and CIDR networks.
+		Banned []string `json:"banned,omitempty"`
 	}
 
 	// Peers represents a slice of peers.
@@ -21,6 +25,8 @@ type (
 	Peer struct {
 		Address string `json:"address"`
 		Port    uint16 `json:"port"`
+		// Score is the peer score, it's only set for connected peers.
+		Score *int32 `json:"score,omitempty"`
 	}
 )
 
@@ -48,6 +54,11 @@ func (g *GetPeers) AddBad(addrs []string) {
 	g.Bad.addPeers(addrs)
 }
 
+// AddStatic adds a set of peers to the static peers slice.
+func (g *GetPeers) AddStatic(addrs []string) {
+	g.Static.addPeers(addrs)
+}
+
 // addPeers adds a set of peers to the given peer slice.
 func (p *Peers) addPeers(addrs []string) {
 	for i := range addrs {
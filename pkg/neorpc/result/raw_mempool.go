@@ -7,4 +7,7 @@ type RawMempool struct {
 	Height     uint32         `json:"height"`
 	Verified   []util.Uint256 `json:"verified"`
 	Unverified []util.Uint256 `json:"unverified"`
+	// Future contains not yet valid transactions waiting for their
+	// NotValidBefore height, it's only returned if requested explicitly.
+	Future []util.Uint256 `json:"future,omitempty"`
 }
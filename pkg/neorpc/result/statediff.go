@@ -0,0 +1,46 @@
+package result
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// StateDiff is a set of state changes made by an invocation. It's computed
+// from the storage changeset of the invocation and returned by invoke* calls
+// when requested.
+type StateDiff struct {
+	Storage   []StorageDiff  `json:"storage"`
+	Balances  []BalanceDiff  `json:"balances"`
+	Contracts []ContractDiff `json:"contracts"`
+}
+
+// StorageDiff is a change of a single contract storage item.
+type StorageDiff struct {
+	Contract util.Uint160 `json:"contract"`
+	// State can be Added, Changed or Deleted.
+	State    string `json:"state"`
+	Key      []byte `json:"key"`
+	OldValue []byte `json:"oldvalue,omitempty"`
+	NewValue []byte `json:"newvalue,omitempty"`
+}
+
+// BalanceDiff is a change of NEP-17 or NEP-11 token balance of an account.
+// Balances are tracked for every account mentioned in Transfer notifications.
+// ID is only set for divisible NEP-11 tokens, for non-divisible ones it's
+// the number of tokens owned by the account.
+type BalanceDiff struct {
+	Asset     util.Uint160 `json:"assethash"`
+	Standard  string       `json:"standard"`
+	Account   util.Uint160 `json:"account"`
+	ID        string       `json:"tokenid,omitempty"`
+	OldAmount string       `json:"oldamount"`
+	NewAmount string       `json:"newamount"`
+}
+
+// ContractDiff is a contract deployment, update or destruction.
+type ContractDiff struct {
+	Hash util.Uint160 `json:"hash"`
+	// State can be Deployed, Updated or Destroyed.
+	State         string `json:"state"`
+	Name          string `json:"name"`
+	UpdateCounter uint16 `json:"updatecounter"`
+}
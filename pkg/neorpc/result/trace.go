@@ -0,0 +1,47 @@
+package result
+
+import (
+	"encoding/json"
+
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// ExecutionTrace is an opcode-level trace of a script executed during block
+// processing (transaction or OnPersist/PostPersist script) replayed by
+// tracetransaction and traceblock calls. Container is a hash of the
+// transaction or of the block for OnPersist and PostPersist triggers.
+// Truncated is set if not all of the steps are included because of the
+// step limit.
+type ExecutionTrace struct {
+	Container      util.Uint256 `json:"container"`
+	Trigger        string       `json:"trigger"`
+	VMState        string       `json:"vmstate"`
+	GasConsumed    int64        `json:"gasconsumed,string"`
+	FaultException string       `json:"exception,omitempty"`
+	Steps          []TraceStep  `json:"steps"`
+	Truncated      bool         `json:"truncated"`
+}
+
+// TraceStep is a single instruction executed. Depth is the invocation stack
+// depth (starting with 1 for the entry script), GasConsumed is the amount of
+// GAS consumed before the instruction. Stack contains the top evaluation
+// stack items (the topmost one first) as stack item JSON with types (see
+// stackitem.ToJSONWithTypes), items exceeding the size limit only have their
+// type and "truncated" flag set.
+type TraceStep struct {
+	IP          int               `json:"ip"`
+	Opcode      string            `json:"opcode"`
+	ScriptHash  util.Uint160      `json:"scripthash"`
+	Depth       int               `json:"depth"`
+	GasConsumed int64             `json:"gasconsumed,string"`
+	Stack       []json.RawMessage `json:"stack,omitempty"`
+	Syscall     *TraceSyscall     `json:"syscall,omitempty"`
+}
+
+// TraceSyscall is an interop function called by SYSCALL instruction with its
+// arguments (the first one first) in the same format as TraceStep stack
+// items.
+type TraceSyscall struct {
+	Name string            `json:"name"`
+	Args []json.RawMessage `json:"args"`
+}
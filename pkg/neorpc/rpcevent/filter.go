@@ -30,6 +30,16 @@ func Matches(f Comparator, r Container) bool {
 	if r.EventID() != expectedEvent {
 		return false
 	}
+	if expectedEvent == neorpc.NotaryRequestEventID {
+		// Notary request state changes are only delivered on request.
+		var wantStates bool
+		if filter != nil {
+			wantStates = filter.(neorpc.TxFilter).StateChanges
+		}
+		if r.EventPayload().(*result.NotaryRequestEvent).NotaryRequest == nil && !wantStates {
+			return false
+		}
+	}
 	if filter == nil {
 		return true
 	}
@@ -41,9 +51,14 @@ func Matches(f Comparator, r Container) bool {
 		sinceOk := filt.Since == nil || *filt.Since <= b.Index
 		tillOk := filt.Till == nil || b.Index <= *filt.Till
 		return primaryOk && sinceOk && tillOk
-	case neorpc.TransactionEventID:
+	case neorpc.TransactionEventID, neorpc.MempoolEventID:
 		filt := filter.(neorpc.TxFilter)
-		tx := r.EventPayload().(*transaction.Transaction)
+		var tx *transaction.Transaction
+		if e, ok := r.EventPayload().(*result.MempoolEvent); ok {
+			tx = e.Transaction
+		} else {
+			tx = r.EventPayload().(*transaction.Transaction)
+		}
 		senderOK := filt.Sender == nil || tx.Sender().Equals(*filt.Sender)
 		signerOK := true
 		if filt.Signer != nil {
@@ -71,6 +86,9 @@ func Matches(f Comparator, r Container) bool {
 	case neorpc.NotaryRequestEventID:
 		filt := filter.(neorpc.TxFilter)
 		req := r.EventPayload().(*result.NotaryRequestEvent)
+		if req.NotaryRequest == nil {
+			return matchesNotaryState(filt, req.State)
+		}
 		senderOk := filt.Sender == nil || req.NotaryRequest.FallbackTransaction.Signers[1].Account == *filt.Sender
 		signerOK := true
 		if filt.Signer != nil {
@@ -86,3 +104,17 @@ func Matches(f Comparator, r Container) bool {
 	}
 	return false
 }
+
+// matchesNotaryState checks that some fallback sender and some main transaction
+// signer of the notary request state match the filter.
+func matchesNotaryState(filt neorpc.TxFilter, st *result.NotaryRequestState) bool {
+	senderOK := filt.Sender == nil
+	for i := 0; i < len(st.Fallbacks) && !senderOK; i++ {
+		senderOK = st.Fallbacks[i].Sender.Equals(*filt.Sender)
+	}
+	signerOK := filt.Signer == nil
+	for i := 0; i < len(st.Witnesses) && !signerOK; i++ {
+		signerOK = st.Witnesses[i].Account.Equals(*filt.Signer)
+	}
+	return senderOK && signerOK
+}
@@ -65,6 +65,12 @@ func TestMatches(t *testing.T) {
 		id:  neorpc.TransactionEventID,
 		pld: &transaction.Transaction{Signers: []transaction.Signer{{Account: sender}, {Account: signer}}},
 	}
+	mpContainer := testContainer{
+		id: neorpc.MempoolEventID,
+		pld: &result.MempoolEvent{
+			Transaction: &transaction.Transaction{Signers: []transaction.Signer{{Account: sender}, {Account: signer}}},
+		},
+	}
 	ntfContainer := testContainer{
 		id:  neorpc.NotificationEventID,
 		pld: &state.ContainedNotificationEvent{NotificationEvent: state.NotificationEvent{ScriptHash: contract, Name: name}},
@@ -82,6 +88,15 @@ func TestMatches(t *testing.T) {
 			},
 		},
 	}
+	ntrStateContainer := testContainer{
+		id: neorpc.NotaryRequestEventID,
+		pld: &result.NotaryRequestEvent{
+			State: &result.NotaryRequestState{
+				Witnesses: []result.NotaryWitnessState{{Account: signer}},
+				Fallbacks: []result.NotaryFallbackState{{Sender: badUint160}, {Sender: sender}},
+			},
+		},
+	}
 	missedContainer := testContainer{
 		id: neorpc.MissedEventID,
 	}
@@ -178,6 +193,42 @@ func TestMatches(t *testing.T) {
 			container: txContainer,
 			expected:  true,
 		},
+		{
+			name:       "transaction, mempool event",
+			comparator: testComparator{id: neorpc.TransactionEventID},
+			container:  mpContainer,
+			expected:   false,
+		},
+		{
+			name:       "mempool event, in-block transaction",
+			comparator: testComparator{id: neorpc.MempoolEventID},
+			container:  txContainer,
+			expected:   false,
+		},
+		{
+			name:       "mempool event, no filter",
+			comparator: testComparator{id: neorpc.MempoolEventID},
+			container:  mpContainer,
+			expected:   true,
+		},
+		{
+			name: "mempool event, sender mismatch",
+			comparator: testComparator{
+				id:     neorpc.MempoolEventID,
+				filter: neorpc.TxFilter{Sender: &badUint160},
+			},
+			container: mpContainer,
+			expected:  false,
+		},
+		{
+			name: "mempool event, filter match",
+			comparator: testComparator{
+				id:     neorpc.MempoolEventID,
+				filter: neorpc.TxFilter{Sender: &sender, Signer: &signer},
+			},
+			container: mpContainer,
+			expected:  true,
+		},
 		{
 			name:       "notification, no filter",
 			comparator: testComparator{id: neorpc.NotificationEventID},
@@ -277,6 +328,57 @@ func TestMatches(t *testing.T) {
 			container: ntrContainer,
 			expected:  true,
 		},
+		{
+			name:       "notary request state, no filter",
+			comparator: testComparator{id: neorpc.NotaryRequestEventID},
+			container:  ntrStateContainer,
+			expected:   false,
+		},
+		{
+			name: "notary request state, without state changes filter",
+			comparator: testComparator{
+				id:     neorpc.NotaryRequestEventID,
+				filter: neorpc.TxFilter{Sender: &sender},
+			},
+			container: ntrStateContainer,
+			expected:  false,
+		},
+		{
+			name: "notary request state, sender mismatch",
+			comparator: testComparator{
+				id:     neorpc.NotaryRequestEventID,
+				filter: neorpc.TxFilter{Sender: &signer, StateChanges: true},
+			},
+			container: ntrStateContainer,
+			expected:  false,
+		},
+		{
+			name: "notary request state, signer mismatch",
+			comparator: testComparator{
+				id:     neorpc.NotaryRequestEventID,
+				filter: neorpc.TxFilter{Signer: &sender, StateChanges: true},
+			},
+			container: ntrStateContainer,
+			expected:  false,
+		},
+		{
+			name: "notary request state, filter match",
+			comparator: testComparator{
+				id:     neorpc.NotaryRequestEventID,
+				filter: neorpc.TxFilter{Sender: &sender, Signer: &signer, StateChanges: true},
+			},
+			container: ntrStateContainer,
+			expected:  true,
+		},
+		{
+			name: "notary request, state changes filter",
+			comparator: testComparator{
+				id:     neorpc.NotaryRequestEventID,
+				filter: neorpc.TxFilter{StateChanges: true},
+			},
+			container: ntrContainer,
+			expected:  true,
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -0,0 +1,33 @@
+package neorpc
+
+// TraceLevel is the level of details included into execution traces returned
+// by tracetransaction and traceblock calls.
+type TraceLevel string
+
+// Trace levels supported.
+const (
+	// TraceSyscalls only includes SYSCALL instructions with their arguments.
+	TraceSyscalls TraceLevel = "syscalls"
+	// TraceOpcodes includes every instruction executed, but no stack items.
+	TraceOpcodes TraceLevel = "opcodes"
+	// TraceFull includes every instruction executed along with the top items
+	// of the evaluation stack.
+	TraceFull TraceLevel = "full"
+)
+
+// TraceOptions is a set of optional parameters for tracetransaction and
+// traceblock calls. Zero values mean server defaults (TraceFull level, one
+// stack item of at most 256 bytes, server limit for the number of steps),
+// limits can't exceed server-side ones.
+type TraceOptions struct {
+	Level TraceLevel `json:"level,omitempty"`
+	// MaxSteps is the maximum number of steps returned for the whole call,
+	// execution continues after the limit is reached, but isn't traced.
+	MaxSteps int `json:"maxsteps,omitempty"`
+	// StackDepth is the number of the top evaluation stack items included
+	// into every step.
+	StackDepth int `json:"stackdepth,omitempty"`
+	// MaxItemSize is the maximum size of a stack item JSON representation,
+	// bigger items only have their type shown.
+	MaxItemSize int `json:"maxitemsize,omitempty"`
+}
@@ -0,0 +1,309 @@
+package network
+
+import (
+	"sync"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/network/payload"
+)
+
+// Block range request parameters. Every peer has its own window (the number
+// of blocks requested at once) adapted to its response time: it grows for
+// peers answering faster than fetchTargetTime and shrinks for slower ones
+// and for the ones not answering in time.
+const (
+	minFetchWindow     = 16
+	initialFetchWindow = 64
+	maxFetchWindow     = payload.MaxHashesCount
+
+	// maxPeerRanges is the number of block ranges that can be requested
+	// from a single peer simultaneously.
+	maxPeerRanges = 2
+
+	fetchTargetTime = time.Second
+
+	// defaultFetchTimeout is used for range and header requests if there
+	// is no TimePerBlock configured.
+	defaultFetchTimeout = 30 * time.Second
+
+	// throughputPeriod is the period sync throughput is measured over.
+	throughputPeriod = 5 * time.Second
+)
+
+// blockFetcher schedules block range requests to multiple peers. Ranges are
+// requested in parallel, each block index is requested from a single peer
+// at a time, ranges not received in time are requested again from other
+// peers. It also tracks header requests for header-first synchronization.
+// It's safe for concurrent use.
+type blockFetcher struct {
+	lock    sync.Mutex
+	timeout time.Duration
+	// peers contains per-peer request state.
+	peers map[Peer]*fetchPeer
+	// ranges contains all in-flight range requests.
+	ranges map[*blockRange]struct{}
+	// pending maps requested block indexes to their ranges.
+	pending map[uint32]*blockRange
+
+	// headersPeer is the peer headers were requested from (if any),
+	// headersSent is the time of this request.
+	headersPeer Peer
+	headersSent time.Time
+
+	// Throughput measurement.
+	rateSince  time.Time
+	rateBlocks int
+}
+
+// fetchPeer is the per-peer state of blockFetcher.
+type fetchPeer struct {
+	window int
+	ranges int
+}
+
+// grow doubles the window up to maxFetchWindow.
+func (fp *fetchPeer) grow() {
+	fp.window *= 2
+	if fp.window > maxFetchWindow {
+		fp.window = maxFetchWindow
+	}
+}
+
+// shrink halves the window down to minFetchWindow.
+func (fp *fetchPeer) shrink() {
+	fp.window /= 2
+	if fp.window < minFetchWindow {
+		fp.window = minFetchWindow
+	}
+}
+
+// blockRange is a single range request.
+type blockRange struct {
+	peer  Peer
+	start uint32
+	count uint32
+	left  uint32
+	sent  time.Time
+}
+
+func newBlockFetcher(timeout time.Duration) *blockFetcher {
+	if timeout <= 0 {
+		timeout = defaultFetchTimeout
+	}
+	return &blockFetcher{
+		timeout:   timeout,
+		peers:     make(map[Peer]*fetchPeer),
+		ranges:    make(map[*blockRange]struct{}),
+		pending:   make(map[uint32]*blockRange),
+		rateSince: time.Now(),
+	}
+}
+
+// peer returns the state of the given peer creating it if needed.
+func (f *blockFetcher) peer(p Peer) *fetchPeer {
+	fp, ok := f.peers[p]
+	if !ok {
+		fp = &fetchPeer{window: initialFetchWindow}
+		f.peers[p] = fp
+	}
+	return fp
+}
+
+// drop removes the range request, leaving its remaining blocks to be
+// requested again.
+func (f *blockFetcher) drop(r *blockRange) {
+	delete(f.ranges, r)
+	for i := r.start; i < r.start+r.count; i++ {
+		if f.pending[i] == r {
+			delete(f.pending, i)
+		}
+	}
+	if fp, ok := f.peers[r.peer]; ok {
+		fp.ranges--
+	}
+	updateSyncRequestsMetric(len(f.ranges))
+}
+
+// next returns the next block range to be requested from the peer given
+// the current height. missing returns the indexes of the blocks not yet
+// received up to the given limit. ok is false if there is nothing to
+// request from this peer. The range returned is considered to be requested,
+// use cancel if it can't be sent.
+func (f *blockFetcher) next(p Peer, height uint32, limit uint32, missing func(from, to uint32) []uint32) (start uint32, count uint32, ok bool) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.dropStale(height)
+	fp := f.peer(p)
+	if limit <= height || fp.ranges >= maxPeerRanges {
+		return 0, 0, false
+	}
+	for _, i := range missing(height+1, limit) {
+		if _, ok := f.pending[i]; ok {
+			if count != 0 {
+				break
+			}
+			continue
+		}
+		if count == 0 {
+			start = i
+		} else if i != start+count {
+			break
+		}
+		count++
+		if count == uint32(fp.window) {
+			break
+		}
+	}
+	if count == 0 {
+		return 0, 0, false
+	}
+	r := &blockRange{peer: p, start: start, count: count, left: count, sent: time.Now()}
+	f.ranges[r] = struct{}{}
+	for i := start; i < start+count; i++ {
+		f.pending[i] = r
+	}
+	fp.ranges++
+	updateSyncRequestsMetric(len(f.ranges))
+	return start, count, true
+}
+
+// cancel drops the range request starting at the given index.
+func (f *blockFetcher) cancel(p Peer, start uint32) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if r, ok := f.pending[start]; ok && r.peer == p && r.start == start {
+		f.drop(r)
+	}
+}
+
+// dropStale drops the ranges that are completely at or below the given
+// height, these blocks were received from other peers.
+func (f *blockFetcher) dropStale(height uint32) {
+	for r := range f.ranges {
+		if r.start+r.count-1 <= height {
+			f.drop(r)
+		}
+	}
+	for i := range f.pending {
+		if i <= height {
+			delete(f.pending, i)
+		}
+	}
+	if len(f.ranges) == 0 && time.Since(f.rateSince) >= throughputPeriod {
+		f.updateThroughput()
+	}
+}
+
+// received marks the block as received from the peer and returns true if
+// it completes the range requested from this peer.
+func (f *blockFetcher) received(p Peer, index uint32) bool {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	r, ok := f.pending[index]
+	if !ok || r.peer != p {
+		return false
+	}
+	delete(f.pending, index)
+	r.left--
+	f.rateBlocks++
+	addSyncBlocksMetric()
+	if time.Since(f.rateSince) >= throughputPeriod {
+		f.updateThroughput()
+	}
+	if r.left != 0 {
+		return false
+	}
+	f.drop(r)
+	if fp, ok := f.peers[p]; ok {
+		switch took := time.Since(r.sent); {
+		case took < fetchTargetTime:
+			fp.grow()
+		case took > 2*fetchTargetTime:
+			fp.shrink()
+		}
+	}
+	return true
+}
+
+// updateThroughput updates sync throughput metric and resets measurement.
+func (f *blockFetcher) updateThroughput() {
+	now := time.Now()
+	updateSyncThroughputMetric(float64(f.rateBlocks) / now.Sub(f.rateSince).Seconds())
+	f.rateSince = now
+	f.rateBlocks = 0
+}
+
+// expire drops the requests not answered in time and returns the peers they
+// were sent to. The window of such peers is reduced, their blocks can be
+// requested from other peers.
+func (f *blockFetcher) expire() []Peer {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	var (
+		slow []Peer
+		now  = time.Now()
+	)
+	for r := range f.ranges {
+		if now.Sub(r.sent) < f.timeout {
+			continue
+		}
+		f.drop(r)
+		addSyncTimeoutMetric()
+		if fp, ok := f.peers[r.peer]; ok {
+			fp.shrink()
+		}
+		slow = append(slow, r.peer)
+	}
+	if f.headersPeer != nil && now.Sub(f.headersSent) >= f.timeout {
+		addSyncTimeoutMetric()
+		slow = append(slow, f.headersPeer)
+		f.headersPeer = nil
+	}
+	return slow
+}
+
+// requestHeaders marks headers as requested from the peer. It returns false
+// if there is a header request in flight already.
+func (f *blockFetcher) requestHeaders(p Peer) bool {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.headersPeer != nil {
+		return false
+	}
+	f.headersPeer = p
+	f.headersSent = time.Now()
+	return true
+}
+
+// headersReceived clears the header request sent to the peer.
+func (f *blockFetcher) headersReceived(p Peer) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.headersPeer == p {
+		f.headersPeer = nil
+	}
+}
+
+// window returns the current window of the peer.
+func (f *blockFetcher) window(p Peer) int {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return f.peer(p).window
+}
+
+// forget drops all the requests and state of the disconnected peer.
+func (f *blockFetcher) forget(p Peer) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	for r := range f.ranges {
+		if r.peer == p {
+			f.drop(r)
+		}
+	}
+	delete(f.peers, p)
+	if f.headersPeer == p {
+		f.headersPeer = nil
+	}
+}
@@ -0,0 +1,134 @@
+package network
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/internal/fakechain"
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/network/payload"
+	"github.com/stretchr/testify/require"
+)
+
+// missingAll returns all indexes of the range as missing.
+func missingAll(from, to uint32) []uint32 {
+	var res []uint32
+	for i := from; i <= to; i++ {
+		res = append(res, i)
+	}
+	return res
+}
+
+func TestBlockFetcher(t *testing.T) {
+	f := newBlockFetcher(time.Hour)
+	p1, p2 := newLocalPeer(t, nil), newLocalPeer(t, nil)
+
+	checkNext := func(t *testing.T, p Peer, height, limit uint32, start, count uint32) {
+		s, c, ok := f.next(p, height, limit, missingAll)
+		require.True(t, ok)
+		require.Equal(t, start, s)
+		require.Equal(t, count, c)
+	}
+	checkNone := func(t *testing.T, p Peer, height, limit uint32) {
+		_, _, ok := f.next(p, height, limit, missingAll)
+		require.False(t, ok)
+	}
+
+	checkNone(t, p1, 10, 10)
+	checkNext(t, p1, 10, 1000, 11, initialFetchWindow)
+	checkNext(t, p2, 10, 1000, 11+initialFetchWindow, initialFetchWindow)
+	// Limit is respected.
+	checkNext(t, p1, 10, 11+2*initialFetchWindow, 11+2*initialFetchWindow, 1)
+	// No more than maxPeerRanges per peer.
+	checkNone(t, p1, 10, 1000)
+
+	// Gaps in missing blocks split ranges.
+	f.cancel(p1, 11+2*initialFetchWindow)
+	_, c, ok := f.next(p1, 10, 1000, func(from, to uint32) []uint32 {
+		return []uint32{11, 200, 201, 203}
+	})
+	require.True(t, ok)
+	require.Equal(t, uint32(2), c)
+
+	// Blocks from other peers don't count.
+	f.forget(p1)
+	checkNext(t, p1, 10, 1000, 11, initialFetchWindow)
+	require.False(t, f.received(p2, 11))
+	for i := uint32(11); i < 10+initialFetchWindow; i++ {
+		require.False(t, f.received(p1, i))
+	}
+	require.True(t, f.received(p1, 10+initialFetchWindow))
+	require.Equal(t, 2*initialFetchWindow, f.window(p1))
+	// The range is complete, so the peer can request more.
+	checkNext(t, p1, 10+initialFetchWindow, 1000, 11+2*initialFetchWindow, 2*initialFetchWindow)
+
+	// Stale ranges are dropped.
+	checkNext(t, p1, 10+3*initialFetchWindow, 1000, 11+4*initialFetchWindow, 2*initialFetchWindow)
+
+	t.Run("timeout", func(t *testing.T) {
+		f := newBlockFetcher(time.Millisecond)
+		s, c, ok := f.next(p1, 0, 1000, missingAll)
+		require.True(t, ok)
+		require.Empty(t, f.expire())
+		time.Sleep(time.Millisecond)
+		require.Equal(t, []Peer{p1}, f.expire())
+		require.Equal(t, initialFetchWindow/2, f.window(p1))
+
+		// Blocks can be requested from other peers now.
+		s2, c2, ok := f.next(p2, 0, 1000, missingAll)
+		require.True(t, ok)
+		require.Equal(t, s, s2)
+		require.Equal(t, c, c2)
+	})
+	t.Run("headers", func(t *testing.T) {
+		f := newBlockFetcher(time.Millisecond)
+		require.True(t, f.requestHeaders(p1))
+		require.False(t, f.requestHeaders(p2))
+		f.headersReceived(p2)
+		require.False(t, f.requestHeaders(p2))
+		f.headersReceived(p1)
+		require.True(t, f.requestHeaders(p2))
+		time.Sleep(time.Millisecond)
+		require.Equal(t, []Peer{p2}, f.expire())
+		require.True(t, f.requestHeaders(p1))
+	})
+}
+
+func TestHeaderFirstSync(t *testing.T) {
+	s := newTestServer(t, ServerConfig{UserAgent: "/test/"})
+	var (
+		chain = s.chain.(*fakechain.FakeChain)
+		p     = newLocalPeer(t, s)
+		cmds  []CommandType
+		reqs  []payload.GetBlockByIndex
+	)
+	p.handshaked = 1
+	p.lastBlockIndex = 100
+	p.messageHandler = func(t *testing.T, msg *Message) {
+		cmds = append(cmds, msg.Command)
+		reqs = append(reqs, *msg.Payload.(*payload.GetBlockByIndex))
+	}
+
+	// Headers are requested first, no blocks are known to be requested.
+	require.NoError(t, s.requestBlocksOrHeaders(p))
+	require.Equal(t, []CommandType{CMDGetHeaders}, cmds)
+	require.Equal(t, payload.GetBlockByIndex{IndexStart: 1, Count: -1}, reqs[0])
+	// Only one header request at a time.
+	require.NoError(t, s.requestBlocksOrHeaders(p))
+	require.Equal(t, 1, len(cmds))
+
+	// Headers not following the current ones are ignored.
+	require.NoError(t, s.handleHeadersCmd(p, &payload.Headers{Hdrs: []*block.Header{{Index: 5}}}))
+	require.Equal(t, uint32(0), chain.HeaderHeight())
+
+	// Blocks are requested once headers are received.
+	hdrs := make([]*block.Header, 10)
+	for i := range hdrs {
+		hdrs[i] = &block.Header{Index: uint32(i + 1)}
+	}
+	require.NoError(t, s.handleHeadersCmd(p, &payload.Headers{Hdrs: hdrs}))
+	require.Equal(t, uint32(10), chain.HeaderHeight())
+	require.Equal(t, []CommandType{CMDGetHeaders, CMDGetHeaders, CMDGetBlockByIndex}, cmds)
+	require.Equal(t, payload.GetBlockByIndex{IndexStart: 11, Count: -1}, reqs[1])
+	require.Equal(t, payload.GetBlockByIndex{IndexStart: 1, Count: 10}, reqs[2])
+}
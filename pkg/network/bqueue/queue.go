@@ -24,6 +24,7 @@ type Queue struct {
 	checkBlocks chan struct{}
 	chain       Blockqueuer
 	relayF      func(*block.Block)
+	failF       func(*block.Block, error)
 	discarded   *atomic.Bool
 	len         int
 	lenUpdateF  func(int)
@@ -37,8 +38,10 @@ func indexToPosition(i uint32) int {
 	return int(i) % CacheSize
 }
 
-// New creates an instance of BlockQueue.
-func New(bc Blockqueuer, log *zap.Logger, relayer func(*block.Block), lenMetricsUpdater func(l int)) *Queue {
+// New creates an instance of BlockQueue. relayer is called for every block
+// successfully added to the chain and failer is called for blocks the chain
+// refused to accept, both are optional.
+func New(bc Blockqueuer, log *zap.Logger, relayer func(*block.Block), failer func(*block.Block, error), lenMetricsUpdater func(l int)) *Queue {
 	if log == nil {
 		return nil
 	}
@@ -49,6 +52,7 @@ func New(bc Blockqueuer, log *zap.Logger, relayer func(*block.Block), lenMetrics
 		checkBlocks: make(chan struct{}, 1),
 		chain:       bc,
 		relayF:      relayer,
+		failF:       failer,
 		discarded:   atomic.NewBool(false),
 		lenUpdateF:  lenMetricsUpdater,
 	}
@@ -89,6 +93,9 @@ func (bq *Queue) Run() {
 						zap.String("error", err.Error()),
 						zap.Uint32("blockHeight", bq.chain.BlockHeight()),
 						zap.Uint32("nextIndex", b.Index))
+					if bq.failF != nil {
+						bq.failF(b, err)
+					}
 				}
 			} else if bq.relayF != nil {
 				bq.relayF(b)
@@ -143,6 +150,32 @@ func (bq *Queue) PutBlock(block *block.Block) error {
 	return nil
 }
 
+// Missing returns the indexes of the blocks from the [from, to] range that
+// are not in the queue yet. Blocks that can't fit into the queue (too far from
+// the current height) are not included.
+func (bq *Queue) Missing(from, to uint32) []uint32 {
+	h := bq.chain.BlockHeight()
+	if from <= h {
+		from = h + 1
+	}
+	if to > h+CacheSize {
+		to = h + CacheSize
+	}
+	bq.queueLock.RLock()
+	defer bq.queueLock.RUnlock()
+	if bq.discarded.Load() || from > to {
+		return nil
+	}
+	res := make([]uint32, 0, to-from+1)
+	for i := from; i <= to; i++ {
+		b := bq.queue[indexToPosition(i)]
+		if b == nil || b.Index != i {
+			res = append(res, i)
+		}
+	}
+	return res
+}
+
 // LastQueued returns the index of the last queued block and the queue's capacity
 // left.
 func (bq *Queue) LastQueued() (uint32, int) {
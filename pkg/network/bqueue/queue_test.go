@@ -1,6 +1,7 @@
 package bqueue
 
 import (
+	"errors"
 	"testing"
 	"time"
 
@@ -13,7 +14,7 @@ import (
 func TestBlockQueue(t *testing.T) {
 	chain := fakechain.NewFakeChain()
 	// notice, it's not yet running
-	bq := New(chain, zaptest.NewLogger(t), nil, nil)
+	bq := New(chain, zaptest.NewLogger(t), nil, nil, nil)
 	blocks := make([]*block.Block, 11)
 	for i := 1; i < 11; i++ {
 		blocks[i] = &block.Block{Header: block.Header{Index: uint32(i)}}
@@ -82,9 +83,52 @@ func TestBlockQueue(t *testing.T) {
 	assert.Equal(t, 0, bq.length())
 }
 
+type failingChain struct {
+	*fakechain.FakeChain
+}
+
+func (failingChain) AddBlock(*block.Block) error {
+	return errors.New("bad block")
+}
+
+func TestBlockQueueFailer(t *testing.T) {
+	var (
+		failed = make(chan *block.Block, 1)
+		bq     = New(failingChain{fakechain.NewFakeChain()}, zaptest.NewLogger(t), nil, func(b *block.Block, err error) {
+			assert.Error(t, err)
+			failed <- b
+		}, nil)
+		b = &block.Block{Header: block.Header{Index: 1}}
+	)
+	go bq.Run()
+	defer bq.Discard()
+	assert.NoError(t, bq.PutBlock(b))
+	select {
+	case actual := <-failed:
+		assert.Equal(t, b, actual)
+	case <-time.After(time.Second):
+		t.Fatal("failer is not called")
+	}
+}
+
 // length wraps len access for tests to make them thread-safe.
 func (bq *Queue) length() int {
 	bq.queueLock.Lock()
 	defer bq.queueLock.Unlock()
 	return bq.len
 }
+
+func TestBlockQueueMissing(t *testing.T) {
+	chain := fakechain.NewFakeChain()
+	bq := New(chain, zaptest.NewLogger(t), nil, nil, nil)
+	for _, i := range []uint32{2, 4} {
+		assert.NoError(t, bq.PutBlock(&block.Block{Header: block.Header{Index: i}}))
+	}
+	assert.Equal(t, []uint32{1, 3, 5}, bq.Missing(0, 5))
+	assert.Equal(t, []uint32{3}, bq.Missing(2, 4))
+	assert.Empty(t, bq.Missing(4, 4))
+	// Range is limited by the queue capacity.
+	assert.Equal(t, CacheSize-2, len(bq.Missing(1, 10*CacheSize)))
+	bq.Discard()
+	assert.Nil(t, bq.Missing(0, 5))
+}
@@ -35,6 +35,16 @@ type Discoverer interface {
 	UnconnectedPeers() []string
 	BadPeers() []string
 	GoodPeers() []AddressWithCapabilities
+	AddStaticPeer(string)
+	RemovePeer(string) bool
+	StaticPeers() []string
+	Ban(string) error
+	BanUntil(string, time.Time) error
+	Unban(string) error
+	Banned() []string
+	TemporaryBans() map[string]time.Time
+	Allow(string) error
+	IsAllowed(string) bool
 }
 
 // AddressWithCapabilities represents a node address with its capabilities.
@@ -46,6 +56,8 @@ type AddressWithCapabilities struct {
 // DefaultDiscovery default implementation of the Discoverer interface.
 type DefaultDiscovery struct {
 	seeds            map[string]string
+	static           map[string]string
+	filter           *ipFilter
 	transport        Transporter
 	lock             sync.RWMutex
 	dialTimeout      time.Duration
@@ -69,6 +81,8 @@ func NewDefaultDiscovery(addrs []string, dt time.Duration, ts Transporter) *Defa
 	}
 	d := &DefaultDiscovery{
 		seeds:            seeds,
+		static:           make(map[string]string),
+		filter:           newIPFilter(),
 		transport:        ts,
 		dialTimeout:      dt,
 		badAddrs:         make(map[string]bool),
@@ -96,8 +110,8 @@ func (d *DefaultDiscovery) BackFill(addrs ...string) {
 
 func (d *DefaultDiscovery) backfill(addrs ...string) {
 	for _, addr := range addrs {
-		if d.badAddrs[addr] || d.connectedAddrs[addr] || d.handshakedAddrs[addr] ||
-			d.unconnectedAddrs[addr] > 0 {
+		if _, isStatic := d.static[addr]; isStatic || d.badAddrs[addr] || d.connectedAddrs[addr] ||
+			d.handshakedAddrs[addr] || d.unconnectedAddrs[addr] > 0 || !d.filter.IsAllowed(addr) {
 			continue
 		}
 		d.pushToPoolOrDrop(addr)
@@ -124,15 +138,26 @@ func (d *DefaultDiscovery) pushToPoolOrDrop(addr string) {
 	}
 }
 
-// RequestRemote tries to establish a connection with n nodes.
+// RequestRemote tries to establish a connection with n nodes. Static peers
+// that are not connected are always dialed in addition to that.
 func (d *DefaultDiscovery) RequestRemote(requested int) {
+	d.lock.Lock()
+	for addr, ip := range d.static {
+		if ip == "" && !d.attempted[addr] && !d.connectedAddrs[addr] && d.filter.IsAllowed(addr) {
+			d.attempted[addr] = true
+			atomic.AddInt32(&d.outstanding, 1)
+			go d.tryAddress(addr)
+		}
+	}
+	d.lock.Unlock()
+
 	outstanding := int(atomic.LoadInt32(&d.outstanding))
 	requested -= outstanding
 	for ; requested > 0; requested-- {
 		var nextAddr string
 		d.lock.Lock()
 		for addr := range d.unconnectedAddrs {
-			if !d.connectedAddrs[addr] && !d.handshakedAddrs[addr] && !d.attempted[addr] {
+			if !d.connectedAddrs[addr] && !d.handshakedAddrs[addr] && !d.attempted[addr] && d.filter.IsAllowed(addr) {
 				nextAddr = addr
 				break
 			}
@@ -141,7 +166,7 @@ func (d *DefaultDiscovery) RequestRemote(requested int) {
 		if nextAddr == "" {
 			// Empty pool, try seeds.
 			for addr, ip := range d.seeds {
-				if ip == "" && !d.attempted[addr] {
+				if ip == "" && !d.attempted[addr] && d.filter.IsAllowed(addr) {
 					nextAddr = addr
 					break
 				}
@@ -172,7 +197,14 @@ func (d *DefaultDiscovery) RegisterSelf(p AddressablePeer) {
 
 func (d *DefaultDiscovery) registerBad(addr string, force bool) {
 	_, isSeed := d.seeds[addr]
-	if isSeed {
+	_, isStatic := d.static[addr]
+	if isStatic {
+		if !force {
+			d.static[addr] = ""
+		} else {
+			d.static[addr] = "forever" // Same as for seeds, that's our own address.
+		}
+	} else if isSeed {
 		if !force {
 			d.seeds[addr] = ""
 		} else {
@@ -253,6 +285,12 @@ func (d *DefaultDiscovery) UnregisterConnected(p AddressablePeer, duplicate bool
 				break
 			}
 		}
+		for addr, ip := range d.static {
+			if ip == peeraddr {
+				d.static[addr] = ""
+				break
+			}
+		}
 		delete(d.handshakedAddrs, peeraddr)
 		if _, ok := d.goodAddrs[peeraddr]; ok {
 			d.backfill(peeraddr)
@@ -305,11 +343,18 @@ func (d *DefaultDiscovery) tryAddress(addr string) {
 	p, err := d.transport.Dial(addr, d.dialTimeout)
 	atomic.AddInt32(&d.outstanding, -1)
 	d.lock.Lock()
-	delete(d.attempted, addr)
+	_, isStatic := d.static[addr]
+	if err == nil || !isStatic {
+		// Failed static peers are kept attempted until the retry delay passes.
+		delete(d.attempted, addr)
+	}
 	if err == nil {
 		if _, ok := d.seeds[addr]; ok {
 			d.seeds[addr] = p.PeerAddr().String()
 		}
+		if isStatic {
+			d.static[addr] = p.PeerAddr().String()
+		}
 		d.registerConnected(addr)
 	} else {
 		d.registerBad(addr, false)
@@ -317,6 +362,144 @@ func (d *DefaultDiscovery) tryAddress(addr string) {
 	d.lock.Unlock()
 	if err != nil {
 		time.Sleep(d.dialTimeout)
+		if isStatic {
+			d.lock.Lock()
+			delete(d.attempted, addr)
+			d.lock.Unlock()
+		}
 		d.RequestRemote(1)
 	}
 }
+
+// AddStaticPeer adds the given "host:port" address to the list of static
+// peers. Static peers are never marked as bad ones and they're dialed by
+// every RequestRemote call until connected.
+func (d *DefaultDiscovery) AddStaticPeer(addr string) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if _, ok := d.static[addr]; ok {
+		return
+	}
+	d.static[addr] = ""
+	delete(d.badAddrs, addr)
+	delete(d.unconnectedAddrs, addr)
+	d.updateNetSize()
+}
+
+// RemovePeer removes the given address from the list of static peers and
+// forgets everything known about it, so that it's not dialed unless received
+// from other peers again. It returns false if the address is not known.
+func (d *DefaultDiscovery) RemovePeer(addr string) bool {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	var found bool
+	if ip, ok := d.static[addr]; ok {
+		delete(d.static, addr)
+		delete(d.goodAddrs, ip)
+		found = true
+	}
+	if _, ok := d.unconnectedAddrs[addr]; ok {
+		delete(d.unconnectedAddrs, addr)
+		found = true
+	}
+	if _, ok := d.goodAddrs[addr]; ok {
+		delete(d.goodAddrs, addr)
+		found = true
+	}
+	if d.badAddrs[addr] {
+		delete(d.badAddrs, addr)
+		found = true
+	}
+	d.updateNetSize()
+	return found
+}
+
+// StaticPeers returns all static peer addresses.
+func (d *DefaultDiscovery) StaticPeers() []string {
+	d.lock.RLock()
+	addrs := make([]string, 0, len(d.static))
+	for addr := range d.static {
+		addrs = append(addrs, addr)
+	}
+	d.lock.RUnlock()
+	return addrs
+}
+
+// Ban adds the given IP address or CIDR network to the denylist. Matching
+// addresses are removed from the pool and never dialed.
+func (d *DefaultDiscovery) Ban(rule string) error {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if err := d.filter.Deny(rule); err != nil {
+		return err
+	}
+	d.dropDenied()
+	return nil
+}
+
+// BanUntil adds the given IP address or CIDR network to the denylist until the
+// specified time, it works the same way as Ban otherwise.
+func (d *DefaultDiscovery) BanUntil(rule string, until time.Time) error {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if err := d.filter.DenyUntil(rule, until); err != nil {
+		return err
+	}
+	d.dropDenied()
+	return nil
+}
+
+// dropDenied removes all addresses not allowed by the filter from the pool.
+// Must be called under write lock.
+func (d *DefaultDiscovery) dropDenied() {
+	for addr := range d.unconnectedAddrs {
+		if !d.filter.IsAllowed(addr) {
+			delete(d.unconnectedAddrs, addr)
+		}
+	}
+	for addr := range d.goodAddrs {
+		if !d.filter.IsAllowed(addr) {
+			delete(d.goodAddrs, addr)
+		}
+	}
+	d.updateNetSize()
+}
+
+// Unban removes the given IP address or CIDR network from the denylist.
+func (d *DefaultDiscovery) Unban(rule string) error {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	return d.filter.Undeny(rule)
+}
+
+// Banned returns the denylist contents.
+func (d *DefaultDiscovery) Banned() []string {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	return d.filter.Denied()
+}
+
+// TemporaryBans returns active temporary bans with their expiration time.
+func (d *DefaultDiscovery) TemporaryBans() map[string]time.Time {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	return d.filter.TemporarilyDenied()
+}
+
+// Allow adds the given IP address or CIDR network to the allowlist. Once
+// there is at least one allowlist entry, only matching addresses are dialed
+// and accepted.
+func (d *DefaultDiscovery) Allow(rule string) error {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	return d.filter.Allow(rule)
+}
+
+// IsAllowed checks whether the given address passes the allowlist and the
+// denylist. Host names are always allowed since they can't be checked
+// without resolving them.
+func (d *DefaultDiscovery) IsAllowed(addr string) bool {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	return d.filter.IsAllowed(addr)
+}
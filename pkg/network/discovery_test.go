@@ -233,3 +233,100 @@ func TestSeedDiscovery(t *testing.T) {
 		}
 	}
 }
+
+func TestStaticPeers(t *testing.T) {
+	var static = "1.1.1.1:10333"
+	ts := &fakeTransp{}
+	ts.dialCh = make(chan string)
+	d := NewDefaultDiscovery(nil, time.Second/10, ts)
+
+	d.AddStaticPeer(static)
+	require.Equal(t, []string{static}, d.StaticPeers())
+	d.BackFill(static) // Static peers are never pooled.
+	require.Equal(t, 0, d.PoolCount())
+
+	// Failed static peer is retried indefinitely and never marked as a bad one.
+	atomic.StoreInt32(&ts.retFalse, 1)
+	for i := 0; i < connRetries+1; i++ {
+		d.RequestRemote(0)
+		select {
+		case a := <-ts.dialCh:
+			require.Equal(t, static, a)
+		case <-time.After(time.Second):
+			t.Fatalf("timeout expecting for transport dial")
+		}
+	}
+	require.Equal(t, 0, len(d.BadPeers()))
+
+	// Connected static peer is not dialed again until disconnected.
+	atomic.StoreInt32(&ts.retFalse, 0)
+	require.Eventually(t, func() bool {
+		d.RequestRemote(0)
+		select {
+		case <-ts.dialCh:
+			return true
+		case <-time.After(time.Second / 20):
+			return false
+		}
+	}, 3*time.Second, time.Millisecond)
+	require.Eventually(t, func() bool { return atomic.LoadInt32(&d.outstanding) == 0 }, time.Second, time.Millisecond)
+	d.RequestRemote(0)
+	select {
+	case <-ts.dialCh:
+		t.Fatalf("connected static peer is dialed")
+	case <-time.After(time.Second / 10):
+	}
+	d.UnregisterConnected(&fakeAPeer{addr: static, peer: static}, false)
+	d.RequestRemote(0)
+	select {
+	case a := <-ts.dialCh:
+		require.Equal(t, static, a)
+	case <-time.After(time.Second):
+		t.Fatalf("timeout expecting for transport dial")
+	}
+
+	require.True(t, d.RemovePeer(static))
+	require.False(t, d.RemovePeer(static))
+	require.Equal(t, 0, len(d.StaticPeers()))
+}
+
+func TestDiscoveryBan(t *testing.T) {
+	ts := &fakeTransp{}
+	ts.dialCh = make(chan string)
+	d := NewDefaultDiscovery([]string{"3.3.3.3:10333"}, time.Second/10, ts)
+
+	d.BackFill("1.1.1.1:10333", "2.2.2.2:10333")
+	require.Equal(t, 2, d.PoolCount())
+
+	require.Error(t, d.Ban("1.1.1"))
+	require.NoError(t, d.Ban("1.1.1.0/24"))
+	require.NoError(t, d.Ban("3.3.3.3"))
+	require.Equal(t, []string{"1.1.1.0/24", "3.3.3.3"}, d.Banned())
+	require.Equal(t, []string{"2.2.2.2:10333"}, d.UnconnectedPeers())
+	require.False(t, d.IsAllowed("1.1.1.1:10333"))
+
+	d.BackFill("1.1.1.2:10333")
+	require.Equal(t, 1, d.PoolCount())
+
+	// Neither banned pool addresses nor banned seeds are dialed.
+	d.RequestRemote(3)
+	select {
+	case a := <-ts.dialCh:
+		require.Equal(t, "2.2.2.2:10333", a)
+	case <-time.After(time.Second):
+		t.Fatalf("timeout expecting for transport dial")
+	}
+	select {
+	case a := <-ts.dialCh:
+		t.Fatalf("unexpected dial to %s", a)
+	case <-time.After(time.Second / 10):
+	}
+
+	require.NoError(t, d.Unban("1.1.1.0/24"))
+	require.Error(t, d.Unban("1.1.1.0/24"))
+	require.True(t, d.IsAllowed("1.1.1.1:10333"))
+
+	require.NoError(t, d.Allow("2.2.2.0/24"))
+	require.False(t, d.IsAllowed("1.1.1.1:10333"))
+	require.True(t, d.IsAllowed("2.2.2.3:10333"))
+}
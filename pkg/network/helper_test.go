@@ -72,6 +72,16 @@ func (d *testDiscovery) BadPeers() []string {
 	return d.bad
 }
 func (d *testDiscovery) GoodPeers() []AddressWithCapabilities { return []AddressWithCapabilities{} }
+func (d *testDiscovery) AddStaticPeer(string)                 {}
+func (d *testDiscovery) RemovePeer(string) bool               { return false }
+func (d *testDiscovery) StaticPeers() []string                { return nil }
+func (d *testDiscovery) Ban(string) error                     { return nil }
+func (d *testDiscovery) BanUntil(string, time.Time) error     { return nil }
+func (d *testDiscovery) TemporaryBans() map[string]time.Time  { return nil }
+func (d *testDiscovery) Unban(string) error                   { return nil }
+func (d *testDiscovery) Banned() []string                     { return nil }
+func (d *testDiscovery) Allow(string) error                   { return nil }
+func (d *testDiscovery) IsAllowed(string) bool                { return true }
 
 var defaultMessageHandler = func(t *testing.T, msg *Message) {}
 
@@ -0,0 +1,184 @@
+package network
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"time"
+)
+
+// errUnknownIPRule is returned when trying to remove a rule that is not
+// present in the filter.
+var errUnknownIPRule = errors.New("unknown IP rule")
+
+// ipFilter is a set of IP address allow and deny rules, every rule is either
+// a single IP address or a CIDR network. Deny rules always take precedence;
+// if there are any allow rules, only the matching addresses are accepted.
+// Deny rules can be temporary, expired ones are ignored. It's not safe for
+// concurrent use.
+type ipFilter struct {
+	deny  map[string]denyRule
+	allow map[string]*net.IPNet
+}
+
+// denyRule is a deny rule valid until the specified time (zero for permanent
+// rules).
+type denyRule struct {
+	net   *net.IPNet
+	until time.Time
+}
+
+func newIPFilter() *ipFilter {
+	return &ipFilter{
+		deny:  make(map[string]denyRule),
+		allow: make(map[string]*net.IPNet),
+	}
+}
+
+// active checks whether the rule is not expired yet.
+func (r denyRule) active(now time.Time) bool {
+	return r.until.IsZero() || now.Before(r.until)
+}
+
+// parseIPRule parses the given IP address or CIDR network and returns its
+// canonical string representation along with the network it covers.
+func parseIPRule(s string) (string, *net.IPNet, error) {
+	if strings.Contains(s, "/") {
+		_, n, err := net.ParseCIDR(s)
+		if err != nil {
+			return "", nil, err
+		}
+		return n.String(), n, nil
+	}
+	ip := net.ParseIP(s)
+	if ip == nil {
+		return "", nil, fmt.Errorf("invalid IP address or CIDR network: %s", s)
+	}
+	var bits = 8 * net.IPv6len
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+		bits = 8 * net.IPv4len
+	}
+	return ip.String(), &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}, nil
+}
+
+// Deny adds the given IP address or CIDR network to the deny rules.
+func (f *ipFilter) Deny(s string) error {
+	key, n, err := parseIPRule(s)
+	if err != nil {
+		return err
+	}
+	f.deny[key] = denyRule{net: n}
+	return nil
+}
+
+// DenyUntil adds the given IP address or CIDR network to the deny rules
+// until the specified time. Permanent rules are not affected by it. Expired
+// rules are dropped.
+func (f *ipFilter) DenyUntil(s string, until time.Time) error {
+	key, n, err := parseIPRule(s)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	for k, r := range f.deny {
+		if !r.active(now) {
+			delete(f.deny, k)
+		}
+	}
+	if r, ok := f.deny[key]; ok && (r.until.IsZero() || r.until.After(until)) {
+		return nil
+	}
+	f.deny[key] = denyRule{net: n, until: until}
+	return nil
+}
+
+// Undeny removes the given IP address or CIDR network from the deny rules.
+func (f *ipFilter) Undeny(s string) error {
+	key, _, err := parseIPRule(s)
+	if err != nil {
+		return err
+	}
+	if _, ok := f.deny[key]; !ok {
+		return fmt.Errorf("%w: %s", errUnknownIPRule, key)
+	}
+	delete(f.deny, key)
+	return nil
+}
+
+// Allow adds the given IP address or CIDR network to the allow rules.
+func (f *ipFilter) Allow(s string) error {
+	key, n, err := parseIPRule(s)
+	if err != nil {
+		return err
+	}
+	f.allow[key] = n
+	return nil
+}
+
+// Denied returns a sorted list of active deny rules.
+func (f *ipFilter) Denied() []string {
+	var (
+		now = time.Now()
+		res = make([]string, 0, len(f.deny))
+	)
+	for k, r := range f.deny {
+		if r.active(now) {
+			res = append(res, k)
+		}
+	}
+	sort.Strings(res)
+	return res
+}
+
+// TemporarilyDenied returns active temporary deny rules with their
+// expiration time.
+func (f *ipFilter) TemporarilyDenied() map[string]time.Time {
+	var (
+		now = time.Now()
+		res = make(map[string]time.Time)
+	)
+	for k, r := range f.deny {
+		if !r.until.IsZero() && r.active(now) {
+			res[k] = r.until
+		}
+	}
+	return res
+}
+
+// IsAllowedIP checks whether the given IP address passes the filter.
+func (f *ipFilter) IsAllowedIP(ip net.IP) bool {
+	var now = time.Now()
+	for _, r := range f.deny {
+		if r.active(now) && r.net.Contains(ip) {
+			return false
+		}
+	}
+	if len(f.allow) == 0 {
+		return true
+	}
+	for _, n := range f.allow {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsAllowed checks whether the given "host:port" (or just "host") address
+// passes the filter. Host names can't be checked without resolving them, so
+// they're always allowed, the real IP address is checked after the
+// connection is established.
+func (f *ipFilter) IsAllowed(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		host = addr
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return true
+	}
+	return f.IsAllowedIP(ip)
+}
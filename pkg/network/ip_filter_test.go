@@ -0,0 +1,44 @@
+package network
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIPFilter(t *testing.T) {
+	f := newIPFilter()
+	require.True(t, f.IsAllowed("10.0.0.1:20333"))
+
+	require.Error(t, f.Deny("not an IP"))
+	require.Error(t, f.Deny("10.0.0.0/33"))
+	require.NoError(t, f.Deny("10.0.0.0/8"))
+	require.NoError(t, f.Deny("::ffff:192.168.0.1"))
+	require.Equal(t, []string{"10.0.0.0/8", "192.168.0.1"}, f.Denied())
+
+	require.False(t, f.IsAllowed("10.1.2.3:20333"))
+	require.False(t, f.IsAllowed("10.1.2.3"))
+	require.False(t, f.IsAllowed("192.168.0.1:20333"))
+	require.False(t, f.IsAllowedIP(net.ParseIP("::ffff:10.0.0.1")))
+	require.True(t, f.IsAllowed("192.168.0.2:20333"))
+	require.True(t, f.IsAllowed("example.com:20333"))
+
+	t.Run("allowlist", func(t *testing.T) {
+		f := newIPFilter()
+		require.NoError(t, f.Allow("172.16.0.0/12"))
+		require.NoError(t, f.Allow("2001:db8::1"))
+		require.NoError(t, f.Deny("172.16.0.1"))
+
+		require.True(t, f.IsAllowed("172.16.0.2:20333"))
+		require.True(t, f.IsAllowed("[2001:db8::1]:20333"))
+		require.False(t, f.IsAllowed("[2001:db8::2]:20333"))
+		require.False(t, f.IsAllowed("172.16.0.1:20333"))
+		require.False(t, f.IsAllowed("8.8.8.8:20333"))
+	})
+
+	require.ErrorIs(t, f.Undeny("10.0.0.1"), errUnknownIPRule)
+	require.Error(t, f.Undeny("bad"))
+	require.NoError(t, f.Undeny("10.0.0.0/8"))
+	require.True(t, f.IsAllowed("10.1.2.3:20333"))
+}
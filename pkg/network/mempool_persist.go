@@ -0,0 +1,154 @@
+package network
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/network/payload"
+	"go.uber.org/zap"
+)
+
+// mempoolDumpVersion is the version of memory pool dump file format.
+const mempoolDumpVersion = 0
+
+// mempoolDump is the contents of memory pool dump file.
+type mempoolDump struct {
+	Magic        uint32
+	Transactions []*transaction.Transaction
+	Requests     []*payload.P2PNotaryRequest
+}
+
+// EncodeBinary implements io.Serializable interface.
+func (d *mempoolDump) EncodeBinary(w *io.BinWriter) {
+	w.WriteB(mempoolDumpVersion)
+	w.WriteU32LE(d.Magic)
+	w.WriteArray(d.Transactions)
+	w.WriteArray(d.Requests)
+}
+
+// DecodeBinary implements io.Serializable interface.
+func (d *mempoolDump) DecodeBinary(r *io.BinReader) {
+	if v := r.ReadB(); r.Err == nil && v != mempoolDumpVersion {
+		r.Err = fmt.Errorf("unsupported dump version %d", v)
+		return
+	}
+	d.Magic = r.ReadU32LE()
+	r.ReadArray(&d.Transactions)
+	r.ReadArray(&d.Requests)
+}
+
+// dumpMemPools saves verified transactions and P2P notary requests to the
+// configured file. The file is replaced atomically, so a crash during dump
+// doesn't corrupt the previous one.
+func (s *Server) dumpMemPools() error {
+	s.persistLock.Lock()
+	defer s.persistLock.Unlock()
+
+	var d = mempoolDump{
+		Magic:        uint32(s.Net),
+		Transactions: s.mempool.GetVerifiedTransactions(),
+	}
+	if s.chain.P2PSigExtensionsEnabled() {
+		for _, fb := range s.notaryRequestPool.GetVerifiedTransactions() {
+			if r, ok := s.notaryRequestPool.TryGetData(fb.Hash()); ok {
+				d.Requests = append(d.Requests, r.(*payload.P2PNotaryRequest))
+			}
+		}
+	}
+	bw := io.NewBufBinWriter()
+	d.EncodeBinary(bw.BinWriter)
+	if bw.Err != nil {
+		return bw.Err
+	}
+	var (
+		path = s.MempoolCfg.PersistFile
+		tmp  = path + ".tmp"
+	)
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return err
+	}
+	if err := os.WriteFile(tmp, bw.Bytes(), 0o644); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return err
+	}
+	s.log.Debug("memory pools saved",
+		zap.Int("transactions", len(d.Transactions)),
+		zap.Int("notary requests", len(d.Requests)))
+	return nil
+}
+
+// restoreMemPools reads transactions and P2P notary requests saved by
+// dumpMemPools and adds them back to memory pools. Every entry is verified
+// against the current chain state the same way as the one received from
+// the network, invalid ones are dropped.
+func (s *Server) restoreMemPools() error {
+	data, err := os.ReadFile(s.MempoolCfg.PersistFile)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	var (
+		d  mempoolDump
+		br = io.NewBinReaderFromBuf(data)
+	)
+	d.DecodeBinary(br)
+	if br.Err != nil {
+		return fmt.Errorf("invalid dump: %w", br.Err)
+	}
+	if d.Magic != uint32(s.Net) {
+		return fmt.Errorf("dump is made for a different network %d", d.Magic)
+	}
+	var txs, reqs int
+	for _, tx := range d.Transactions {
+		if err := s.verifyAndPoolTX(tx); err != nil {
+			s.log.Debug("saved transaction is not restored",
+				zap.Stringer("hash", tx.Hash()), zap.Error(err))
+			continue
+		}
+		txs++
+	}
+	if s.chain.P2PSigExtensionsEnabled() {
+		for _, r := range d.Requests {
+			if err := s.verifyAndPoolNotaryRequest(r); err != nil {
+				s.log.Debug("saved notary request is not restored",
+					zap.Stringer("main", r.MainTransaction.Hash()),
+					zap.Stringer("fallback", r.FallbackTransaction.Hash()),
+					zap.Error(err))
+				continue
+			}
+			reqs++
+		}
+	}
+	s.log.Info("memory pools restored",
+		zap.Int("transactions", txs),
+		zap.Int("dropped transactions", len(d.Transactions)-txs),
+		zap.Int("notary requests", reqs),
+		zap.Int("dropped notary requests", len(d.Requests)-reqs))
+	return nil
+}
+
+// persistMemPoolsLoop periodically saves memory pools until the server is
+// stopped.
+func (s *Server) persistMemPoolsLoop() {
+	t := time.NewTicker(s.MempoolCfg.PersistInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-s.quit:
+			return
+		case <-t.C:
+			if err := s.dumpMemPools(); err != nil {
+				s.log.Warn("failed to save memory pools", zap.Error(err))
+			}
+		}
+	}
+}
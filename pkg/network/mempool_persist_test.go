@@ -0,0 +1,125 @@
+package network
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/internal/fakechain"
+	"github.com/nspcc-dev/neo-go/internal/random"
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/core/mempool"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/network/payload"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemPoolsPersistence(t *testing.T) {
+	var (
+		file   = filepath.Join(t.TempDir(), "mempool", "dump.bin")
+		srvCfg = ServerConfig{
+			UserAgent:  "/test/",
+			Net:        42,
+			MempoolCfg: config.Mempool{PersistFile: file},
+		}
+	)
+	newServer := func(t *testing.T) *Server {
+		s := newTestServer(t, srvCfg)
+		bc := s.chain.(*fakechain.FakeChain)
+		bc.UtilityTokenBalance = big.NewInt(1_0000_0000)
+		bc.PoolTxF = func(tx *transaction.Transaction) error {
+			return bc.Pool.Add(tx, bc)
+		}
+		bc.PoolTxWithDataF = func(tx *transaction.Transaction, data any, mp *mempool.Pool) error {
+			return mp.Add(tx, bc, data)
+		}
+		return s
+	}
+
+	s := newServer(t)
+	// Nothing to restore.
+	require.NoError(t, s.restoreMemPools())
+
+	tx1, tx2 := newDummyTx(), newDummyTx()
+	require.NoError(t, s.mempool.Add(tx1, s.chain))
+	require.NoError(t, s.mempool.Add(tx2, s.chain))
+
+	mainTx := transaction.New(random.Bytes(100), 123)
+	mainTx.Signers = []transaction.Signer{{Account: random.Uint160()}}
+	mainTx.Scripts = []transaction.Witness{{}}
+	mainTx.Attributes = []transaction.Attribute{{Type: transaction.NotaryAssistedT, Value: &transaction.NotaryAssisted{NKeys: 1}}}
+	mainTx.ValidUntilBlock = 123
+	fallbackTx := transaction.New(random.Bytes(100), 123)
+	fallbackTx.ValidUntilBlock = 123
+	fallbackTx.Signers = []transaction.Signer{{Account: random.Uint160()}, {Account: random.Uint160()}}
+	fallbackTx.Scripts = []transaction.Witness{{InvocationScript: append([]byte{byte(opcode.PUSHDATA1), keys.SignatureLen}, make([]byte, keys.SignatureLen)...)}, {}}
+	fallbackTx.Attributes = []transaction.Attribute{
+		{Type: transaction.NotValidBeforeT, Value: &transaction.NotValidBefore{Height: 1}},
+		{Type: transaction.ConflictsT, Value: &transaction.Conflicts{Hash: mainTx.Hash()}},
+		{Type: transaction.NotaryAssistedT, Value: &transaction.NotaryAssisted{NKeys: 0}},
+	}
+	r := &payload.P2PNotaryRequest{
+		MainTransaction:     mainTx,
+		FallbackTransaction: fallbackTx,
+		Witness: transaction.Witness{
+			InvocationScript:   []byte{1, 2, 3},
+			VerificationScript: []byte{1, 2, 3},
+		},
+	}
+	require.NoError(t, s.notaryRequestPool.Add(r.FallbackTransaction, s.chain, r))
+	require.NoError(t, s.dumpMemPools())
+	require.NoFileExists(t, file+".tmp")
+
+	t.Run("restore", func(t *testing.T) {
+		s := newServer(t)
+		bc := s.chain.(*fakechain.FakeChain)
+		// One transaction is not valid anymore.
+		bc.PoolTxF = func(tx *transaction.Transaction) error {
+			if tx.Hash() == tx2.Hash() {
+				return mempool.ErrOOM
+			}
+			return bc.Pool.Add(tx, bc)
+		}
+		require.NoError(t, s.restoreMemPools())
+		require.Equal(t, 1, s.mempool.Count())
+		require.True(t, s.mempool.ContainsKey(tx1.Hash()))
+
+		require.Equal(t, 1, s.notaryRequestPool.Count())
+		data, ok := s.notaryRequestPool.TryGetData(fallbackTx.Hash())
+		require.True(t, ok)
+		actual := data.(*payload.P2PNotaryRequest)
+		require.Equal(t, r.MainTransaction.Hash(), actual.MainTransaction.Hash())
+		require.Equal(t, r.Witness, actual.Witness)
+	})
+	t.Run("on start and shutdown", func(t *testing.T) {
+		s := newServer(t)
+		s.MempoolCfg.PersistInterval = time.Millisecond * 10
+		startWithCleanup(t, s)
+		require.Eventually(t, func() bool { return s.mempool.Count() == 2 }, time.Second, time.Millisecond*10)
+		require.Equal(t, 1, s.notaryRequestPool.Count())
+	})
+	t.Run("different network", func(t *testing.T) {
+		s := newServer(t)
+		s.Net++
+		require.Error(t, s.restoreMemPools())
+		require.Equal(t, 0, s.mempool.Count())
+	})
+	t.Run("corrupted", func(t *testing.T) {
+		data, err := os.ReadFile(file)
+		require.NoError(t, err)
+
+		s := newServer(t)
+		s.MempoolCfg.PersistFile = filepath.Join(t.TempDir(), "bad.bin")
+		require.NoError(t, os.WriteFile(s.MempoolCfg.PersistFile, data[:len(data)/2], os.ModePerm))
+		require.Error(t, s.restoreMemPools())
+
+		data[0] = mempoolDumpVersion + 1
+		require.NoError(t, os.WriteFile(s.MempoolCfg.PersistFile, data, os.ModePerm))
+		require.Error(t, s.restoreMemPools())
+		require.Equal(t, 0, s.mempool.Count())
+	})
+}
@@ -0,0 +1,302 @@
+package network
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/network/bqueue"
+	"go.uber.org/zap"
+)
+
+// Peer score changes. Scores are kept per peer address (IP and announced
+// port), so reconnecting doesn't reset them and peers sharing an IP address
+// (like the ones behind NAT) don't share their scores. Every peer starts with
+// zero and the score can't exceed maxPeerScore.
+const (
+	maxPeerScore = 100
+
+	scoreUsefulData        = 1
+	scoreSlowResponse      = -5
+	scoreInvalidExtensible = -20
+	scoreProtocolViolation = -25
+	scoreInvalidBlock      = -50
+
+	defaultBanScore    = -100
+	defaultBanDuration = 24 * time.Hour
+)
+
+// Score change reasons used for logging and metrics.
+const (
+	reasonUsefulData        = "useful data"
+	reasonSlowResponse      = "slow response"
+	reasonInvalidExtensible = "invalid extensible"
+	reasonProtocolViolation = "protocol violation"
+	reasonInvalidBlock      = "invalid block"
+	reasonInvalidHeaders    = "invalid headers"
+)
+
+var (
+	errLowScore   = errors.New("peer score is too low")
+	errPeerBanned = errors.New("peer is banned")
+)
+
+// peerScores keeps peer scores along with the data needed to calculate them.
+// It's safe for concurrent use.
+type peerScores struct {
+	lock sync.Mutex
+	// scores are kept by peer address.
+	scores map[string]int32
+	// senders contains the address of the first peer that sent the block
+	// with the given index.
+	senders map[uint32]string
+}
+
+func newPeerScores() *peerScores {
+	return &peerScores{
+		scores:  make(map[string]int32),
+		senders: make(map[uint32]string),
+	}
+}
+
+// add changes the score of the given peer address by delta and returns the
+// new value.
+func (ps *peerScores) add(addr string, delta int32) int32 {
+	ps.lock.Lock()
+	defer ps.lock.Unlock()
+	score := ps.scores[addr] + delta
+	if score > maxPeerScore {
+		score = maxPeerScore
+	}
+	ps.scores[addr] = score
+	return score
+}
+
+// get returns the score of the given peer address.
+func (ps *peerScores) get(addr string) int32 {
+	ps.lock.Lock()
+	defer ps.lock.Unlock()
+	return ps.scores[addr]
+}
+
+// reset drops the score of the given peer address.
+func (ps *peerScores) reset(addr string) {
+	ps.lock.Lock()
+	defer ps.lock.Unlock()
+	delete(ps.scores, addr)
+}
+
+// forget drops the score of the disconnected peer. Negative scores are kept
+// to survive reconnections, positive ones are dropped unless there are other
+// peers with the same address.
+func (ps *peerScores) forget(p Peer, addrConnected bool) {
+	ps.lock.Lock()
+	defer ps.lock.Unlock()
+	addr := peerAddr(p)
+	if !addrConnected && ps.scores[addr] >= 0 {
+		delete(ps.scores, addr)
+	}
+}
+
+// setSender remembers the address of the block sender if there is no
+// sender for this block index yet. Entries for blocks that are not expected
+// to be processed anymore (at or below the given height) are dropped when
+// there are too many of them.
+func (ps *peerScores) setSender(index uint32, addr string, height uint32) {
+	ps.lock.Lock()
+	defer ps.lock.Unlock()
+	if len(ps.senders) >= bqueue.CacheSize {
+		for i := range ps.senders {
+			if i <= height {
+				delete(ps.senders, i)
+			}
+		}
+	}
+	if _, ok := ps.senders[index]; !ok {
+		ps.senders[index] = addr
+	}
+}
+
+// popSender returns and forgets the address of the block sender.
+func (ps *peerScores) popSender(index uint32) (string, bool) {
+	ps.lock.Lock()
+	defer ps.lock.Unlock()
+	addr, ok := ps.senders[index]
+	delete(ps.senders, index)
+	return addr, ok
+}
+
+// peerAddr returns the address of the peer (IP and announced port) as a
+// string, it's used as the peer score key.
+func peerAddr(p Peer) string {
+	return p.PeerAddr().String()
+}
+
+// PeerScore returns the score of the peer with the given address (as
+// returned by ConnectedPeers).
+func (s *Server) PeerScore(addr string) int32 {
+	return s.scores.get(addr)
+}
+
+// updatePeerScore changes the score of the peer by delta.
+func (s *Server) updatePeerScore(p Peer, delta int32, reason string) {
+	s.updateScore(peerAddr(p), delta, reason)
+}
+
+// updateScore changes the score of all peers with the given address by
+// delta. If the score drops below the half of BanScore, peers are
+// disconnected and if it drops below BanScore, their IP address is banned for
+// BanDuration. Other peers with the same IP address are not disconnected,
+// but they can't reconnect until the ban expires.
+func (s *Server) updateScore(addr string, delta int32, reason string) {
+	score := s.scores.add(addr, delta)
+	updatePeerScoreMetric(addr, score)
+	if delta >= 0 {
+		return
+	}
+	addPeerPenaltyMetric(reason)
+	s.log.Debug("peer penalized",
+		zap.String("addr", addr),
+		zap.String("reason", reason),
+		zap.Int32("score", score))
+
+	var dropErr error
+	switch {
+	case score <= int32(s.BanScore):
+		ip, _, err := net.SplitHostPort(addr)
+		if err != nil {
+			ip = addr
+		}
+		until := time.Now().Add(s.BanDuration)
+		if err := s.discovery.BanUntil(ip, until); err != nil {
+			s.log.Warn("failed to ban peer", zap.String("ip", ip), zap.Error(err))
+			return
+		}
+		s.scores.reset(addr)
+		updatePeerScoreMetric(addr, 0)
+		addPeerBanMetric()
+		s.log.Info("peer banned",
+			zap.String("addr", addr),
+			zap.String("reason", reason),
+			zap.Time("until", until))
+		if len(s.BansFile) != 0 {
+			if err := s.saveBans(); err != nil {
+				s.log.Warn("failed to save bans", zap.Error(err))
+			}
+		}
+		dropErr = fmt.Errorf("%w: %s", errPeerBanned, reason)
+	case score <= int32(s.BanScore/2):
+		dropErr = fmt.Errorf("%w: %s", errLowScore, reason)
+	default:
+		return
+	}
+	for _, p := range s.getPeers(func(p Peer) bool { return peerAddr(p) == addr }) {
+		go p.Disconnect(dropErr)
+	}
+}
+
+// forgetPeerScore drops the score data of the disconnected peer.
+func (s *Server) forgetPeerScore(p Peer) {
+	addr := peerAddr(p)
+	s.scores.forget(p, len(s.getPeers(func(other Peer) bool { return peerAddr(other) == addr })) != 0)
+	if s.scores.get(addr) == 0 {
+		deletePeerScoreMetric(addr)
+	}
+}
+
+// sortPeersByScore sorts the given peers by their scores in descending order
+// and returns the number of peers with non-negative score.
+func (s *Server) sortPeersByScore(peers []Peer) int {
+	var (
+		good   int
+		scores = make(map[Peer]int32, len(peers))
+	)
+	for _, p := range peers {
+		sc := s.scores.get(peerAddr(p))
+		scores[p] = sc
+		if sc >= 0 {
+			good++
+		}
+	}
+	sort.SliceStable(peers, func(i, j int) bool {
+		return scores[peers[i]] > scores[peers[j]]
+	})
+	return good
+}
+
+// hasBetterPeer checks whether there is a handshaked peer with a non-negative
+// score having the block with the given height.
+func (s *Server) hasBetterPeer(height uint32) bool {
+	return len(s.getPeers(func(p Peer) bool {
+		return p.Handshaked() && p.LastBlockIndex() >= height && s.scores.get(peerAddr(p)) >= 0
+	})) != 0
+}
+
+// blockAdded rewards the peer that sent the block added to the chain.
+func (s *Server) blockAdded(b *block.Block) {
+	if addr, ok := s.scores.popSender(b.Index); ok {
+		s.updateScore(addr, scoreUsefulData, reasonUsefulData)
+	}
+}
+
+// blockFailed penalizes the peer that sent the block rejected by the chain.
+func (s *Server) blockFailed(b *block.Block, _ error) {
+	if addr, ok := s.scores.popSender(b.Index); ok {
+		s.updateScore(addr, scoreInvalidBlock, reasonInvalidBlock)
+	}
+}
+
+// saveBans saves temporary bans to the BansFile.
+func (s *Server) saveBans() error {
+	s.bansLock.Lock()
+	defer s.bansLock.Unlock()
+
+	data, err := json.Marshal(s.discovery.TemporaryBans())
+	if err != nil {
+		return err
+	}
+	var (
+		path = s.BansFile
+		tmp  = path + ".tmp"
+	)
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return err
+	}
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// restoreBans reads temporary bans saved by saveBans and applies the ones
+// that are not expired yet.
+func (s *Server) restoreBans() error {
+	data, err := os.ReadFile(s.BansFile)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	var bans map[string]time.Time
+	if err := json.Unmarshal(data, &bans); err != nil {
+		return fmt.Errorf("invalid bans file: %w", err)
+	}
+	var now = time.Now()
+	for rule, until := range bans {
+		if !until.After(now) {
+			continue
+		}
+		if err := s.discovery.BanUntil(rule, until); err != nil {
+			return fmt.Errorf("invalid ban %q: %w", rule, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,176 @@
+package network
+
+import (
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/internal/fakechain"
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestPeerScores(t *testing.T) {
+	ps := newPeerScores()
+	require.Equal(t, int32(-5), ps.add("1.1.1.1", -5))
+	require.Equal(t, int32(maxPeerScore), ps.add("2.2.2.2", maxPeerScore+10))
+	require.Equal(t, int32(-5), ps.get("1.1.1.1"))
+	ps.reset("1.1.1.1")
+	require.Equal(t, int32(0), ps.get("1.1.1.1"))
+
+	ps.setSender(5, "1.1.1.1", 0)
+	ps.setSender(5, "2.2.2.2", 0)
+	ip, ok := ps.popSender(5)
+	require.True(t, ok)
+	require.Equal(t, "1.1.1.1", ip)
+	_, ok = ps.popSender(5)
+	require.False(t, ok)
+
+	// Negative scores survive disconnection, positive ones don't.
+	p := newLocalPeer(t, nil)
+	ps.add(peerAddr(p), 10)
+	ps.forget(p, true)
+	require.Equal(t, int32(10), ps.get(peerAddr(p)))
+	ps.forget(p, false)
+	require.Equal(t, int32(0), ps.get(peerAddr(p)))
+	ps.add(peerAddr(p), -10)
+	ps.forget(p, false)
+	require.Equal(t, int32(-10), ps.get(peerAddr(p)))
+}
+
+func TestServerPeerScoring(t *testing.T) {
+	bansFile := filepath.Join(t.TempDir(), "bans.json")
+	newServer := func(t *testing.T) *Server {
+		cfg := ServerConfig{
+			Addresses:    []config.AnnounceableAddress{{Address: ":0"}},
+			BansFile:     bansFile,
+			BanDuration:  time.Hour,
+			TimePerBlock: time.Millisecond,
+		}
+		s, err := newServerFromConstructors(cfg, fakechain.NewFakeChain(), new(fakechain.FakeStateSync), zaptest.NewLogger(t),
+			newFakeTransp, newDefaultDiscovery)
+		require.NoError(t, err)
+		require.Equal(t, defaultBanScore, s.BanScore)
+		return s
+	}
+	s := newServer(t)
+	startWithCleanup(t, s)
+	var (
+		ip   = "0.0.0.0"
+		addr = ip + ":0"
+	)
+
+	connect := func(t *testing.T) *localPeer {
+		p := newLocalPeer(t, s)
+		p.handshaked = 1
+		s.register <- p
+		require.Eventually(t, func() bool { return s.PeerCount() == 1 }, time.Second, time.Millisecond*10)
+		return p
+	}
+
+	p := connect(t)
+	s.updatePeerScore(p, scoreUsefulData, reasonUsefulData)
+	require.Equal(t, int32(scoreUsefulData), s.PeerScore(addr))
+
+	// Slow response.
+	chain := s.chain.(*fakechain.FakeChain)
+	atomic.StoreUint32(&chain.Headerheight, 10)
+	p.lastBlockIndex = 10
+	require.NoError(t, s.requestBlocks(s.chain, p))
+	time.Sleep(2 * s.TimePerBlock)
+	require.NoError(t, s.requestBlocks(s.chain, p))
+	require.Equal(t, int32(scoreUsefulData+scoreSlowResponse), s.PeerScore(addr))
+
+	// Useful and invalid blocks.
+	blocks := make([]*block.Block, 8)
+	for i := range blocks {
+		blocks[i] = &block.Block{Header: block.Header{Index: uint32(i)}}
+		chain.PutHeader(blocks[i])
+	}
+	require.NoError(t, s.handleBlockCmd(p, blocks[5]))
+	s.blockAdded(blocks[5])
+	require.Equal(t, int32(2*scoreUsefulData+scoreSlowResponse), s.PeerScore(addr))
+	s.blockAdded(blocks[5]) // No sender anymore.
+	require.Equal(t, int32(2*scoreUsefulData+scoreSlowResponse), s.PeerScore(addr))
+	require.NoError(t, s.handleBlockCmd(p, blocks[6]))
+	s.blockFailed(blocks[6], nil)
+
+	// Score is below the disconnection threshold now.
+	require.Eventually(t, func() bool { return s.PeerCount() == 0 }, time.Second, time.Millisecond*10)
+	require.ErrorIs(t, p.droppedWith.Load().(error), errLowScore)
+	require.Equal(t, int32(2*scoreUsefulData+scoreSlowResponse+scoreInvalidBlock), s.PeerScore(addr))
+
+	// Reconnection doesn't help, another invalid block leads to ban.
+	p = connect(t)
+	require.NoError(t, s.handleBlockCmd(p, blocks[7]))
+	s.blockFailed(blocks[7], nil)
+	require.Eventually(t, func() bool { return s.PeerCount() == 0 }, time.Second, time.Millisecond*10)
+	require.ErrorIs(t, p.droppedWith.Load().(error), errPeerBanned)
+	require.Equal(t, int32(0), s.PeerScore(addr))
+	require.Equal(t, []string{ip}, s.BannedPeers())
+	require.FileExists(t, bansFile)
+
+	t.Run("restore", func(t *testing.T) {
+		s := newServer(t)
+		require.Equal(t, []string{ip}, s.BannedPeers())
+		require.NoError(t, s.UnbanPeer(ip))
+		require.Equal(t, 0, len(s.BannedPeers()))
+
+		s = newServer(t)
+		require.Equal(t, 0, len(s.BannedPeers()))
+	})
+}
+
+func TestSharedIPPeerScores(t *testing.T) {
+	cfg := ServerConfig{
+		Addresses:   []config.AnnounceableAddress{{Address: ":0"}},
+		BanDuration: time.Hour,
+	}
+	s, err := newServerFromConstructors(cfg, fakechain.NewFakeChain(), new(fakechain.FakeStateSync), zaptest.NewLogger(t),
+		newFakeTransp, newDefaultDiscovery)
+	require.NoError(t, err)
+	startWithCleanup(t, s)
+	peers := make([]*localPeer, 2)
+	for i := range peers {
+		p := newLocalPeer(t, s)
+		p.netaddr.IP = []byte{1, 1, 1, 1}
+		p.netaddr.Port = 20333 + i
+		p.handshaked = 1
+		s.register <- p
+		peers[i] = p
+	}
+	require.Eventually(t, func() bool { return s.PeerCount() == 2 }, time.Second, time.Millisecond*10)
+
+	s.updatePeerScore(peers[1], scoreUsefulData, reasonUsefulData)
+	s.updatePeerScore(peers[0], scoreInvalidBlock, reasonInvalidBlock)
+	require.Eventually(t, func() bool { return s.PeerCount() == 1 }, time.Second, time.Millisecond*10)
+	require.ErrorIs(t, peers[0].droppedWith.Load().(error), errLowScore)
+	require.Equal(t, int32(scoreInvalidBlock), s.PeerScore("1.1.1.1:20333"))
+	require.Equal(t, int32(scoreUsefulData), s.PeerScore("1.1.1.1:20334"))
+
+	// The ban is IP-wide, but it doesn't drop other connected peers.
+	s.updatePeerScore(peers[0], scoreInvalidBlock, reasonInvalidBlock)
+	require.Equal(t, []string{"1.1.1.1"}, s.BannedPeers())
+	require.Never(t, func() bool { return s.PeerCount() != 1 }, 100*time.Millisecond, time.Millisecond*10)
+	require.Nil(t, peers[1].droppedWith.Load())
+	require.Equal(t, int32(scoreUsefulData), s.PeerScore("1.1.1.1:20334"))
+}
+
+func TestSortPeersByScore(t *testing.T) {
+	s := newTestServer(t, ServerConfig{})
+	peers := make([]Peer, 3)
+	for i := range peers {
+		p := newLocalPeer(t, s)
+		p.netaddr.IP = []byte{1, 1, 1, byte(i)}
+		peers[i] = p
+	}
+	s.scores.add(peerAddr(peers[0]), -10)
+	s.scores.add(peerAddr(peers[2]), 10)
+	require.Equal(t, 2, s.sortPeersByScore(peers))
+	require.Equal(t, "1.1.1.2:0", peerAddr(peers[0]))
+	require.Equal(t, "1.1.1.1:0", peerAddr(peers[1]))
+	require.Equal(t, "1.1.1.0:0", peerAddr(peers[2]))
+}
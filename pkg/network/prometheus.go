@@ -68,6 +68,64 @@ var (
 	)
 	p2pCmds = make(map[CommandType]prometheus.Histogram)
 
+	peerScore = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Help:      "Peer score by address",
+			Name:      "peer_score",
+			Namespace: "neogo",
+		},
+		[]string{"address"},
+	)
+
+	peerPenalties = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Help:      "Number of peer penalties by reason",
+			Name:      "peer_penalties_total",
+			Namespace: "neogo",
+		},
+		[]string{"reason"},
+	)
+
+	peerBans = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Help:      "Number of temporary peer bans",
+			Name:      "peer_bans_total",
+			Namespace: "neogo",
+		},
+	)
+
+	syncBlocksReceived = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Help:      "Number of blocks received in response to sync requests",
+			Name:      "sync_blocks_received_total",
+			Namespace: "neogo",
+		},
+	)
+
+	syncThroughput = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Help:      "Block sync throughput (blocks per second)",
+			Name:      "sync_throughput",
+			Namespace: "neogo",
+		},
+	)
+
+	syncRequestsInFlight = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Help:      "Number of block range requests in flight",
+			Name:      "sync_block_requests_in_flight",
+			Namespace: "neogo",
+		},
+	)
+
+	syncTimeouts = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Help:      "Number of block range and header requests not answered in time",
+			Name:      "sync_request_timeouts_total",
+			Namespace: "neogo",
+		},
+	)
+
 	// notarypoolUnsortedTx prometheus metric.
 	notarypoolUnsortedTx = prometheus.NewGauge(
 		prometheus.GaugeOpts{
@@ -88,6 +146,13 @@ func init() {
 		poolCount,
 		blockQueueLength,
 		notarypoolUnsortedTx,
+		peerScore,
+		peerPenalties,
+		peerBans,
+		syncBlocksReceived,
+		syncThroughput,
+		syncRequestsInFlight,
+		syncTimeouts,
 	)
 	for _, cmd := range []CommandType{CMDVersion, CMDVerack, CMDGetAddr,
 		CMDAddr, CMDPing, CMDPong, CMDGetHeaders, CMDHeaders, CMDGetBlocks,
@@ -149,3 +214,35 @@ func addCmdTimeMetric(cmd CommandType, t time.Duration) {
 func updateNotarypoolMetrics(unsortedTxnLen int) {
 	notarypoolUnsortedTx.Set(float64(unsortedTxnLen))
 }
+
+func updatePeerScoreMetric(addr string, score int32) {
+	peerScore.WithLabelValues(addr).Set(float64(score))
+}
+
+func deletePeerScoreMetric(addr string) {
+	peerScore.DeleteLabelValues(addr)
+}
+
+func addPeerPenaltyMetric(reason string) {
+	peerPenalties.WithLabelValues(reason).Inc()
+}
+
+func addPeerBanMetric() {
+	peerBans.Inc()
+}
+
+func addSyncBlocksMetric() {
+	syncBlocksReceived.Inc()
+}
+
+func updateSyncThroughputMetric(rate float64) {
+	syncThroughput.Set(rate)
+}
+
+func updateSyncRequestsMetric(n int) {
+	syncRequestsInFlight.Set(float64(n))
+}
+
+func addSyncTimeoutMetric() {
+	syncTimeouts.Inc()
+}
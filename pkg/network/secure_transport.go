@@ -0,0 +1,341 @@
+package network
+
+import (
+	"bufio"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"go.uber.org/zap"
+)
+
+const (
+	// tlsRecordHandshake is the first byte of any TLS connection (handshake
+	// record type). It never starts a plain P2P message, these start with
+	// the message flags byte (0 or 1).
+	tlsRecordHandshake = 0x16
+
+	// defaultSecureHandshakeTimeout is the time allowed for protocol
+	// negotiation and TLS handshake if DialTimeout is not set.
+	defaultSecureHandshakeTimeout = 5 * time.Second
+
+	// plainPeerRetryInterval is the interval after which encryption is tried
+	// again for the peers that didn't support it.
+	plainPeerRetryInterval = time.Hour
+)
+
+var (
+	errUntrustedPeer      = errors.New("untrusted peer key")
+	errEncryptionRequired = errors.New("peer doesn't support encryption")
+)
+
+// transportSecurity contains node key and TLS configuration shared by all
+// secure transports of the node.
+type transportSecurity struct {
+	key      *keys.PrivateKey
+	trusted  keys.PublicKeys
+	required bool
+	tls      *tls.Config
+}
+
+// newTransportSecurity creates transportSecurity from the given
+// configuration. Encryption is always required if trusted keys are set,
+// plain connections would bypass peer authentication otherwise.
+func newTransportSecurity(cfg config.P2PEncryption) (*transportSecurity, error) {
+	var (
+		sec = &transportSecurity{required: cfg.Required || len(cfg.TrustedKeys) != 0}
+		err error
+	)
+	if len(cfg.KeyFile) != 0 {
+		sec.key, err = readNodeKey(cfg.KeyFile)
+	} else {
+		sec.key, err = keys.NewPrivateKey()
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range cfg.TrustedKeys {
+		pub, err := keys.NewPublicKeyFromString(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted key %q: %w", s, err)
+		}
+		sec.trusted = append(sec.trusted, pub)
+	}
+	cert, err := selfSignedCert(sec.key)
+	if err != nil {
+		return nil, fmt.Errorf("can't create certificate: %w", err)
+	}
+	sec.tls = &tls.Config{
+		MinVersion:   tls.VersionTLS13,
+		Certificates: []tls.Certificate{cert},
+		ClientAuth:   tls.RequireAnyClientCert,
+		// Certificates are self-signed, peers are authenticated by their
+		// keys in VerifyPeerCertificate.
+		InsecureSkipVerify:    true, //nolint:gosec // G402: see VerifyPeerCertificate.
+		VerifyPeerCertificate: sec.verifyPeer,
+	}
+	return sec, nil
+}
+
+// readNodeKey reads PEM-encoded secp256r1 key (SEC 1 or PKCS #8) from the
+// given file.
+func readNodeKey(path string) (*keys.PrivateKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("can't read node key: %w", err)
+	}
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return nil, fmt.Errorf("no private key found in %s", path)
+		}
+		var k any
+		switch block.Type {
+		case "EC PRIVATE KEY":
+			k, err = x509.ParseECPrivateKey(block.Bytes)
+		case "PRIVATE KEY":
+			k, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid node key: %w", err)
+		}
+		ek, ok := k.(*ecdsa.PrivateKey)
+		if !ok || ek.Curve != elliptic.P256() {
+			return nil, errors.New("node key is not a secp256r1 key")
+		}
+		return &keys.PrivateKey{PrivateKey: *ek}, nil
+	}
+}
+
+// selfSignedCert creates a self-signed certificate for the given key.
+func selfSignedCert(key *keys.PrivateKey) (tls.Certificate, error) {
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	now := time.Now()
+	tmpl := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: hex.EncodeToString(key.PublicKey().Bytes())},
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.AddDate(10, 0, 0),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PrivateKey.PublicKey, &key.PrivateKey)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: &key.PrivateKey}, nil
+}
+
+// verifyPeer checks that the peer uses secp256r1 key and that this key is
+// trusted.
+func (sec *transportSecurity) verifyPeer(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+	if len(rawCerts) == 0 {
+		return errors.New("no peer certificate")
+	}
+	cert, err := x509.ParseCertificate(rawCerts[0])
+	if err != nil {
+		return err
+	}
+	ek, ok := cert.PublicKey.(*ecdsa.PublicKey)
+	if !ok || ek.Curve != elliptic.P256() {
+		return errors.New("peer key is not a secp256r1 key")
+	}
+	if len(sec.trusted) == 0 {
+		return nil
+	}
+	pub := (*keys.PublicKey)(ek)
+	if !sec.trusted.Contains(pub) {
+		return fmt.Errorf("%w: %s", errUntrustedPeer, hex.EncodeToString(pub.Bytes()))
+	}
+	return nil
+}
+
+// SecureTCPTransport is a TCP transport encrypting connections with TLS.
+// Encryption is negotiated per connection: incoming TLS and plain TCP
+// connections are both accepted, outgoing connections fall back to plain TCP
+// if the peer doesn't support encryption (it replies with non-TLS data).
+// Fallback can be disabled, making encryption mandatory.
+type SecureTCPTransport struct {
+	*TCPTransport
+	sec     *transportSecurity
+	timeout time.Duration
+
+	plainLock sync.Mutex
+	// plain contains addresses of the peers not supporting encryption along
+	// with the time they were detected.
+	plain map[string]time.Time
+}
+
+// NewSecureTCPTransport returns a new SecureTCPTransport for the given
+// encryption settings.
+func NewSecureTCPTransport(s *Server, bindAddr string, cfg config.P2PEncryption, log *zap.Logger) (*SecureTCPTransport, error) {
+	sec, err := newTransportSecurity(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return newSecureTCPTransport(s, bindAddr, sec, log), nil
+}
+
+func newSecureTCPTransport(s *Server, bindAddr string, sec *transportSecurity, log *zap.Logger) *SecureTCPTransport {
+	var timeout = defaultSecureHandshakeTimeout
+	if s != nil && s.DialTimeout > 0 {
+		timeout = s.DialTimeout
+	}
+	return &SecureTCPTransport{
+		TCPTransport: NewTCPTransport(s, bindAddr, log),
+		sec:          sec,
+		timeout:      timeout,
+		plain:        make(map[string]time.Time),
+	}
+}
+
+// Dial implements the Transporter interface.
+func (t *SecureTCPTransport) Dial(addr string, timeout time.Duration) (AddressablePeer, error) {
+	conn, err := t.dial(addr, timeout)
+	if err != nil {
+		return nil, err
+	}
+	p := NewTCPPeer(conn, addr, t.server)
+	go p.handleConn()
+	return p, nil
+}
+
+// dial establishes an encrypted connection to the given address falling back
+// to plain TCP if allowed.
+func (t *SecureTCPTransport) dial(addr string, timeout time.Duration) (net.Conn, error) {
+	conn, err := net.DialTimeout("tcp", addr, timeout)
+	if err != nil || t.isPlain(addr) {
+		return conn, err
+	}
+	tconn, err := t.handshake(conn, timeout)
+	if err == nil {
+		return tconn, nil
+	}
+	conn.Close()
+	if !isPlainPeerErr(err) {
+		return nil, err
+	}
+	if t.sec.required {
+		return nil, fmt.Errorf("%w: %s", errEncryptionRequired, err)
+	}
+	t.log.Debug("peer doesn't support encryption, falling back to plain TCP",
+		zap.String("address", addr), zap.Error(err))
+	t.setPlain(addr)
+	return net.DialTimeout("tcp", addr, timeout)
+}
+
+// handshake performs client TLS handshake over the given connection.
+func (t *SecureTCPTransport) handshake(conn net.Conn, timeout time.Duration) (net.Conn, error) {
+	if timeout == 0 {
+		timeout = t.timeout
+	}
+	tconn := tls.Client(conn, t.sec.tls)
+	_ = conn.SetDeadline(time.Now().Add(timeout))
+	if err := tconn.Handshake(); err != nil {
+		return nil, err
+	}
+	_ = conn.SetDeadline(time.Time{})
+	return tconn, nil
+}
+
+// isPlainPeerErr checks whether the TLS handshake error means that the peer
+// doesn't speak TLS, that's only the case if it sends some non-TLS data (plain
+// peers send their version). Closed connections, resets and timeouts can be
+// caused by anyone on the path, so they're never treated as such.
+func isPlainPeerErr(err error) bool {
+	var recErr tls.RecordHeaderError
+	return errors.As(err, &recErr)
+}
+
+func (t *SecureTCPTransport) isPlain(addr string) bool {
+	t.plainLock.Lock()
+	defer t.plainLock.Unlock()
+	detected, ok := t.plain[addr]
+	if ok && time.Since(detected) > plainPeerRetryInterval {
+		delete(t.plain, addr)
+		return false
+	}
+	return ok
+}
+
+func (t *SecureTCPTransport) setPlain(addr string) {
+	t.plainLock.Lock()
+	defer t.plainLock.Unlock()
+	t.plain[addr] = time.Now()
+}
+
+// Accept implements the Transporter interface.
+func (t *SecureTCPTransport) Accept() {
+	t.accept(func(conn net.Conn) {
+		go func() {
+			conn, err := t.negotiate(conn)
+			if err != nil {
+				t.log.Debug("incoming connection rejected",
+					zap.Stringer("address", conn.RemoteAddr()), zap.Error(err))
+				conn.Close()
+				return
+			}
+			p := NewTCPPeer(conn, "", t.server)
+			p.handleConn()
+		}()
+	})
+}
+
+// negotiate detects whether the incoming connection is a TLS one and performs
+// the handshake if so. Plain connections are returned as is (keeping the
+// data read during detection) unless encryption is required. The connection
+// returned is to be closed by the caller on error.
+func (t *SecureTCPTransport) negotiate(conn net.Conn) (net.Conn, error) {
+	_ = conn.SetDeadline(time.Now().Add(t.timeout))
+	r := bufio.NewReader(conn)
+	first, err := r.Peek(1)
+	var netErr net.Error
+	if err != nil && !(errors.As(err, &netErr) && netErr.Timeout()) {
+		return conn, err
+	}
+	// Peers waiting for us to speak first can only be plain ones.
+	if len(first) == 0 || first[0] != tlsRecordHandshake {
+		if t.sec.required {
+			return conn, errEncryptionRequired
+		}
+		_ = conn.SetDeadline(time.Time{})
+		return &bufferedConn{Conn: conn, r: r}, nil
+	}
+	tconn := tls.Server(&bufferedConn{Conn: conn, r: r}, t.sec.tls)
+	if err := tconn.Handshake(); err != nil {
+		return conn, err
+	}
+	_ = conn.SetDeadline(time.Time{})
+	return tconn, nil
+}
+
+// bufferedConn is a net.Conn reading via the given buffered reader.
+type bufferedConn struct {
+	net.Conn
+	r *bufio.Reader
+}
+
+// Read implements the io.Reader interface.
+func (c *bufferedConn) Read(b []byte) (int, error) {
+	return c.r.Read(b)
+}
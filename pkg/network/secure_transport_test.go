@@ -0,0 +1,270 @@
+package network
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/internal/fakechain"
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest"
+)
+
+func newTestSecureTransport(t *testing.T, cfg config.P2PEncryption) *SecureTCPTransport {
+	tr, err := NewSecureTCPTransport(nil, "127.0.0.1:0", cfg, zaptest.NewLogger(t))
+	require.NoError(t, err)
+	return tr
+}
+
+// listenLoopback starts a loopback listener passing accepted connections to
+// the handler.
+func listenLoopback(t *testing.T, handle func(net.Conn)) string {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { l.Close() })
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go handle(conn)
+		}
+	}()
+	return l.Addr().String()
+}
+
+// pong reads a single byte from the connection and writes it back.
+func pong(conn net.Conn) {
+	defer conn.Close()
+	b := make([]byte, 1)
+	if _, err := io.ReadFull(conn, b); err == nil {
+		_, _ = conn.Write(b)
+	}
+}
+
+// plainPeer imitates a plain node: it sends some data (like version message)
+// first, then reads whatever is sent and echoes its first byte.
+func plainPeer(conn net.Conn) {
+	defer conn.Close()
+	_, _ = conn.Write(make([]byte, 8))
+	b := make([]byte, 4096)
+	if n, err := conn.Read(b); err == nil && n > 0 {
+		_, _ = conn.Write(b[:1])
+	}
+}
+
+func ping(t *testing.T, conn net.Conn) {
+	_, err := conn.Write([]byte{0})
+	require.NoError(t, err)
+	b := make([]byte, 1)
+	_, err = io.ReadFull(conn, b)
+	require.NoError(t, err)
+	require.Equal(t, []byte{0}, b)
+}
+
+func TestSecureTransportNegotiation(t *testing.T) {
+	server := newTestSecureTransport(t, config.P2PEncryption{Enabled: true})
+	addr := listenLoopback(t, func(conn net.Conn) {
+		c, err := server.negotiate(conn)
+		if err != nil {
+			conn.Close()
+			return
+		}
+		pong(c)
+	})
+
+	t.Run("encrypted", func(t *testing.T) {
+		client := newTestSecureTransport(t, config.P2PEncryption{Enabled: true})
+		conn, err := client.dial(addr, time.Second)
+		require.NoError(t, err)
+		defer conn.Close()
+		require.IsType(t, &tls.Conn{}, conn)
+		ping(t, conn)
+		require.False(t, client.isPlain(addr))
+	})
+	t.Run("plain client", func(t *testing.T) {
+		conn, err := net.Dial("tcp", addr)
+		require.NoError(t, err)
+		defer conn.Close()
+		ping(t, conn)
+	})
+	t.Run("plain server", func(t *testing.T) {
+		plainAddr := listenLoopback(t, plainPeer)
+		client := newTestSecureTransport(t, config.P2PEncryption{Enabled: true})
+		conn, err := client.dial(plainAddr, time.Second)
+		require.NoError(t, err)
+		defer conn.Close()
+		require.IsType(t, &net.TCPConn{}, conn)
+		require.True(t, client.isPlain(plainAddr))
+
+		client = newTestSecureTransport(t, config.P2PEncryption{Enabled: true, Required: true})
+		_, err = client.dial(plainAddr, time.Second)
+		require.ErrorIs(t, err, errEncryptionRequired)
+	})
+	t.Run("no fallback", func(t *testing.T) {
+		// Closed connections and timeouts can be caused by anyone, plain
+		// TCP is only used for peers sending non-TLS data.
+		closeAddr := listenLoopback(t, func(conn net.Conn) { conn.Close() })
+		stallAddr := listenLoopback(t, func(conn net.Conn) {
+			defer conn.Close()
+			_, _ = io.Copy(io.Discard, conn)
+		})
+		client := newTestSecureTransport(t, config.P2PEncryption{Enabled: true})
+		for _, a := range []string{closeAddr, stallAddr} {
+			_, err := client.dial(a, 100*time.Millisecond)
+			require.Error(t, err)
+			require.NotErrorIs(t, err, errEncryptionRequired)
+			require.False(t, client.isPlain(a))
+		}
+	})
+	t.Run("trusted keys", func(t *testing.T) {
+		plainAddr := listenLoopback(t, plainPeer)
+		client := newTestSecureTransport(t, config.P2PEncryption{
+			Enabled:     true,
+			TrustedKeys: []string{hex.EncodeToString(server.sec.key.PublicKey().Bytes())},
+		})
+		_, err := client.dial(plainAddr, time.Second)
+		require.ErrorIs(t, err, errEncryptionRequired)
+		require.False(t, client.isPlain(plainAddr))
+
+		errCh := make(chan error, 1)
+		trustedAddr := listenLoopback(t, func(conn net.Conn) {
+			_, err := client.negotiate(conn)
+			conn.Close()
+			errCh <- err
+		})
+		conn, err := net.Dial("tcp", trustedAddr)
+		require.NoError(t, err)
+		defer conn.Close()
+		_, err = conn.Write([]byte{0})
+		require.NoError(t, err)
+		require.ErrorIs(t, <-errCh, errEncryptionRequired)
+	})
+	t.Run("untrusted", func(t *testing.T) {
+		k, err := keys.NewPrivateKey()
+		require.NoError(t, err)
+		client := newTestSecureTransport(t, config.P2PEncryption{
+			Enabled:     true,
+			TrustedKeys: []string{hex.EncodeToString(k.PublicKey().Bytes())},
+		})
+		_, err = client.dial(addr, time.Second)
+		require.ErrorIs(t, err, errUntrustedPeer)
+		require.False(t, client.isPlain(addr))
+
+		client.sec.trusted = append(client.sec.trusted, server.sec.key.PublicKey())
+		conn, err := client.dial(addr, time.Second)
+		require.NoError(t, err)
+		defer conn.Close()
+		ping(t, conn)
+	})
+	t.Run("required", func(t *testing.T) {
+		strict := newTestSecureTransport(t, config.P2PEncryption{Enabled: true, Required: true})
+		errCh := make(chan error, 1)
+		strictAddr := listenLoopback(t, func(conn net.Conn) {
+			_, err := strict.negotiate(conn)
+			conn.Close()
+			errCh <- err
+		})
+		conn, err := net.Dial("tcp", strictAddr)
+		require.NoError(t, err)
+		defer conn.Close()
+		_, err = conn.Write([]byte{0})
+		require.NoError(t, err)
+		require.ErrorIs(t, <-errCh, errEncryptionRequired)
+	})
+}
+
+func TestReadNodeKey(t *testing.T) {
+	dir := t.TempDir()
+	k, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+	der, err := x509.MarshalECPrivateKey(&k.PrivateKey)
+	require.NoError(t, err)
+	keyFile := filepath.Join(dir, "node.key")
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}), 0o600))
+
+	actual, err := readNodeKey(keyFile)
+	require.NoError(t, err)
+	require.Equal(t, k.Bytes(), actual.Bytes())
+
+	_, err = readNodeKey(filepath.Join(dir, "missing"))
+	require.Error(t, err)
+
+	badFile := filepath.Join(dir, "bad.key")
+	require.NoError(t, os.WriteFile(badFile, []byte("not a key"), 0o600))
+	_, err = readNodeKey(badFile)
+	require.Error(t, err)
+}
+
+func TestSecureTransportServers(t *testing.T) {
+	newServer := func(t *testing.T, enc config.P2PEncryption) *Server {
+		cfg := ServerConfig{
+			Addresses:    []config.AnnounceableAddress{{Address: "127.0.0.1:0"}},
+			Encryption:   enc,
+			PingInterval: time.Minute,
+			PingTimeout:  time.Minute,
+			TimePerBlock: time.Second,
+		}
+		newTransport := func(s *Server, addr string) Transporter {
+			return NewTCPTransport(s, addr, s.log)
+		}
+		if enc.Enabled {
+			sec, err := newTransportSecurity(enc)
+			require.NoError(t, err)
+			newTransport = func(s *Server, addr string) Transporter {
+				return newSecureTCPTransport(s, addr, sec, s.log)
+			}
+		}
+		// Server's run loop can outlive the test, so test logger can't be used.
+		s, err := newServerFromConstructors(cfg, fakechain.NewFakeChain(), new(fakechain.FakeStateSync),
+			zap.NewNop(), newTransport, newTestDiscovery)
+		require.NoError(t, err)
+		startWithCleanup(t, s)
+		require.Eventually(t, func() bool {
+			_, port := s.transports[0].HostPort()
+			return port != "0"
+		}, time.Second, 10*time.Millisecond)
+		return s
+	}
+	address := func(s *Server) string {
+		host, port := s.transports[0].HostPort()
+		return net.JoinHostPort(host, port)
+	}
+	// connect dials b from a and returns the connection established if
+	// both nodes complete the handshake.
+	connect := func(t *testing.T, a, b *Server) net.Conn {
+		ap, err := a.transports[0].Dial(address(b), time.Second)
+		require.NoError(t, err)
+		p := ap.(*TCPPeer)
+		require.Eventually(t, func() bool {
+			return p.Handshaked() && b.HandshakedPeersCount() == 1
+		}, 2*time.Second, 10*time.Millisecond)
+		return p.conn
+	}
+
+	secure := newServer(t, config.P2PEncryption{Enabled: true})
+	require.IsType(t, &SecureTCPTransport{}, secure.transports[0])
+
+	t.Run("encrypted", func(t *testing.T) {
+		s := newServer(t, config.P2PEncryption{Enabled: true})
+		require.IsType(t, &tls.Conn{}, connect(t, s, newServer(t, config.P2PEncryption{Enabled: true})))
+	})
+	t.Run("plain to secure", func(t *testing.T) {
+		s := newServer(t, config.P2PEncryption{Enabled: true})
+		require.IsType(t, &net.TCPConn{}, connect(t, newServer(t, config.P2PEncryption{}), s))
+	})
+	t.Run("secure to plain", func(t *testing.T) {
+		require.IsType(t, &net.TCPConn{}, connect(t, secure, newServer(t, config.P2PEncryption{})))
+	})
+}
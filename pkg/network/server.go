@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/rand"
 	"encoding/binary"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"math/big"
@@ -41,7 +42,10 @@ const (
 	defaultExtensiblePoolSize = 20
 	defaultBroadcastFactor    = 0
 	maxBlockBatch             = 200
-	peerTimeFactor            = 1000
+	// maxHeadersAhead is the maximum number of headers that can be
+	// requested ahead of the current block height.
+	maxHeadersAhead = 2 * bqueue.CacheSize
+	peerTimeFactor  = 1000
 )
 
 var (
@@ -49,6 +53,8 @@ var (
 	errIdenticalID      = errors.New("identical node id")
 	errInvalidNetwork   = errors.New("invalid network")
 	errMaxPeers         = errors.New("max peers reached")
+	errPeerDenied       = errors.New("peer address is not allowed")
+	errPeerRemoved      = errors.New("peer removed")
 	errServerShutdown   = errors.New("server shutdown")
 	errInvalidInvType   = errors.New("invalid inventory type")
 )
@@ -75,6 +81,7 @@ type (
 		PoolTx(t *transaction.Transaction, pools ...*mempool.Pool) error
 		PoolTxWithData(t *transaction.Transaction, data any, mp *mempool.Pool, feer mempool.Feer, verificationFunction func(t *transaction.Transaction, data any) error) error
 		RegisterPostBlock(f func(func(*transaction.Transaction, *mempool.Pool, bool) bool, *mempool.Pool, *block.Block))
+		SetFutureTxCallback(f func(*transaction.Transaction))
 		SubscribeForBlocks(ch chan *block.Block)
 		UnsubscribeFromBlocks(ch chan *block.Block)
 	}
@@ -103,10 +110,14 @@ type (
 		chain             Ledger
 		bQueue            *bqueue.Queue
 		bSyncQueue        *bqueue.Queue
+		fetcher           *blockFetcher
 		mempool           *mempool.Pool
 		notaryRequestPool *mempool.Pool
 		extensiblePool    *extpool.Pool
 		notaryFeer        NotaryFeer
+		persistLock       sync.Mutex
+		scores            *peerScores
+		bansLock          sync.Mutex
 
 		serviceLock    sync.RWMutex
 		services       map[string]Service
@@ -121,8 +132,6 @@ type (
 		lock  sync.RWMutex
 		peers map[Peer]bool
 
-		// lastRequestedBlock contains a height of the last requested block.
-		lastRequestedBlock atomic.Uint32
 		// lastRequestedHeader contains a height of the last requested header.
 		lastRequestedHeader atomic.Uint32
 
@@ -155,9 +164,26 @@ func randomID() uint32 {
 
 // NewServer returns a new Server, initialized with the given configuration.
 func NewServer(config ServerConfig, chain Ledger, stSync StateSync, log *zap.Logger) (*Server, error) {
-	return newServerFromConstructors(config, chain, stSync, log, func(s *Server, addr string) Transporter {
-		return NewTCPTransport(s, addr, s.log)
+	if !config.Encryption.Enabled {
+		return newServerFromConstructors(config, chain, stSync, log, func(s *Server, addr string) Transporter {
+			return NewTCPTransport(s, addr, s.log)
+		}, newDefaultDiscovery)
+	}
+	sec, err := newTransportSecurity(config.Encryption)
+	if err != nil {
+		return nil, fmt.Errorf("failed to setup P2P encryption: %w", err)
+	}
+	s, err := newServerFromConstructors(config, chain, stSync, log, func(s *Server, addr string) Transporter {
+		return newSecureTCPTransport(s, addr, sec, s.log)
 	}, newDefaultDiscovery)
+	if err != nil {
+		return nil, err
+	}
+	s.log.Info("P2P encryption enabled",
+		zap.String("key", hex.EncodeToString(sec.key.PublicKey().Bytes())),
+		zap.Bool("required", sec.required),
+		zap.Int("trusted keys", len(sec.trusted)))
+	return s, nil
 }
 
 func newServerFromConstructors(config ServerConfig, chain Ledger, stSync StateSync, log *zap.Logger,
@@ -195,6 +221,8 @@ func newServerFromConstructors(config ServerConfig, chain Ledger, stSync StateSy
 		services:       make(map[string]Service),
 		extensHandlers: make(map[string]func(*payload.Extensible) error),
 		stateSync:      stSync,
+		scores:         newPeerScores(),
+		fetcher:        newBlockFetcher(2 * config.TimePerBlock),
 	}
 	if chain.P2PSigExtensionsEnabled() {
 		s.notaryFeer = NewNotaryFeer(chain)
@@ -206,10 +234,11 @@ func newServerFromConstructors(config ServerConfig, chain Ledger, stSync StateSy
 		})
 	}
 	s.bQueue = bqueue.New(chain, log, func(b *block.Block) {
+		s.blockAdded(b)
 		s.tryStartServices()
-	}, updateBlockQueueLenMetric)
+	}, s.blockFailed, updateBlockQueueLenMetric)
 
-	s.bSyncQueue = bqueue.New(s.stateSync, log, nil, updateBlockQueueLenMetric)
+	s.bSyncQueue = bqueue.New(s.stateSync, log, nil, nil, updateBlockQueueLenMetric)
 
 	if s.MinPeers < 0 {
 		s.log.Info("bad MinPeers configured, using the default value",
@@ -239,6 +268,19 @@ func newServerFromConstructors(config ServerConfig, chain Ledger, stSync StateSy
 		s.BroadcastFactor = defaultBroadcastFactor
 	}
 
+	if s.BanScore >= 0 {
+		if s.BanScore > 0 {
+			s.log.Info("bad BanScore configured, using the default value",
+				zap.Int("configured", s.BanScore),
+				zap.Int("actual", defaultBanScore))
+		}
+		s.BanScore = defaultBanScore
+	}
+
+	if s.BanDuration <= 0 {
+		s.BanDuration = defaultBanDuration
+	}
+
 	if len(s.ServerConfig.Addresses) == 0 {
 		return nil, errors.New("no bind addresses configured")
 	}
@@ -254,6 +296,27 @@ func newServerFromConstructors(config ServerConfig, chain Ledger, stSync StateSy
 		// dial, and it doesn't matter which one.
 		s.transports[0],
 	)
+	for _, addr := range s.ServerConfig.StaticPeers {
+		if _, _, err := net.SplitHostPort(addr); err != nil {
+			return nil, fmt.Errorf("invalid static peer %q: %w", addr, err)
+		}
+		s.discovery.AddStaticPeer(addr)
+	}
+	for _, rule := range s.Denylist {
+		if err := s.discovery.Ban(rule); err != nil {
+			return nil, fmt.Errorf("invalid denylist entry: %w", err)
+		}
+	}
+	for _, rule := range s.Allowlist {
+		if err := s.discovery.Allow(rule); err != nil {
+			return nil, fmt.Errorf("invalid allowlist entry: %w", err)
+		}
+	}
+	if len(s.BansFile) != 0 {
+		if err := s.restoreBans(); err != nil {
+			s.log.Warn("failed to restore bans", zap.Error(err))
+		}
+	}
 
 	return s, nil
 }
@@ -271,7 +334,18 @@ func (s *Server) Start() {
 		zap.Uint32("headerHeight", s.chain.HeaderHeight()))
 
 	s.tryStartServices()
+	if len(s.MempoolCfg.PersistFile) != 0 {
+		if err := s.restoreMemPools(); err != nil {
+			s.log.Warn("failed to restore memory pools", zap.Error(err))
+		}
+		if s.MempoolCfg.PersistInterval > 0 {
+			go s.persistMemPoolsLoop()
+		}
+	}
 	s.initStaleMemPools()
+	s.chain.SetFutureTxCallback(func(tx *transaction.Transaction) {
+		s.broadcastTX(tx, nil)
+	})
 
 	var txThreads = optimalNumOfThreads()
 	for i := 0; i < txThreads; i++ {
@@ -307,6 +381,16 @@ func (s *Server) Shutdown() {
 		svc.Shutdown()
 	}
 	s.serviceLock.RUnlock()
+	if len(s.MempoolCfg.PersistFile) != 0 {
+		if err := s.dumpMemPools(); err != nil {
+			s.log.Warn("failed to save memory pools", zap.Error(err))
+		}
+	}
+	if len(s.BansFile) != 0 {
+		if err := s.saveBans(); err != nil {
+			s.log.Warn("failed to save bans", zap.Error(err))
+		}
+	}
 	if s.chain.P2PSigExtensionsEnabled() {
 		s.notaryRequestPool.StopSubscriptions()
 	}
@@ -403,6 +487,74 @@ func (s *Server) BadPeers() []string {
 	return s.discovery.BadPeers()
 }
 
+// StaticPeers returns a list of static peers the server always keeps
+// connections to.
+func (s *Server) StaticPeers() []string {
+	return s.discovery.StaticPeers()
+}
+
+// BannedPeers returns a list of denied IP addresses and CIDR networks.
+func (s *Server) BannedPeers() []string {
+	return s.discovery.Banned()
+}
+
+// AddPeer adds the given "host:port" address to the list of static peers and
+// initiates a connection to it. The peer is reconnected whenever the
+// connection is lost until it's removed with RemovePeer.
+func (s *Server) AddPeer(addr string) error {
+	if _, _, err := net.SplitHostPort(addr); err != nil {
+		return err
+	}
+	if !s.discovery.IsAllowed(addr) {
+		return errPeerDenied
+	}
+	s.discovery.AddStaticPeer(addr)
+	s.discovery.RequestRemote(0)
+	return nil
+}
+
+// RemovePeer removes the given "host:port" address from the list of static
+// peers and known addresses and drops connections to it. It returns false if
+// the address is neither known nor connected.
+func (s *Server) RemovePeer(addr string) bool {
+	found := s.discovery.RemovePeer(addr)
+	for _, p := range s.getPeers(func(p Peer) bool {
+		return p.ConnectionAddr() == addr || p.PeerAddr().String() == addr
+	}) {
+		found = true
+		go p.Disconnect(errPeerRemoved)
+	}
+	return found
+}
+
+// BanPeer adds the given IP address or CIDR network to the denylist and drops
+// all matching connections.
+func (s *Server) BanPeer(rule string) error {
+	if err := s.discovery.Ban(rule); err != nil {
+		return err
+	}
+	for _, p := range s.getPeers(func(p Peer) bool {
+		return !s.discovery.IsAllowed(p.RemoteAddr().String())
+	}) {
+		go p.Disconnect(errPeerDenied)
+	}
+	return nil
+}
+
+// UnbanPeer removes the given IP address or CIDR network from the denylist
+// (including temporary bans).
+func (s *Server) UnbanPeer(rule string) error {
+	if err := s.discovery.Unban(rule); err != nil {
+		return err
+	}
+	if len(s.BansFile) != 0 {
+		if err := s.saveBans(); err != nil {
+			s.log.Warn("failed to save bans", zap.Error(err))
+		}
+	}
+	return nil
+}
+
 // ConnectedPeers returns a list of currently connected peers.
 func (s *Server) ConnectedPeers() []string {
 	s.lock.RLock()
@@ -452,6 +604,9 @@ func (s *Server) run() {
 				connN = optimalN - peerN
 			}
 			s.discovery.RequestRemote(connN)
+		} else {
+			// Reconnect static peers if needed.
+			s.discovery.RequestRemote(0)
 		}
 
 		if addrCheckTimeout || s.discovery.PoolCount() < s.AttemptConnPeers {
@@ -471,6 +626,11 @@ func (s *Server) run() {
 			s.peers[p] = true
 			s.lock.Unlock()
 			peerCount := s.PeerCount()
+			if !s.discovery.IsAllowed(p.RemoteAddr().String()) {
+				// It will send us unregister signal.
+				go p.Disconnect(errPeerDenied)
+				continue
+			}
 			s.log.Info("new peer connected", zap.Stringer("addr", p.RemoteAddr()), zap.Int("peerCount", peerCount))
 			if peerCount > s.MaxPeers {
 				s.lock.RLock()
@@ -489,6 +649,8 @@ func (s *Server) run() {
 			if s.peers[drop.peer] {
 				delete(s.peers, drop.peer)
 				s.lock.Unlock()
+				s.forgetPeerScore(drop.peer)
+				s.fetcher.forget(drop.peer)
 				s.log.Warn("peer disconnected",
 					zap.Stringer("addr", drop.peer.RemoteAddr()),
 					zap.Error(drop.reason),
@@ -730,10 +892,25 @@ func (s *Server) handleVersionCmd(p Peer, version *payload.Version) error {
 
 // handleBlockCmd processes the block received from its peer.
 func (s *Server) handleBlockCmd(p Peer, block *block.Block) error {
+	var err error
 	if s.stateSync.IsActive() {
-		return s.bSyncQueue.PutBlock(block)
+		err = s.bSyncQueue.PutBlock(block)
+	} else {
+		if h := s.chain.BlockHeight(); block.Index > h {
+			// Headers are verified already, so the block must match.
+			if block.Index <= s.chain.HeaderHeight() && !s.chain.GetHeaderHash(block.Index).Equals(block.Hash()) {
+				s.updatePeerScore(p, scoreInvalidBlock, reasonInvalidBlock)
+				return nil
+			}
+			s.scores.setSender(block.Index, peerAddr(p), h)
+		}
+		err = s.bQueue.PutBlock(block)
 	}
-	return s.bQueue.PutBlock(block)
+	if err == nil && s.fetcher.received(p, block.Index) {
+		// The range is complete, request the next one.
+		err = s.requestBlocksOrHeaders(p)
+	}
+	return err
 }
 
 // handlePing processes a ping request.
@@ -763,6 +940,11 @@ func (s *Server) requestBlocksOrHeaders(p Peer) error {
 	if s.stateSync.IsActive() {
 		bq = s.stateSync
 		requestMPTNodes = s.stateSync.NeedMPTNodes()
+	} else if hh := s.chain.HeaderHeight(); hh < p.LastBlockIndex() && hh < s.chain.BlockHeight()+maxHeadersAhead {
+		err := s.requestChainHeaders(p)
+		if err != nil {
+			return err
+		}
 	}
 	if bq.BlockHeight() >= p.LastBlockIndex() {
 		return nil
@@ -783,6 +965,20 @@ func (s *Server) requestHeaders(p Peer) error {
 	return p.EnqueueP2PMessage(NewMessage(CMDGetHeaders, pl))
 }
 
+// requestChainHeaders requests headers following the current header height
+// from the peer for header-first synchronization. Headers must be added
+// sequentially, so only one request can be in flight.
+func (s *Server) requestChainHeaders(p Peer) error {
+	if !s.fetcher.requestHeaders(p) {
+		return nil
+	}
+	err := p.EnqueueP2PMessage(NewMessage(CMDGetHeaders, payload.NewGetBlockByIndex(s.chain.HeaderHeight()+1, -1)))
+	if err != nil {
+		s.fetcher.headersReceived(p)
+	}
+	return err
+}
+
 // handlePing processes a pong request.
 func (s *Server) handlePong(p Peer, pong *payload.Ping) error {
 	err := p.HandlePong(pong)
@@ -1068,21 +1264,38 @@ func (s *Server) handleGetHeadersCmd(p Peer, gh *payload.GetBlockByIndex) error
 
 // handleHeadersCmd processes headers payload.
 func (s *Server) handleHeadersCmd(p Peer, h *payload.Headers) error {
-	return s.stateSync.AddHeaders(h.Hdrs...)
+	if s.stateSync.IsActive() {
+		return s.stateSync.AddHeaders(h.Hdrs...)
+	}
+	s.fetcher.headersReceived(p)
+	// Headers can't be added if they don't follow the current ones (late
+	// reply to the request that was already answered by another peer).
+	if len(h.Hdrs) == 0 || h.Hdrs[0].Index > s.chain.HeaderHeight()+1 {
+		return nil
+	}
+	if err := s.chain.AddHeaders(h.Hdrs...); err != nil {
+		s.log.Debug("failed to add headers", zap.Stringer("addr", p.RemoteAddr()), zap.Error(err))
+		s.updatePeerScore(p, scoreInvalidBlock, reasonInvalidHeaders)
+		return nil
+	}
+	s.requestBlocksFromPeers()
+	return s.requestBlocksOrHeaders(p)
 }
 
 // handleExtensibleCmd processes the received extensible payload.
-func (s *Server) handleExtensibleCmd(e *payload.Extensible) error {
+func (s *Server) handleExtensibleCmd(p Peer, e *payload.Extensible) error {
 	if !s.syncReached.Load() {
 		return nil
 	}
 	ok, err := s.extensiblePool.Add(e)
 	if err != nil {
+		s.updatePeerScore(p, scoreInvalidExtensible, reasonInvalidExtensible)
 		return err
 	}
 	if !ok { // payload is already in cache
 		return nil
 	}
+	s.updatePeerScore(p, scoreUsefulData, reasonUsefulData)
 	s.serviceLock.RLock()
 	handler := s.extensHandlers[e.Category]
 	s.serviceLock.RUnlock()
@@ -1248,30 +1461,59 @@ func (s *Server) handleGetAddrCmd(p Peer) error {
 }
 
 // requestBlocks sends a CMDGetBlockByIndex message to the peer
-// to sync up in blocks. A maximum of maxBlockBatch will be
-// sent at once. There are two things we need to take care of:
+// to sync up in blocks. There are two things we need to take care of:
 //  1. If possible, blocks should be fetched in parallel.
-//     height..+500 to one peer, height+500..+1000 to another etc.
+//     height..+64 to one peer, height+64..+128 to another etc.
 //  2. Every block must eventually be fetched even if the peer sends no answer.
 //
-// Thus, the following algorithm is used:
-// 1. Block range is divided into chunks of payload.MaxHashesCount.
-// 2. Send requests for chunk in increasing order.
-// 3. After all requests have been sent, request random height.
+// Thus, the following algorithm is used (see blockFetcher):
+//  1. The lowest range of blocks that are neither queued nor requested is
+//     requested from the peer, the range size depends on the peer's response
+//     time. Every peer can have a couple of ranges in flight.
+//  2. Ranges not received in time are requested again from other peers.
+//  3. Only the blocks with known headers are requested (header-first sync).
+//
+// Peers that don't answer block requests in time are penalized and peers with
+// negative score are only asked for blocks if there are no better ones.
 func (s *Server) requestBlocks(bq bqueue.Blockqueuer, p Peer) error {
-	pl := getRequestBlocksPayload(p, bq.BlockHeight(), &s.lastRequestedBlock)
-	lq, capLeft := s.bQueue.LastQueued()
-	if capLeft == 0 {
-		// No more blocks will fit into the queue.
+	for _, slow := range s.fetcher.expire() {
+		s.updatePeerScore(slow, scoreSlowResponse, reasonSlowResponse)
+	}
+	var (
+		height = bq.BlockHeight()
+		limit  = p.LastBlockIndex()
+		q      = s.bQueue
+	)
+	if s.scores.get(peerAddr(p)) < 0 && s.hasBetterPeer(height+1) {
 		return nil
 	}
-	if lq >= pl.IndexStart {
-		if capLeft < payload.MaxHashesCount {
-			pl.Count = int16(capLeft)
-		}
-		pl.IndexStart = lq + 1
+	if s.stateSync.IsActive() {
+		q = s.bSyncQueue
+	}
+	// Only the blocks with known headers are requested, so that they can
+	// be checked upon receipt.
+	if hh := s.chain.HeaderHeight(); hh < limit {
+		limit = hh
+	}
+	start, count, ok := s.fetcher.next(p, height, limit, q.Missing)
+	if !ok {
+		return nil
+	}
+	err := p.EnqueueP2PMessage(NewMessage(CMDGetBlockByIndex, payload.NewGetBlockByIndex(start, int16(count))))
+	if err != nil {
+		s.fetcher.cancel(p, start)
+	}
+	return err
+}
+
+// requestBlocksFromPeers requests blocks from all handshaked peers having
+// them, it's used to parallelize block fetching once new headers are
+// received.
+func (s *Server) requestBlocksFromPeers() {
+	height := s.chain.BlockHeight()
+	for _, p := range s.getPeers(func(p Peer) bool { return p.Handshaked() && p.LastBlockIndex() > height }) {
+		_ = s.requestBlocks(s.chain, p)
 	}
-	return p.EnqueueP2PMessage(NewMessage(CMDGetBlockByIndex, pl))
 }
 
 func getRequestBlocksPayload(p Peer, currHeight uint32, lastRequestedHeight *atomic.Uint32) *payload.GetBlockByIndex {
@@ -1314,6 +1556,7 @@ func (s *Server) handleMessage(peer Peer, msg *Message) error {
 	if peer.Handshaked() {
 		if inv, ok := msg.Payload.(*payload.Inventory); ok {
 			if !inv.Type.Valid(s.chain.P2PSigExtensionsEnabled()) || len(inv.Hashes) == 0 {
+				s.updatePeerScore(peer, scoreProtocolViolation, reasonProtocolViolation)
 				return errInvalidInvType
 			}
 		}
@@ -1356,7 +1599,7 @@ func (s *Server) handleMessage(peer Peer, msg *Message) error {
 			return s.handleBlockCmd(peer, block)
 		case CMDExtensible:
 			cp := msg.Payload.(*payload.Extensible)
-			return s.handleExtensibleCmd(cp)
+			return s.handleExtensibleCmd(peer, cp)
 		case CMDTX:
 			tx := msg.Payload.(*transaction.Transaction)
 			return s.handleTxCmd(tx)
@@ -1370,6 +1613,7 @@ func (s *Server) handleMessage(peer Peer, msg *Message) error {
 			pong := msg.Payload.(*payload.Ping)
 			return s.handlePong(peer, pong)
 		case CMDVersion, CMDVerack:
+			s.updatePeerScore(peer, scoreProtocolViolation, reasonProtocolViolation)
 			return fmt.Errorf("received '%s' after the handshake", msg.Command.String())
 		}
 	} else {
@@ -1384,6 +1628,7 @@ func (s *Server) handleMessage(peer Peer, msg *Message) error {
 			}
 			go peer.StartProtocol()
 		default:
+			s.updatePeerScore(peer, scoreProtocolViolation, reasonProtocolViolation)
 			return fmt.Errorf("received '%s' during handshake", msg.Command.String())
 		}
 	}
@@ -1497,6 +1742,13 @@ func (s *Server) iteratePeersWithSendMsg(msg *Message, send func(Peer, context.C
 	if peerN == 0 {
 		return
 	}
+	// Peers with higher score are tried first and peers with negative score
+	// are only used if there are not enough good ones.
+	goodN := s.sortPeersByScore(peers)
+	if s.BroadcastFactor < 100 && goodN > 0 && goodN >= s.discovery.GetFanOut() {
+		peers = peers[:goodN]
+		peerN = goodN
+	}
 	pkt, err := msg.Bytes()
 	if err != nil {
 		return
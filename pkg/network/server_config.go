@@ -44,6 +44,29 @@ type (
 		// Seeds is a list of initial nodes used to establish connectivity.
 		Seeds []string
 
+		// StaticPeers is a list of nodes the server always keeps connections to.
+		StaticPeers []string
+
+		// Denylist is a list of IP addresses and CIDR networks the server
+		// never connects to and never accepts connections from.
+		Denylist []string
+
+		// Allowlist is a list of IP addresses and CIDR networks, if it's not
+		// empty, only the matching peers are allowed.
+		Allowlist []string
+
+		// BanScore is the peer score below which the peer is temporarily banned.
+		BanScore int
+
+		// BanDuration is the duration of temporary bans.
+		BanDuration time.Duration
+
+		// BansFile is the file temporary bans are persisted to.
+		BansFile string
+
+		// Encryption is encrypted transport configuration.
+		Encryption config.P2PEncryption
+
 		// Maximum duration a single dial may take.
 		DialTimeout time.Duration
 
@@ -76,6 +99,9 @@ type (
 
 		// BroadcastFactor is the factor (0-100) for fan-out optimization.
 		BroadcastFactor int
+
+		// MempoolCfg is memory pool persistence configuration.
+		MempoolCfg config.Mempool
 	}
 )
 
@@ -134,6 +160,13 @@ func NewServerConfig(cfg config.Config) (ServerConfig, error) {
 		Net:                protoConfig.Magic,
 		Relay:              appConfig.Relay,
 		Seeds:              protoConfig.SeedList,
+		StaticPeers:        appConfig.P2P.StaticPeers,
+		Denylist:           appConfig.P2P.Denylist,
+		Allowlist:          appConfig.P2P.Allowlist,
+		BanScore:           appConfig.P2P.BanScore,
+		BanDuration:        appConfig.P2P.BanDuration,
+		BansFile:           appConfig.P2P.BansFile,
+		Encryption:         appConfig.P2P.Encryption,
 		DialTimeout:        dialTimeout,
 		ProtoTickInterval:  protoTickInterval,
 		PingInterval:       pingInterval,
@@ -147,6 +180,7 @@ func NewServerConfig(cfg config.Config) (ServerConfig, error) {
 		StateRootCfg:       appConfig.StateRoot,
 		ExtensiblePoolSize: extPoolSize,
 		BroadcastFactor:    broadcastFactor,
+		MempoolCfg:         appConfig.Mempool,
 	}
 	return c, nil
 }
@@ -21,6 +21,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/mpt"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
 	"github.com/nspcc-dev/neo-go/pkg/network/capability"
 	"github.com/nspcc-dev/neo-go/pkg/network/payload"
 	"github.com/nspcc-dev/neo-go/pkg/util"
@@ -54,6 +55,10 @@ func (f *fakeConsensus) OnTransaction(tx *transaction.Transaction) {
 	f.txs = append(f.txs, tx)
 }
 func (f *fakeConsensus) GetPayload(h util.Uint256) *payload.Extensible { panic("implement me") }
+func (f *fakeConsensus) GetState() result.ConsensusState {
+	return result.ConsensusState{Index: -1}
+}
+func (f *fakeConsensus) GetHistory(n int) []result.ConsensusTimeline { return nil }
 
 func TestNewServer(t *testing.T) {
 	bc := &fakechain.FakeChain{Blockchain: config.Blockchain{
@@ -177,16 +182,62 @@ func TestServerRegisterPeer(t *testing.T) {
 }
 
 func TestGetBlocksByIndex(t *testing.T) {
-	testGetBlocksByIndex(t, CMDGetBlockByIndex)
+	s := newTestServer(t, ServerConfig{UserAgent: "/test/"})
+	chain := s.chain.(*fakechain.FakeChain)
+	chain.Headerheight = 5000
+
+	var (
+		ps       = make([]*localPeer, 3)
+		requests = make([][]payload.GetBlockByIndex, len(ps))
+	)
+	for i := range ps {
+		i := i
+		ps[i] = newLocalPeer(t, s)
+		ps[i].handshaked = 1
+		ps[i].lastBlockIndex = 5000
+		ps[i].messageHandler = func(t *testing.T, msg *Message) {
+			require.Equal(t, CMDGetBlockByIndex, msg.Command)
+			requests[i] = append(requests[i], *msg.Payload.(*payload.GetBlockByIndex))
+		}
+	}
+	check := func(t *testing.T, i int, start uint32, count int16) {
+		require.NoError(t, s.requestBlocks(s.chain, ps[i]))
+		require.Equal(t, payload.GetBlockByIndex{IndexStart: start, Count: count}, requests[i][len(requests[i])-1])
+	}
+
+	// Ranges are distributed between peers, every peer can have two of them.
+	check(t, 0, 1, initialFetchWindow)
+	check(t, 1, 1+initialFetchWindow, initialFetchWindow)
+	check(t, 0, 1+2*initialFetchWindow, initialFetchWindow)
+	require.NoError(t, s.requestBlocks(s.chain, ps[0]))
+	require.Equal(t, 2, len(requests[0]))
+
+	// Completed range increases the window and the next range is requested.
+	for i := uint32(1); i <= initialFetchWindow; i++ {
+		b := &block.Block{Header: block.Header{Index: i, Nonce: uint64(i)}}
+		chain.PutHeader(b)
+		require.NoError(t, s.handleBlockCmd(ps[0], b))
+	}
+	require.Equal(t, 3, len(requests[0]))
+	require.Equal(t, payload.GetBlockByIndex{IndexStart: 1 + 3*initialFetchWindow, Count: 2 * initialFetchWindow}, requests[0][2])
+	require.Equal(t, 2*initialFetchWindow, s.fetcher.window(ps[0]))
+
+	// Blocks not matching known headers are rejected.
+	s.scores.add(peerAddr(ps[0]), 10) // Keep it connected.
+	b := &block.Block{Header: block.Header{Index: 1 + 2*initialFetchWindow}}
+	require.NoError(t, s.handleBlockCmd(ps[0], b))
+	require.Equal(t, int32(10+scoreInvalidBlock), s.PeerScore(peerAddr(ps[0])))
+
+	// Blocks are only requested up to the header height.
+	chain.Headerheight = 0
+	require.NoError(t, s.requestBlocks(s.chain, ps[2]))
+	require.Equal(t, 0, len(requests[2]))
 }
 
-func testGetBlocksByIndex(t *testing.T, cmd CommandType) {
+func testGetHeaders(t *testing.T) {
 	s := newTestServer(t, ServerConfig{UserAgent: "/test/"})
-	start := s.chain.BlockHeight()
-	if cmd == CMDGetHeaders {
-		start = s.chain.HeaderHeight()
-		s.stateSync.(*fakechain.FakeStateSync).RequestHeaders.Store(true)
-	}
+	start := s.chain.HeaderHeight()
+	s.stateSync.(*fakechain.FakeStateSync).RequestHeaders.Store(true)
 	ps := make([]*localPeer, 10)
 	expectsCmd := make([]CommandType, 10)
 	expectedHeight := make([][]uint32, 10)
@@ -195,16 +246,16 @@ func testGetBlocksByIndex(t *testing.T, cmd CommandType) {
 		ps[i] = newLocalPeer(t, s)
 		ps[i].messageHandler = func(t *testing.T, msg *Message) {
 			require.Equal(t, expectsCmd[i], msg.Command)
-			if expectsCmd[i] == cmd {
+			if expectsCmd[i] == CMDGetHeaders {
 				p, ok := msg.Payload.(*payload.GetBlockByIndex)
 				require.True(t, ok)
 				require.Contains(t, expectedHeight[i], p.IndexStart)
 				expectsCmd[i] = CMDPong
 			} else if expectsCmd[i] == CMDPong {
-				expectsCmd[i] = cmd
+				expectsCmd[i] = CMDGetHeaders
 			}
 		}
-		expectsCmd[i] = cmd
+		expectsCmd[i] = CMDGetHeaders
 		expectedHeight[i] = []uint32{start + 1}
 	}
 	go s.transports[0].Accept()
@@ -222,7 +273,7 @@ func testGetBlocksByIndex(t *testing.T, cmd CommandType) {
 	checkPingRespond(t, 2, 5000, 1+2*payload.MaxHashesCount)
 	checkPingRespond(t, 3, 5000, 1+3*payload.MaxHashesCount)
 
-	// Receive some blocks.
+	// Receive some headers.
 	s.chain.(*fakechain.FakeChain).Blockheight = 2123
 
 	// Minimum chunk has priority.
@@ -699,7 +750,7 @@ func TestGetHeaders(t *testing.T) {
 		require.Nil(t, actual)
 	})
 	t.Run("distribute requests between peers", func(t *testing.T) {
-		testGetBlocksByIndex(t, CMDGetHeaders)
+		testGetHeaders(t)
 	})
 }
 
@@ -1151,3 +1202,83 @@ func TestServer_Port(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, uint16(123), actual)
 }
+
+func TestServerPeerManagement(t *testing.T) {
+	newServer := func(t *testing.T, cfg ServerConfig) (*Server, error) {
+		cfg.Addresses = []config.AnnounceableAddress{{Address: ":0"}}
+		s, err := newServerFromConstructors(cfg, fakechain.NewFakeChain(), new(fakechain.FakeStateSync), zaptest.NewLogger(t),
+			newFakeTransp, newDefaultDiscovery)
+		if err == nil {
+			s.transports[0].(*fakeTransp).dialCh = make(chan string, 16)
+		}
+		return s, err
+	}
+	t.Run("bad config", func(t *testing.T) {
+		_, err := newServer(t, ServerConfig{StaticPeers: []string{"1.1.1.1"}})
+		require.Error(t, err)
+		_, err = newServer(t, ServerConfig{Denylist: []string{"1.1.1.1:10333"}})
+		require.Error(t, err)
+		_, err = newServer(t, ServerConfig{Allowlist: []string{"1.1.1.1/100"}})
+		require.Error(t, err)
+	})
+
+	s, err := newServer(t, ServerConfig{
+		StaticPeers: []string{"1.1.1.1:10333"},
+		Denylist:    []string{"2.2.2.0/24"},
+	})
+	require.NoError(t, err)
+	dialCh := s.transports[0].(*fakeTransp).dialCh
+	startWithCleanup(t, s)
+
+	// Static peer is dialed on start.
+	select {
+	case a := <-dialCh:
+		require.Equal(t, "1.1.1.1:10333", a)
+	case <-time.After(time.Second):
+		t.Fatalf("timeout expecting for transport dial")
+	}
+	require.Equal(t, []string{"1.1.1.1:10333"}, s.StaticPeers())
+	require.Equal(t, []string{"2.2.2.0/24"}, s.BannedPeers())
+
+	require.Error(t, s.AddPeer("3.3.3.3"))
+	require.ErrorIs(t, s.AddPeer("2.2.2.2:10333"), errPeerDenied)
+	require.NoError(t, s.AddPeer("3.3.3.3:10333"))
+	select {
+	case a := <-dialCh:
+		require.Equal(t, "3.3.3.3:10333", a)
+	case <-time.After(time.Second):
+		t.Fatalf("timeout expecting for transport dial")
+	}
+	require.ElementsMatch(t, []string{"1.1.1.1:10333", "3.3.3.3:10333"}, s.StaticPeers())
+	require.True(t, s.RemovePeer("3.3.3.3:10333"))
+	require.False(t, s.RemovePeer("4.4.4.4:10333"))
+	require.Equal(t, []string{"1.1.1.1:10333"}, s.StaticPeers())
+
+	p := newLocalPeer(t, s)
+	s.register <- p
+	require.Eventually(t, func() bool { return s.PeerCount() == 1 }, time.Second, time.Millisecond*10)
+	require.True(t, s.RemovePeer(p.ConnectionAddr()))
+	require.Eventually(t, func() bool { return s.PeerCount() == 0 }, time.Second, time.Millisecond*10)
+	require.ErrorIs(t, p.droppedWith.Load().(error), errPeerRemoved)
+
+	p = newLocalPeer(t, s)
+	s.register <- p
+	require.Eventually(t, func() bool { return s.PeerCount() == 1 }, time.Second, time.Millisecond*10)
+	require.Error(t, s.BanPeer("0.0.0"))
+	require.NoError(t, s.BanPeer("0.0.0.0"))
+	require.Eventually(t, func() bool { return s.PeerCount() == 0 }, time.Second, time.Millisecond*10)
+	require.ErrorIs(t, p.droppedWith.Load().(error), errPeerDenied)
+
+	// Banned peers are dropped right after connection.
+	p = newLocalPeer(t, s)
+	s.register <- p
+	require.Eventually(t, func() bool { return p.droppedWith.Load() != nil }, time.Second, time.Millisecond*10)
+	require.ErrorIs(t, p.droppedWith.Load().(error), errPeerDenied)
+	require.Eventually(t, func() bool { return s.PeerCount() == 0 }, time.Second, time.Millisecond*10)
+
+	require.NoError(t, s.UnbanPeer("0.0.0.0"))
+	require.Error(t, s.UnbanPeer("0.0.0.0"))
+	p = newLocalPeer(t, s)
+	s.register <- p
+	require.Eventually(t, func() bool { return s.PeerCount() == 1 }, time.Second, time.Millisecond*10)
+}
@@ -57,6 +57,15 @@ func (t *TCPTransport) Dial(addr string, timeout time.Duration) (AddressablePeer
 
 // Accept implements the Transporter interface.
 func (t *TCPTransport) Accept() {
+	t.accept(func(conn net.Conn) {
+		p := NewTCPPeer(conn, "", t.server)
+		go p.handleConn()
+	})
+}
+
+// accept listens on the bind address and passes every accepted connection to
+// the given handler until the transport is closed.
+func (t *TCPTransport) accept(handle func(net.Conn)) {
 	l, err := net.Listen("tcp", t.bindAddr)
 	if err != nil {
 		t.log.Panic("TCP listen error", zap.Error(err))
@@ -86,8 +95,7 @@ func (t *TCPTransport) Accept() {
 			t.log.Warn("TCP accept error", zap.Stringer("address", l.Addr()), zap.Error(err))
 			continue
 		}
-		p := NewTCPPeer(conn, "", t.server)
-		go p.handleConn()
+		handle(conn)
 	}
 }
 
@@ -44,6 +44,14 @@ type RPCInvoke interface {
 	InvokeScript(script []byte, signers []transaction.Signer) (*result.Invoke, error)
 }
 
+// RPCInvokeStateDiff is a set of RPC methods needed to execute things at the
+// current blockchain height retrieving the state diff of the invocation
+// (NeoGo extension). It's optional for Invoker clients.
+type RPCInvokeStateDiff interface {
+	InvokeFunctionWithStateDiff(contract util.Uint160, operation string, params []smartcontract.Parameter, signers []transaction.Signer) (*result.Invoke, error)
+	InvokeScriptWithStateDiff(script []byte, signers []transaction.Signer) (*result.Invoke, error)
+}
+
 // RPCInvokeHistoric is a set of RPC methods needed to execute things at some
 // fixed point in blockchain's life.
 type RPCInvokeHistoric interface {
@@ -57,6 +65,10 @@ type RPCInvokeHistoric interface {
 	InvokeScriptWithState(stateroot util.Uint256, script []byte, signers []transaction.Signer) (*result.Invoke, error)
 }
 
+// ErrStateDiffUnsupported is returned from CallWithStateDiff and
+// RunWithStateDiff if the client doesn't implement RPCInvokeStateDiff.
+var ErrStateDiffUnsupported = errors.New("state diff is not supported by the client")
+
 // Invoker allows to test-execute things using RPC client. Its API simplifies
 // reusing the same signers list for a series of invocations and at the
 // same time uses regular Go types for call parameters. It doesn't do anything with
@@ -178,6 +190,34 @@ func (v *Invoker) Run(script []byte) (*result.Invoke, error) {
 	return v.client.InvokeScript(script, v.signers)
 }
 
+// CallWithStateDiff is similar to Call, but the result also contains the
+// state diff of the invocation (storage, balance and contract changes). It
+// requires the client to implement RPCInvokeStateDiff, historic invokers
+// don't support it.
+func (v *Invoker) CallWithStateDiff(contract util.Uint160, operation string, params ...any) (*result.Invoke, error) {
+	c, ok := v.client.(RPCInvokeStateDiff)
+	if !ok {
+		return nil, ErrStateDiffUnsupported
+	}
+	ps, err := smartcontract.NewParametersFromValues(params...)
+	if err != nil {
+		return nil, err
+	}
+	return c.InvokeFunctionWithStateDiff(contract, operation, ps, v.signers)
+}
+
+// RunWithStateDiff is similar to Run, but the result also contains the state
+// diff of the invocation (storage, balance and contract changes). It requires
+// the client to implement RPCInvokeStateDiff, historic invokers don't support
+// it.
+func (v *Invoker) RunWithStateDiff(script []byte) (*result.Invoke, error) {
+	c, ok := v.client.(RPCInvokeStateDiff)
+	if !ok {
+		return nil, ErrStateDiffUnsupported
+	}
+	return c.InvokeScriptWithStateDiff(script, v.signers)
+}
+
 // TerminateSession closes the given session, returning an error if anything
 // goes wrong. It's not strictly required to close the session (it'll expire on
 // the server anyway), but it helps to release server resources earlier.
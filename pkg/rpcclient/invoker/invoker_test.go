@@ -47,6 +47,12 @@ func (r *rpcInv) InvokeScriptAtHeight(height uint32, script []byte, signers []tr
 func (r *rpcInv) InvokeScriptWithState(stateroot util.Uint256, script []byte, signers []transaction.Signer) (*result.Invoke, error) {
 	return r.resInv, r.err
 }
+func (r *rpcInv) InvokeFunctionWithStateDiff(contract util.Uint160, operation string, params []smartcontract.Parameter, signers []transaction.Signer) (*result.Invoke, error) {
+	return r.resInv, r.err
+}
+func (r *rpcInv) InvokeScriptWithStateDiff(script []byte, signers []transaction.Signer) (*result.Invoke, error) {
+	return r.resInv, r.err
+}
 func (r *rpcInv) TerminateSession(sessionID uuid.UUID) (bool, error) {
 	return r.resTrm, r.err
 }
@@ -111,6 +117,25 @@ func TestInvoker(t *testing.T) {
 		require.Panics(t, func() { _, _ = inv.Verify(util.Uint160{}, nil, "param") })
 		require.Panics(t, func() { _, _ = inv.Run([]byte{1}) })
 	})
+	t.Run("state diff", func(t *testing.T) {
+		inv := New(ri, nil)
+		res, err := inv.CallWithStateDiff(util.Uint160{}, "method", 42)
+		require.NoError(t, err)
+		require.Equal(t, resExp, res)
+
+		_, err = inv.CallWithStateDiff(util.Uint160{}, "method", make(map[int]int))
+		require.Error(t, err)
+
+		res, err = inv.RunWithStateDiff([]byte{1})
+		require.NoError(t, err)
+		require.Equal(t, resExp, res)
+
+		inv = NewHistoricAtHeight(100500, ri, nil)
+		_, err = inv.CallWithStateDiff(util.Uint160{}, "method")
+		require.ErrorIs(t, err, ErrStateDiffUnsupported)
+		_, err = inv.RunWithStateDiff([]byte{1})
+		require.ErrorIs(t, err, ErrStateDiffUnsupported)
+	})
 	t.Run("terminate session", func(t *testing.T) {
 		for _, inv := range []*Invoker{New(ri, nil), NewHistoricWithState(util.Uint256{}, ri, nil)} {
 			ri.err = errors.New("")
@@ -0,0 +1,423 @@
+package notary
+
+import (
+	"bytes"
+	"context"
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/core/mempoolevent"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/network/payload"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/actor"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
+)
+
+var (
+	// ErrUnknownProposal is returned when there are no notary requests for
+	// the proposal in the notary request pool.
+	ErrUnknownProposal = errors.New("unknown proposal")
+	// ErrProposalRemoved is returned when all notary requests for the
+	// proposal are removed from the notary request pool before all of the
+	// signatures were collected (the main transaction can still be accepted
+	// if it was completed by some other means, otherwise fallbacks are).
+	ErrProposalRemoved = errors.New("proposal removed from the pool")
+	// ErrAlreadyApproved is returned on an attempt to approve the proposal
+	// that already has a signature of the given key.
+	ErrAlreadyApproved = errors.New("already approved")
+)
+
+type (
+	// ProposalReader is a set of methods required from RPC client to fetch
+	// proposals from the notary request pool.
+	ProposalReader interface {
+		GetRawNotaryRequests(mainHash util.Uint256) ([]*payload.P2PNotaryRequest, error)
+	}
+
+	// ProposalWatcher is a set of methods required from WebSocket RPC client
+	// to track proposal approvals, [rpcclient.WSClient] implements it.
+	ProposalWatcher interface {
+		ProposalReader
+
+		ReceiveNotaryRequests(flt *neorpc.TxFilter, rcvr chan<- *result.NotaryRequestEvent) (string, error)
+		Unsubscribe(id string) error
+	}
+
+	// MultisigRPC is a set of methods required from RPC client to create
+	// MultisigActor.
+	MultisigRPC interface {
+		RPCActor
+		ProposalReader
+	}
+
+	// Proposal is a transaction signed by some M out of N multisignature
+	// account that is being approved by the account key owners. Every
+	// approval is a notary request containing the main transaction with a
+	// single signature of the approving key owner, so proposals live in the
+	// notary request pool and the notary service completes the transaction
+	// once enough of them are collected.
+	Proposal struct {
+		// Tx is the main transaction, its witnesses are incomplete.
+		Tx *transaction.Transaction
+		// Account is the multisignature account signing the transaction.
+		Account util.Uint160
+		// Required is the number of signatures required.
+		Required int
+		// Keys are all keys of the account.
+		Keys keys.PublicKeys
+		// Approvals are the keys whose signatures were found in notary
+		// requests.
+		Approvals keys.PublicKeys
+		// Fallbacks are hashes of fallback transactions of notary requests
+		// known to be in the pool.
+		Fallbacks []util.Uint256
+
+		index int
+		net   netmode.Magic
+	}
+
+	// MultisigActor is a notary Actor for M out of N multisignature accounts.
+	// It allows to propose transactions signed by such account and to approve
+	// proposals made by other key owners of it via P2P notary requests, so
+	// that signatures are collected by the notary service asynchronously
+	// without any exchange of partially signed transactions.
+	MultisigActor struct {
+		Actor
+
+		acc *wallet.Account
+		rpc MultisigRPC
+	}
+)
+
+// NewProposal creates a proposal for the given main transaction signed by the
+// given multisignature account, the transaction must contain the account
+// witness with verification script. Signatures are to be added via AddRequest.
+func NewProposal(net netmode.Magic, tx *transaction.Transaction, acc util.Uint160) (*Proposal, error) {
+	index := -1
+	for i := range tx.Signers {
+		if tx.Signers[i].Account.Equals(acc) {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return nil, fmt.Errorf("account %s is not a transaction signer", acc.StringLE())
+	}
+	if len(tx.Scripts) <= index {
+		return nil, fmt.Errorf("no witness for account %s", acc.StringLE())
+	}
+	script := tx.Scripts[index].VerificationScript
+	m, pubs, ok := vm.ParseMultiSigContract(script)
+	if !ok || !hash.Hash160(script).Equals(acc) {
+		return nil, fmt.Errorf("account %s is not a multisignature one", acc.StringLE())
+	}
+	p := &Proposal{
+		Tx:       tx,
+		Account:  acc,
+		Required: m,
+		Keys:     make(keys.PublicKeys, len(pubs)),
+		index:    index,
+		net:      net,
+	}
+	for i := range pubs {
+		pub, err := keys.NewPublicKeyFromBytes(pubs[i], elliptic.P256())
+		if err != nil {
+			return nil, fmt.Errorf("invalid key #%d: %w", i, err)
+		}
+		p.Keys[i] = pub
+	}
+	return p, nil
+}
+
+// FindMultisigSigner returns the first transaction signer that has a
+// multisignature verification script in its witness.
+func FindMultisigSigner(tx *transaction.Transaction) (util.Uint160, bool) {
+	for i := range tx.Signers {
+		if i < len(tx.Scripts) && vm.IsMultiSigContract(tx.Scripts[i].VerificationScript) {
+			return tx.Signers[i].Account, true
+		}
+	}
+	return util.Uint160{}, false
+}
+
+// GetProposal fetches the proposal with the given main transaction hash from
+// the notary request pool. If acc is nil, the first multisignature signer of
+// the transaction is used. ErrUnknownProposal is returned if there are no
+// requests for the transaction in the pool.
+func GetProposal(c ProposalReader, net netmode.Magic, mainHash util.Uint256, acc *util.Uint160) (*Proposal, error) {
+	reqs, err := c.GetRawNotaryRequests(mainHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notary requests: %w", err)
+	}
+	if len(reqs) == 0 {
+		return nil, ErrUnknownProposal
+	}
+	var account util.Uint160
+	if acc != nil {
+		account = *acc
+	} else {
+		var ok bool
+		account, ok = FindMultisigSigner(reqs[0].MainTransaction)
+		if !ok {
+			return nil, errors.New("no multisignature signer in the main transaction")
+		}
+	}
+	p, err := NewProposal(net, reqs[0].MainTransaction, account)
+	if err != nil {
+		return nil, err
+	}
+	for _, req := range reqs {
+		if _, err := p.AddRequest(req); err != nil {
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+// AddRequest adds the notary request for the proposal main transaction to it,
+// the signature of the multisignature account witness (if any) is checked
+// against the account keys. It returns true if it's a new approval, requests
+// with invalid signatures are not treated as approvals (the notary service
+// doesn't accept them either).
+func (p *Proposal) AddRequest(req *payload.P2PNotaryRequest) (bool, error) {
+	if !req.MainTransaction.Hash().Equals(p.Tx.Hash()) {
+		return false, errors.New("main transaction mismatch")
+	}
+	fbHash := req.FallbackTransaction.Hash()
+	var known bool
+	for _, h := range p.Fallbacks {
+		if h.Equals(fbHash) {
+			known = true
+			break
+		}
+	}
+	if !known {
+		p.Fallbacks = append(p.Fallbacks, fbHash)
+	}
+	if len(req.MainTransaction.Scripts) <= p.index {
+		return false, nil
+	}
+	inv := req.MainTransaction.Scripts[p.index].InvocationScript
+	if len(inv) != 2+keys.SignatureLen || !bytes.HasPrefix(inv, []byte{byte(opcode.PUSHDATA1), keys.SignatureLen}) {
+		return false, nil
+	}
+	for _, pub := range p.Keys {
+		if !pub.VerifyHashable(inv[2:], uint32(p.net), p.Tx) {
+			continue
+		}
+		if p.isApprovedBy(pub) {
+			return false, nil
+		}
+		p.Approvals = append(p.Approvals, pub)
+		return true, nil
+	}
+	return false, nil
+}
+
+// removeRequest removes the fallback of the notary request from the proposal,
+// it returns false if there are no other requests left.
+func (p *Proposal) removeRequest(req *payload.P2PNotaryRequest) bool {
+	fbHash := req.FallbackTransaction.Hash()
+	for i, h := range p.Fallbacks {
+		if h.Equals(fbHash) {
+			p.Fallbacks = append(p.Fallbacks[:i], p.Fallbacks[i+1:]...)
+			break
+		}
+	}
+	return len(p.Fallbacks) != 0
+}
+
+func (p *Proposal) isApprovedBy(pub *keys.PublicKey) bool {
+	for _, k := range p.Approvals {
+		if k.Equal(pub) {
+			return true
+		}
+	}
+	return false
+}
+
+// Missing returns the keys that haven't yet approved the proposal.
+func (p *Proposal) Missing() keys.PublicKeys {
+	var res = keys.PublicKeys{}
+	for _, pub := range p.Keys {
+		if !p.isApprovedBy(pub) {
+			res = append(res, pub)
+		}
+	}
+	return res
+}
+
+// Completed checks whether the proposal has enough approvals.
+func (p *Proposal) Completed() bool {
+	return len(p.Approvals) >= p.Required
+}
+
+// NewMultisigActor creates a MultisigActor for the given multisignature
+// account signer (its account must have a key to sign with) and the account
+// that will sign notary requests and pay for fallbacks (see NewActor). Main
+// transactions created by it only have the multisignature account and the
+// notary contract as signers.
+func NewMultisigActor(c MultisigRPC, signer actor.SignerAccount, simpleAcc *wallet.Account) (*MultisigActor, error) {
+	if signer.Account == nil || signer.Account.Contract == nil || !vm.IsMultiSigContract(signer.Account.Contract.Script) {
+		return nil, errors.New("signer is not a multisignature account")
+	}
+	if !signer.Account.CanSign() {
+		return nil, errors.New("multisignature account can't sign")
+	}
+	a, err := NewActor(c, []actor.SignerAccount{signer}, simpleAcc)
+	if err != nil {
+		return nil, err
+	}
+	return &MultisigActor{*a, signer.Account, c}, nil
+}
+
+// Propose sends a notary request for the given main transaction (signed with
+// the actor's key, see Notarize) and returns the resulting proposal to be
+// approved by other key owners.
+func (m *MultisigActor) Propose(mainTx *transaction.Transaction, err error) (*Proposal, error) {
+	if err != nil {
+		return nil, err
+	}
+	p, err := NewProposal(m.GetNetwork(), mainTx, m.acc.ScriptHash())
+	if err != nil {
+		return nil, err
+	}
+	_, fbHash, _, err := m.Notarize(mainTx, nil)
+	if err != nil {
+		return nil, err
+	}
+	p.Fallbacks = append(p.Fallbacks, fbHash)
+	p.Approvals = append(p.Approvals, m.acc.PublicKey())
+	return p, nil
+}
+
+// GetProposal fetches the proposal with the given main transaction hash for
+// the actor's multisignature account from the notary request pool.
+func (m *MultisigActor) GetProposal(mainHash util.Uint256) (*Proposal, error) {
+	acc := m.acc.ScriptHash()
+	return GetProposal(m.rpc, m.GetNetwork(), mainHash, &acc)
+}
+
+// Approve signs the proposal main transaction with the actor's key and sends
+// a notary request for it. The values returned are main and fallback
+// transaction hashes, ValidUntilBlock and error if any (see Notarize).
+func (m *MultisigActor) Approve(p *Proposal) (util.Uint256, util.Uint256, uint32, error) {
+	var (
+		mainHash = p.Tx.Hash()
+		vub      = p.Tx.ValidUntilBlock
+	)
+	if !p.Account.Equals(m.acc.ScriptHash()) {
+		return mainHash, util.Uint256{}, vub, errors.New("proposal is made for another account")
+	}
+	if p.isApprovedBy(m.acc.PublicKey()) {
+		return mainHash, util.Uint256{}, vub, ErrAlreadyApproved
+	}
+	// Every notary request can contain only one signature per witness.
+	tx := *p.Tx
+	tx.Scripts = make([]transaction.Witness, len(p.Tx.Scripts))
+	for i, w := range p.Tx.Scripts {
+		tx.Scripts[i].VerificationScript = w.VerificationScript
+		if len(w.VerificationScript) == 0 {
+			tx.Scripts[i].InvocationScript = w.InvocationScript
+		}
+	}
+	err := m.acc.SignTx(m.GetNetwork(), &tx)
+	if err != nil {
+		return mainHash, util.Uint256{}, vub, fmt.Errorf("failed to sign main transaction: %w", err)
+	}
+	return m.Notarize(&tx, nil)
+}
+
+// WaitProposal tracks notary requests for the given proposal using WebSocket
+// notary request events until it gets all of the signatures required,
+// onUpdate (if not nil) is called for every new approval. It returns
+// ErrProposalRemoved if all requests for the proposal are removed from the
+// pool before that. Use Actor.Wait to wait for the resulting transaction
+// after that.
+func WaitProposal(ctx context.Context, c ProposalWatcher, p *Proposal, onUpdate func(*Proposal)) error {
+	var (
+		mainHash = p.Tx.Hash()
+		rcvr     = make(chan *result.NotaryRequestEvent)
+		acc      = p.Account
+	)
+	id, err := c.ReceiveNotaryRequests(&neorpc.TxFilter{Signer: &acc}, rcvr)
+	if err != nil {
+		return fmt.Errorf("failed to subscribe for notary requests: %w", err)
+	}
+	defer func() {
+		unsubDone := make(chan struct{})
+		go func() {
+			_ = c.Unsubscribe(id)
+			close(unsubDone)
+		}()
+		for {
+			select {
+			case <-rcvr:
+			case <-unsubDone:
+				return
+			}
+		}
+	}()
+
+	// Requests might be added before the subscription.
+	reqs, err := c.GetRawNotaryRequests(mainHash)
+	if err != nil {
+		return fmt.Errorf("failed to get notary requests: %w", err)
+	}
+	var updated bool
+	for _, req := range reqs {
+		ok, err := p.AddRequest(req)
+		if err != nil {
+			return err
+		}
+		updated = updated || ok
+	}
+	if updated && onUpdate != nil {
+		onUpdate(p)
+	}
+	if p.Completed() {
+		return nil
+	}
+	if len(reqs) == 0 {
+		return ErrProposalRemoved
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case ev, ok := <-rcvr:
+			if !ok {
+				return errors.New("notary request subscription is closed")
+			}
+			if ev.NotaryRequest == nil || !ev.NotaryRequest.MainTransaction.Hash().Equals(mainHash) {
+				continue
+			}
+			switch ev.Type {
+			case mempoolevent.TransactionAdded:
+				ok, err := p.AddRequest(ev.NotaryRequest)
+				if err != nil || !ok {
+					continue
+				}
+				if onUpdate != nil {
+					onUpdate(p)
+				}
+				if p.Completed() {
+					return nil
+				}
+			case mempoolevent.TransactionRemoved:
+				if !p.removeRequest(ev.NotaryRequest) {
+					return ErrProposalRemoved
+				}
+			}
+		}
+	}
+}
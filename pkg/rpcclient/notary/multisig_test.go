@@ -0,0 +1,190 @@
+package notary
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/network/payload"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/actor"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
+	"github.com/stretchr/testify/require"
+)
+
+type multisigRPCClient struct {
+	*RPCClient
+	proposalReader
+}
+
+type proposalReader struct {
+	reqs []*payload.P2PNotaryRequest
+	err  error
+}
+
+func (r *proposalReader) GetRawNotaryRequests(mainHash util.Uint256) ([]*payload.P2PNotaryRequest, error) {
+	return r.reqs, r.err
+}
+
+func newMultisigAccounts(t *testing.T, m, n int) []*wallet.Account {
+	var (
+		accs = make([]*wallet.Account, n)
+		pubs = make(keys.PublicKeys, n)
+	)
+	for i := range accs {
+		var err error
+		accs[i], err = wallet.NewAccount()
+		require.NoError(t, err)
+		pubs[i] = accs[i].PublicKey()
+	}
+	for i := range accs {
+		require.NoError(t, accs[i].ConvertMultisig(m, pubs))
+	}
+	return accs
+}
+
+func TestProposal(t *testing.T) {
+	const net = netmode.UnitTestNet
+
+	accs := newMultisigAccounts(t, 2, 3)
+	multiHash := accs[0].ScriptHash()
+
+	tx := transaction.New([]byte{1, 2, 3}, 100)
+	tx.ValidUntilBlock = 123
+	tx.Signers = []transaction.Signer{{Account: util.Uint160{1, 2, 3}}, {Account: multiHash}, {Account: Hash}}
+	tx.Scripts = []transaction.Witness{{}, {VerificationScript: accs[0].Contract.Script}, {}}
+
+	signedRequest := func(acc *wallet.Account, fbNonce uint32) *payload.P2PNotaryRequest {
+		main := *tx
+		main.Scripts = []transaction.Witness{{}, {VerificationScript: acc.Contract.Script}, {}}
+		require.NoError(t, acc.SignTx(net, &main))
+		fb := transaction.New([]byte{byte(fbNonce)}, 0)
+		fb.Nonce = fbNonce
+		return &payload.P2PNotaryRequest{MainTransaction: &main, FallbackTransaction: fb}
+	}
+
+	t.Run("not a signer", func(t *testing.T) {
+		_, err := NewProposal(net, tx, util.Uint160{3, 2, 1})
+		require.Error(t, err)
+	})
+	t.Run("not a multisig", func(t *testing.T) {
+		_, err := NewProposal(net, tx, util.Uint160{1, 2, 3})
+		require.Error(t, err)
+	})
+
+	acc, ok := FindMultisigSigner(tx)
+	require.True(t, ok)
+	require.Equal(t, multiHash, acc)
+
+	p, err := NewProposal(net, tx, multiHash)
+	require.NoError(t, err)
+	require.Equal(t, 2, p.Required)
+	require.Equal(t, 3, len(p.Keys))
+	require.Equal(t, 3, len(p.Missing()))
+	require.False(t, p.Completed())
+
+	t.Run("main transaction mismatch", func(t *testing.T) {
+		other := *tx
+		other.Nonce++
+		_, err := p.AddRequest(&payload.P2PNotaryRequest{MainTransaction: &other, FallbackTransaction: transaction.New([]byte{1}, 0)})
+		require.Error(t, err)
+	})
+
+	// Unsigned request is not an approval, but its fallback is tracked.
+	added, err := p.AddRequest(&payload.P2PNotaryRequest{MainTransaction: tx, FallbackTransaction: transaction.New([]byte{1}, 0)})
+	require.NoError(t, err)
+	require.False(t, added)
+	require.Equal(t, 0, len(p.Approvals))
+	require.Equal(t, 1, len(p.Fallbacks))
+
+	req0 := signedRequest(accs[0], 2)
+	added, err = p.AddRequest(req0)
+	require.NoError(t, err)
+	require.True(t, added)
+	require.Equal(t, keys.PublicKeys{accs[0].PublicKey()}, p.Approvals)
+	require.ElementsMatch(t, keys.PublicKeys{accs[1].PublicKey(), accs[2].PublicKey()}, p.Missing())
+	require.False(t, p.Completed())
+
+	// The same signature can't approve twice.
+	added, err = p.AddRequest(signedRequest(accs[0], 3))
+	require.NoError(t, err)
+	require.False(t, added)
+	require.Equal(t, 3, len(p.Fallbacks))
+
+	// Signature made by an unrelated key is ignored.
+	stranger, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+	strangerReq := signedRequest(accs[1], 4)
+	strangerReq.MainTransaction.Scripts[1].InvocationScript = append([]byte{byte(opcode.PUSHDATA1), keys.SignatureLen}, stranger.SignHashable(uint32(net), tx)...)
+	added, err = p.AddRequest(strangerReq)
+	require.NoError(t, err)
+	require.False(t, added)
+
+	added, err = p.AddRequest(signedRequest(accs[2], 5))
+	require.NoError(t, err)
+	require.True(t, added)
+	require.True(t, p.Completed())
+	require.Equal(t, keys.PublicKeys{accs[1].PublicKey()}, p.Missing())
+
+	require.True(t, p.removeRequest(req0))
+	require.Equal(t, 4, len(p.Fallbacks))
+
+	t.Run("GetProposal", func(t *testing.T) {
+		r := &proposalReader{err: errors.New("")}
+		_, err := GetProposal(r, net, tx.Hash(), nil)
+		require.Error(t, err)
+
+		r.err = nil
+		_, err = GetProposal(r, net, tx.Hash(), nil)
+		require.ErrorIs(t, err, ErrUnknownProposal)
+
+		r.reqs = []*payload.P2PNotaryRequest{signedRequest(accs[1], 1), signedRequest(accs[2], 2)}
+		p, err := GetProposal(r, net, tx.Hash(), nil)
+		require.NoError(t, err)
+		require.Equal(t, multiHash, p.Account)
+		require.Equal(t, keys.PublicKeys{accs[1].PublicKey(), accs[2].PublicKey()}, p.Approvals)
+		require.True(t, p.Completed())
+
+		wrong := util.Uint160{1, 2, 3}
+		_, err = GetProposal(r, net, tx.Hash(), &wrong)
+		require.Error(t, err)
+	})
+}
+
+func TestNewMultisigActor(t *testing.T) {
+	client := &multisigRPCClient{RPCClient: &RPCClient{version: &result.Version{Protocol: result.Protocol{Network: netmode.UnitTestNet}}}}
+	simple, err := wallet.NewAccount()
+	require.NoError(t, err)
+
+	_, err = NewMultisigActor(client, actor.SignerAccount{
+		Signer:  transaction.Signer{Account: simple.ScriptHash()},
+		Account: simple,
+	}, simple)
+	require.Error(t, err)
+
+	accs := newMultisigAccounts(t, 2, 3)
+	accs[0].Close() // No key to sign with.
+	_, err = NewMultisigActor(client, actor.SignerAccount{
+		Signer:  transaction.Signer{Account: accs[0].ScriptHash()},
+		Account: accs[0],
+	}, simple)
+	require.Error(t, err)
+
+	m, err := NewMultisigActor(client, actor.SignerAccount{
+		Signer:  transaction.Signer{Account: accs[1].ScriptHash(), Scopes: transaction.CalledByEntry},
+		Account: accs[1],
+	}, simple)
+	require.NoError(t, err)
+
+	p := &Proposal{Account: util.Uint160{1, 2, 3}, Tx: transaction.New([]byte{1}, 0)}
+	_, _, _, err = m.Approve(p)
+	require.Error(t, err)
+
+	p = &Proposal{Account: accs[1].ScriptHash(), Tx: transaction.New([]byte{1}, 0), Approvals: keys.PublicKeys{accs[1].PublicKey()}}
+	_, _, _, err = m.Approve(p)
+	require.ErrorIs(t, err, ErrAlreadyApproved)
+}
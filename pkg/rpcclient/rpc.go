@@ -405,6 +405,103 @@ func (c *Client) GetPeers() (*result.GetPeers, error) {
 	return resp, nil
 }
 
+// GetConsensusState returns the timeline of the current consensus round
+// along with per-validator statistics for the recent rounds (NeoGo
+// extension, it requires consensus service to be running on the node).
+func (c *Client) GetConsensusState() (*result.ConsensusState, error) {
+	var resp = new(result.ConsensusState)
+
+	if err := c.performRequest("getconsensusstate", nil, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetConsensusHistory returns timelines of up to n latest finished consensus
+// rounds, the latest one first (NeoGo extension, it requires consensus service
+// to be running on the node). All of the rounds kept by the node are returned
+// if n is zero.
+func (c *Client) GetConsensusHistory(n int) ([]result.ConsensusTimeline, error) {
+	var (
+		params []any
+		resp   []result.ConsensusTimeline
+	)
+	if n > 0 {
+		params = []any{n}
+	}
+	if err := c.performRequest("getconsensushistory", params, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetOracleRequests returns audit records of oracle requests known to the
+// node with the given status (all of them if it's empty) sorted by request ID
+// (NeoGo extension, it requires oracle service to be running on the node).
+func (c *Client) GetOracleRequests(status result.OracleRequestStatus) ([]result.OracleRequestRecord, error) {
+	var (
+		params []any
+		resp   []result.OracleRequestRecord
+	)
+	if len(status) != 0 {
+		params = []any{status}
+	}
+	if err := c.performRequest("getoraclerequests", params, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetOracleResponse returns the audit record of the oracle request with the
+// given ID (NeoGo extension, it requires oracle service to be running on the
+// node).
+func (c *Client) GetOracleResponse(id uint64) (*result.OracleRequestRecord, error) {
+	var resp = new(result.OracleRequestRecord)
+
+	if err := c.performRequest("getoracleresponse", []any{id}, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// AddPeer adds the given "host:port" address to the node's static peers
+// list and connects to it. It requires administration methods to be enabled
+// on the server.
+func (c *Client) AddPeer(addr string) (bool, error) {
+	return c.peerRequest("addpeer", addr)
+}
+
+// RemovePeer removes the given "host:port" address from the node's static
+// peers and known addresses lists and disconnects it. It returns `true` iff
+// the peer was known to the node. It requires administration methods to be
+// enabled on the server.
+func (c *Client) RemovePeer(addr string) (bool, error) {
+	return c.peerRequest("removepeer", addr)
+}
+
+// BanPeer adds the given IP address or CIDR network to the node's denylist and
+// drops matching connections. It requires administration methods to be
+// enabled on the server.
+func (c *Client) BanPeer(rule string) (bool, error) {
+	return c.peerRequest("banpeer", rule)
+}
+
+// UnbanPeer removes the given IP address or CIDR network from the node's
+// denylist. It requires administration methods to be enabled on the server.
+func (c *Client) UnbanPeer(rule string) (bool, error) {
+	return c.peerRequest("unbanpeer", rule)
+}
+
+// peerRequest performs a peer management request with a single string
+// parameter and a boolean result.
+func (c *Client) peerRequest(method string, param string) (bool, error) {
+	var resp bool
+	if err := c.performRequest(method, []any{param}, &resp); err != nil {
+		return false, err
+	}
+	return resp, nil
+}
+
 // GetRawMemPool returns a list of unconfirmed transactions in the memory.
 func (c *Client) GetRawMemPool() ([]util.Uint256, error) {
 	var resp = new([]util.Uint256)
@@ -415,6 +512,21 @@ func (c *Client) GetRawMemPool() ([]util.Uint256, error) {
 	return *resp, nil
 }
 
+// GetRawMemPoolVerbose returns verbose memory pool contents along with the
+// current height. If future is true, then not yet valid transactions waiting
+// for their NotValidBefore height are returned as well (if the node keeps
+// them).
+func (c *Client) GetRawMemPoolVerbose(future bool) (*result.RawMempool, error) {
+	var (
+		params = []any{true, future}
+		resp   = new(result.RawMempool)
+	)
+	if err := c.performRequest("getrawmempool", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // GetRawTransaction returns a transaction by hash.
 func (c *Client) GetRawTransaction(hash util.Uint256) (*transaction.Transaction, error) {
 	var (
@@ -665,6 +777,24 @@ func (c *Client) InvokeFunctionWithState(stateOrBlock util.Uint256, contract uti
 	return c.invokeSomething("invokefunctionhistoric", p, signers)
 }
 
+// InvokeScriptWithStateDiff is similar to InvokeScript, but also requests
+// the state diff (storage, balance and contract changes made by the script)
+// to be returned in the result.
+// NOTE: This is a test invoke and will not affect the blockchain.
+func (c *Client) InvokeScriptWithStateDiff(script []byte, signers []transaction.Signer) (*result.Invoke, error) {
+	var p = []any{script, nonNilSigners(signers), false, true}
+	return c.invokeSomething("invokescript", p, nil)
+}
+
+// InvokeFunctionWithStateDiff is similar to InvokeFunction, but also requests
+// the state diff (storage, balance and contract changes made by the
+// invocation) to be returned in the result.
+// NOTE: this is test invoke and will not affect the blockchain.
+func (c *Client) InvokeFunctionWithStateDiff(contract util.Uint160, operation string, params []smartcontract.Parameter, signers []transaction.Signer) (*result.Invoke, error) {
+	var p = []any{contract.StringLE(), operation, params, nonNilSigners(signers), false, true}
+	return c.invokeSomething("invokefunction", p, nil)
+}
+
 // InvokeContractVerify returns the results after calling `verify` method of the smart contract
 // with the given parameters under verification trigger type.
 // NOTE: this is test invoke and will not affect the blockchain.
@@ -717,6 +847,60 @@ func (c *Client) invokeSomething(method string, p []any, signers []transaction.S
 	return resp, nil
 }
 
+// TraceTransaction replays the persisted transaction with the given hash
+// using the state of its block and returns an opcode-level trace of its
+// execution (NeoGo extension, it requires an archival node). opts can be nil
+// to use server defaults.
+func (c *Client) TraceTransaction(hash util.Uint256, opts *neorpc.TraceOptions) (*result.ExecutionTrace, error) {
+	var (
+		params = []any{hash.StringLE()}
+		resp   = new(result.ExecutionTrace)
+	)
+	if opts != nil {
+		params = append(params, opts)
+	}
+	if err := c.performRequest("tracetransaction", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// TraceBlockByIndex replays the block with the given index and returns
+// opcode-level traces of all scripts executed during its processing (NeoGo
+// extension, it requires an archival node). opts can be nil to use server
+// defaults.
+func (c *Client) TraceBlockByIndex(index uint32, opts *neorpc.TraceOptions) ([]result.ExecutionTrace, error) {
+	return c.traceBlock(index, opts)
+}
+
+// TraceBlockByHash is similar to TraceBlockByIndex, but accepts block hash.
+func (c *Client) TraceBlockByHash(hash util.Uint256, opts *neorpc.TraceOptions) ([]result.ExecutionTrace, error) {
+	return c.traceBlock(hash.StringLE(), opts)
+}
+
+func (c *Client) traceBlock(param any, opts *neorpc.TraceOptions) ([]result.ExecutionTrace, error) {
+	var (
+		params = []any{param}
+		resp   []result.ExecutionTrace
+	)
+	if opts != nil {
+		params = append(params, opts)
+	}
+	if err := c.performRequest("traceblock", params, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// nonNilSigners returns signers as is or an empty slice for nil, it's used
+// when more parameters follow signers.
+func nonNilSigners(signers []transaction.Signer) []transaction.Signer {
+	if signers == nil {
+		return []transaction.Signer{}
+	}
+	return signers
+}
+
 // SendRawTransaction broadcasts the given transaction to the Neo network.
 // It always returns transaction hash, when successful (no error) this is the
 // hash returned from server, when not it's a locally calculated rawTX hash.
@@ -984,6 +1168,42 @@ func (c *Client) CalculateNotaryFee(nKeys uint8) (int64, error) {
 		nil
 }
 
+// MineBlocks asks development network node to generate the specified number
+// of blocks and returns hashes of them. It's a NeoGo-specific extension that
+// is only supported by `neo-go devnet` nodes.
+func (c *Client) MineBlocks(n int) ([]util.Uint256, error) {
+	var (
+		params = []any{n}
+		resp   []util.Uint256
+	)
+	if err := c.performRequest("mineblocks", params, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetRawNotaryRequests returns notary requests with the given main transaction
+// hash from the RPC node's notary request pool. It requires P2PSigExtensions to
+// be enabled on the network.
+func (c *Client) GetRawNotaryRequests(mainHash util.Uint256) ([]*payload.P2PNotaryRequest, error) {
+	var (
+		params = []any{mainHash.StringLE()}
+		resp   [][]byte
+	)
+	if err := c.performRequest("getrawnotaryrequests", params, &resp); err != nil {
+		return nil, err
+	}
+	res := make([]*payload.P2PNotaryRequest, len(resp))
+	for i := range resp {
+		req, err := payload.NewP2PNotaryRequestFromBytes(resp[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode notary request #%d: %w", i, err)
+		}
+		res[i] = req
+	}
+	return res, nil
+}
+
 // SubmitP2PNotaryRequest submits given P2PNotaryRequest payload to the RPC node.
 func (c *Client) SubmitP2PNotaryRequest(req *payload.P2PNotaryRequest) (util.Uint256, error) {
 	var resp = new(result.RelayResult)
@@ -27,7 +27,9 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/encoding/bigint"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/fixedn"
 	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc"
 	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/network/payload"
 	"github.com/nspcc-dev/neo-go/pkg/services/rpcsrv/params"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
@@ -693,6 +695,168 @@ var rpcClientTestCases = map[string][]rpcClientTestCase{
 			},
 		},
 	},
+	"addpeer": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (any, error) {
+				return c.AddPeer("127.0.0.1:20333")
+			},
+			serverResponse: `{"id":1,"jsonrpc":"2.0","result":true}`,
+			result: func(c *Client) any {
+				return true
+			},
+		},
+	},
+	"removepeer": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (any, error) {
+				return c.RemovePeer("127.0.0.1:20333")
+			},
+			serverResponse: `{"id":1,"jsonrpc":"2.0","result":true}`,
+			result: func(c *Client) any {
+				return true
+			},
+		},
+	},
+	"banpeer": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (any, error) {
+				return c.BanPeer("10.0.0.0/8")
+			},
+			serverResponse: `{"id":1,"jsonrpc":"2.0","result":true}`,
+			result: func(c *Client) any {
+				return true
+			},
+		},
+	},
+	"unbanpeer": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (any, error) {
+				return c.UnbanPeer("10.0.0.0/8")
+			},
+			serverResponse: `{"id":1,"jsonrpc":"2.0","result":true}`,
+			result: func(c *Client) any {
+				return true
+			},
+		},
+	},
+	"getconsensusstate": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (any, error) {
+				return c.GetConsensusState()
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"index":0,"round":{"height":5,"view":1,"primary":1,"validators":["03b209fd4f53a7170ea4444e0cb0a6bb6a53c2bd016926989cf85f9b0fba17a70c"],"start":100,"messages":[{"type":"ChangeView","validator":0,"view":0,"time":150,"reason":"Timeout"}],"viewchanges":[{"view":1,"time":200,"reason":"Timeout"}],"recoveryrequests":0,"recoverymessages":1},"validators":[{"publickey":"03b209fd4f53a7170ea4444e0cb0a6bb6a53c2bd016926989cf85f9b0fba17a70c","rounds":1,"preparations":0,"commits":0,"missedcommits":0,"changeviews":1,"commitdelay":0,"lastseen":150}]}}`,
+			result: func(c *Client) any {
+				pub, err := keys.NewPublicKeyFromString("03b209fd4f53a7170ea4444e0cb0a6bb6a53c2bd016926989cf85f9b0fba17a70c")
+				if err != nil {
+					panic(err)
+				}
+				return &result.ConsensusState{
+					Index: 0,
+					Round: &result.ConsensusTimeline{
+						Height:           5,
+						View:             1,
+						Primary:          1,
+						Validators:       keys.PublicKeys{pub},
+						Start:            100,
+						Messages:         []result.ConsensusMessage{{Type: "ChangeView", View: 0, Time: 150, Reason: "Timeout"}},
+						ViewChanges:      []result.ConsensusViewChange{{View: 1, Time: 200, Reason: "Timeout"}},
+						RecoveryMessages: 1,
+					},
+					Validators: []result.ConsensusValidatorStats{{
+						PublicKey:   pub,
+						Rounds:      1,
+						ChangeViews: 1,
+						LastSeen:    150,
+					}},
+				}
+			},
+		},
+	},
+	"getconsensushistory": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (any, error) {
+				return c.GetConsensusHistory(1)
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":[{"height":4,"view":0,"primary":0,"validators":[],"start":100,"end":200,"block":"0x0000000000000000000000000000000000000000000000000000000000030201","messages":[],"viewchanges":[],"recoveryrequests":0,"recoverymessages":0}]}`,
+			result: func(c *Client) any {
+				h := util.Uint256{1, 2, 3}
+				return []result.ConsensusTimeline{{
+					Height:      4,
+					Validators:  keys.PublicKeys{},
+					Start:       100,
+					End:         200,
+					Block:       &h,
+					Messages:    []result.ConsensusMessage{},
+					ViewChanges: []result.ConsensusViewChange{},
+				}}
+			},
+		},
+	},
+	"getoraclerequests": {
+		{
+			name: "all",
+			invoke: func(c *Client) (any, error) {
+				return c.GetOracleRequests("")
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":[{"id":1,"url":"https://example.com","status":"pending","received":100,"updated":100,"attempts":0,"fetchedsize":0,"resultsize":0,"signatures":null,"backupsignatures":null,"required":0}]}`,
+			result: func(c *Client) any {
+				return []result.OracleRequestRecord{{
+					ID:       1,
+					URL:      "https://example.com",
+					Status:   result.OracleRequestPending,
+					Received: 100,
+					Updated:  100,
+				}}
+			},
+		},
+		{
+			name: "by status",
+			invoke: func(c *Client) (any, error) {
+				return c.GetOracleRequests(result.OracleRequestSent)
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":[]}`,
+			result: func(c *Client) any {
+				return []result.OracleRequestRecord{}
+			},
+		},
+	},
+	"getoracleresponse": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (any, error) {
+				return c.GetOracleResponse(2)
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"id":2,"status":"sent","received":100,"updated":200,"attempts":1,"code":"Success","fetchedsize":10,"resultsize":5,"signatures":["03b209fd4f53a7170ea4444e0cb0a6bb6a53c2bd016926989cf85f9b0fba17a70c"],"backupsignatures":[],"required":1,"senttxhash":"0x0000000000000000000000000000000000000000000000000000000000030201"}}`,
+			result: func(c *Client) any {
+				pub, err := keys.NewPublicKeyFromString("03b209fd4f53a7170ea4444e0cb0a6bb6a53c2bd016926989cf85f9b0fba17a70c")
+				if err != nil {
+					panic(err)
+				}
+				code := transaction.Success
+				h := util.Uint256{1, 2, 3}
+				return &result.OracleRequestRecord{
+					ID:               2,
+					Status:           result.OracleRequestSent,
+					Received:         100,
+					Updated:          200,
+					Attempts:         1,
+					Code:             &code,
+					FetchedSize:      10,
+					ResultSize:       5,
+					Signatures:       keys.PublicKeys{pub},
+					BackupSignatures: keys.PublicKeys{},
+					Required:         1,
+					SentTxHash:       &h,
+				}
+			},
+		},
+	},
 	"getpeers": {
 		{
 			name: "positive",
@@ -724,6 +888,34 @@ var rpcClientTestCases = map[string][]rpcClientTestCase{
 				}
 			},
 		},
+		{
+			name: "with scores, static and banned",
+			invoke: func(c *Client) (any, error) {
+				return c.GetPeers()
+			},
+			serverResponse: `{"id":1,"jsonrpc":"2.0","result":{"unconnected":[],"connected":[{"address":"127.0.0.1","port":20335,"score":-5}],"bad":[],"static":[{"address":"10.0.0.1","port":20333}],"banned":["192.168.0.0/16"]}}`,
+			result: func(c *Client) any {
+				var score int32 = -5
+				return &result.GetPeers{
+					Unconnected: result.Peers{},
+					Connected: result.Peers{
+						{
+							Address: "127.0.0.1",
+							Port:    20335,
+							Score:   &score,
+						},
+					},
+					Bad: result.Peers{},
+					Static: result.Peers{
+						{
+							Address: "10.0.0.1",
+							Port:    20333,
+						},
+					},
+					Banned: []string{"192.168.0.0/16"},
+				}
+			},
+		},
 	},
 	"getrawmempool": {
 		{
@@ -740,6 +932,41 @@ var rpcClientTestCases = map[string][]rpcClientTestCase{
 				return []util.Uint256{hash}
 			},
 		},
+		{
+			name: "verbose with future",
+			invoke: func(c *Client) (any, error) {
+				return c.GetRawMemPoolVerbose(true)
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"height":5,"verified":["0x9786cce0dddb524c40ddbdd5e31a41ed1f6b5c8a683c122f627ca4a007a7cf4e"],"unverified":[],"future":["0xf5fbd303799f24ba247529d7544d4276cca54ea79f4b98095f2b0557313c5275"]}}`,
+			result: func(c *Client) any {
+				verified, err := util.Uint256DecodeStringLE("9786cce0dddb524c40ddbdd5e31a41ed1f6b5c8a683c122f627ca4a007a7cf4e")
+				if err != nil {
+					panic(err)
+				}
+				future, err := util.Uint256DecodeStringLE("f5fbd303799f24ba247529d7544d4276cca54ea79f4b98095f2b0557313c5275")
+				if err != nil {
+					panic(err)
+				}
+				return &result.RawMempool{
+					Height:     5,
+					Verified:   []util.Uint256{verified},
+					Unverified: []util.Uint256{},
+					Future:     []util.Uint256{future},
+				}
+			},
+		},
+	},
+	"getrawnotaryrequests": {
+		{
+			name: "no requests",
+			invoke: func(c *Client) (any, error) {
+				return c.GetRawNotaryRequests(util.Uint256{1, 2, 3})
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":[]}`,
+			result: func(c *Client) any {
+				return []*payload.P2PNotaryRequest{}
+			},
+		},
 	},
 	"getrawtransaction": {
 		{
@@ -1250,6 +1477,75 @@ var rpcClientTestCases = map[string][]rpcClientTestCase{
 			},
 		},
 	},
+	"mineblocks": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (any, error) {
+				return c.MineBlocks(1)
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":["0x8a717428e3383450fb7129121d1782a723c6f46aeeebd85274ea0b14f021a86e"]}`,
+			result: func(c *Client) any {
+				h, err := util.Uint256DecodeStringLE("8a717428e3383450fb7129121d1782a723c6f46aeeebd85274ea0b14f021a86e")
+				if err != nil {
+					panic(err)
+				}
+				return []util.Uint256{h}
+			},
+		},
+	},
+	"tracetransaction": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (any, error) {
+				return c.TraceTransaction(util.Uint256{1, 2, 3}, &neorpc.TraceOptions{Level: neorpc.TraceSyscalls})
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"container":"0x0000000000000000000000000000000000000000000000000000000000030201","trigger":"Application","vmstate":"HALT","gasconsumed":"1048560","steps":[{"ip":0,"opcode":"SYSCALL","scripthash":"0x0000000000000000000000000000000000000000","depth":1,"gasconsumed":"0","syscall":{"name":"System.Runtime.Log","args":[{"type":"ByteString","value":"aGk="}]}}],"truncated":false}}`,
+			result: func(c *Client) any {
+				return &result.ExecutionTrace{
+					Container:   util.Uint256{1, 2, 3},
+					Trigger:     "Application",
+					VMState:     "HALT",
+					GasConsumed: 1048560,
+					Steps: []result.TraceStep{{
+						Opcode: "SYSCALL",
+						Depth:  1,
+						Syscall: &result.TraceSyscall{
+							Name: "System.Runtime.Log",
+							Args: []json.RawMessage{json.RawMessage(`{"type":"ByteString","value":"aGk="}`)},
+						},
+					}},
+				}
+			},
+		},
+	},
+	"traceblock": {
+		{
+			name: "by index",
+			invoke: func(c *Client) (any, error) {
+				return c.TraceBlockByIndex(1, nil)
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":[{"container":"0x0000000000000000000000000000000000000000000000000000000000030201","trigger":"OnPersist","vmstate":"HALT","gasconsumed":"0","steps":[],"truncated":true}]}`,
+			result: func(c *Client) any {
+				return []result.ExecutionTrace{{
+					Container: util.Uint256{1, 2, 3},
+					Trigger:   "OnPersist",
+					VMState:   "HALT",
+					Steps:     []result.TraceStep{},
+					Truncated: true,
+				}}
+			},
+		},
+		{
+			name: "by hash",
+			invoke: func(c *Client) (any, error) {
+				return c.TraceBlockByHash(util.Uint256{1, 2, 3}, &neorpc.TraceOptions{MaxSteps: 1})
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":[]}`,
+			result: func(c *Client) any {
+				return []result.ExecutionTrace{}
+			},
+		},
+	},
 	"validateaddress": {
 		{
 			name: "positive",
@@ -313,6 +313,53 @@ func (r *executionReceiver) Close() {
 	close(r.ch)
 }
 
+// mempoolEventReceiver stores information about memory pool events subscriber.
+type mempoolEventReceiver struct {
+	filter *neorpc.TxFilter
+	ch     chan<- *result.MempoolEvent
+}
+
+// EventID implements neorpc.Comparator interface.
+func (r *mempoolEventReceiver) EventID() neorpc.EventID {
+	return neorpc.MempoolEventID
+}
+
+// Filter implements neorpc.Comparator interface.
+func (r *mempoolEventReceiver) Filter() any {
+	if r.filter == nil {
+		return nil
+	}
+	return *r.filter
+}
+
+// Receiver implements notificationReceiver interface.
+func (r *mempoolEventReceiver) Receiver() any {
+	return r.ch
+}
+
+// TrySend implements notificationReceiver interface.
+func (r *mempoolEventReceiver) TrySend(ntf Notification, nonBlocking bool) (bool, bool) {
+	if rpcevent.Matches(r, ntf) {
+		if nonBlocking {
+			select {
+			case r.ch <- ntf.Value.(*result.MempoolEvent):
+			default:
+				return true, true
+			}
+		} else {
+			r.ch <- ntf.Value.(*result.MempoolEvent)
+		}
+
+		return true, false
+	}
+	return false, false
+}
+
+// Close implements notificationReceiver interface.
+func (r *mempoolEventReceiver) Close() {
+	close(r.ch)
+}
+
 // notaryRequestReceiver stores information about notary requests subscriber.
 type notaryRequestReceiver struct {
 	filter *neorpc.TxFilter
@@ -575,6 +622,8 @@ readloop:
 				ntf.Value = block.New(sr)
 			case neorpc.TransactionEventID:
 				ntf.Value = &transaction.Transaction{}
+			case neorpc.MempoolEventID:
+				ntf.Value = new(result.MempoolEvent)
 			case neorpc.NotificationEventID:
 				ntf.Value = new(state.ContainedNotificationEvent)
 			case neorpc.ExecutionEventID:
@@ -862,6 +911,26 @@ func (c *WSClient) ReceiveTransactions(flt *neorpc.TxFilter, rcvr chan<- *transa
 	return c.performSubscription(params, r)
 }
 
+// ReceiveMempoolEvents registers provided channel as a receiver for memory
+// pool events (transaction additions, removals and replace-by-fee evictions).
+// Events can be filtered by the given TxFilter, nil value doesn't add any
+// filter. See WSClient comments for generic Receive* behaviour details.
+func (c *WSClient) ReceiveMempoolEvents(flt *neorpc.TxFilter, rcvr chan<- *result.MempoolEvent) (string, error) {
+	if rcvr == nil {
+		return "", ErrNilNotificationReceiver
+	}
+	params := []any{"mempool_event"}
+	if flt != nil {
+		flt = flt.Copy()
+		params = append(params, *flt)
+	}
+	r := &mempoolEventReceiver{
+		filter: flt,
+		ch:     rcvr,
+	}
+	return c.performSubscription(params, r)
+}
+
 // SubscribeForExecutionNotifications adds subscription for notifications
 // generated during transaction execution to this instance of the client. It can be
 // filtered by the contract's hash (that emits notifications), nil value puts no such
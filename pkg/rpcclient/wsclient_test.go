@@ -16,6 +16,7 @@ import (
 	"github.com/gorilla/websocket"
 	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/core/mempoolevent"
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/neorpc"
@@ -23,6 +24,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/network/payload"
 	"github.com/nspcc-dev/neo-go/pkg/services/rpcsrv/params"
 	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
 	"github.com/nspcc-dev/neo-go/pkg/vm/vmstate"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -49,6 +51,7 @@ func TestWSClientSubscription(t *testing.T) {
 	aerCh := make(chan *state.AppExecResult)
 	ntfCh := make(chan *state.ContainedNotificationEvent)
 	ntrCh := make(chan *result.NotaryRequestEvent)
+	mpCh := make(chan *result.MempoolEvent)
 	var cases = map[string]func(*WSClient) (string, error){
 		"blocks": func(wsc *WSClient) (string, error) {
 			return wsc.ReceiveBlocks(nil, bCh)
@@ -65,6 +68,9 @@ func TestWSClientSubscription(t *testing.T) {
 		"notary requests": func(wsc *WSClient) (string, error) {
 			return wsc.ReceiveNotaryRequests(nil, ntrCh)
 		},
+		"mempool events": func(wsc *WSClient) (string, error) {
+			return wsc.ReceiveMempoolEvents(nil, mpCh)
+		},
 	}
 	t.Run("good", func(t *testing.T) {
 		for name, f := range cases {
@@ -143,6 +149,77 @@ func TestWSClientUnsubscription(t *testing.T) {
 	}
 }
 
+func TestWSClientMempoolEvents(t *testing.T) {
+	tx := transaction.New([]byte{byte(opcode.PUSH1)}, 0)
+	tx.Signers = []transaction.Signer{{Account: util.Uint160{1, 2, 3}}}
+	tx.Scripts = []transaction.Witness{{}}
+	txJSON, err := json.Marshal(tx)
+	require.NoError(t, err)
+	var events = []string{
+		fmt.Sprintf(`{"jsonrpc":"2.0","method":"transaction_added","params":[%s]}`, txJSON),
+		fmt.Sprintf(`{"jsonrpc":"2.0","method":"mempool_event","params":[{"type":"replaced","transaction":%s,"replacedby":"0x%s"}]}`, txJSON, util.Uint256{1, 2, 3}.StringLE()),
+		`{"jsonrpc":"2.0","method":"event_missed","params":[]}`,
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/ws" && req.Method == "GET" {
+			var upgrader = websocket.Upgrader{}
+			ws, err := upgrader.Upgrade(w, req, nil)
+			require.NoError(t, err)
+			for _, event := range events {
+				err = ws.SetWriteDeadline(time.Now().Add(2 * time.Second))
+				require.NoError(t, err)
+				err = ws.WriteMessage(1, []byte(event))
+				if err != nil {
+					break
+				}
+			}
+			ws.Close()
+			return
+		}
+	}))
+	wsc, err := NewWS(context.TODO(), httpURLtoWS(srv.URL), WSOptions{})
+	require.NoError(t, err)
+	wsc.getNextRequestID = getTestRequestID
+	wsc.cacheLock.Lock()
+	wsc.cache.initDone = true // Our server mock is restricted, so perform initialisation manually.
+	wsc.cache.network = netmode.UnitTestNet
+	wsc.cacheLock.Unlock()
+
+	txCh := make(chan *transaction.Transaction, 2)
+	mpCh := make(chan *result.MempoolEvent, 2)
+	wsc.subscriptionsLock.Lock()
+	wsc.subscriptions["0"] = &txReceiver{ch: txCh}
+	wsc.receivers[chan<- *transaction.Transaction(txCh)] = []string{"0"}
+	wsc.subscriptions["1"] = &mempoolEventReceiver{ch: mpCh}
+	wsc.receivers[chan<- *result.MempoolEvent(mpCh)] = []string{"1"}
+	wsc.subscriptionsLock.Unlock()
+
+	var (
+		actualTx *transaction.Transaction
+		actualEv *result.MempoolEvent
+	)
+	select {
+	case actualTx = <-txCh:
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for event")
+	}
+	require.Equal(t, tx.Hash(), actualTx.Hash())
+	select {
+	case actualEv = <-mpCh:
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for event")
+	}
+	require.Equal(t, mempoolevent.TransactionReplaced, actualEv.Type)
+	require.Equal(t, tx.Hash(), actualEv.Transaction.Hash())
+	require.Equal(t, util.Uint256{1, 2, 3}, *actualEv.ReplacedBy)
+
+	// Channels must be closed by the missed event.
+	_, ok := <-txCh
+	require.False(t, ok)
+	_, ok = <-mpCh
+	require.False(t, ok)
+}
+
 func TestWSClientEvents(t *testing.T) {
 	var ok bool
 	// Events from RPC server testchain.
@@ -501,6 +578,23 @@ func TestWSFilteredSubscriptions(t *testing.T) {
 				require.Equal(t, util.Uint160{0, 42}, *filt.Signer)
 			},
 		},
+		{"mempool events signer",
+			func(t *testing.T, wsc *WSClient) {
+				signer := util.Uint160{0, 42}
+				_, err := wsc.ReceiveMempoolEvents(&neorpc.TxFilter{Signer: &signer}, make(chan *result.MempoolEvent))
+				require.NoError(t, err)
+			},
+			func(t *testing.T, p *params.Params) {
+				event, err := p.Value(0).GetString()
+				require.NoError(t, err)
+				require.Equal(t, "mempool_event", event)
+				param := p.Value(1)
+				filt := new(neorpc.TxFilter)
+				require.NoError(t, json.Unmarshal(param.RawMessage, filt))
+				require.Nil(t, filt.Sender)
+				require.Equal(t, util.Uint160{0, 42}, *filt.Signer)
+			},
+		},
 		{"notifications contract hash",
 			func(t *testing.T, wsc *WSClient) {
 				contract := util.Uint160{1, 2, 3, 4, 5}
@@ -0,0 +1,348 @@
+/*
+Package devnet implements a block producer for single-node development
+networks. It's used by the `neo-go devnet` command to generate blocks on
+demand (via RPC) or instantly after a transaction gets into the memory pool.
+*/
+package devnet
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/core/fee"
+	"github.com/nspcc-dev/neo-go/pkg/core/interop"
+	"github.com/nspcc-dev/neo-go/pkg/core/mempool"
+	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+)
+
+const (
+	// MaxBlocksPerRequest is the maximum number of blocks that can be generated
+	// with a single MineBlocks call.
+	MaxBlocksPerRequest = 1000
+
+	// autoMineInterval is the period of memory pool checks in automine mode.
+	autoMineInterval = 50 * time.Millisecond
+)
+
+type (
+	// Ledger is the interface to Blockchain sufficient for Miner.
+	Ledger interface {
+		AddBlock(block *block.Block) error
+		ApplyPolicyToTxSet([]*transaction.Transaction) []*transaction.Transaction
+		BlockHeight() uint32
+		FeePerByte() int64
+		GetBaseExecFee() int64
+		GetConfig() config.Blockchain
+		GetHeader(hash util.Uint256) (*block.Header, error)
+		GetHeaderHash(uint32) util.Uint256
+		GetMemPool() *mempool.Pool
+		GetNativeContractScriptHash(string) (util.Uint160, error)
+		GetNextBlockValidators() ([]*keys.PublicKey, error)
+		GetStateRoot(height uint32) (*state.MPTRoot, error)
+		GetTestVM(t trigger.Type, tx *transaction.Transaction, b *block.Block) (*interop.Context, error)
+	}
+
+	// Config contains Miner parameters.
+	Config struct {
+		// Chain is the Ledger instance blocks are generated for.
+		Chain Ledger
+		// Log is a logger instance.
+		Log *zap.Logger
+		// Key is the private key of the only network validator.
+		Key *keys.PrivateKey
+		// AutoMine makes Miner produce a new block as soon as some
+		// transaction appears in the memory pool.
+		AutoMine bool
+	}
+
+	// Miner is a service producing blocks signed by the single validator of
+	// the development network. It implements network.Service interface.
+	Miner struct {
+		Config
+
+		// lock serializes block generation.
+		lock    sync.Mutex
+		started *atomic.Bool
+		stopCh  chan struct{}
+		done    chan struct{}
+	}
+)
+
+// New creates a Miner instance using the given configuration.
+func New(cfg Config) (*Miner, error) {
+	if cfg.Chain == nil {
+		return nil, errors.New("no chain provided")
+	}
+	if cfg.Key == nil {
+		return nil, errors.New("no validator key provided")
+	}
+	if cfg.Log == nil {
+		cfg.Log = zap.NewNop()
+	}
+	cfg.Log = cfg.Log.With(zap.String("service", "devnet-miner"))
+	return &Miner{
+		Config:  cfg,
+		started: atomic.NewBool(false),
+		stopCh:  make(chan struct{}),
+		done:    make(chan struct{}),
+	}, nil
+}
+
+// Name returns service name.
+func (m *Miner) Name() string {
+	return "devnet-miner"
+}
+
+// Start runs Miner event loop if AutoMine is enabled. The service only starts
+// once, subsequent calls to Start are no-op.
+func (m *Miner) Start() {
+	if !m.started.CompareAndSwap(false, true) {
+		return
+	}
+	if !m.AutoMine {
+		close(m.done)
+		return
+	}
+	m.Log.Info("starting devnet miner in automine mode")
+	go m.eventLoop()
+}
+
+// Shutdown stops Miner event loop. It can only be called once, subsequent
+// calls to Shutdown on the same instance are no-op. The instance that was
+// stopped can not be started again by calling Start (use a new instance if
+// needed).
+func (m *Miner) Shutdown() {
+	if !m.started.CompareAndSwap(true, false) {
+		return
+	}
+	close(m.stopCh)
+	<-m.done
+}
+
+func (m *Miner) eventLoop() {
+	ticker := time.NewTicker(autoMineInterval)
+	defer ticker.Stop()
+	mp := m.Chain.GetMemPool()
+	for {
+		select {
+		case <-m.stopCh:
+			close(m.done)
+			return
+		case <-ticker.C:
+			if mp.Count() == 0 {
+				continue
+			}
+			if _, err := m.MineBlocks(1); err != nil {
+				m.Log.Warn("failed to mine block", zap.Error(err))
+			}
+		}
+	}
+}
+
+// MineBlocks generates n blocks containing transactions from the memory pool
+// (if any) and adds them to the chain. It returns hashes of the generated blocks.
+func (m *Miner) MineBlocks(n int) ([]util.Uint256, error) {
+	if n <= 0 || n > MaxBlocksPerRequest {
+		return nil, fmt.Errorf("invalid number of blocks %d: should be in [1, %d] range", n, MaxBlocksPerRequest)
+	}
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	var hashes = make([]util.Uint256, 0, n)
+	for i := 0; i < n; i++ {
+		txs := m.Chain.ApplyPolicyToTxSet(m.Chain.GetMemPool().GetVerifiedTransactions())
+		b, err := m.mineBlock(txs)
+		if err != nil {
+			return hashes, err
+		}
+		hashes = append(hashes, b.Hash())
+	}
+	return hashes, nil
+}
+
+// PutBlock implements consensus.BlockQueuer interface, it's used as a block
+// queue for dBFT running along with Miner (when AutoMine is disabled) to
+// avoid races between dBFT and MineBlocks. Blocks are added to the chain
+// synchronously, but only if there is no block generation in progress and
+// there is no block with the same index in the chain already, otherwise
+// they're dropped. Effectively, dBFT is paused while MineBlocks is running,
+// it restarts from the new chain height after that.
+func (m *Miner) PutBlock(b *block.Block) error {
+	// Waiting for the lock is not an option, MineBlocks can't finish without
+	// dBFT receiving new blocks from the chain.
+	if !m.lock.TryLock() {
+		m.Log.Debug("block generation is in progress, dBFT block dropped", zap.Uint32("index", b.Index))
+		return nil
+	}
+	defer m.lock.Unlock()
+	if b.Index <= m.Chain.BlockHeight() {
+		m.Log.Debug("dBFT block is outdated, dropped", zap.Uint32("index", b.Index))
+		return nil
+	}
+	return m.Chain.AddBlock(b)
+}
+
+// Prefund transfers the specified amounts of NEO and GAS (in their minimal
+// units) to each of the given accounts from the validators multisignature
+// account that owns the initial token supply. Transfers are made with a
+// single transaction included into a newly generated block, the hash of this
+// transaction is returned.
+func (m *Miner) Prefund(accs []util.Uint160, neoAmount, gasAmount *big.Int) (util.Uint256, error) {
+	neoHash, err := m.Chain.GetNativeContractScriptHash(nativenames.Neo)
+	if err != nil {
+		return util.Uint256{}, err
+	}
+	gasHash, err := m.Chain.GetNativeContractScriptHash(nativenames.Gas)
+	if err != nil {
+		return util.Uint256{}, err
+	}
+	verif, err := m.validatorsScript()
+	if err != nil {
+		return util.Uint256{}, err
+	}
+	from := hash.Hash160(verif)
+
+	bld := smartcontract.NewBuilder()
+	for _, acc := range accs {
+		if neoAmount != nil && neoAmount.Sign() > 0 {
+			bld.InvokeWithAssert(neoHash, "transfer", from, acc, neoAmount, nil)
+		}
+		if gasAmount != nil && gasAmount.Sign() > 0 {
+			bld.InvokeWithAssert(gasHash, "transfer", from, acc, gasAmount, nil)
+		}
+	}
+	if bld.Len() == 0 {
+		return util.Uint256{}, errors.New("nothing to transfer")
+	}
+	script, err := bld.Script()
+	if err != nil {
+		return util.Uint256{}, err
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	tx := transaction.New(script, 0)
+	tx.Nonce = uint32(time.Now().UnixNano())
+	tx.ValidUntilBlock = m.Chain.BlockHeight() + m.Chain.GetConfig().MaxValidUntilBlockIncrement
+	tx.Signers = []transaction.Signer{{Account: from, Scopes: transaction.CalledByEntry}}
+	tx.SystemFee, err = m.calculateSystemFee(tx)
+	if err != nil {
+		return util.Uint256{}, fmt.Errorf("failed to calculate system fee: %w", err)
+	}
+	netFee, sizeDelta := fee.Calculate(m.Chain.GetBaseExecFee(), verif)
+	tx.NetworkFee = netFee + int64(io.GetVarSize(tx)+sizeDelta)*m.Chain.FeePerByte()
+	tx.Scripts = []transaction.Witness{{
+		InvocationScript:   m.invocationScript(tx),
+		VerificationScript: verif,
+	}}
+	if _, err = m.mineBlock([]*transaction.Transaction{tx}); err != nil {
+		return util.Uint256{}, err
+	}
+	return tx.Hash(), nil
+}
+
+// calculateSystemFee runs tx script in the test VM and returns the amount of
+// GAS consumed.
+func (m *Miner) calculateSystemFee(tx *transaction.Transaction) (int64, error) {
+	// Test invocation can cache transaction hash, thus use a copy.
+	ttx := *tx
+	ic, err := m.Chain.GetTestVM(trigger.Application, &ttx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer ic.Finalize()
+	ic.VM.LoadWithFlags(tx.Script, callflag.All)
+	if err := ic.VM.Run(); err != nil {
+		return 0, err
+	}
+	return ic.VM.GasConsumed(), nil
+}
+
+// mineBlock creates, signs and adds a new block with the given transactions
+// to the chain. It must be called with lock held.
+func (m *Miner) mineBlock(txs []*transaction.Transaction) (*block.Block, error) {
+	cfg := m.Chain.GetConfig()
+	height := m.Chain.BlockHeight()
+	prev, err := m.Chain.GetHeader(m.Chain.GetHeaderHash(height))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get previous header: %w", err)
+	}
+	verif, err := m.validatorsScript()
+	if err != nil {
+		return nil, err
+	}
+	ts := uint64(time.Now().UnixNano() / int64(time.Millisecond))
+	if ts <= prev.Timestamp {
+		ts = prev.Timestamp + 1
+	}
+	b := &block.Block{
+		Header: block.Header{
+			PrevHash:      prev.Hash(),
+			Timestamp:     ts,
+			Nonce:         uint64(time.Now().UnixNano()),
+			Index:         height + 1,
+			NextConsensus: hash.Hash160(verif),
+			Script: transaction.Witness{
+				VerificationScript: verif,
+			},
+		},
+		Transactions: txs,
+	}
+	if cfg.StateRootInHeader {
+		sr, err := m.Chain.GetStateRoot(height)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get state root: %w", err)
+		}
+		b.StateRootEnabled = true
+		b.PrevStateRoot = sr.Root
+	}
+	b.RebuildMerkleRoot()
+	b.Script.InvocationScript = m.invocationScript(b)
+	if err := m.Chain.AddBlock(b); err != nil {
+		return nil, fmt.Errorf("failed to add block %d: %w", b.Index, err)
+	}
+	m.Log.Debug("new block generated",
+		zap.Uint32("index", b.Index),
+		zap.Int("tx count", len(b.Transactions)))
+	return b, nil
+}
+
+// validatorsScript returns the verification script of the next block
+// validators.
+func (m *Miner) validatorsScript() ([]byte, error) {
+	vals, err := m.Chain.GetNextBlockValidators()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get validators: %w", err)
+	}
+	script, err := smartcontract.CreateDefaultMultiSigRedeemScript(vals)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create multisignature script: %w", err)
+	}
+	return script, nil
+}
+
+// invocationScript returns the invocation script with the validator
+// signature for the given hashable item.
+func (m *Miner) invocationScript(h hash.Hashable) []byte {
+	buf := io.NewBufBinWriter()
+	emit.Bytes(buf.BinWriter, m.Key.SignHashable(uint32(m.Chain.GetConfig().Magic), h))
+	return buf.Bytes()
+}
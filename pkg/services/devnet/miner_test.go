@@ -0,0 +1,83 @@
+package devnet_test
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/neotest"
+	"github.com/nspcc-dev/neo-go/pkg/neotest/chain"
+	"github.com/nspcc-dev/neo-go/pkg/services/devnet"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestMiner(t *testing.T) {
+	bc, validators := chain.NewSingle(t)
+	e := neotest.NewExecutor(t, bc, validators, validators)
+	key := validators.(neotest.MultiSigner).Single(0).Account().PrivateKey()
+
+	_, err := devnet.New(devnet.Config{Chain: bc})
+	require.Error(t, err)
+
+	m, err := devnet.New(devnet.Config{Chain: bc, Key: key, Log: zaptest.NewLogger(t)})
+	require.NoError(t, err)
+
+	t.Run("MineBlocks", func(t *testing.T) {
+		_, err := m.MineBlocks(0)
+		require.Error(t, err)
+		_, err = m.MineBlocks(devnet.MaxBlocksPerRequest + 1)
+		require.Error(t, err)
+
+		h := bc.BlockHeight()
+		hashes, err := m.MineBlocks(3)
+		require.NoError(t, err)
+		require.Equal(t, 3, len(hashes))
+		require.Equal(t, h+3, bc.BlockHeight())
+		require.Equal(t, hashes[2], bc.CurrentBlockHash())
+	})
+
+	t.Run("PutBlock", func(t *testing.T) {
+		b := e.SignBlock(e.NewUnsignedBlock(t))
+		require.NoError(t, m.PutBlock(b))
+		require.Equal(t, b.Hash(), bc.CurrentBlockHash())
+
+		// Block for the height already generated by MineBlocks is dropped.
+		stale := e.SignBlock(e.NewUnsignedBlock(t))
+		hashes, err := m.MineBlocks(1)
+		require.NoError(t, err)
+		require.NoError(t, m.PutBlock(stale))
+		require.Equal(t, stale.Index, bc.BlockHeight())
+		require.Equal(t, hashes[0], bc.CurrentBlockHash())
+	})
+
+	t.Run("Prefund", func(t *testing.T) {
+		accs := []util.Uint160{{1, 2, 3}, {4, 5, 6}}
+		txHash, err := m.Prefund(accs, big.NewInt(10), big.NewInt(500))
+		require.NoError(t, err)
+		e.CheckHalt(t, txHash)
+		for _, acc := range accs {
+			e.CheckGASBalance(t, acc, big.NewInt(500))
+			neo, _ := bc.GetGoverningTokenBalance(acc)
+			require.Equal(t, int64(10), neo.Int64())
+		}
+
+		_, err = m.Prefund(accs, nil, big.NewInt(0))
+		require.Error(t, err)
+	})
+
+	t.Run("AutoMine", func(t *testing.T) {
+		m, err := devnet.New(devnet.Config{Chain: bc, Key: key, AutoMine: true})
+		require.NoError(t, err)
+		m.Start()
+		t.Cleanup(m.Shutdown)
+
+		h := bc.BlockHeight()
+		tx := e.PrepareInvocation(t, []byte{byte(opcode.RET)}, []neotest.Signer{validators})
+		require.NoError(t, bc.PoolTx(tx))
+		require.Eventually(t, func() bool { return bc.BlockHeight() > h }, time.Second, 10*time.Millisecond)
+		e.CheckHalt(t, tx.Hash())
+	})
+}
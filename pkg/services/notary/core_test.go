@@ -20,6 +20,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
 	"github.com/nspcc-dev/neo-go/pkg/neotest"
 	"github.com/nspcc-dev/neo-go/pkg/neotest/chain"
 	"github.com/nspcc-dev/neo-go/pkg/network"
@@ -475,6 +476,30 @@ func TestNotary(t *testing.T) {
 	checkFallbackTxs(t, r, false)
 	r, _ = checkCompleteMultisigRequest(t, 3, 4, true)
 	checkFallbackTxs(t, r, false)
+	t.Run("multisignature request state", func(t *testing.T) {
+		var st result.NotaryRequestState
+		require.Eventually(t, func() bool {
+			var ok bool
+			st, ok = ntr1.GetRequestState(r[0].MainTransaction.Hash())
+			return ok && st.Status == result.NotaryRequestSent
+		}, time.Second*3, time.Millisecond*50)
+		mainHash := r[0].MainTransaction.Hash()
+		require.Equal(t, &mainHash, st.SentHash)
+		require.Empty(t, st.Error)
+		require.Equal(t, 2, len(st.Witnesses))
+		require.Equal(t, "multisignature", st.Witnesses[0].Type)
+		require.Equal(t, 3, st.Witnesses[0].Required)
+		require.Equal(t, 3, len(st.Witnesses[0].Collected))
+		require.Equal(t, 1, len(st.Witnesses[0].Missing))
+		require.True(t, st.Witnesses[0].Completed)
+		require.Equal(t, "contract", st.Witnesses[1].Type)
+		require.Equal(t, bc.GetNotaryContractScriptHash(), st.Witnesses[1].Account)
+		require.Equal(t, 4, len(st.Fallbacks))
+		require.Equal(t, r[0].FallbackTransaction.Hash(), st.Fallbacks[0].Hash)
+		require.Equal(t, r[0].FallbackTransaction.Signers[1].Account, st.Fallbacks[0].Sender)
+		require.Equal(t, result.NotaryDeposit{}, st.Fallbacks[0].Deposit)
+		require.LessOrEqual(t, st.Received, st.Updated)
+	})
 	r, _ = checkCompleteMultisigRequest(t, 3, 10, true)
 	checkFallbackTxs(t, r, false)
 
@@ -526,9 +551,20 @@ func TestNotary(t *testing.T) {
 	checkFallbackTxs(t, requests, false)
 
 	// PostPersist: complete fallback, signature request
+	stateCh := make(chan result.NotaryRequestState, 100)
+	ntr1.SubscribeForStateChanges(stateCh)
 	setFinalizeWithError(true)
 	requests, requesters = checkCompleteStandardRequest(t, 3, false)
 	checkFallbackTxs(t, requests, false)
+	mainHash := requests[0].MainTransaction.Hash()
+	require.Eventually(t, func() bool {
+		for _, st := range ntr1.GetRequests() {
+			if st.Hash == mainHash {
+				return st.Status == result.NotaryRequestCompleted && st.Error != ""
+			}
+		}
+		return false
+	}, time.Second*3, time.Millisecond*50)
 	// make fallbacks valid
 	e.GenerateNewBlocks(t, int(nvbDiffFallback))
 	require.NoError(t, err)
@@ -541,6 +577,32 @@ func TestNotary(t *testing.T) {
 	e.AddNewBlock(t)
 	checkMainTx(t, requesters, requests, len(requests), false)
 	checkFallbackTxs(t, requests, true)
+	t.Run("fallback request state", func(t *testing.T) {
+		var st result.NotaryRequestState
+		require.Eventually(t, func() bool {
+			var ok bool
+			st, ok = ntr1.GetRequestState(mainHash)
+			return ok && len(st.Fallbacks) == 0
+		}, time.Second*3, time.Millisecond*50)
+		require.Equal(t, result.NotaryRequestFallback, st.Status)
+		require.Equal(t, result.FallbackExpired, st.FallbackReason)
+		require.Equal(t, "error while finalizing transaction", st.Error)
+		require.NotNil(t, st.SentHash)
+		require.NotEqual(t, mainHash, *st.SentHash)
+		for _, pending := range ntr1.GetRequests() {
+			require.NotEqual(t, mainHash, pending.Hash)
+		}
+
+		var statuses []string
+		for len(stateCh) > 0 {
+			st := <-stateCh
+			if st.Hash == mainHash && (len(statuses) == 0 || statuses[len(statuses)-1] != st.Status) {
+				statuses = append(statuses, st.Status)
+			}
+		}
+		ntr1.UnsubscribeFromStateChanges(stateCh)
+		require.Equal(t, []string{result.NotaryRequestPending, result.NotaryRequestCompleted, result.NotaryRequestFallback}, statuses)
+	})
 
 	// PostPersist: complete fallback, multisignature request
 	nSigs, nKeys := 3, 5
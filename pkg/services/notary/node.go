@@ -2,9 +2,8 @@ package notary
 
 import (
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
-	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
+	"github.com/nspcc-dev/neo-go/pkg/services/signer"
 	"github.com/nspcc-dev/neo-go/pkg/util"
-	"github.com/nspcc-dev/neo-go/pkg/wallet"
 	"go.uber.org/zap"
 )
 
@@ -21,33 +20,21 @@ func (n *Notary) UpdateNotaryNodes(notaryNodes keys.PublicKeys) {
 		}
 	}
 
-	var acc *wallet.Account
-	for _, node := range notaryNodes {
-		acc = n.wallet.GetAccount(node.GetScriptHash())
-		if acc != nil {
-			if acc.CanSign() {
-				break
-			}
-			err := acc.Decrypt(n.Config.MainCfg.UnlockWallet.Password, n.wallet.Scrypt)
-			if err != nil {
-				n.Config.Log.Warn("can't unlock notary node account",
-					zap.String("address", address.Uint160ToString(acc.Contract.ScriptHash())),
-					zap.Error(err))
-				acc = nil
-			}
-			break
-		}
+	_, acc, err := signer.Find(n.signer, notaryNodes)
+	if err != nil {
+		n.Config.Log.Warn("can't get notary node account", zap.Error(err))
 	}
 
 	n.currAccount = acc
 	if acc == nil {
 		n.reqMtx.Lock()
 		n.requests = make(map[util.Uint256]*request)
+		notaryPendingRequests.Set(0)
 		n.reqMtx.Unlock()
 	}
 }
 
-func (n *Notary) getAccount() *wallet.Account {
+func (n *Notary) getAccount() *signer.Account {
 	n.accMtx.RLock()
 	defer n.accMtx.RUnlock()
 	return n.currAccount
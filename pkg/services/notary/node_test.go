@@ -45,11 +45,11 @@ func TestUpdateNotaryNodes(t *testing.T) {
 	require.Nil(t, ntr.currAccount)
 	// set account for the first time
 	ntr.UpdateNotaryNodes(keys.PublicKeys{acc.PublicKey()})
-	require.Equal(t, acc, ntr.currAccount)
+	require.Equal(t, acc.PublicKey(), ntr.currAccount.PublicKey())
 
 	t.Run("account is already set", func(t *testing.T) {
 		ntr.UpdateNotaryNodes(keys.PublicKeys{acc.PublicKey(), randomKey.PublicKey()})
-		require.Equal(t, acc, ntr.currAccount)
+		require.Equal(t, acc.PublicKey(), ntr.currAccount.PublicKey())
 	})
 
 	t.Run("another account from the same wallet", func(t *testing.T) {
@@ -58,7 +58,7 @@ func TestUpdateNotaryNodes(t *testing.T) {
 			require.NoError(t, err)
 			require.NoError(t, w.Accounts[1].Decrypt("one", w.Scrypt))
 			ntr.UpdateNotaryNodes(keys.PublicKeys{w.Accounts[1].PublicKey()})
-			require.Equal(t, w.Accounts[1], ntr.currAccount)
+			require.Equal(t, w.Accounts[1].PublicKey(), ntr.currAccount.PublicKey())
 		})
 		t.Run("bad config password", func(t *testing.T) {
 			w, err := wallet.NewWalletFromFile("./testdata/notary1.json")
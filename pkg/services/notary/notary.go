@@ -6,7 +6,9 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"math/big"
 	"sync"
+	"time"
 
 	"github.com/nspcc-dev/neo-go/pkg/config"
 	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
@@ -17,11 +19,12 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
 	"github.com/nspcc-dev/neo-go/pkg/network/payload"
+	"github.com/nspcc-dev/neo-go/pkg/services/signer"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm"
 	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
-	"github.com/nspcc-dev/neo-go/pkg/wallet"
 	"go.uber.org/atomic"
 	"go.uber.org/zap"
 )
@@ -31,7 +34,9 @@ type (
 	Ledger interface {
 		BlockHeight() uint32
 		GetMaxVerificationGAS() int64
+		GetNotaryBalance(acc util.Uint160) *big.Int
 		GetNotaryContractScriptHash() util.Uint160
+		GetNotaryDepositExpiration(acc util.Uint160) uint32
 		SubscribeForBlocks(ch chan *block.Block)
 		UnsubscribeFromBlocks(ch chan *block.Block)
 		VerifyWitness(util.Uint160, hash.Hashable, *transaction.Witness, int64) (int64, error)
@@ -56,11 +61,20 @@ type (
 		// requests represents a map of main transactions which needs to be completed
 		// with the associated fallback transactions grouped by the main transaction hash
 		requests map[util.Uint256]*request
+		// history contains states of recently removed requests.
+		history []result.NotaryRequestState
+
+		// stateCh is a channel where request state changes are sent to be
+		// delivered to subscribers.
+		stateCh chan result.NotaryRequestState
+		// subsMtx protects stateSubs.
+		subsMtx   sync.RWMutex
+		stateSubs map[chan result.NotaryRequestState]struct{}
 
 		// accMtx protects account.
 		accMtx      sync.RWMutex
-		currAccount *wallet.Account
-		wallet      *wallet.Wallet
+		currAccount *signer.Account
+		signer      signer.Signer
 
 		mp *mempool.Pool
 		// requests channel
@@ -97,6 +111,21 @@ type (
 		fallbacks         []*transaction.Transaction
 
 		witnessInfo []witnessInfo
+
+		// status is the request processing status (see result.NotaryRequestState).
+		status string
+		// received and updated are Unix timestamps (in milliseconds) of the
+		// request receiving and the last state change.
+		received uint64
+		updated  uint64
+		// verificationErr is the main transaction verification error.
+		verificationErr error
+		// sendErr is the last main transaction sending error.
+		sendErr error
+		// sentHash is the hash of the last transaction sent.
+		sentHash *util.Uint256
+		// fallbackReasonSent is the reason of the last fallback sending.
+		fallbackReasonSent string
 	}
 
 	// witnessInfo represents information about the signer and its witness.
@@ -130,29 +159,22 @@ func (r request) isMainCompleted() bool {
 
 // NewNotary returns a new Notary module.
 func NewNotary(cfg Config, net netmode.Magic, mp *mempool.Pool, onTransaction func(tx *transaction.Transaction) error) (*Notary, error) {
-	w := cfg.MainCfg.UnlockWallet
-	wallet, err := wallet.NewWalletFromFile(w.Path)
+	s, err := signer.New(cfg.MainCfg.UnlockWallet, cfg.MainCfg.RemoteSigner, signer.DomainNotary)
 	if err != nil {
 		return nil, err
 	}
-
-	haveAccount := false
-	for _, acc := range wallet.Accounts {
-		if err := acc.Decrypt(w.Password, wallet.Scrypt); err == nil {
-			haveAccount = true
-			break
-		}
-	}
-	if !haveAccount {
-		return nil, errors.New("no wallet account could be unlocked")
+	if s == nil {
+		return nil, errors.New("no wallet or remote signer configured")
 	}
 
 	return &Notary{
 		requests:      make(map[util.Uint256]*request),
+		stateCh:       make(chan result.NotaryRequestState, defaultTxChannelCapacity),
+		stateSubs:     make(map[chan result.NotaryRequestState]struct{}),
 		Config:        cfg,
 		Network:       net,
 		started:       atomic.NewBool(false),
-		wallet:        wallet,
+		signer:        s,
 		onTransaction: onTransaction,
 		newTxs:        make(chan txHashPair, defaultTxChannelCapacity),
 		mp:            mp,
@@ -178,6 +200,7 @@ func (n *Notary) Start() {
 	n.Config.Chain.SubscribeForBlocks(n.blocksCh)
 	n.mp.SubscribeForTransactions(n.reqCh)
 	go n.newTxCallbackLoop()
+	go n.stateEventLoop()
 	go n.mainLoop()
 }
 
@@ -227,7 +250,7 @@ func (n *Notary) Shutdown() {
 	n.Config.Log.Info("stopping notary service")
 	close(n.stopCh)
 	<-n.done
-	n.wallet.Close()
+	n.signer.Close()
 }
 
 // OnNewRequest is a callback method which is called after a new notary request is added to the notary request pool.
@@ -251,7 +274,8 @@ func (n *Notary) OnNewRequest(payload *payload.P2PNotaryRequest) {
 	}
 	n.reqMtx.Lock()
 	defer n.reqMtx.Unlock()
-	r, exists := n.requests[payload.MainTransaction.Hash()]
+	mainHash := payload.MainTransaction.Hash()
+	r, exists := n.requests[mainHash]
 	if exists {
 		for _, fb := range r.fallbacks {
 			if fb.Hash().Equals(payload.FallbackTransaction.Hash()) {
@@ -270,19 +294,26 @@ func (n *Notary) OnNewRequest(payload *payload.P2PNotaryRequest) {
 		r = &request{
 			main:              &cp,
 			minNotValidBefore: nvbFallback,
+			received:          uint64(time.Now().UnixMilli()),
 		}
-		n.requests[payload.MainTransaction.Hash()] = r
+		r.setStatus(result.NotaryRequestPending)
+		n.requests[mainHash] = r
+		notaryPendingRequests.Set(float64(len(n.requests)))
 	}
-	if r.witnessInfo == nil && validationErr == nil {
-		r.witnessInfo = newInfo
+	if r.witnessInfo == nil {
+		if validationErr == nil {
+			r.witnessInfo = newInfo
+		}
+		r.verificationErr = validationErr
 	}
 	// Allow modification of a fallback transaction got from the notary request pool.
 	// It has dummy Notary witness attached => its size won't be changed.
 	r.fallbacks = append(r.fallbacks, payload.FallbackTransaction)
+	defer n.notifyState(mainHash, r)
 	if exists && r.isMainCompleted() || validationErr != nil {
 		return
 	}
-	mainHash := hash.NetSha256(uint32(n.Network), r.main).BytesBE()
+	mainSigHash := hash.NetSha256(uint32(n.Network), r.main).BytesBE()
 	for i, w := range payload.MainTransaction.Scripts {
 		if len(w.InvocationScript) == 0 || // check that signature for this witness was provided
 			(r.witnessInfo[i].nSigsLeft == 0 && r.witnessInfo[i].typ != Contract) { // check that signature wasn't yet added (consider receiving the same payload multiple times)
@@ -297,7 +328,7 @@ func (n *Notary) OnNewRequest(payload *payload.P2PNotaryRequest) {
 			}
 			r.main.Scripts[i].InvocationScript = w.InvocationScript
 		case Signature:
-			if r.witnessInfo[i].pubs[0].Verify(w.InvocationScript[2:], mainHash) {
+			if r.witnessInfo[i].pubs[0].Verify(w.InvocationScript[2:], mainSigHash) {
 				r.main.Scripts[i] = w
 				r.witnessInfo[i].nSigsLeft--
 			}
@@ -310,7 +341,7 @@ func (n *Notary) OnNewRequest(payload *payload.P2PNotaryRequest) {
 				if r.witnessInfo[i].sigs[pub] != nil {
 					continue // signature for this pub has already been added
 				}
-				if pub.Verify(w.InvocationScript[2:], mainHash) { // then pub is the owner of the signature
+				if pub.Verify(w.InvocationScript[2:], mainSigHash) { // then pub is the owner of the signature
 					r.witnessInfo[i].sigs[pub] = w.InvocationScript
 					r.witnessInfo[i].nSigsLeft--
 					if r.witnessInfo[i].nSigsLeft == 0 {
@@ -329,8 +360,11 @@ func (n *Notary) OnNewRequest(payload *payload.P2PNotaryRequest) {
 			// been added - we're OK with that, let the fallback TX to be added
 		}
 	}
+	if r.isMainCompleted() && r.status == result.NotaryRequestPending {
+		r.setStatus(result.NotaryRequestCompleted)
+	}
 	if r.isMainCompleted() && r.minNotValidBefore > n.Config.Chain.BlockHeight() {
-		if err := n.finalize(acc, r.main, payload.MainTransaction.Hash()); err != nil {
+		if err := n.finalize(acc, r.main, mainHash); err != nil {
 			n.Config.Log.Error("failed to finalize main transaction",
 				zap.String("hash", r.main.Hash().StringLE()),
 				zap.Error(err))
@@ -358,7 +392,9 @@ func (n *Notary) OnRequestRemoval(pld *payload.P2PNotaryRequest) {
 		}
 	}
 	if len(r.fallbacks) == 0 {
-		delete(n.requests, r.main.Hash())
+		n.removeRequest(pld.MainTransaction.Hash(), r)
+	} else {
+		n.notifyState(pld.MainTransaction.Hash(), r)
 	}
 }
 
@@ -395,13 +431,17 @@ func (n *Notary) PostPersist() {
 }
 
 // finalize adds missing Notary witnesses to the transaction (main or fallback) and pushes it to the network.
-func (n *Notary) finalize(acc *wallet.Account, tx *transaction.Transaction, h util.Uint256) error {
+func (n *Notary) finalize(acc *signer.Account, tx *transaction.Transaction, h util.Uint256) error {
+	sig, err := acc.SignHashable(uint32(n.Network), tx, signer.DomainNotary)
+	if err != nil {
+		return fmt.Errorf("failed to sign transaction: %w", err)
+	}
 	notaryWitness := transaction.Witness{
-		InvocationScript:   append([]byte{byte(opcode.PUSHDATA1), keys.SignatureLen}, acc.SignHashable(n.Network, tx)...),
+		InvocationScript:   append([]byte{byte(opcode.PUSHDATA1), keys.SignatureLen}, sig...),
 		VerificationScript: []byte{},
 	}
-	for i, signer := range tx.Signers {
-		if signer.Account == n.Config.Chain.GetNotaryContractScriptHash() {
+	for i, s := range tx.Signers {
+		if s.Account == n.Config.Chain.GetNotaryContractScriptHash() {
 			tx.Scripts[i] = notaryWitness
 			break
 		}
@@ -459,13 +499,29 @@ func (n *Notary) newTxCallbackLoop() {
 			err := n.onTransaction(tx.tx)
 			if err != nil {
 				n.Config.Log.Error("new transaction callback finished with error", zap.Error(err))
+				if isMain {
+					n.reqMtx.Lock()
+					if r.sendErr == nil || r.sendErr.Error() != err.Error() {
+						r.sendErr = err
+						n.notifyState(tx.mainHash, r)
+					}
+					n.reqMtx.Unlock()
+				}
 				continue
 			}
 
 			n.reqMtx.Lock()
+			sentHash := tx.tx.Hash()
+			r.sentHash = &sentHash
 			if isMain {
 				r.isSent = true
+				r.sendErr = nil
+				r.setStatus(result.NotaryRequestSent)
+				n.notifyState(tx.mainHash, r)
 			} else {
+				r.fallbackReasonSent = r.fallbackReason()
+				notaryFallbacks.WithLabelValues(r.fallbackReasonSent).Inc()
+				r.setStatus(result.NotaryRequestFallback)
 				for i := range r.fallbacks {
 					if r.fallbacks[i].Hash() == tx.tx.Hash() {
 						r.fallbacks = append(r.fallbacks[:i], r.fallbacks[i+1:]...)
@@ -473,7 +529,9 @@ func (n *Notary) newTxCallbackLoop() {
 					}
 				}
 				if len(r.fallbacks) == 0 {
-					delete(n.requests, tx.mainHash)
+					n.removeRequest(tx.mainHash, r)
+				} else {
+					n.notifyState(tx.mainHash, r)
 				}
 			}
 			n.reqMtx.Unlock()
@@ -0,0 +1,41 @@
+package notary
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// Metrics for monitoring service.
+var (
+	// notaryPendingRequests prometheus metric.
+	notaryPendingRequests = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Help:      "Number of main transactions being processed by the notary service",
+			Name:      "notary_pending_requests",
+			Namespace: "neogo",
+		},
+	)
+	// notaryRequestTransitions prometheus metric.
+	notaryRequestTransitions = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Help:      "Number of notary request status changes by the new status",
+			Name:      "notary_request_transitions_total",
+			Namespace: "neogo",
+		},
+		[]string{"status"},
+	)
+	// notaryFallbacks prometheus metric.
+	notaryFallbacks = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Help:      "Number of fallback transactions sent by reason",
+			Name:      "notary_fallbacks_total",
+			Namespace: "neogo",
+		},
+		[]string{"reason"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(
+		notaryPendingRequests,
+		notaryRequestTransitions,
+		notaryFallbacks,
+	)
+}
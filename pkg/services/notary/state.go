@@ -0,0 +1,239 @@
+package notary
+
+import (
+	"sort"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// requestHistorySize is the number of states of removed requests kept.
+const requestHistorySize = 256
+
+// String returns the request type name.
+func (t RequestType) String() string {
+	switch t {
+	case Signature:
+		return "signature"
+	case MultiSignature:
+		return "multisignature"
+	case Contract:
+		return "contract"
+	default:
+		return "unknown"
+	}
+}
+
+// setStatus updates the request status. Subscribers are to be notified
+// about the change via notifyState.
+func (r *request) setStatus(status string) {
+	r.status = status
+	notaryRequestTransitions.WithLabelValues(status).Inc()
+}
+
+// notifyState sends the request state to subscribers. It must be called with
+// reqMtx held.
+func (n *Notary) notifyState(h util.Uint256, r *request) {
+	r.updated = uint64(time.Now().UnixMilli())
+	select {
+	case n.stateCh <- n.requestState(h, r):
+	default:
+	}
+}
+
+// removeRequest drops the request saving its state to the history. It must be
+// called with reqMtx held.
+func (n *Notary) removeRequest(h util.Uint256, r *request) {
+	delete(n.requests, h)
+	notaryPendingRequests.Set(float64(len(n.requests)))
+	if r.status == result.NotaryRequestPending || r.status == result.NotaryRequestCompleted {
+		r.setStatus(result.NotaryRequestRemoved)
+	}
+	n.notifyState(h, r)
+	if len(n.history) == requestHistorySize {
+		n.history = n.history[1:]
+	}
+	n.history = append(n.history, n.requestState(h, r))
+}
+
+// fallbackReason returns the reason of the fallback sending for the request.
+func (r *request) fallbackReason() string {
+	switch {
+	case r.verificationErr != nil:
+		return result.FallbackInvalid
+	case !r.isMainCompleted():
+		return result.FallbackIncomplete
+	default:
+		return result.FallbackExpired
+	}
+}
+
+// requestState returns the current state of the request without deposits
+// (they're filled in by fillDeposits).
+func (n *Notary) requestState(h util.Uint256, r *request) result.NotaryRequestState {
+	st := result.NotaryRequestState{
+		Hash:            h,
+		Status:          r.status,
+		Received:        r.received,
+		Updated:         r.updated,
+		ValidUntilBlock: r.main.ValidUntilBlock,
+		FallbackHeight:  r.minNotValidBefore,
+		Witnesses:       make([]result.NotaryWitnessState, 0, len(r.witnessInfo)),
+		Fallbacks:       make([]result.NotaryFallbackState, 0, len(r.fallbacks)),
+		FallbackReason:  r.fallbackReasonSent,
+	}
+	if r.sentHash != nil {
+		sent := *r.sentHash
+		st.SentHash = &sent
+	}
+	switch {
+	case r.verificationErr != nil:
+		st.Error = r.verificationErr.Error()
+	case r.sendErr != nil:
+		st.Error = r.sendErr.Error()
+	}
+	for i, wi := range r.witnessInfo {
+		ws := result.NotaryWitnessState{
+			Account:   r.main.Signers[i].Account,
+			Type:      wi.typ.String(),
+			Collected: keys.PublicKeys{},
+			Missing:   keys.PublicKeys{},
+			Completed: wi.nSigsLeft == 0,
+		}
+		switch wi.typ {
+		case Signature:
+			ws.Required = 1
+			if wi.nSigsLeft == 0 {
+				ws.Collected = append(ws.Collected, wi.pubs[0])
+			} else {
+				ws.Missing = append(ws.Missing, wi.pubs[0])
+			}
+		case MultiSignature:
+			ws.Required = int(wi.nSigsLeft) + len(wi.sigs)
+			for _, pub := range wi.pubs {
+				if wi.sigs[pub] != nil {
+					ws.Collected = append(ws.Collected, pub)
+				} else {
+					ws.Missing = append(ws.Missing, pub)
+				}
+			}
+		}
+		st.Witnesses = append(st.Witnesses, ws)
+	}
+	for _, fb := range r.fallbacks {
+		st.Fallbacks = append(st.Fallbacks, result.NotaryFallbackState{
+			Hash:           fb.Hash(),
+			NotValidBefore: fb.GetAttributes(transaction.NotValidBeforeT)[0].Value.(*transaction.NotValidBefore).Height,
+			Sender:         fb.Signers[1].Account,
+		})
+	}
+	return st
+}
+
+// fillDeposits sets Notary contract deposits of fallback senders.
+func (n *Notary) fillDeposits(st *result.NotaryRequestState) {
+	for i := range st.Fallbacks {
+		acc := st.Fallbacks[i].Sender
+		st.Fallbacks[i].Deposit = result.NotaryDeposit{
+			Amount: n.Config.Chain.GetNotaryBalance(acc).Int64(),
+			Till:   n.Config.Chain.GetNotaryDepositExpiration(acc),
+		}
+	}
+}
+
+// GetRequests returns states of requests being processed by the service
+// sorted by the receiving time.
+func (n *Notary) GetRequests() []result.NotaryRequestState {
+	n.reqMtx.RLock()
+	res := make([]result.NotaryRequestState, 0, len(n.requests))
+	for h, r := range n.requests {
+		res = append(res, n.requestState(h, r))
+	}
+	n.reqMtx.RUnlock()
+
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Received != res[j].Received {
+			return res[i].Received < res[j].Received
+		}
+		return res[i].Hash.CompareTo(res[j].Hash) < 0
+	})
+	for i := range res {
+		n.fillDeposits(&res[i])
+	}
+	return res
+}
+
+// GetRequestState returns the state of the request with the given main
+// transaction hash. States of some recently removed requests are also
+// available.
+func (n *Notary) GetRequestState(h util.Uint256) (result.NotaryRequestState, bool) {
+	n.reqMtx.RLock()
+	var (
+		st result.NotaryRequestState
+		ok bool
+	)
+	if r, exists := n.requests[h]; exists {
+		st, ok = n.requestState(h, r), true
+	} else {
+		for i := len(n.history) - 1; i >= 0; i-- {
+			if n.history[i].Hash == h {
+				st, ok = n.history[i], true
+				break
+			}
+		}
+	}
+	n.reqMtx.RUnlock()
+	if ok {
+		n.fillDeposits(&st)
+	}
+	return st, ok
+}
+
+// SubscribeForStateChanges adds the given channel to request state change
+// event broadcasting. Make sure it's read from regularly as not reading these
+// events might affect other Notary functions.
+func (n *Notary) SubscribeForStateChanges(ch chan result.NotaryRequestState) {
+	n.subsMtx.Lock()
+	defer n.subsMtx.Unlock()
+	n.stateSubs[ch] = struct{}{}
+}
+
+// UnsubscribeFromStateChanges unsubscribes the given channel from request
+// state change events, you can close it afterwards. Passing non-subscribed
+// channel is a no-op. The channel is drained until unsubscription is
+// completed, so it's safe to call it from the routine reading the channel.
+func (n *Notary) UnsubscribeFromStateChanges(ch chan result.NotaryRequestState) {
+	done := make(chan struct{})
+	go func() {
+		n.subsMtx.Lock()
+		delete(n.stateSubs, ch)
+		n.subsMtx.Unlock()
+		close(done)
+	}()
+	for {
+		select {
+		case <-ch:
+		case <-done:
+			return
+		}
+	}
+}
+
+func (n *Notary) stateEventLoop() {
+	for {
+		select {
+		case st := <-n.stateCh:
+			n.fillDeposits(&st)
+			n.subsMtx.RLock()
+			for ch := range n.stateSubs {
+				ch <- st
+			}
+			n.subsMtx.RUnlock()
+		case <-n.stopCh:
+			return
+		}
+	}
+}
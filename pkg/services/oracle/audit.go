@@ -0,0 +1,338 @@
+package oracle
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"go.uber.org/zap"
+)
+
+// defaultAuditMaxRecords is the default number of request records kept.
+const defaultAuditMaxRecords = 1000
+
+// auditRecord is a request record with its update order.
+type auditRecord struct {
+	result.OracleRequestRecord
+	// seq is the number of the last record update, it defines the eviction
+	// order.
+	seq uint64
+}
+
+// auditLog keeps request records in memory and (optionally) appends every
+// record update to a file, records are restored from it on startup. The file
+// is compacted when it contains too many outdated records.
+type auditLog struct {
+	lock    sync.RWMutex
+	max     int
+	records map[uint64]*auditRecord
+	seq     uint64
+	active  int
+	path    string
+	file    *os.File
+	// lines is the number of records in the file.
+	lines int
+	log   *zap.Logger
+}
+
+func completed(s result.OracleRequestStatus) bool {
+	return s == result.OracleRequestFinished || s == result.OracleRequestExpired
+}
+
+func newAuditLog(cfg config.OracleAuditLog, log *zap.Logger) (*auditLog, error) {
+	a := &auditLog{
+		max:     cfg.MaxRecords,
+		records: make(map[uint64]*auditRecord),
+		path:    cfg.Path,
+		log:     log,
+	}
+	if a.max <= 0 {
+		a.max = defaultAuditMaxRecords
+	}
+	if len(cfg.Path) == 0 {
+		return a, nil
+	}
+	if err := a.load(); err != nil {
+		return nil, fmt.Errorf("failed to load audit log: %w", err)
+	}
+	if err := a.compact(); err != nil {
+		return nil, fmt.Errorf("failed to compact audit log: %w", err)
+	}
+	oracleActiveRequests.Set(float64(a.active))
+	return a, nil
+}
+
+// load reads records from the file, the latest record for every request is
+// used.
+func (a *auditLog) load() error {
+	f, err := os.Open(a.path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	defer f.Close()
+
+	sc := bufio.NewScanner(f)
+	sc.Buffer(nil, 1024*1024)
+	for sc.Scan() {
+		r := new(auditRecord)
+		if err := json.Unmarshal(sc.Bytes(), &r.OracleRequestRecord); err != nil {
+			// The last line can be incomplete if the node was stopped
+			// abnormally.
+			a.log.Warn("skipping invalid oracle audit record", zap.Error(err))
+			continue
+		}
+		a.seq++
+		r.seq = a.seq
+		a.records[r.ID] = r
+	}
+	if err := sc.Err(); err != nil {
+		return err
+	}
+	for _, r := range a.records {
+		if !completed(r.Status) {
+			a.active++
+		}
+	}
+	a.evict()
+	return nil
+}
+
+// compact rewrites the file with the current records only keeping their
+// update order and reopens it for appending. It must be called with the
+// lock held (or before the log is used).
+func (a *auditLog) compact() error {
+	if a.file != nil {
+		_ = a.file.Close()
+		a.file = nil
+	}
+	rs := make([]*auditRecord, 0, len(a.records))
+	for _, r := range a.records {
+		rs = append(rs, r)
+	}
+	sort.Slice(rs, func(i, j int) bool { return rs[i].seq < rs[j].seq })
+
+	tmp := a.path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(f)
+	for _, r := range rs {
+		data, err := json.Marshal(&r.OracleRequestRecord)
+		if err != nil {
+			_ = f.Close()
+			return err
+		}
+		_, _ = w.Write(append(data, '\n'))
+	}
+	err = w.Flush()
+	if err == nil {
+		err = f.Close()
+	} else {
+		_ = f.Close()
+	}
+	if err != nil {
+		return err
+	}
+	if err = os.Rename(tmp, a.path); err != nil {
+		return err
+	}
+	a.lines = len(rs)
+	a.file, err = os.OpenFile(a.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	return err
+}
+
+// needsCompaction checks whether the file contains at least twice as many
+// records as needed (but not less than twice the limit, so that compaction
+// happens rarely). It must be called with the lock held.
+func (a *auditLog) needsCompaction() bool {
+	return a.lines >= 2*a.max && a.lines >= 2*len(a.records)
+}
+
+// update applies f to the request record creating it if needed (and allowed)
+// and saves the result.
+func (a *auditLog) update(id uint64, create bool, f func(r *result.OracleRequestRecord)) {
+	now := uint64(time.Now().UnixMilli())
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	r, ok := a.records[id]
+	if !ok {
+		if !create {
+			return
+		}
+		r = &auditRecord{OracleRequestRecord: result.OracleRequestRecord{
+			ID:       id,
+			Status:   result.OracleRequestPending,
+			Received: now,
+		}}
+		a.records[id] = r
+		a.active++
+		oracleRequests.Inc()
+	}
+	wasCompleted := completed(r.Status)
+	f(&r.OracleRequestRecord)
+	a.seq++
+	r.seq = a.seq
+	r.Updated = now
+	if completed(r.Status) != wasCompleted {
+		if wasCompleted {
+			a.active++
+		} else {
+			a.active--
+			oracleCompletedRequests.WithLabelValues(string(r.Status)).Inc()
+			oracleRequestDuration.Observe(float64(r.Updated-r.Received) / 1000)
+		}
+	}
+	oracleActiveRequests.Set(float64(a.active))
+	a.evict()
+	if a.file != nil {
+		a.save(r)
+	}
+}
+
+// save appends the record to the file compacting it if needed. It must be
+// called with the lock held.
+func (a *auditLog) save(r *auditRecord) {
+	data, err := json.Marshal(&r.OracleRequestRecord)
+	if err == nil {
+		_, err = a.file.Write(append(data, '\n'))
+	}
+	if err != nil {
+		a.log.Warn("failed to save oracle audit record", zap.Uint64("id", r.ID), zap.Error(err))
+		return
+	}
+	a.lines++
+	if a.needsCompaction() {
+		if err := a.compact(); err != nil {
+			a.log.Warn("failed to compact oracle audit log", zap.Error(err))
+		}
+	}
+}
+
+// evict drops the least recently updated records of completed requests
+// until the number of records fits the limit. It must be called with the
+// lock held.
+func (a *auditLog) evict() {
+	for len(a.records) > a.max {
+		var oldest *auditRecord
+		for _, r := range a.records {
+			if completed(r.Status) && (oldest == nil || r.seq < oldest.seq) {
+				oldest = r
+			}
+		}
+		if oldest == nil {
+			return
+		}
+		delete(a.records, oldest.ID)
+	}
+}
+
+// get returns a copy of the request record.
+func (a *auditLog) get(id uint64) (result.OracleRequestRecord, bool) {
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+	r, ok := a.records[id]
+	if !ok {
+		return result.OracleRequestRecord{}, false
+	}
+	return r.OracleRequestRecord, true
+}
+
+// list returns copies of records with the given status (all of them if it's
+// empty) sorted by request ID.
+func (a *auditLog) list(status result.OracleRequestStatus) []result.OracleRequestRecord {
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+	res := make([]result.OracleRequestRecord, 0, len(a.records))
+	for _, r := range a.records {
+		if len(status) == 0 || r.Status == status {
+			res = append(res, r.OracleRequestRecord)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].ID < res[j].ID })
+	return res
+}
+
+func (a *auditLog) close() {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	if a.file != nil {
+		_ = a.file.Close()
+		a.file = nil
+	}
+}
+
+// auditRequest saves request data into its record.
+func (o *Oracle) auditRequest(id uint64, req *state.OracleRequest) {
+	o.audit.update(id, true, func(r *result.OracleRequestRecord) {
+		setRequestData(r, req)
+	})
+}
+
+func setRequestData(r *result.OracleRequestRecord, req *state.OracleRequest) {
+	r.URL = req.URL
+	r.Filter = req.Filter
+	txID := req.OriginalTxID
+	r.OriginalTxID = &txID
+}
+
+// auditResponse saves the state of response transactions into the request
+// record, sent is the transaction sent to the network (if ready is true).
+// It must be called with incTx locked.
+func (o *Oracle) auditResponse(id uint64, incTx *incompleteTx, ready bool, sent *transaction.Transaction, f func(r *result.OracleRequestRecord)) {
+	required := smartcontract.GetDefaultHonestNodeCount(len(o.getOracleNodes()))
+	o.audit.update(id, true, func(r *result.OracleRequestRecord) {
+		r.Attempts = incTx.attempts
+		r.Required = required
+		r.Signatures = incTx.signers(false)
+		r.BackupSignatures = incTx.signers(true)
+		if incTx.tx != nil {
+			h := incTx.tx.Hash()
+			r.TxHash = &h
+		}
+		if incTx.backupTx != nil {
+			h := incTx.backupTx.Hash()
+			r.BackupTxHash = &h
+		}
+		if f != nil {
+			f(r)
+		}
+		if ready {
+			h := sent.Hash()
+			r.SentTxHash = &h
+			if !completed(r.Status) {
+				r.Status = result.OracleRequestSent
+			}
+		}
+	})
+	if ready {
+		addTransactionMetric(sent == incTx.backupTx)
+	}
+}
+
+// GetRequest returns the audit record of the request with the given ID.
+func (o *Oracle) GetRequest(id uint64) (result.OracleRequestRecord, bool) {
+	return o.audit.get(id)
+}
+
+// GetRequests returns audit records of requests with the given status (all
+// records are returned for an empty one) sorted by request ID. Records of
+// completed requests are kept up to the configured limit.
+func (o *Oracle) GetRequests(status result.OracleRequestStatus) []result.OracleRequestRecord {
+	return o.audit.list(status)
+}
@@ -0,0 +1,103 @@
+package oracle
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestAuditLog(t *testing.T) {
+	cfg := config.OracleAuditLog{
+		Path:       filepath.Join(t.TempDir(), "audit.log"),
+		MaxRecords: 3,
+	}
+	a, err := newAuditLog(cfg, zaptest.NewLogger(t))
+	require.NoError(t, err)
+
+	for i := uint64(0); i < 4; i++ {
+		a.update(i, true, func(r *result.OracleRequestRecord) {
+			r.URL = "https://example.com"
+		})
+	}
+	a.update(10, false, func(r *result.OracleRequestRecord) {})
+	_, ok := a.get(10)
+	require.False(t, ok)
+
+	// Active requests are never evicted.
+	require.Equal(t, 4, len(a.list("")))
+	a.update(1, false, func(r *result.OracleRequestRecord) { r.Status = result.OracleRequestFinished })
+	require.Equal(t, 3, len(a.list("")))
+	_, ok = a.get(1)
+	require.False(t, ok)
+
+	a.update(0, false, func(r *result.OracleRequestRecord) { r.Status = result.OracleRequestExpired })
+	a.update(2, false, func(r *result.OracleRequestRecord) { r.Status = result.OracleRequestSent })
+	a.close()
+
+	// Append some garbage imitating an incomplete write.
+	f, err := os.OpenFile(cfg.Path, os.O_APPEND|os.O_WRONLY, 0)
+	require.NoError(t, err)
+	_, err = f.Write([]byte(`{"id":`))
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	a, err = newAuditLog(cfg, zaptest.NewLogger(t))
+	require.NoError(t, err)
+	t.Cleanup(a.close)
+
+	rs := a.list("")
+	require.Equal(t, 3, len(rs))
+	require.Equal(t, []uint64{0, 2, 3}, []uint64{rs[0].ID, rs[1].ID, rs[2].ID})
+	require.Equal(t, result.OracleRequestExpired, rs[0].Status)
+	require.Equal(t, result.OracleRequestSent, rs[1].Status)
+	require.Equal(t, result.OracleRequestPending, rs[2].Status)
+	require.Equal(t, "https://example.com", rs[2].URL)
+	require.Equal(t, 2, a.active)
+	require.Equal(t, []result.OracleRequestRecord{rs[1]}, a.list(result.OracleRequestSent))
+
+	// The file is compacted on startup.
+	data, err := os.ReadFile(cfg.Path)
+	require.NoError(t, err)
+	lines := 0
+	for _, b := range data {
+		if b == '\n' {
+			lines++
+		}
+	}
+	require.Equal(t, 3, lines)
+}
+
+func TestAuditLogCompaction(t *testing.T) {
+	cfg := config.OracleAuditLog{
+		Path:       filepath.Join(t.TempDir(), "audit.log"),
+		MaxRecords: 2,
+	}
+	a, err := newAuditLog(cfg, zaptest.NewLogger(t))
+	require.NoError(t, err)
+	t.Cleanup(a.close)
+
+	countLines := func() int {
+		data, err := os.ReadFile(cfg.Path)
+		require.NoError(t, err)
+		return bytes.Count(data, []byte{'\n'})
+	}
+	for i := uint64(0); i < 10; i++ {
+		a.update(i, true, func(r *result.OracleRequestRecord) {})
+		a.update(i, false, func(r *result.OracleRequestRecord) { r.Status = result.OracleRequestFinished })
+		require.Less(t, countLines(), 2*cfg.MaxRecords+1)
+	}
+	require.Equal(t, a.lines, countLines())
+
+	// Updates are still saved after compaction.
+	a.close()
+	a, err = newAuditLog(cfg, zaptest.NewLogger(t))
+	require.NoError(t, err)
+	rs := a.list("")
+	require.Equal(t, []uint64{8, 9}, []uint64{rs[0].ID, rs[1].ID})
+}
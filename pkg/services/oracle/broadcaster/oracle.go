@@ -40,10 +40,7 @@ func New(cfg config.OracleConfiguration, log *zap.Logger) *OracleBroadcaster {
 }
 
 // SendResponse implements interfaces.Broadcaster.
-func (r *OracleBroadcaster) SendResponse(priv *keys.PrivateKey, resp *transaction.OracleResponse, txSig []byte) {
-	pub := priv.PublicKey()
-	data := GetMessage(pub.Bytes(), resp.ID, txSig)
-	msgSig := priv.Sign(data)
+func (r *OracleBroadcaster) SendResponse(pub *keys.PublicKey, resp *transaction.OracleResponse, txSig []byte, msgSig []byte) {
 	params := []any{
 		base64.StdEncoding.EncodeToString(pub.Bytes()),
 		resp.ID,
@@ -0,0 +1,75 @@
+package oracle
+
+import (
+	"context"
+	"errors"
+	gio "io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+)
+
+// FileScheme is the name of file URI scheme.
+const FileScheme = "file"
+
+// fileRootParameter is the file protocol parameter specifying the directory
+// to serve files from.
+const fileRootParameter = "Root"
+
+// fileProtocol serves files from a local directory, file:///path/to/data URL
+// refers to the Root/path/to/data file. It's mostly useful for tests and
+// private networks.
+type fileProtocol struct {
+	root string
+}
+
+// NewFileProtocol returns a handler for the file scheme serving files from
+// the given directory. Paths can't refer to files outside of it.
+func NewFileProtocol(root string) ProtocolHandler {
+	return &fileProtocol{root: root}
+}
+
+func newFileProtocol(cfg config.OracleProtocol) (ProtocolHandler, error) {
+	root := cfg.Parameters[fileRootParameter]
+	if len(root) == 0 {
+		return nil, errors.New("no Root parameter specified")
+	}
+	st, err := os.Stat(root)
+	if err != nil {
+		return nil, err
+	}
+	if !st.IsDir() {
+		return nil, errors.New("root path is not a directory")
+	}
+	return NewFileProtocol(root), nil
+}
+
+// Fetch implements the ProtocolHandler interface.
+func (p *fileProtocol) Fetch(_ context.Context, req ProtocolRequest) (gio.ReadCloser, transaction.OracleResponseCode, error) {
+	name := req.URL.Path
+	if len(name) == 0 {
+		name = req.URL.Opaque
+	}
+	// Cleaning the rooted path removes all ".." elements leading outside.
+	name = filepath.Join(p.root, filepath.FromSlash(path.Clean("/"+name)))
+	f, err := os.Open(name)
+	if err != nil {
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+			return nil, transaction.NotFound, err
+		case errors.Is(err, fs.ErrPermission):
+			return nil, transaction.Forbidden, err
+		default:
+			return nil, transaction.Error, err
+		}
+	}
+	if st, err := f.Stat(); err != nil || st.IsDir() {
+		_ = f.Close()
+		return nil, transaction.NotFound, err
+	}
+	return f, transaction.Success, nil
+}
@@ -2,19 +2,106 @@ package oracle
 
 import (
 	"bytes"
+	"encoding/csv"
 	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
 	"unicode/utf8"
 
 	json "github.com/nspcc-dev/go-ordered-json"
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/services/oracle/jsonpath"
+	"github.com/nspcc-dev/neo-go/pkg/services/oracle/xpath"
 )
 
-func filter(value []byte, path string) ([]byte, error) {
+// Filter language prefixes, filters without a prefix are JSONPath ones.
+const (
+	jsonPathPrefix = "jsonpath:"
+	xpathPrefix    = "xpath:"
+	csvPrefix      = "csv:"
+)
+
+const (
+	// maxFilterResults is the maximum number of values selected by a filter.
+	maxFilterResults = 1024
+	// maxNumberLength is the maximum length of a string parsed as a number
+	// by aggregate functions.
+	maxNumberLength = 64
+	// maxNumberExponent is the maximum absolute value of a number exponent
+	// accepted by aggregate functions.
+	maxNumberExponent = 400
+)
+
+// aggregateFunc calculates a single value from the values selected.
+type aggregateFunc func([]any) (any, error)
+
+var (
+	aggregates = map[string]aggregateFunc{
+		"length": func(vs []any) (any, error) { return len(vs), nil },
+		"first":  func(vs []any) (any, error) { return pick(vs, 0) },
+		"last":   func(vs []any) (any, error) { return pick(vs, len(vs)-1) },
+		"sum":    sum,
+		"min":    func(vs []any) (any, error) { return extremum(vs, -1) },
+		"max":    func(vs []any) (any, error) { return extremum(vs, 1) },
+	}
+
+	// numberRegexp matches JSON numbers.
+	numberRegexp = regexp.MustCompile(`^-?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][+-]?[0-9]+)?$`)
+)
+
+// filter applies the filter to the value and returns JSON-encoded result.
+// The filter is an expression in one of the supported languages (JSONPath
+// by default) optionally wrapped into an aggregate function call.
+func filter(value []byte, f string) ([]byte, error) {
 	if !utf8.Valid(value) {
 		return nil, errors.New("not an UTF-8")
 	}
 
+	var agg aggregateFunc
+	if i := strings.IndexByte(f, '('); i > 0 && strings.HasSuffix(f, ")") {
+		if agg = aggregates[f[:i]]; agg != nil {
+			f = f[i+1 : len(f)-1]
+		}
+	}
+
+	var (
+		values []any
+		err    error
+	)
+	switch {
+	case strings.HasPrefix(f, xpathPrefix):
+		values, err = filterXPath(value, f[len(xpathPrefix):])
+	case strings.HasPrefix(f, csvPrefix):
+		values, err = filterCSV(value, f[len(csvPrefix):])
+	default:
+		values, err = filterJSONPath(value, strings.TrimPrefix(f, jsonPathPrefix))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var result any = values
+	if agg != nil {
+		result, err = agg(values)
+		if err != nil {
+			return nil, err
+		}
+	}
+	data, err := json.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > transaction.MaxOracleResultSize {
+		return nil, ErrResponseTooLarge
+	}
+	return data, nil
+}
+
+func filterJSONPath(value []byte, path string) ([]any, error) {
 	buf := bytes.NewBuffer(value)
 	d := json.NewDecoder(buf)
 	d.UseOrderedObject()
@@ -28,7 +115,240 @@ func filter(value []byte, path string) ([]byte, error) {
 	if !ok {
 		return nil, errors.New("invalid filter")
 	}
-	return json.Marshal(result)
+	return result, nil
+}
+
+func filterXPath(value []byte, path string) ([]any, error) {
+	ss, err := xpath.Get(path, value)
+	if err != nil {
+		return nil, err
+	}
+	values := make([]any, len(ss))
+	for i := range ss {
+		values[i] = ss[i]
+	}
+	return values, nil
+}
+
+// filterCSV selects CSV data according to the ';'-separated list of options:
+// header (the first record is a header), column=<name or 0-based index>,
+// row=<0-based index of data record, negative ones count from the end> and
+// delimiter=<character, 'tab' or 'semicolon'>. Selected records are returned
+// as arrays (or objects if there is a header) of strings, cells are returned
+// as strings if a column is specified.
+func filterCSV(value []byte, spec string) ([]any, error) {
+	var (
+		header    bool
+		column    string
+		hasColumn bool
+		row       int
+		hasRow    bool
+		comma     = ','
+		err       error
+	)
+	if len(spec) != 0 {
+		for _, opt := range strings.Split(spec, ";") {
+			name, val, hasVal := strings.Cut(opt, "=")
+			switch {
+			case name == "header" && !hasVal:
+				header = true
+			case name == "column" && hasVal:
+				column, hasColumn = val, true
+			case name == "row" && hasVal:
+				row, err = strconv.Atoi(val)
+				if err != nil {
+					return nil, fmt.Errorf("invalid row: %w", err)
+				}
+				hasRow = true
+			case name == "delimiter" && hasVal:
+				switch val {
+				case "tab":
+					comma = '\t'
+				case "semicolon":
+					comma = ';'
+				default:
+					if utf8.RuneCountInString(val) != 1 {
+						return nil, fmt.Errorf("invalid delimiter %q", val)
+					}
+					comma, _ = utf8.DecodeRuneInString(val)
+				}
+			default:
+				return nil, fmt.Errorf("invalid CSV filter option %q", opt)
+			}
+		}
+	}
+
+	r := csv.NewReader(bytes.NewReader(value))
+	r.Comma = comma
+	records, err := r.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	if header {
+		if len(records) == 0 {
+			return nil, errors.New("no CSV header")
+		}
+		names, records = records[0], records[1:]
+		for i := range names {
+			for j := 0; j < i; j++ {
+				if names[i] == names[j] {
+					return nil, fmt.Errorf("duplicate CSV column %q", names[i])
+				}
+			}
+		}
+	}
+
+	col := -1
+	if hasColumn {
+		for i := range names {
+			if names[i] == column {
+				col = i
+				break
+			}
+		}
+		if col < 0 {
+			width := len(names)
+			if !header && len(records) != 0 {
+				width = len(records[0])
+			}
+			n, err := strconv.Atoi(column)
+			if err != nil || n < 0 || n >= width {
+				return nil, fmt.Errorf("unknown CSV column %q", column)
+			}
+			col = n
+		}
+	}
+
+	if hasRow {
+		if row < 0 {
+			row += len(records)
+		}
+		if row < 0 || row >= len(records) {
+			records = nil
+		} else {
+			records = records[row : row+1]
+		}
+	}
+	if len(records) > maxFilterResults {
+		return nil, errors.New("too many CSV records selected")
+	}
+
+	values := make([]any, len(records))
+	for i, rec := range records {
+		switch {
+		case col >= 0:
+			values[i] = rec[col]
+		case header:
+			obj := make(json.OrderedObject, len(rec))
+			for j := range rec {
+				obj[j] = json.Member{Key: names[j], Value: rec[j]}
+			}
+			values[i] = obj
+		default:
+			cells := make([]any, len(rec))
+			for j := range rec {
+				cells[j] = rec[j]
+			}
+			values[i] = cells
+		}
+	}
+	return values, nil
+}
+
+func pick(vs []any, i int) (any, error) {
+	if len(vs) == 0 {
+		return nil, errors.New("nothing selected")
+	}
+	return vs[i], nil
+}
+
+func sum(vs []any) (any, error) {
+	var (
+		total = new(big.Rat)
+		scale int
+	)
+	for _, v := range vs {
+		n, s, err := toDecimal(v)
+		if err != nil {
+			return nil, err
+		}
+		total.Add(total, n)
+		if s > scale {
+			scale = s
+		}
+	}
+	return formatDecimal(total, scale), nil
+}
+
+// extremum returns the minimum (sign = -1) or the maximum (sign = 1) number.
+func extremum(vs []any, sign int) (any, error) {
+	if len(vs) == 0 {
+		return nil, errors.New("nothing selected")
+	}
+	var (
+		res   *big.Rat
+		scale int
+	)
+	for _, v := range vs {
+		n, s, err := toDecimal(v)
+		if err != nil {
+			return nil, err
+		}
+		if res == nil || n.Cmp(res) == sign {
+			res, scale = n, s
+		}
+	}
+	return formatDecimal(res, scale), nil
+}
+
+// toDecimal converts a JSON number or a string containing one into an exact
+// rational value and returns the number of its fractional decimal digits.
+func toDecimal(v any) (*big.Rat, int, error) {
+	var s string
+	switch v := v.(type) {
+	case float64:
+		s = strconv.FormatFloat(v, 'g', -1, 64)
+	case string:
+		s = strings.TrimSpace(v)
+	default:
+		return nil, 0, fmt.Errorf("not a number: %T", v)
+	}
+	m := numberRegexp.FindStringSubmatch(s)
+	if len(s) > maxNumberLength || m == nil {
+		return nil, 0, fmt.Errorf("not a number: %q", s)
+	}
+	var exp int
+	if len(m[3]) != 0 {
+		var err error
+		exp, err = strconv.Atoi(strings.TrimPrefix(m[3][1:], "+"))
+		if err != nil || exp > maxNumberExponent || exp < -maxNumberExponent {
+			return nil, 0, fmt.Errorf("number is out of range: %s", s)
+		}
+	}
+	scale := len(m[2]) - 1 - exp
+	if len(m[2]) == 0 {
+		scale = -exp
+	}
+	if scale < 0 {
+		scale = 0
+	}
+	n, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return nil, 0, fmt.Errorf("not a number: %q", s)
+	}
+	return n, scale, nil
+}
+
+// formatDecimal returns the number in the plain decimal notation without
+// trailing fractional zeros.
+func formatDecimal(n *big.Rat, scale int) json.Number {
+	if n.IsInt() {
+		return json.Number(n.Num().String())
+	}
+	s := strings.TrimRight(n.FloatString(scale), "0")
+	return json.Number(strings.TrimSuffix(s, "."))
 }
 
 func filterRequest(result []byte, req *state.OracleRequest) ([]byte, error) {
@@ -81,3 +81,109 @@ func TestFilterOOM(t *testing.T) {
 		require.Error(t, err)
 	})
 }
+
+func TestFilterLanguages(t *testing.T) {
+	const (
+		js   = `{"Prices":[{"Name":"a","Price":1.5},{"Name":"b","Price":2.25},{"Name":"c","Price":"3"}]}`
+		xml  = `<rates><rate currency="USD">1.07</rate><rate currency="GBP">0.88</rate></rates>`
+		data = "name,price\nanvil,50\nbolt,0.25\nnut,0.5\n"
+	)
+	testCases := []struct {
+		value, filter, result string
+	}{
+		{js, "$.Prices[0].Name", `["a"]`},
+		{js, "jsonpath:$.Prices[0].Name", `["a"]`},
+		{js, "jsonpath:", `[` + js + `]`},
+		{js, "length($.Prices[*])", `3`},
+		{js, "length(jsonpath:$.Missing)", `0`},
+		{js, "first($.Prices[*].Name)", `"a"`},
+		{js, "last($.Prices[*])", `{"Name":"c","Price":"3"}`},
+		{js, "sum($.Prices[*].Price)", `6.75`},
+		{js, "min($.Prices[*].Price)", `1.5`},
+		{js, "max($.Prices[*].Price)", `3`},
+		{js, "sum($.Missing)", `0`},
+		{xml, "xpath:/rates/rate", `["1.07","0.88"]`},
+		{xml, "xpath:/rates/rate[@currency='GBP']", `["0.88"]`},
+		{xml, "first(xpath://rate/@currency)", `"USD"`},
+		{xml, "sum(xpath:/rates/rate)", `1.95`},
+		{data, "csv:", `[["name","price"],["anvil","50"],["bolt","0.25"],["nut","0.5"]]`},
+		{data, "csv:header", `[{"name":"anvil","price":"50"},{"name":"bolt","price":"0.25"},{"name":"nut","price":"0.5"}]`},
+		{data, "csv:header;row=1", `[{"name":"bolt","price":"0.25"}]`},
+		{data, "csv:header;row=-1;column=name", `["nut"]`},
+		{data, "csv:header;row=5", `[]`},
+		{data, "csv:header;column=1", `["50","0.25","0.5"]`},
+		{data, "csv:row=0;column=1", `["price"]`},
+		{data, "sum(csv:header;column=price)", `50.75`},
+		{data, "max(csv:header;column=price)", `50`},
+		{"a\tb\n1\t2\n", "csv:header;delimiter=tab;column=b", `["2"]`},
+		{"a;b\n1;2\n", "csv:delimiter=semicolon;column=0", `["a","1"]`},
+		{"a|b\n1|2\n", "csv:delimiter=|;row=1", `[["1","2"]]`},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.filter, func(t *testing.T) {
+			actual, err := filter([]byte(tc.value), tc.filter)
+			require.NoError(t, err)
+			require.Equal(t, tc.result, string(actual))
+		})
+	}
+
+	errCases := []struct {
+		value, filter string
+	}{
+		{js, "length($.Prices[*]"},
+		{js, "avg($.Prices[*].Price)"},
+		{js, "first($.Missing)"},
+		{js, "max($.Missing)"},
+		{js, "sum($.Prices[*])"},
+		{js, "sum($.Prices[*].Name)"},
+		{`[true]`, "sum($[*])"},
+		{`["0x10"]`, "sum($[*])"},
+		{`["1/2"]`, "sum($[*])"},
+		{`["1e401"]`, "sum($[*])"},
+		{`["1e99999999999999999999"]`, "sum($[*])"},
+		{xml, "jsonpath:$.a"},
+		{xml, "xpath:rate"},
+		{js, "xpath:/rates"},
+		{data, "csv:header;column=weight"},
+		{data, "csv:column=2"},
+		{data, "csv:row=x"},
+		{data, "csv:header=1"},
+		{data, "csv:unknown"},
+		{data, "csv:delimiter=ab"},
+		{data, "csv:delimiter=\""},
+		{"a,a\n1,2\n", "csv:header"},
+		{"a,b\n1\n", "csv:"},
+		{"", "csv:header"},
+	}
+	for _, tc := range errCases {
+		t.Run(tc.filter, func(t *testing.T) {
+			_, err := filter([]byte(tc.value), tc.filter)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestFilterLimits(t *testing.T) {
+	t.Run("CSV records", func(t *testing.T) {
+		data := strings.Repeat("1\n", maxFilterResults)
+		_, err := filter([]byte(data), "sum(csv:)")
+		require.Error(t, err) // Records are arrays.
+		actual, err := filter([]byte(data), "sum(csv:column=0)")
+		require.NoError(t, err)
+		require.Equal(t, "1024", string(actual))
+
+		data += "1\n"
+		_, err = filter([]byte(data), "length(csv:)")
+		require.Error(t, err)
+		actual, err = filter([]byte(data), "csv:row=-1")
+		require.NoError(t, err)
+		require.Equal(t, `[["1"]]`, string(actual))
+	})
+	t.Run("result size", func(t *testing.T) {
+		data := `["` + strings.Repeat("a", 30000) + `"]`
+		_, err := filter([]byte(data), "$[0,0]")
+		require.NoError(t, err)
+		_, err = filter([]byte(data), "$[0,0,0]")
+		require.ErrorIs(t, err, ErrResponseTooLarge)
+	})
+}
@@ -0,0 +1,85 @@
+package jsonpath
+
+import (
+	"bytes"
+	"errors"
+	"io/fs"
+	"os"
+	"reflect"
+	"testing"
+
+	json "github.com/nspcc-dev/go-ordered-json"
+	"github.com/stretchr/testify/require"
+)
+
+// ctsFile is the JSONPath Compliance Test Suite
+// (https://github.com/jsonpath-standard/jsonpath-compliance-test-suite)
+// vendored unmodified.
+const ctsFile = "testdata/cts.json"
+
+// ctsTestCase is a test case of the JSONPath Compliance Test Suite.
+type ctsTestCase struct {
+	Name     string          `json:"name"`
+	Selector string          `json:"selector"`
+	Document json.RawMessage `json:"document"`
+	Result   json.RawMessage `json:"result"`
+	// Results contain all valid results for non-deterministic selectors.
+	Results         []json.RawMessage `json:"results"`
+	InvalidSelector bool              `json:"invalid_selector"`
+	Tags            []string          `json:"tags"`
+}
+
+func TestComplianceSuite(t *testing.T) {
+	if _, err := os.Stat(ctsFile); errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("%s is missing, it must be vendored from the compliance suite repository", ctsFile)
+	}
+	testSuite(t, ctsFile)
+}
+
+// TestFilterCases runs the package's own filter test cases which use the
+// compliance suite format.
+func TestFilterCases(t *testing.T) {
+	testSuite(t, "testdata/filter.json")
+}
+
+func testSuite(t *testing.T, file string) {
+	data, err := os.ReadFile(file)
+	require.NoError(t, err)
+	var suite struct {
+		Tests []ctsTestCase `json:"tests"`
+	}
+	require.NoError(t, json.Unmarshal(data, &suite))
+	require.NotEmpty(t, suite.Tests)
+
+	for _, tc := range suite.Tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			var doc any
+			if tc.Document != nil {
+				d := json.NewDecoder(bytes.NewReader(tc.Document))
+				d.UseOrderedObject()
+				require.NoError(t, d.Decode(&doc))
+			}
+			res, ok := Get(tc.Selector, doc)
+			if tc.InvalidSelector {
+				require.False(t, ok)
+				return
+			}
+			require.True(t, ok)
+			actual, err := json.Marshal(res)
+			require.NoError(t, err)
+			if tc.Results == nil {
+				require.JSONEq(t, string(tc.Result), string(actual))
+				return
+			}
+			for _, r := range tc.Results {
+				var exp, act any
+				require.NoError(t, json.Unmarshal(r, &exp))
+				require.NoError(t, json.Unmarshal(actual, &act))
+				if reflect.DeepEqual(exp, act) {
+					return
+				}
+			}
+			t.Fatalf("result %s doesn't match any of the expected ones", actual)
+		})
+	}
+}
@@ -0,0 +1,788 @@
+package jsonpath
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	json "github.com/nspcc-dev/go-ordered-json"
+)
+
+// Filter selectors (`[?<expr>]`) follow RFC 9535: expressions can contain
+// relative (`@`) and absolute (`$`) queries, literals, comparison (`==`,
+// `!=`, `<`, `<=`, `>`, `>=`) and logical (`&&`, `||`, `!`) operators,
+// parentheses and length(), count(), match(), search() and value() functions.
+// Queries are evaluated with the same grammar and the nesting depth remaining
+// after the filter selector.
+
+type (
+	// evalState is shared by all parsers processing a single path.
+	evalState struct {
+		root    any
+		ops     int
+		failed  bool
+		regexps map[string]*regexp.Regexp
+	}
+
+	// logicalExpr is a filter expression producing a boolean result.
+	logicalExpr interface {
+		test(p *pathParser, cur any) bool
+	}
+
+	// valueExpr is a filter expression producing a single value or nothing.
+	valueExpr interface {
+		value(p *pathParser, cur any) (any, bool)
+	}
+
+	orExpr  []logicalExpr
+	andExpr []logicalExpr
+	notExpr struct {
+		e logicalExpr
+	}
+
+	// existExpr tests whether the query selects any node.
+	existExpr struct {
+		q *query
+	}
+
+	compExpr struct {
+		op   string
+		l, r valueExpr
+	}
+
+	literal struct {
+		v any
+	}
+
+	// query is a relative or an absolute query, it's kept as a position in
+	// the path and evaluated with a separate parser every time.
+	query struct {
+		absolute bool
+		singular bool
+		start    int
+		end      int
+		depth    int
+	}
+
+	funcCall struct {
+		name string
+		args []any // valueExpr or *query depending on the function.
+	}
+)
+
+const (
+	// maxFilterOperations is the maximum number of filter tests and nodes
+	// selected by queries during the path evaluation.
+	maxFilterOperations = 1 << 16
+	// maxRegexpLength is the maximum length of a regular expression used in
+	// match() and search() functions.
+	maxRegexpLength = 256
+)
+
+// funcTypes contains the result types of filter functions, true for functions
+// producing a value and false for logical ones.
+var funcTypes = map[string]bool{
+	"length": true,
+	"count":  true,
+	"value":  true,
+	"match":  false,
+	"search": false,
+}
+
+// processFilter processes a filter selector, it selects array elements and
+// object member values matching the filter expression.
+func (p *pathParser) processFilter(objs []any) ([]any, bool) {
+	if p.depth <= 0 {
+		return nil, false
+	}
+	p.depth--
+
+	e, ok := p.parseLogicalOr()
+	if !ok {
+		return nil, false
+	}
+	p.skipSpaces()
+	if typ, _ := p.nextToken(); typ != pathRightBracket {
+		return nil, false
+	}
+
+	var values []any
+	for i := range objs {
+		var children []any
+		switch obj := objs[i].(type) {
+		case []any:
+			children = obj
+		case json.OrderedObject:
+			children = make([]any, len(obj))
+			for j := range obj {
+				children[j] = obj[j].Value
+			}
+		}
+		for _, c := range children {
+			if !p.st.spend(1) {
+				return nil, false
+			}
+			match := e.test(p, c)
+			if p.st.failed {
+				return nil, false
+			}
+			if match {
+				if maxObjects < len(values)+1 {
+					return nil, false
+				}
+				values = append(values, c)
+			}
+		}
+	}
+	return values, true
+}
+
+func (st *evalState) spend(n int) bool {
+	st.ops += n
+	if st.ops > maxFilterOperations {
+		st.failed = true
+	}
+	return !st.failed
+}
+
+func (p *pathParser) skipSpaces() {
+	for p.i < len(p.s) {
+		switch p.s[p.i] {
+		case ' ', '\t', '\n', '\r':
+			p.i++
+		default:
+			return
+		}
+	}
+}
+
+// consume skips spaces and the token if it's present.
+func (p *pathParser) consume(tok string) bool {
+	p.skipSpaces()
+	if strings.HasPrefix(p.s[p.i:], tok) {
+		p.i += len(tok)
+		return true
+	}
+	return false
+}
+
+func (p *pathParser) parseLogicalOr() (logicalExpr, bool) {
+	var es orExpr
+	for {
+		e, ok := p.parseLogicalAnd()
+		if !ok {
+			return nil, false
+		}
+		es = append(es, e)
+		if !p.consume("||") {
+			break
+		}
+	}
+	if len(es) == 1 {
+		return es[0], true
+	}
+	return es, true
+}
+
+func (p *pathParser) parseLogicalAnd() (logicalExpr, bool) {
+	var es andExpr
+	for {
+		e, ok := p.parseBasic()
+		if !ok {
+			return nil, false
+		}
+		es = append(es, e)
+		if !p.consume("&&") {
+			break
+		}
+	}
+	if len(es) == 1 {
+		return es[0], true
+	}
+	return es, true
+}
+
+func (p *pathParser) parseBasic() (logicalExpr, bool) {
+	if p.consume("!") {
+		if p.consume("(") {
+			e, ok := p.parseParen()
+			return notExpr{e}, ok
+		}
+		e, ok := p.parseTest()
+		return notExpr{e}, ok
+	}
+	if p.consume("(") {
+		return p.parseParen()
+	}
+
+	start := p.i
+	l, isTest, ok := p.parseOperand()
+	if !ok {
+		return nil, false
+	}
+	op, isComp := p.parseOperator()
+	if !isComp {
+		if !isTest {
+			return nil, false
+		}
+		p.i = start
+		return p.parseTest()
+	}
+	lv, ok := toValueExpr(l)
+	if !ok {
+		return nil, false
+	}
+	r, _, ok := p.parseOperand()
+	if !ok {
+		return nil, false
+	}
+	rv, ok := toValueExpr(r)
+	if !ok {
+		return nil, false
+	}
+	return compExpr{op: op, l: lv, r: rv}, true
+}
+
+func (p *pathParser) parseParen() (logicalExpr, bool) {
+	e, ok := p.parseLogicalOr()
+	if !ok || !p.consume(")") {
+		return nil, false
+	}
+	return e, true
+}
+
+// parseTest parses a test expression: a query or a logical function call.
+func (p *pathParser) parseTest() (logicalExpr, bool) {
+	e, isTest, ok := p.parseOperand()
+	if !ok || !isTest {
+		return nil, false
+	}
+	switch e := e.(type) {
+	case *query:
+		return existExpr{e}, true
+	case *funcCall:
+		return e, true
+	}
+	return nil, false
+}
+
+func (p *pathParser) parseOperator() (string, bool) {
+	p.skipSpaces()
+	for _, op := range []string{"==", "!=", "<=", ">=", "<", ">"} {
+		if strings.HasPrefix(p.s[p.i:], op) {
+			p.i += len(op)
+			return op, true
+		}
+	}
+	return "", false
+}
+
+// parseOperand parses a literal, a query or a function call. It also returns
+// true if the operand can be used as a test expression.
+func (p *pathParser) parseOperand() (any, bool, bool) {
+	p.skipSpaces()
+	if p.i >= len(p.s) {
+		return nil, false, false
+	}
+	switch c := p.s[p.i]; {
+	case c == '@' || c == '$':
+		q, ok := p.parseQuery()
+		return q, true, ok
+	case c == '\'' || c == '"':
+		s, ok := p.parseStringLiteral()
+		return literal{s}, false, ok
+	case c == '-' || ('0' <= c && c <= '9'):
+		n, ok := p.parseNumberLiteral()
+		return literal{n}, false, ok
+	case 'a' <= c && c <= 'z':
+		end := p.i + 1
+		for end < len(p.s) && (p.s[end] == '_' || ('a' <= p.s[end] && p.s[end] <= 'z') || ('0' <= p.s[end] && p.s[end] <= '9')) {
+			end++
+		}
+		name := p.s[p.i:end]
+		if end < len(p.s) && p.s[end] == '(' {
+			p.i = end + 1
+			f, ok := p.parseFuncCall(name)
+			return f, ok && !funcTypes[name], ok
+		}
+		p.i = end
+		switch name {
+		case "true":
+			return literal{true}, false, true
+		case "false":
+			return literal{false}, false, true
+		case "null":
+			return literal{nil}, false, true
+		}
+	}
+	return nil, false, false
+}
+
+// parseQuery parses a query without evaluating it.
+func (p *pathParser) parseQuery() (*query, bool) {
+	q := &query{
+		absolute: p.s[p.i] == '$',
+		start:    p.i,
+		depth:    p.depth,
+	}
+	p.i++
+	for p.i < len(p.s) && (p.s[p.i] == '.' || p.s[p.i] == '[') {
+		var ok bool
+		switch typ, _ := p.nextToken(); typ {
+		case pathDot:
+			_, ok = p.processDot(nil)
+		case pathLeftBracket:
+			_, ok = p.processLeftBracket(nil)
+		}
+		if !ok {
+			return nil, false
+		}
+	}
+	p.depth = q.depth
+	q.end = p.i
+	q.singular = isSingular(p.s[q.start+1 : q.end])
+	return q, true
+}
+
+// isSingular checks whether the segments only contain names and indices.
+func isSingular(s string) bool {
+	p := pathParser{s: s}
+	for p.i < len(p.s) {
+		switch typ, _ := p.nextToken(); typ {
+		case pathDot:
+			if typ, _ := p.nextToken(); typ != pathIdentifier {
+				return false
+			}
+		case pathLeftBracket:
+			if typ, _ := p.nextToken(); typ != pathNumber && typ != pathString {
+				return false
+			}
+			if typ, _ := p.nextToken(); typ != pathRightBracket {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func (p *pathParser) parseFuncCall(name string) (*funcCall, bool) {
+	var params []bool // true for value parameters, false for nodes.
+	switch name {
+	case "length":
+		params = []bool{true}
+	case "count", "value":
+		params = []bool{false}
+	case "match", "search":
+		params = []bool{true, true}
+	default:
+		return nil, false
+	}
+	f := &funcCall{name: name}
+	for i, isValue := range params {
+		if i != 0 && !p.consume(",") {
+			return nil, false
+		}
+		arg, _, ok := p.parseOperand()
+		if !ok {
+			return nil, false
+		}
+		if isValue {
+			arg, ok = toValueExpr(arg)
+		} else {
+			_, ok = arg.(*query)
+		}
+		if !ok {
+			return nil, false
+		}
+		f.args = append(f.args, arg)
+	}
+	if !p.consume(")") {
+		return nil, false
+	}
+	return f, true
+}
+
+// toValueExpr checks whether the operand produces a single value.
+func toValueExpr(e any) (valueExpr, bool) {
+	switch e := e.(type) {
+	case literal:
+		return e, true
+	case *query:
+		return e, e.singular
+	case *funcCall:
+		return e, funcTypes[e.name]
+	}
+	return nil, false
+}
+
+// parseStringLiteral parses a single- or double-quoted string with escape
+// sequences.
+func (p *pathParser) parseStringLiteral() (string, bool) {
+	quote := p.s[p.i]
+	var sb strings.Builder
+	for i := p.i + 1; i < len(p.s); {
+		c := p.s[i]
+		switch {
+		case c == quote:
+			p.i = i + 1
+			return sb.String(), true
+		case c < 0x20:
+			return "", false
+		case c != '\\':
+			sb.WriteByte(c)
+			i++
+			continue
+		}
+		if i+1 >= len(p.s) {
+			return "", false
+		}
+		i += 2
+		switch e := p.s[i-1]; e {
+		case 'b':
+			sb.WriteByte('\b')
+		case 'f':
+			sb.WriteByte('\f')
+		case 'n':
+			sb.WriteByte('\n')
+		case 'r':
+			sb.WriteByte('\r')
+		case 't':
+			sb.WriteByte('\t')
+		case '/', '\\':
+			sb.WriteByte(e)
+		case '\'', '"':
+			if e != quote {
+				return "", false
+			}
+			sb.WriteByte(e)
+		case 'u':
+			r, n, ok := parseUnicodeEscape(p.s[i:])
+			if !ok {
+				return "", false
+			}
+			sb.WriteRune(r)
+			i += n
+		default:
+			return "", false
+		}
+	}
+	return "", false
+}
+
+// parseUnicodeEscape parses XXXX of \uXXXX (possibly followed by the low
+// surrogate escape) and returns the number of characters consumed.
+func parseUnicodeEscape(s string) (rune, int, bool) {
+	hex := func(s string) (rune, bool) {
+		if len(s) < 4 {
+			return 0, false
+		}
+		n, err := strconv.ParseUint(s[:4], 16, 16)
+		return rune(n), err == nil
+	}
+	r, ok := hex(s)
+	switch {
+	case !ok:
+		return 0, 0, false
+	case utf16.IsSurrogate(r):
+		if r >= 0xDC00 || !strings.HasPrefix(s[4:], `\u`) {
+			return 0, 0, false
+		}
+		low, ok := hex(s[6:])
+		if !ok {
+			return 0, 0, false
+		}
+		r = utf16.DecodeRune(r, low)
+		if r == utf8.RuneError {
+			return 0, 0, false
+		}
+		return r, 10, true
+	}
+	return r, 4, true
+}
+
+var numberLiteral = regexp.MustCompile(`^-?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][+-]?[0-9]+)?`)
+
+func (p *pathParser) parseNumberLiteral() (float64, bool) {
+	s := numberLiteral.FindString(p.s[p.i:])
+	if len(s) == 0 {
+		return 0, false
+	}
+	n, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, false
+	}
+	p.i += len(s)
+	return n, true
+}
+
+func (e orExpr) test(p *pathParser, cur any) bool {
+	for i := range e {
+		if e[i].test(p, cur) {
+			return true
+		}
+	}
+	return false
+}
+
+func (e andExpr) test(p *pathParser, cur any) bool {
+	for i := range e {
+		if !e[i].test(p, cur) {
+			return false
+		}
+	}
+	return true
+}
+
+func (e notExpr) test(p *pathParser, cur any) bool {
+	return !e.e.test(p, cur)
+}
+
+func (e existExpr) test(p *pathParser, cur any) bool {
+	return len(e.q.nodes(p, cur)) != 0
+}
+
+func (e compExpr) test(p *pathParser, cur any) bool {
+	l, lok := e.l.value(p, cur)
+	r, rok := e.r.value(p, cur)
+	switch e.op {
+	case "==":
+		return equal(l, lok, r, rok)
+	case "!=":
+		return !equal(l, lok, r, rok)
+	case "<":
+		return lok && rok && less(l, r)
+	case "<=":
+		return lok && rok && less(l, r) || equal(l, lok, r, rok)
+	case ">":
+		return lok && rok && less(r, l)
+	default: // ">="
+		return lok && rok && less(r, l) || equal(l, lok, r, rok)
+	}
+}
+
+func (l literal) value(*pathParser, any) (any, bool) {
+	return l.v, true
+}
+
+// nodes evaluates the query for the current node.
+func (q *query) nodes(p *pathParser, cur any) []any {
+	if p.st.failed {
+		return nil
+	}
+	sub := pathParser{
+		s:     p.s[:q.end],
+		i:     q.start + 1,
+		depth: q.depth,
+		st:    p.st,
+	}
+	objs := []any{cur}
+	if q.absolute {
+		objs = []any{p.st.root}
+	}
+	for sub.i < len(sub.s) {
+		var ok bool
+		switch typ, _ := sub.nextToken(); typ {
+		case pathDot:
+			objs, ok = sub.processDot(objs)
+		case pathLeftBracket:
+			objs, ok = sub.processLeftBracket(objs)
+		}
+		if !ok || maxObjects < len(objs) || !p.st.spend(len(objs)+1) {
+			p.st.failed = true
+			return nil
+		}
+	}
+	return objs
+}
+
+func (q *query) value(p *pathParser, cur any) (any, bool) {
+	objs := q.nodes(p, cur)
+	if len(objs) != 1 {
+		return nil, false
+	}
+	return objs[0], true
+}
+
+func (f *funcCall) value(p *pathParser, cur any) (any, bool) {
+	switch f.name {
+	case "length":
+		v, ok := f.args[0].(valueExpr).value(p, cur)
+		if !ok {
+			return nil, false
+		}
+		switch v := v.(type) {
+		case string:
+			return float64(utf8.RuneCountInString(v)), true
+		case []any:
+			return float64(len(v)), true
+		case json.OrderedObject:
+			return float64(len(v)), true
+		}
+		return nil, false
+	case "count":
+		return float64(len(f.args[0].(*query).nodes(p, cur))), true
+	default: // "value"
+		return f.args[0].(*query).value(p, cur)
+	}
+}
+
+func (f *funcCall) test(p *pathParser, cur any) bool {
+	v, ok := f.args[0].(valueExpr).value(p, cur)
+	if !ok {
+		return false
+	}
+	s, ok := v.(string)
+	if !ok {
+		return false
+	}
+	v, ok = f.args[1].(valueExpr).value(p, cur)
+	if !ok {
+		return false
+	}
+	pattern, ok := v.(string)
+	if !ok {
+		return false
+	}
+	re := p.st.compileRegexp(pattern, f.name == "match")
+	return re != nil && re.MatchString(s)
+}
+
+// compileRegexp translates I-Regexp (RFC 9485) into the Go syntax and
+// compiles it, nil is returned for invalid expressions.
+func (st *evalState) compileRegexp(pattern string, full bool) *regexp.Regexp {
+	key := pattern
+	if full {
+		key = "^" + pattern
+	}
+	if re, ok := st.regexps[key]; ok {
+		return re
+	}
+	if st.regexps == nil {
+		st.regexps = make(map[string]*regexp.Regexp)
+	}
+	var re *regexp.Regexp
+	if s, ok := translateIRegexp(pattern); ok {
+		if full {
+			s = "^(?:" + s + ")$"
+		}
+		re, _ = regexp.Compile(s)
+	}
+	st.regexps[key] = re
+	return re
+}
+
+func translateIRegexp(pattern string) (string, bool) {
+	if len(pattern) > maxRegexpLength || !utf8.ValidString(pattern) {
+		return "", false
+	}
+	var (
+		sb      strings.Builder
+		inClass bool
+	)
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+		switch {
+		case c == '\\':
+			if i+1 >= len(pattern) {
+				return "", false
+			}
+			i++
+			switch e := pattern[i]; e {
+			case '\\', '|', '.', '-', '^', '?', '*', '+', '{', '}', '(', ')', '[', ']', 'n', 'r', 't':
+				sb.WriteByte('\\')
+				sb.WriteByte(e)
+			case 'p', 'P':
+				end := strings.IndexByte(pattern[i:], '}')
+				if end < 0 || pattern[i+1] != '{' {
+					return "", false
+				}
+				sb.WriteByte('\\')
+				sb.WriteString(pattern[i : i+end+1])
+				i += end
+			default:
+				return "", false
+			}
+		case inClass:
+			if c == ']' {
+				inClass = false
+			} else if c == '[' {
+				return "", false
+			}
+			sb.WriteByte(c)
+		case c == '[':
+			inClass = true
+			sb.WriteByte(c)
+		case c == '.':
+			sb.WriteString(`[^\n\r]`)
+		case c == '^' || c == '$':
+			sb.WriteByte('\\')
+			sb.WriteByte(c)
+		case c == '(' && i+1 < len(pattern) && pattern[i+1] == '?':
+			return "", false
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	if inClass {
+		return "", false
+	}
+	return sb.String(), true
+}
+
+func equal(l any, lok bool, r any, rok bool) bool {
+	if !lok || !rok {
+		return lok == rok
+	}
+	switch l := l.(type) {
+	case []any:
+		r, ok := r.([]any)
+		if !ok || len(l) != len(r) {
+			return false
+		}
+		for i := range l {
+			if !equal(l[i], true, r[i], true) {
+				return false
+			}
+		}
+		return true
+	case json.OrderedObject:
+		r, ok := r.(json.OrderedObject)
+		if !ok || len(l) != len(r) {
+			return false
+		}
+		for i := range l {
+			var found bool
+			for j := range r {
+				if l[i].Key == r[j].Key {
+					if !equal(l[i].Value, true, r[j].Value, true) {
+						return false
+					}
+					found = true
+					break
+				}
+			}
+			if !found {
+				return false
+			}
+		}
+		return true
+	default:
+		return l == r
+	}
+}
+
+func less(l, r any) bool {
+	switch l := l.(type) {
+	case float64:
+		r, ok := r.(float64)
+		return ok && l < r
+	case string:
+		r, ok := r.(string)
+		return ok && l < r
+	}
+	return false
+}
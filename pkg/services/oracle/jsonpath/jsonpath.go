@@ -16,6 +16,7 @@ type (
 		s     string
 		i     int
 		depth int
+		st    *evalState
 	}
 )
 
@@ -31,6 +32,7 @@ const (
 	pathIdentifier
 	pathString
 	pathNumber
+	pathQuestion
 )
 
 const (
@@ -48,6 +50,7 @@ func Get(path string, value any) ([]any, bool) {
 	p := pathParser{
 		depth: maxNestingDepth,
 		s:     path,
+		st:    &evalState{root: value},
 	}
 
 	typ, _ := p.nextToken()
@@ -104,6 +107,8 @@ func (p *pathParser) nextToken() (pathTokenType, string) {
 		typ = pathComma
 	case ':':
 		typ = pathColon
+	case '?':
+		typ = pathQuestion
 	case '\'':
 		typ = pathString
 		value, numRead, ok = p.parseString()
@@ -216,27 +221,56 @@ func (p *pathParser) descend(objs []any) ([]any, bool) {
 	return values, true
 }
 
-// descendRecursive performs recursive descent.
+// descendRecursive performs recursive descent. The selector following it
+// (name, wildcard or bracketed one) is applied to the current nodes and to
+// every level of their descendants. Levels walked don't count towards the
+// nesting depth of the following segments, but each selector application
+// starts with the same depth.
 func (p *pathParser) descendRecursive(objs []any) ([]any, bool) {
-	typ, val := p.nextToken()
-	if typ != pathIdentifier {
-		return nil, false
-	}
-
-	var values []any
+	var (
+		start  = p.i
+		depth  = p.depth
+		levels = p.depth
+		end    int
+		after  int
+		values []any
+	)
 
-	for len(objs) > 0 {
-		newObjs, _ := p.descendByIdentAux(objs, false, val)
-		if maxObjects < len(values)+len(newObjs) {
+	for {
+		p.i, p.depth = start, depth
+		newObjs, ok := p.processDescendantSelector(objs)
+		if !ok || maxObjects < len(values)+len(newObjs) {
 			return nil, false
 		}
 		values = append(values, newObjs...)
+		end, after = p.i, p.depth
+		if len(objs) == 0 {
+			break
+		}
+		p.depth = levels
 		objs, _ = p.descend(objs)
+		levels = p.depth
 	}
 
+	p.i, p.depth = end, after
 	return values, true
 }
 
+// processDescendantSelector applies the selector following recursive descent.
+func (p *pathParser) processDescendantSelector(objs []any) ([]any, bool) {
+	typ, val := p.nextToken()
+	switch typ {
+	case pathIdentifier:
+		return p.descendByIdentAux(objs, false, val)
+	case pathAsterisk:
+		return p.descend(objs)
+	case pathLeftBracket:
+		return p.processLeftBracket(objs)
+	default:
+		return nil, false
+	}
+}
+
 // descendByIdent performs map's field access by name.
 func (p *pathParser) descendByIdent(objs []any, names ...string) ([]any, bool) {
 	return p.descendByIdentAux(objs, true, names...)
@@ -303,7 +337,7 @@ func (p *pathParser) descendByIndex(objs []any, indices ...int) ([]any, bool) {
 }
 
 // processLeftBracket processes index expressions which can be either
-// array/map access, array sub-slice or union of indices.
+// array/map access, array sub-slice, union of indices or filter.
 func (p *pathParser) processLeftBracket(objs []any) ([]any, bool) {
 	typ, value := p.nextToken()
 	switch typ {
@@ -316,6 +350,8 @@ func (p *pathParser) processLeftBracket(objs []any) ([]any, bool) {
 		return p.descend(objs)
 	case pathColon:
 		return p.processSlice(objs, 0)
+	case pathQuestion:
+		return p.processFilter(objs)
 	case pathNumber:
 		subTyp, _ := p.nextToken()
 		switch subTyp {
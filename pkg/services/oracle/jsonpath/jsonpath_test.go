@@ -48,7 +48,6 @@ func TestInvalidPaths(t *testing.T) {
 		"$.&",
 		"$.[0]",
 		"$..",
-		"$..*",
 		"$..&",
 		"$..1",
 		"$[&]",
@@ -83,6 +82,49 @@ func TestInvalidPaths(t *testing.T) {
 	}
 }
 
+// TestDescendRecursive checks selectors other than names after the recursive
+// descent (like `$..*`), such paths are rejected by C# nodes.
+func TestDescendRecursive(t *testing.T) {
+	js := `{
+		"a": [1, {"b": 2}],
+		"c": 3,
+		"books": [
+			{"isbn": "1", "price": 8},
+			{"price": 12},
+			{"isbn": "2", "price": 10}
+		]
+	}`
+
+	testCases := []pathTestCase{
+		{"$.a..*", `[1,{"b":2},2]`},
+		{"$..[0]", `[1,{"isbn":"1","price":8}]`},
+		{"$..[?@==2]", `[2]`},
+		{"$..books[?@.isbn]", `[{"isbn":"1","price":8},{"isbn":"2","price":10}]`},
+		{"$..books[?(@.price>9)]", `[{"price":12},{"isbn":"2","price":10}]`},
+		{"$..[?@.isbn].price", `[8,10]`},
+		{"$..['b','isbn']", `[2,"1","2"]`},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.path, func(t *testing.T) {
+			tc.testUnmarshalGet(t, js)
+		})
+	}
+
+	t.Run("wildcard", func(t *testing.T) {
+		p := pathTestCase{"$..*", `[[1,{"b":2}],3,[{"isbn":"1","price":8},{"price":12},{"isbn":"2","price":10}],` +
+			`1,{"b":2},{"isbn":"1","price":8},{"price":12},{"isbn":"2","price":10},2,"1",8,12,"2",10]`}
+		p.testUnmarshalGet(t, js)
+	})
+
+	t.Run("depth is kept", func(t *testing.T) {
+		js := `{"a":{"b":{"c":{"d":{"e":{"f":1}}}}}}`
+		p := pathTestCase{"$..e.f", `[1]`}
+		p.testUnmarshalGet(t, js)
+		p = pathTestCase{"$.a..e[?@==1]", `[1]`}
+		p.testUnmarshalGet(t, js)
+	})
+}
+
 func TestDescendByIdent(t *testing.T) {
 	js := `{
 		"store": {
@@ -334,3 +376,39 @@ func TestCSharpCompat(t *testing.T) {
 		require.False(t, ok)
 	})
 }
+
+func TestFilterLimits(t *testing.T) {
+	t.Run("depth", func(t *testing.T) {
+		js := `{"a":{"b":{"c":{"d":{"e":[{"x":1}]}}}}}`
+		_, ok := unmarshalGet(t, js, "$.a.b.c.d[?@.e]")
+		require.True(t, ok)
+		_, ok = unmarshalGet(t, js, "$.a.b.c.d.e[?@.x]")
+		require.False(t, ok)
+		_, ok = unmarshalGet(t, js, "$.a.b.c.d.e[?@]")
+		require.True(t, ok)
+		_, ok = unmarshalGet(t, js, "$[?@[?@[?@[?@[?@[?@[?@]]]]]]]")
+		require.False(t, ok)
+	})
+	t.Run("results", func(t *testing.T) {
+		js := "[" + strings.Repeat("1,", maxObjects) + "1]"
+		_, ok := unmarshalGet(t, js, "$[?@==1]")
+		require.False(t, ok)
+		res, ok := unmarshalGet(t, js, "$[?@==2]")
+		require.True(t, ok)
+		require.Empty(t, res)
+	})
+	t.Run("operations", func(t *testing.T) {
+		js := "[" + strings.Repeat("1,", 199) + "1]"
+		_, ok := unmarshalGet(t, js, "$[?count($[*])>0]")
+		require.True(t, ok)
+		js = "[" + strings.Repeat("1,", 299) + "1]"
+		_, ok = unmarshalGet(t, js, "$[?count($[*])>0]")
+		require.False(t, ok)
+	})
+	t.Run("regexp length", func(t *testing.T) {
+		js := `{"re":"` + strings.Repeat("a", maxRegexpLength+1) + `","v":["` + strings.Repeat("a", maxRegexpLength+1) + `"]}`
+		res, ok := unmarshalGet(t, js, "$.v[?match(@, $.re)]")
+		require.True(t, ok)
+		require.Empty(t, res)
+	})
+}
@@ -2,9 +2,8 @@ package oracle
 
 import (
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
-	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
+	"github.com/nspcc-dev/neo-go/pkg/services/signer"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
-	"github.com/nspcc-dev/neo-go/pkg/wallet"
 	"go.uber.org/zap"
 )
 
@@ -26,23 +25,11 @@ func (o *Oracle) UpdateOracleNodes(oracleNodes keys.PublicKeys) {
 		}
 	}
 
-	var acc *wallet.Account
-	for i := range oracleNodes {
-		acc = o.wallet.GetAccount(oracleNodes[i].GetScriptHash())
-		if acc != nil {
-			if acc.CanSign() {
-				break
-			}
-			err := acc.Decrypt(o.MainCfg.UnlockWallet.Password, o.wallet.Scrypt)
-			if err != nil {
-				o.Log.Error("can't unlock account",
-					zap.String("address", address.Uint160ToString(acc.Contract.ScriptHash())),
-					zap.Error(err))
-				o.currAccount = nil
-				return
-			}
-			break
-		}
+	_, acc, err := signer.Find(o.signer, oracleNodes)
+	if err != nil {
+		o.Log.Error("can't get oracle node account", zap.Error(err))
+		o.currAccount = nil
+		return
 	}
 
 	o.currAccount = acc
@@ -50,7 +37,7 @@ func (o *Oracle) UpdateOracleNodes(oracleNodes keys.PublicKeys) {
 	o.oracleNodes = oracleNodes
 }
 
-func (o *Oracle) getAccount() *wallet.Account {
+func (o *Oracle) getAccount() *signer.Account {
 	o.accMtx.RLock()
 	defer o.accMtx.RUnlock()
 	return o.currAccount
@@ -67,3 +54,15 @@ func (o *Oracle) getOracleSignContract() []byte {
 	defer o.accMtx.RUnlock()
 	return o.oracleSignContract
 }
+
+// getNeoFSKey returns the key to be used for NeoFS requests. It's the oracle
+// node key if it's available locally and a random session key otherwise
+// (remote signers can't be used for NeoFS requests).
+func (o *Oracle) getNeoFSKey(acc *signer.Account) *keys.PrivateKey {
+	if l, ok := o.signer.(*signer.Local); ok && acc != nil {
+		if priv := l.PrivateKey(acc.PublicKey()); priv != nil {
+			return priv
+		}
+	}
+	return o.neofsKey
+}
@@ -13,11 +13,12 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
 	"github.com/nspcc-dev/neo-go/pkg/services/oracle/broadcaster"
+	"github.com/nspcc-dev/neo-go/pkg/services/signer"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/util/slice"
-	"github.com/nspcc-dev/neo-go/pkg/wallet"
 	"go.uber.org/zap"
 )
 
@@ -46,7 +47,7 @@ type (
 
 		// accMtx protects account and oracle nodes.
 		accMtx             sync.RWMutex
-		currAccount        *wallet.Account
+		currAccount        *signer.Account
 		oracleNodes        keys.PublicKeys
 		oracleSignContract []byte
 
@@ -66,7 +67,14 @@ type (
 		// removed contains ids of requests which won't be processed further due to expiration.
 		removed map[uint64]bool
 
-		wallet *wallet.Wallet
+		signer signer.Signer
+		// neofsKey is a session key for NeoFS requests used when the oracle
+		// node key is not available locally.
+		neofsKey *keys.PrivateKey
+		// protocols contains handlers for all supported URI schemes.
+		protocols map[string]ProtocolHandler
+		// audit keeps records of requests processing.
+		audit *auditLog
 	}
 
 	// Config contains oracle module parameters.
@@ -78,6 +86,9 @@ type (
 		Chain           Ledger
 		ResponseHandler Broadcaster
 		OnTransaction   TxCallback
+		// Protocols contains additional protocol handlers per URI scheme,
+		// they override the configured ones.
+		Protocols map[string]ProtocolHandler
 	}
 
 	// HTTPClient is an interface capable of doing oracle requests.
@@ -87,7 +98,10 @@ type (
 
 	// Broadcaster broadcasts oracle responses.
 	Broadcaster interface {
-		SendResponse(priv *keys.PrivateKey, resp *transaction.OracleResponse, txSig []byte)
+		// SendResponse sends the response transaction signature of the
+		// node with the given key, msgSig is a signature of the message
+		// returned by broadcaster.GetMessage for the response.
+		SendResponse(pub *keys.PublicKey, resp *transaction.OracleResponse, txSig []byte, msgSig []byte)
 		Run()
 		Shutdown()
 	}
@@ -144,20 +158,15 @@ func NewOracle(cfg Config) (*Oracle, error) {
 	}
 
 	var err error
-	w := cfg.MainCfg.UnlockWallet
-	if o.wallet, err = wallet.NewWalletFromFile(w.Path); err != nil {
+	if o.signer, err = signer.New(cfg.MainCfg.UnlockWallet, cfg.MainCfg.RemoteSigner, signer.DomainOracle); err != nil {
 		return nil, err
 	}
-
-	haveAccount := false
-	for _, acc := range o.wallet.Accounts {
-		if err := acc.Decrypt(w.Password, o.wallet.Scrypt); err == nil {
-			haveAccount = true
-			break
-		}
+	if o.signer == nil {
+		return nil, errors.New("no wallet or remote signer configured")
 	}
-	if !haveAccount {
-		return nil, errors.New("no wallet account could be unlocked")
+	if o.neofsKey, err = keys.NewPrivateKey(); err != nil {
+		o.signer.Close()
+		return nil, err
 	}
 
 	if o.ResponseHandler == nil {
@@ -169,6 +178,14 @@ func NewOracle(cfg Config) (*Oracle, error) {
 	if o.Client == nil {
 		o.Client = getDefaultClient(o.MainCfg)
 	}
+	if err = o.initProtocols(); err != nil {
+		o.signer.Close()
+		return nil, err
+	}
+	if o.audit, err = newAuditLog(o.MainCfg.AuditLog, o.Log); err != nil {
+		o.signer.Close()
+		return nil, err
+	}
 	return o, nil
 }
 
@@ -191,7 +208,8 @@ func (o *Oracle) Shutdown() {
 	close(o.close)
 	o.ResponseHandler.Shutdown()
 	<-o.done
-	o.wallet.Close()
+	o.signer.Close()
+	o.audit.close()
 }
 
 // Start runs the oracle service in a separate goroutine.
@@ -241,6 +259,11 @@ main:
 				delete(o.responses, id)
 			}
 			o.respMtx.Unlock()
+			for id := range o.removed {
+				o.audit.update(id, false, func(r *result.OracleRequestRecord) {
+					r.Status = result.OracleRequestExpired
+				})
+			}
 
 			for _, id := range reprocess {
 				o.requestCh <- request{ID: id}
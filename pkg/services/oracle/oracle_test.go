@@ -2,6 +2,7 @@ package oracle_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/binary"
 	"encoding/json"
 	"errors"
@@ -24,6 +25,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/interop/native/roles"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
 	"github.com/nspcc-dev/neo-go/pkg/neotest"
 	"github.com/nspcc-dev/neo-go/pkg/neotest/chain"
 	"github.com/nspcc-dev/neo-go/pkg/services/oracle"
@@ -77,6 +79,10 @@ func getTestOracle(t *testing.T, bc *core.Blockchain, walletPath, pass string) (
 	})
 	orcCfg.ResponseHandler = &saveToMapBroadcaster{m: m}
 	orcCfg.OnTransaction = saveTxToChan(ch)
+	orcCfg.MainCfg.Protocols = map[string]config.OracleProtocol{
+		oracle.FileScheme: {Parameters: map[string]string{"Root": "./testdata/files"}},
+	}
+	orcCfg.Protocols = map[string]oracle.ProtocolHandler{"custom": customProtocol{}}
 	orc, err := oracle.NewOracle(orcCfg)
 	require.NoError(t, err)
 
@@ -140,6 +146,23 @@ func TestOracle_InvalidWallet(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestOracle_InvalidProtocols(t *testing.T) {
+	bc, _, _ := chain.NewMulti(t)
+
+	for name, p := range map[string]map[string]config.OracleProtocol{
+		"unknown":  {"unknown": {}},
+		"no root":  {oracle.FileScheme: {}},
+		"bad root": {oracle.FileScheme: {Parameters: map[string]string{"Root": "./testdata/oracle1.json"}}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			cfg := getOracleConfig(t, bc, "./testdata/oracle1.json", "one", nil)
+			cfg.MainCfg.Protocols = p
+			_, err := oracle.NewOracle(cfg)
+			require.Error(t, err)
+		})
+	}
+}
+
 func TestOracle(t *testing.T) {
 	bc, validator, committee := chain.NewMulti(t)
 	e := neotest.NewExecutor(t, bc, validator, committee)
@@ -191,6 +214,14 @@ func TestOracle(t *testing.T) {
 
 	putOracleRequest(t, cInvoker, "https://get.invalidcontent", nil, "handle", []byte{}, 10_000_000)
 
+	putOracleRequest(t, cInvoker, "file:///values.json", &flt, "handle", []byte{}, 10_000_000)
+	putOracleRequest(t, cInvoker, "file:///../files/missing.json", nil, "handle", []byte{}, 10_000_000)
+	putOracleRequest(t, cInvoker, "custom://1234", nil, "handle", []byte{}, 10_000_000)
+	putOracleRequest(t, cInvoker, "ftp://get.1234", nil, "handle", []byte{}, 10_000_000)
+
+	aggFlt := "sum($.Values[*])"
+	putOracleRequest(t, cInvoker, "file:///values.json", &aggFlt, "handle", []byte{}, 10_000_000)
+
 	checkResp := func(t *testing.T, id uint64, resp *transaction.OracleResponse) *state.OracleRequest {
 		// Use a hack to get request from Oracle contract, because we can't use GetRequestInternal directly.
 		requestKey := make([]byte, 9)
@@ -255,6 +286,35 @@ func TestOracle(t *testing.T) {
 		orc1.AddResponse(acc2.PublicKey(), m2[0].resp.ID, m2[0].txSig)
 		checkEmitTx(t, ch1)
 
+		t.Run("Audit", func(t *testing.T) {
+			r, ok := orc1.GetRequest(0)
+			require.True(t, ok)
+			require.Equal(t, result.OracleRequestSent, r.Status)
+			require.Equal(t, req.URL, r.URL)
+			require.Equal(t, req.OriginalTxID, *r.OriginalTxID)
+			require.Equal(t, transaction.Success, *r.Code)
+			require.Equal(t, 4, r.FetchedSize)
+			require.Equal(t, 4, r.ResultSize)
+			require.Equal(t, 1, r.Attempts)
+			require.Equal(t, 2, r.Required)
+			require.ElementsMatch(t, oracleNodes, r.Signatures)
+			require.ElementsMatch(t, keys.PublicKeys{acc1.PublicKey()}, r.BackupSignatures)
+			require.NotNil(t, r.TxHash)
+			require.Equal(t, r.TxHash, r.SentTxHash)
+			require.NotEqual(t, r.TxHash, r.BackupTxHash)
+
+			r, ok = orc2.GetRequest(0)
+			require.True(t, ok)
+			require.Equal(t, result.OracleRequestSigning, r.Status)
+			require.Nil(t, r.SentTxHash)
+
+			orc1.RemoveRequests([]uint64{0})
+			r, ok = orc1.GetRequest(0)
+			require.True(t, ok)
+			require.Equal(t, result.OracleRequestFinished, r.Status)
+			require.Empty(t, orc1.GetRequests(result.OracleRequestSent))
+		})
+
 		t.Run("FirstOtherThenMe", func(t *testing.T) {
 			const reqID = 1
 
@@ -337,6 +397,69 @@ func TestOracle(t *testing.T) {
 			Code: transaction.ContentTypeNotSupported,
 		})
 	})
+	t.Run("File", func(t *testing.T) {
+		checkResp(t, 12, &transaction.OracleResponse{
+			ID:     12,
+			Code:   transaction.Success,
+			Result: []byte(`[2]`),
+		})
+		t.Run("NotFound", func(t *testing.T) {
+			checkResp(t, 13, &transaction.OracleResponse{
+				ID:   13,
+				Code: transaction.NotFound,
+			})
+		})
+	})
+	t.Run("CustomProtocol", func(t *testing.T) {
+		checkResp(t, 14, &transaction.OracleResponse{
+			ID:     14,
+			Code:   transaction.Success,
+			Result: []byte(`1234`),
+		})
+	})
+	t.Run("UnknownProtocol", func(t *testing.T) {
+		checkResp(t, 15, &transaction.OracleResponse{
+			ID:   15,
+			Code: transaction.ProtocolNotSupported,
+		})
+	})
+	t.Run("AggregateFilter", func(t *testing.T) {
+		checkResp(t, 16, &transaction.OracleResponse{
+			ID:     16,
+			Code:   transaction.Success,
+			Result: []byte(`6`),
+		})
+	})
+	t.Run("Audit", func(t *testing.T) {
+		r, ok := orc1.GetRequest(9)
+		require.True(t, ok)
+		require.Equal(t, flt, *r.Filter)
+		require.Equal(t, transaction.Success, *r.Code)
+		require.Greater(t, r.FetchedSize, r.ResultSize)
+		require.Equal(t, 3, r.ResultSize)
+		require.Empty(t, r.Error)
+
+		r, ok = orc1.GetRequest(10)
+		require.True(t, ok)
+		require.Equal(t, transaction.Error, *r.Code)
+		require.Zero(t, r.ResultSize)
+		require.NotEmpty(t, r.Error)
+
+		r, ok = orc1.GetRequest(15)
+		require.True(t, ok)
+		require.Equal(t, transaction.ProtocolNotSupported, *r.Code)
+		require.NotEmpty(t, r.Error)
+
+		_, ok = orc1.GetRequest(100)
+		require.False(t, ok)
+
+		signing := orc1.GetRequests(result.OracleRequestSigning)
+		require.Equal(t, 16, len(signing))
+		for i := 1; i < len(signing); i++ {
+			require.Less(t, signing[i-1].ID, signing[i].ID)
+		}
+		require.Equal(t, 17, len(orc1.GetRequests("")))
+	})
 }
 
 func TestOracleFull(t *testing.T) {
@@ -438,7 +561,7 @@ type saveToMapBroadcaster struct {
 	m   map[uint64]*responseWithSig
 }
 
-func (b *saveToMapBroadcaster) SendResponse(_ *keys.PrivateKey, resp *transaction.OracleResponse, txSig []byte) {
+func (b *saveToMapBroadcaster) SendResponse(_ *keys.PublicKey, resp *transaction.OracleResponse, txSig []byte, _ []byte) {
 	b.mtx.Lock()
 	defer b.mtx.Unlock()
 	b.m[resp.ID] = &responseWithSig{
@@ -461,6 +584,15 @@ func saveTxToChan(ch chan *transaction.Transaction) oracle.TxCallback {
 	}
 }
 
+// customProtocol is an oracle.ProtocolHandler returning URL host as the
+// result.
+type customProtocol struct{}
+
+// Fetch implements the oracle.ProtocolHandler interface.
+func (customProtocol) Fetch(_ context.Context, req oracle.ProtocolRequest) (gio.ReadCloser, transaction.OracleResponseCode, error) {
+	return gio.NopCloser(strings.NewReader(req.URL.Host)), transaction.Success, nil
+}
+
 type (
 	// httpClient implements oracle.HTTPClient with
 	// mocked URL or responses.
@@ -0,0 +1,85 @@
+package oracle
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Metrics for monitoring service.
+var (
+	// oracleRequests prometheus metric.
+	oracleRequests = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Help:      "Number of oracle requests seen",
+			Name:      "oracle_requests_total",
+			Namespace: "neogo",
+		},
+	)
+	// oracleActiveRequests prometheus metric.
+	oracleActiveRequests = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Help:      "Number of oracle requests not yet completed",
+			Name:      "oracle_active_requests",
+			Namespace: "neogo",
+		},
+	)
+	// oracleResponses prometheus metric.
+	oracleResponses = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Help:      "Number of oracle responses created by response code",
+			Name:      "oracle_responses_total",
+			Namespace: "neogo",
+		},
+		[]string{"code"},
+	)
+	// oracleTransactions prometheus metric.
+	oracleTransactions = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Help:      "Number of oracle response transactions sent by type (response or backup)",
+			Name:      "oracle_transactions_total",
+			Namespace: "neogo",
+		},
+		[]string{"type"},
+	)
+	// oracleCompletedRequests prometheus metric.
+	oracleCompletedRequests = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Help:      "Number of completed oracle requests by status (finished or expired)",
+			Name:      "oracle_completed_requests_total",
+			Namespace: "neogo",
+		},
+		[]string{"status"},
+	)
+	// oracleRequestDuration prometheus metric.
+	oracleRequestDuration = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Help:      "Time from receiving an oracle request to its completion",
+			Name:      "oracle_request_duration_seconds",
+			Namespace: "neogo",
+			Buckets:   []float64{1, 5, 15, 30, 60, 180, 600, 1800, 3600},
+		},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(
+		oracleRequests,
+		oracleActiveRequests,
+		oracleResponses,
+		oracleTransactions,
+		oracleCompletedRequests,
+		oracleRequestDuration,
+	)
+}
+
+func addResponseMetric(code transaction.OracleResponseCode) {
+	oracleResponses.WithLabelValues(code.String()).Inc()
+}
+
+func addTransactionMetric(backup bool) {
+	typ := "response"
+	if backup {
+		typ = "backup"
+	}
+	oracleTransactions.WithLabelValues(typ).Inc()
+}
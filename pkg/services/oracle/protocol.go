@@ -0,0 +1,192 @@
+package oracle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	gio "io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/services/oracle/neofs"
+	"go.uber.org/zap"
+)
+
+// HTTPSScheme is the name of https URI scheme.
+const HTTPSScheme = "https"
+
+type (
+	// ProtocolHandler fetches data for oracle requests with URLs of some
+	// scheme.
+	ProtocolHandler interface {
+		// Fetch retrieves the data for the request. The reader returned (if
+		// any) is closed by the caller, the data is only read from it for
+		// the Success code (and the reader must be provided then). The
+		// error returned is logged, it doesn't affect the response code.
+		Fetch(ctx context.Context, req ProtocolRequest) (gio.ReadCloser, transaction.OracleResponseCode, error)
+	}
+
+	// ProtocolRequest is an oracle request passed to ProtocolHandler.
+	ProtocolRequest struct {
+		// ID is the oracle request ID.
+		ID uint64
+		// URL is the request URL.
+		URL *url.URL
+		// Attempt is the number of previous attempts to process the
+		// request.
+		Attempt int
+	}
+
+	// ProtocolFactory creates a protocol handler from its configuration.
+	ProtocolFactory func(cfg config.OracleProtocol) (ProtocolHandler, error)
+)
+
+var (
+	factoriesLock sync.RWMutex
+	factories     = map[string]ProtocolFactory{
+		FileScheme: newFileProtocol,
+	}
+)
+
+// RegisterProtocol registers the protocol handler factory for the URI scheme.
+// Oracle services create handlers for schemes mentioned in the Protocols
+// section of their configuration. It panics if the scheme is already
+// registered or is handled by the service itself (https and neofs).
+func RegisterProtocol(scheme string, f ProtocolFactory) {
+	factoriesLock.Lock()
+	defer factoriesLock.Unlock()
+	if _, ok := factories[scheme]; ok || scheme == HTTPSScheme || scheme == neofs.URIScheme {
+		panic(fmt.Sprintf("oracle protocol %s is already registered", scheme))
+	}
+	factories[scheme] = f
+}
+
+func getProtocolFactory(scheme string) ProtocolFactory {
+	factoriesLock.RLock()
+	defer factoriesLock.RUnlock()
+	return factories[scheme]
+}
+
+// initProtocols creates handlers for all enabled protocols.
+func (o *Oracle) initProtocols() error {
+	o.protocols = make(map[string]ProtocolHandler)
+	if !o.MainCfg.Protocols[HTTPSScheme].Disabled {
+		o.protocols[HTTPSScheme] = &httpsProtocol{
+			client:              o.Client,
+			allowedContentTypes: o.MainCfg.AllowedContentTypes,
+		}
+	}
+	if len(o.MainCfg.NeoFS.Nodes) != 0 && !o.MainCfg.Protocols[neofs.URIScheme].Disabled {
+		o.protocols[neofs.URIScheme] = &neofsProtocol{o: o, nodes: o.MainCfg.NeoFS.Nodes}
+	}
+	for scheme, cfg := range o.MainCfg.Protocols {
+		if cfg.Disabled || scheme == HTTPSScheme || scheme == neofs.URIScheme {
+			continue
+		}
+		f := getProtocolFactory(scheme)
+		if f == nil {
+			return fmt.Errorf("unknown oracle protocol %s", scheme)
+		}
+		h, err := f(cfg)
+		if err != nil {
+			return fmt.Errorf("failed to create %s oracle protocol handler: %w", scheme, err)
+		}
+		o.protocols[scheme] = h
+	}
+	for scheme, h := range o.Protocols {
+		o.protocols[scheme] = h
+	}
+	return nil
+}
+
+// getProtocolTimeout returns the request timeout for the scheme.
+func (o *Oracle) getProtocolTimeout(scheme string) time.Duration {
+	if t := o.MainCfg.Protocols[scheme].Timeout; t > 0 {
+		return t
+	}
+	if scheme == neofs.URIScheme {
+		return o.MainCfg.NeoFS.Timeout
+	}
+	return o.MainCfg.RequestTimeout
+}
+
+// fetch performs the request using the handler and returns its result along
+// with the error occurred (if any).
+func (o *Oracle) fetch(h ProtocolHandler, u *url.URL, req request, attempt int) ([]byte, transaction.OracleResponseCode, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), o.getProtocolTimeout(u.Scheme))
+	defer cancel()
+	rc, code, err := h.Fetch(ctx, ProtocolRequest{ID: req.ID, URL: u, Attempt: attempt})
+	if rc != nil {
+		defer rc.Close() // intentionally skip the closing error, there is nothing to do with it.
+	}
+	if err != nil {
+		o.Log.Warn("oracle request failed", zap.String("url", req.Req.URL), zap.Error(err), zap.Stringer("code", code))
+	}
+	if code != transaction.Success {
+		return nil, code, err
+	}
+	if rc == nil {
+		o.Log.Warn("no data returned for oracle request", zap.String("url", req.Req.URL))
+		return nil, transaction.Error, errors.New("no data returned")
+	}
+	return o.readResponse(rc, req.Req.URL)
+}
+
+// httpsProtocol is a handler for https requests.
+type httpsProtocol struct {
+	client              HTTPClient
+	allowedContentTypes []string
+}
+
+// Fetch implements the ProtocolHandler interface.
+func (p *httpsProtocol) Fetch(ctx context.Context, req ProtocolRequest) (gio.ReadCloser, transaction.OracleResponseCode, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, "GET", req.URL.String(), nil)
+	if err != nil {
+		return nil, transaction.Error, fmt.Errorf("failed to create http request: %w", err)
+	}
+	httpReq.Header.Set("User-Agent", "NeoOracleService/3.0")
+	httpReq.Header.Set("Content-Type", "application/json")
+	r, err := p.client.Do(httpReq)
+	if err != nil {
+		if errors.Is(err, ErrRestrictedRedirect) {
+			return nil, transaction.Forbidden, err
+		}
+		return nil, transaction.Error, err
+	}
+	switch r.StatusCode {
+	case http.StatusOK:
+		if !checkMediaType(r.Header.Get("Content-Type"), p.allowedContentTypes) {
+			return r.Body, transaction.ContentTypeNotSupported, nil
+		}
+		return r.Body, transaction.Success, nil
+	case http.StatusForbidden:
+		return r.Body, transaction.Forbidden, nil
+	case http.StatusNotFound:
+		return r.Body, transaction.NotFound, nil
+	case http.StatusRequestTimeout:
+		return r.Body, transaction.Timeout, nil
+	default:
+		return r.Body, transaction.Error, nil
+	}
+}
+
+// neofsProtocol is a handler for neofs requests, NeoFS nodes are used in
+// round-robin fashion.
+type neofsProtocol struct {
+	o     *Oracle
+	nodes []string
+}
+
+// Fetch implements the ProtocolHandler interface.
+func (p *neofsProtocol) Fetch(ctx context.Context, req ProtocolRequest) (gio.ReadCloser, transaction.OracleResponseCode, error) {
+	index := (int(req.ID) + req.Attempt) % len(p.nodes)
+	rc, err := neofs.Get(ctx, p.o.getNeoFSKey(p.o.getAccount()), req.URL, p.nodes[index])
+	if err != nil {
+		return rc, transaction.Error, err
+	}
+	return rc, transaction.Success, nil
+}
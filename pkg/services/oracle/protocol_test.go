@@ -0,0 +1,75 @@
+package oracle
+
+import (
+	"context"
+	gio "io"
+	"net/url"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileProtocol(t *testing.T) {
+	p := NewFileProtocol("./testdata/files")
+	fetch := func(t *testing.T, u string) ([]byte, transaction.OracleResponseCode) {
+		parsed, err := url.ParseRequestURI(u)
+		require.NoError(t, err)
+		rc, code, _ := p.Fetch(context.Background(), ProtocolRequest{URL: parsed})
+		if rc == nil {
+			return nil, code
+		}
+		defer rc.Close()
+		data, err := gio.ReadAll(rc)
+		require.NoError(t, err)
+		return data, code
+	}
+
+	data, code := fetch(t, "file:///values.json")
+	require.Equal(t, transaction.Success, code)
+	require.Equal(t, `{"Values":[1,2,3]}`, string(data))
+
+	data, code = fetch(t, "file:values.json")
+	require.Equal(t, transaction.Success, code)
+	require.Equal(t, `{"Values":[1,2,3]}`, string(data))
+
+	for _, u := range []string{
+		"file:///missing.json",
+		"file:///",
+		"file:///../oracle1.json",
+		"file:../oracle1.json",
+	} {
+		_, code = fetch(t, u)
+		require.Equal(t, transaction.NotFound, code, u)
+	}
+}
+
+func TestRegisterProtocol(t *testing.T) {
+	var created config.OracleProtocol
+	RegisterProtocol("test", func(cfg config.OracleProtocol) (ProtocolHandler, error) {
+		created = cfg
+		return NewFileProtocol("."), nil
+	})
+	t.Cleanup(func() {
+		factoriesLock.Lock()
+		delete(factories, "test")
+		factoriesLock.Unlock()
+	})
+	for _, scheme := range []string{"test", FileScheme, HTTPSScheme, "neofs"} {
+		require.Panics(t, func() { RegisterProtocol(scheme, nil) }, scheme)
+	}
+
+	o := &Oracle{Config: Config{MainCfg: config.OracleConfiguration{
+		Protocols: map[string]config.OracleProtocol{
+			"test":      {Parameters: map[string]string{"key": "value"}},
+			HTTPSScheme: {Disabled: true},
+		},
+		NeoFS: config.NeoFSConfiguration{Nodes: []string{"localhost:8080"}},
+	}}}
+	require.NoError(t, o.initProtocols())
+	require.Equal(t, "value", created.Parameters["key"])
+	require.Contains(t, o.protocols, "test")
+	require.Contains(t, o.protocols, "neofs")
+	require.NotContains(t, o.protocols, HTTPSScheme)
+}
@@ -1,18 +1,19 @@
 package oracle
 
 import (
-	"context"
 	"errors"
+	"fmt"
 	"mime"
-	"net/http"
 	"net/url"
 	"time"
 
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/storage"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
-	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
-	"github.com/nspcc-dev/neo-go/pkg/services/oracle/neofs"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/services/oracle/broadcaster"
+	"github.com/nspcc-dev/neo-go/pkg/services/signer"
 	"go.uber.org/zap"
 )
 
@@ -33,7 +34,7 @@ func (o *Oracle) runRequestWorker() {
 			if acc == nil {
 				continue
 			}
-			err := o.processRequest(acc.PrivateKey(), req)
+			err := o.processRequest(acc, req)
 			if err != nil {
 				o.Log.Debug("can't process request", zap.Uint64("id", req.ID), zap.Error(err))
 			}
@@ -55,6 +56,11 @@ func (o *Oracle) RemoveRequests(ids []uint64) {
 			delete(o.responses, id)
 		}
 	}
+	for _, id := range ids {
+		o.audit.update(id, false, func(r *result.OracleRequestRecord) {
+			r.Status = result.OracleRequestFinished
+		})
+	}
 }
 
 // AddRequests saves all requests in-fly for further processing.
@@ -62,6 +68,9 @@ func (o *Oracle) AddRequests(reqs map[uint64]*state.OracleRequest) {
 	if len(reqs) == 0 {
 		return
 	}
+	for id, r := range reqs {
+		o.auditRequest(id, r)
+	}
 
 	o.respMtx.Lock()
 	if !o.running {
@@ -96,15 +105,15 @@ func (o *Oracle) ProcessRequestsInternal(reqs map[uint64]*state.OracleRequest) {
 
 	// Process actual requests.
 	for id, req := range reqs {
-		if err := o.processRequest(acc.PrivateKey(), request{ID: id, Req: req}); err != nil {
+		if err := o.processRequest(acc, request{ID: id, Req: req}); err != nil {
 			o.Log.Debug("can't process request", zap.Error(err))
 		}
 	}
 }
 
-func (o *Oracle) processRequest(priv *keys.PrivateKey, req request) error {
+func (o *Oracle) processRequest(acc *signer.Account, req request) error {
 	if req.Req == nil {
-		o.processFailedRequest(priv, req)
+		o.processFailedRequest(acc, req)
 		return nil
 	}
 
@@ -117,72 +126,29 @@ func (o *Oracle) processRequest(priv *keys.PrivateKey, req request) error {
 	if err != nil {
 		o.Log.Warn("malformed oracle request", zap.String("url", req.Req.URL), zap.Error(err))
 		resp.Code = transaction.ProtocolNotSupported
+	} else if h, ok := o.protocols[u.Scheme]; !ok {
+		resp.Code = transaction.ProtocolNotSupported
+		err = fmt.Errorf("unsupported scheme %q", u.Scheme)
+		o.Log.Warn("unknown oracle request scheme", zap.String("url", req.Req.URL))
 	} else {
-		switch u.Scheme {
-		case "https":
-			httpReq, err := http.NewRequest("GET", req.Req.URL, nil)
-			if err != nil {
-				o.Log.Warn("failed to create http request", zap.String("url", req.Req.URL), zap.Error(err))
-				resp.Code = transaction.Error
-				break
-			}
-			httpReq.Header.Set("User-Agent", "NeoOracleService/3.0")
-			httpReq.Header.Set("Content-Type", "application/json")
-			r, err := o.Client.Do(httpReq)
-			if err != nil {
-				if errors.Is(err, ErrRestrictedRedirect) {
-					resp.Code = transaction.Forbidden
-				} else {
-					resp.Code = transaction.Error
-				}
-				o.Log.Warn("oracle request failed", zap.String("url", req.Req.URL), zap.Error(err), zap.Stringer("code", resp.Code))
-				break
-			}
-			defer r.Body.Close()
-			switch r.StatusCode {
-			case http.StatusOK:
-				if !checkMediaType(r.Header.Get("Content-Type"), o.MainCfg.AllowedContentTypes) {
-					resp.Code = transaction.ContentTypeNotSupported
-					break
-				}
-
-				resp.Result, resp.Code = o.readResponse(r.Body, req.Req.URL)
-			case http.StatusForbidden:
-				resp.Code = transaction.Forbidden
-			case http.StatusNotFound:
-				resp.Code = transaction.NotFound
-			case http.StatusRequestTimeout:
-				resp.Code = transaction.Timeout
-			default:
-				resp.Code = transaction.Error
-			}
-		case neofs.URIScheme:
-			ctx, cancel := context.WithTimeout(context.Background(), o.MainCfg.NeoFS.Timeout)
-			defer cancel()
-			index := (int(req.ID) + incTx.attempts) % len(o.MainCfg.NeoFS.Nodes)
-			rc, err := neofs.Get(ctx, priv, u, o.MainCfg.NeoFS.Nodes[index])
-			if err != nil {
-				resp.Code = transaction.Error
-				o.Log.Warn("failed to perform oracle request", zap.String("url", req.Req.URL), zap.Error(err))
-				if rc != nil {
-					rc.Close() // intentionally skip the closing error, make it unified with Oracle `https` protocol.
-				}
-				break
-			}
-			resp.Result, resp.Code = o.readResponse(rc, req.Req.URL)
-			rc.Close() // intentionally skip the closing error, make it unified with Oracle `https` protocol.
-		default:
-			resp.Code = transaction.ProtocolNotSupported
-			o.Log.Warn("unknown oracle request scheme", zap.String("url", req.Req.URL))
-		}
+		resp.Result, resp.Code, err = o.fetch(h, u, req, incTx.attempts)
 	}
+	var (
+		fetchErr    = err
+		fetchedSize = len(resp.Result)
+	)
 	if resp.Code == transaction.Success {
 		resp.Result, err = filterRequest(resp.Result, req.Req)
 		if err != nil {
 			o.Log.Warn("oracle filter failed", zap.Uint64("request", req.ID), zap.Error(err))
 			resp.Code = transaction.Error
+			if errors.Is(err, ErrResponseTooLarge) {
+				resp.Code = transaction.ResponseTooLarge
+			}
+			fetchErr = fmt.Errorf("filter failed: %w", err)
 		}
 	}
+	addResponseMetric(resp.Code)
 	o.Log.Debug("oracle request processed", zap.String("url", req.Req.URL), zap.Int("code", int(resp.Code)), zap.String("result", string(resp.Result)))
 
 	currentHeight := o.Chain.BlockHeight()
@@ -217,11 +183,19 @@ func (o *Oracle) processRequest(priv *keys.PrivateKey, req request) error {
 	incTx.backupTx = backupTx
 	incTx.reverifyTx(o.Network)
 
-	txSig := priv.SignHashable(uint32(o.Network), tx)
-	incTx.addResponse(priv.PublicKey(), txSig, false)
+	txSig, err := acc.SignHashable(uint32(o.Network), tx, signer.DomainOracle)
+	if err != nil {
+		incTx.Unlock()
+		return fmt.Errorf("failed to sign response transaction: %w", err)
+	}
+	incTx.addResponse(acc.PublicKey(), txSig, false)
 
-	backupSig := priv.SignHashable(uint32(o.Network), backupTx)
-	incTx.addResponse(priv.PublicKey(), backupSig, true)
+	backupSig, err := acc.SignHashable(uint32(o.Network), backupTx, signer.DomainOracle)
+	if err != nil {
+		incTx.Unlock()
+		return fmt.Errorf("failed to sign backup transaction: %w", err)
+	}
+	incTx.addResponse(acc.PublicKey(), backupSig, true)
 
 	readyTx, ready := incTx.finalize(o.getOracleNodes(), false)
 	if ready {
@@ -230,16 +204,30 @@ func (o *Oracle) processRequest(priv *keys.PrivateKey, req request) error {
 	}
 	incTx.time = time.Now()
 	incTx.attempts++
+	o.auditResponse(req.ID, incTx, ready, readyTx, func(r *result.OracleRequestRecord) {
+		setRequestData(r, req.Req)
+		if r.Status == result.OracleRequestPending {
+			r.Status = result.OracleRequestSigning
+		}
+		code := resp.Code
+		r.Code = &code
+		r.FetchedSize = fetchedSize
+		r.ResultSize = len(resp.Result)
+		r.Error = ""
+		if fetchErr != nil {
+			r.Error = fetchErr.Error()
+		}
+	})
 	incTx.Unlock()
 
-	o.ResponseHandler.SendResponse(priv, resp, txSig)
+	o.sendResponse(acc, resp, txSig)
 	if ready {
 		o.sendTx(readyTx)
 	}
 	return nil
 }
 
-func (o *Oracle) processFailedRequest(priv *keys.PrivateKey, req request) {
+func (o *Oracle) processFailedRequest(acc *signer.Account, req request) {
 	// Request is being processed again.
 	incTx := o.getResponse(req.ID, false)
 	if incTx == nil {
@@ -260,10 +248,11 @@ func (o *Oracle) processFailedRequest(priv *keys.PrivateKey, req request) {
 	}
 	incTx.time = time.Now()
 	incTx.attempts++
-	txSig := incTx.backupSigs[string(priv.PublicKey().Bytes())].sig
+	txSig := incTx.backupSigs[string(acc.PublicKey().Bytes())].sig
+	o.auditResponse(req.ID, incTx, ready, readyTx, nil)
 	incTx.Unlock()
 
-	o.ResponseHandler.SendResponse(priv, getFailedResponse(req.ID), txSig)
+	o.sendResponse(acc, getFailedResponse(req.ID), txSig)
 	if ready {
 		o.sendTx(readyTx)
 	}
@@ -286,3 +275,14 @@ func checkMediaType(hdr string, allowed []string) bool {
 	}
 	return false
 }
+
+// sendResponse signs the response message and sends it to other oracle nodes.
+func (o *Oracle) sendResponse(acc *signer.Account, resp *transaction.OracleResponse, txSig []byte) {
+	msg := broadcaster.GetMessage(acc.PublicKey().Bytes(), resp.ID, txSig)
+	msgSig, err := acc.Sign(signer.Request{Hash: hash.Sha256(msg), Domain: signer.DomainOracle})
+	if err != nil {
+		o.Log.Warn("can't sign oracle response", zap.Uint64("id", resp.ID), zap.Error(err))
+		return
+	}
+	o.ResponseHandler.SendResponse(acc.PublicKey(), resp, txSig, msgSig)
+}
@@ -58,6 +58,7 @@ func (o *Oracle) AddResponse(pub *keys.PublicKey, reqID uint64, txSig []byte) {
 		ready = !incTx.isSent
 		incTx.isSent = true
 	}
+	o.auditResponse(reqID, incTx, ready, readyTx, nil)
 	incTx.Unlock()
 
 	if ready {
@@ -68,7 +69,7 @@ func (o *Oracle) AddResponse(pub *keys.PublicKey, reqID uint64, txSig []byte) {
 // ErrResponseTooLarge is returned when a response exceeds the max allowed size.
 var ErrResponseTooLarge = errors.New("too big response")
 
-func (o *Oracle) readResponse(rc gio.Reader, url string) ([]byte, transaction.OracleResponseCode) {
+func (o *Oracle) readResponse(rc gio.Reader, url string) ([]byte, transaction.OracleResponseCode, error) {
 	const limit = transaction.MaxOracleResultSize
 	buf := make([]byte, limit+1)
 	n, err := gio.ReadFull(rc, buf)
@@ -83,15 +84,15 @@ func (o *Oracle) readResponse(rc gio.Reader, url string) ([]byte, transaction.Or
 	return o.handleResponseError(nil, err, url)
 }
 
-func (o *Oracle) handleResponseError(data []byte, err error, url string) ([]byte, transaction.OracleResponseCode) {
+func (o *Oracle) handleResponseError(data []byte, err error, url string) ([]byte, transaction.OracleResponseCode, error) {
 	if err != nil {
 		o.Log.Warn("failed to read data for oracle request", zap.String("url", url), zap.Error(err))
 		if errors.Is(err, ErrResponseTooLarge) {
-			return nil, transaction.ResponseTooLarge
+			return nil, transaction.ResponseTooLarge, err
 		}
-		return nil, transaction.Error
+		return nil, transaction.Error, err
 	}
-	return data, transaction.Success
+	return data, transaction.Success, nil
 }
 
 func checkUTF8(v []byte) ([]byte, error) {
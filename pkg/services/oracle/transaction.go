@@ -1,6 +1,7 @@
 package oracle
 
 import (
+	"sort"
 	"sync"
 	"time"
 
@@ -116,3 +117,20 @@ func finalizeTx(oracleNodes keys.PublicKeys, tx *transaction.Transaction, txSigs
 	tx.Scripts[1].InvocationScript = w.Bytes()
 	return true
 }
+
+// signers returns sorted keys of nodes with valid signatures for the main
+// (or backup) transaction.
+func (t *incompleteTx) signers(backup bool) keys.PublicKeys {
+	sigs := t.sigs
+	if backup {
+		sigs = t.backupSigs
+	}
+	res := make(keys.PublicKeys, 0, len(sigs))
+	for _, sig := range sigs {
+		if sig.ok {
+			res = append(res, sig.pub)
+		}
+	}
+	sort.Sort(res)
+	return res
+}
@@ -0,0 +1,463 @@
+/*
+Package xpath implements a subset of XPath 1.0 used by oracle filters.
+
+Supported paths consist of steps separated by '/' (child axis) or '//'
+(descendant axis) starting from the document root. A step is an element name
+(matched against the local name, namespaces are ignored), '*', an attribute
+('@name' or '@*') or 'text()'. Element steps can have predicates:
+
+	[2]            a 1-based position among the matching siblings
+	[last()]       the last matching sibling
+	[@id]          elements having the attribute
+	[@id='x']      elements with the attribute equal to (or not equal with !=) the literal
+	[name='x']     elements with a child element having such a string value
+	[.='x']        elements with such a string value
+
+Selected nodes are returned in the document order as their string values:
+attribute values, text data or the concatenated text of all element's
+descendants.
+*/
+package xpath
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// MaxDepth is the maximum element nesting depth of a document.
+	MaxDepth = 64
+	// MaxNodes is the maximum number of nodes in a document.
+	MaxNodes = 16384
+	// MaxResults is the maximum number of nodes selected by every path step.
+	MaxResults = 1024
+)
+
+type (
+	nodeKind byte
+
+	node struct {
+		kind     nodeKind
+		index    int // Position in the document order.
+		name     string
+		value    string // Attribute value or text data.
+		attrs    []*node
+		children []*node
+	}
+
+	stepKind byte
+
+	step struct {
+		descendant bool
+		kind       stepKind
+		name       string // Empty for '*' and '@*'.
+		predicates []predicate
+	}
+
+	predicate struct {
+		position int  // 1-based position, 0 if not a positional predicate.
+		last     bool // last() predicate.
+		operand  string
+		attr     bool // Operand refers to an attribute.
+		hasValue bool
+		negate   bool
+		value    string
+	}
+)
+
+const (
+	elementNode nodeKind = iota
+	attributeNode
+	textNode
+)
+
+const (
+	elementStep stepKind = iota
+	attributeStep
+	textStep
+)
+
+// ErrLimit is returned when the document or evaluation exceeds limits.
+var ErrLimit = errors.New("limit exceeded")
+
+// Get parses XML document and returns string values of nodes selected by
+// the path.
+func Get(path string, doc []byte) ([]string, error) {
+	steps, err := parsePath(path)
+	if err != nil {
+		return nil, fmt.Errorf("invalid path: %w", err)
+	}
+	root, err := parseDocument(doc)
+	if err != nil {
+		return nil, err
+	}
+	nodes := []*node{root}
+	for i := range steps {
+		nodes, err = steps[i].apply(nodes)
+		if err != nil {
+			return nil, err
+		}
+	}
+	res := make([]string, len(nodes))
+	for i := range nodes {
+		res[i] = nodes[i].stringValue()
+	}
+	return res, nil
+}
+
+func parsePath(path string) ([]step, error) {
+	if !strings.HasPrefix(path, "/") {
+		return nil, errors.New("path must be absolute")
+	}
+	var steps []step
+	for len(path) != 0 {
+		var s step
+		switch {
+		case strings.HasPrefix(path, "//"):
+			s.descendant = true
+			path = path[2:]
+		case path[0] == '/':
+			path = path[1:]
+		default:
+			return nil, fmt.Errorf("unexpected %q", path)
+		}
+		end := strings.IndexAny(path, "/[")
+		if end < 0 {
+			end = len(path)
+		}
+		test := path[:end]
+		path = path[end:]
+		switch {
+		case test == "text()":
+			s.kind = textStep
+		case strings.HasPrefix(test, "@"):
+			s.kind = attributeStep
+			s.name = test[1:]
+			if s.name == "*" {
+				s.name = ""
+			} else if !isName(s.name) {
+				return nil, fmt.Errorf("invalid attribute name %q", s.name)
+			}
+		case test == "*":
+		case isName(test):
+			s.name = test
+		default:
+			return nil, fmt.Errorf("invalid step %q", test)
+		}
+		for strings.HasPrefix(path, "[") {
+			end := predicateEnd(path)
+			if end < 0 {
+				return nil, errors.New("unterminated predicate")
+			}
+			if s.kind != elementStep {
+				return nil, errors.New("predicates are only allowed for elements")
+			}
+			p, err := parsePredicate(path[1:end])
+			if err != nil {
+				return nil, err
+			}
+			s.predicates = append(s.predicates, p)
+			path = path[end+1:]
+		}
+		if s.kind != elementStep && len(path) != 0 {
+			return nil, errors.New("attribute and text steps must be the last ones")
+		}
+		steps = append(steps, s)
+	}
+	if len(steps) == 0 {
+		return nil, errors.New("empty path")
+	}
+	return steps, nil
+}
+
+// predicateEnd returns the index of ']' closing the predicate at the
+// beginning of s skipping quoted literals, -1 if there is none.
+func predicateEnd(s string) int {
+	var quote byte
+	for i := 1; i < len(s); i++ {
+		switch c := s[i]; {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == ']':
+			return i
+		}
+	}
+	return -1
+}
+
+func parsePredicate(s string) (predicate, error) {
+	var p predicate
+	if s == "last()" {
+		p.last = true
+		return p, nil
+	}
+	if n, err := strconv.Atoi(s); err == nil {
+		if n < 1 {
+			return p, fmt.Errorf("invalid position %d", n)
+		}
+		p.position = n
+		return p, nil
+	}
+	operand := s
+	if i := strings.IndexByte(s, '='); i >= 0 {
+		operand, s = s[:i], s[i+1:]
+		if strings.HasSuffix(operand, "!") {
+			p.negate = true
+			operand = operand[:len(operand)-1]
+		}
+		if len(s) < 2 || (s[0] != '\'' && s[0] != '"') || s[len(s)-1] != s[0] {
+			return p, fmt.Errorf("invalid literal %s", s)
+		}
+		p.hasValue = true
+		p.value = s[1 : len(s)-1]
+	}
+	switch {
+	case operand == ".":
+		if !p.hasValue {
+			return p, errors.New("'.' predicate requires a value")
+		}
+	case strings.HasPrefix(operand, "@") && isName(operand[1:]):
+		p.attr = true
+		operand = operand[1:]
+	case isName(operand):
+	default:
+		return p, fmt.Errorf("invalid predicate operand %q", operand)
+	}
+	p.operand = operand
+	return p, nil
+}
+
+func isName(s string) bool {
+	if len(s) == 0 {
+		return false
+	}
+	for i, c := range s {
+		switch {
+		case c == '_' || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z'):
+		case i != 0 && (c == '-' || c == '.' || c == ':' || ('0' <= c && c <= '9')):
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// parseDocument builds a node tree from XML data. The root node returned is
+// the document node containing top-level elements.
+func parseDocument(doc []byte) (*node, error) {
+	d := xml.NewDecoder(bytes.NewReader(doc))
+	var (
+		root  = &node{}
+		stack = []*node{root}
+		count int
+	)
+	add := func(parent *node, n *node, attr bool) error {
+		count++
+		if count > MaxNodes {
+			return fmt.Errorf("%w: too many nodes", ErrLimit)
+		}
+		n.index = count
+		if attr {
+			parent.attrs = append(parent.attrs, n)
+		} else {
+			parent.children = append(parent.children, n)
+		}
+		return nil
+	}
+	for {
+		tok, err := d.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		parent := stack[len(stack)-1]
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if len(stack) > MaxDepth {
+				return nil, fmt.Errorf("%w: document is too deep", ErrLimit)
+			}
+			if len(stack) == 1 && len(root.children) != 0 {
+				return nil, errors.New("multiple root elements")
+			}
+			n := &node{kind: elementNode, name: t.Name.Local}
+			if err := add(parent, n, false); err != nil {
+				return nil, err
+			}
+			for _, a := range t.Attr {
+				if a.Name.Space == "xmlns" || (a.Name.Space == "" && a.Name.Local == "xmlns") {
+					continue
+				}
+				if err := add(n, &node{kind: attributeNode, name: a.Name.Local, value: a.Value}, true); err != nil {
+					return nil, err
+				}
+			}
+			stack = append(stack, n)
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			if len(stack) == 1 {
+				continue // Whitespace around the root element.
+			}
+			if l := len(parent.children); l != 0 && parent.children[l-1].kind == textNode {
+				parent.children[l-1].value += string(t)
+				continue
+			}
+			if err := add(parent, &node{kind: textNode, value: string(t)}, false); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if len(root.children) == 0 {
+		return nil, errors.New("no root element")
+	}
+	return root, nil
+}
+
+func (s *step) apply(nodes []*node) ([]*node, error) {
+	if s.descendant {
+		nodes = descendantsOrSelf(nodes)
+	}
+	var res []*node
+	for _, n := range nodes {
+		switch s.kind {
+		case attributeStep:
+			for _, a := range n.attrs {
+				if s.name == "" || a.name == s.name {
+					res = append(res, a)
+				}
+			}
+		case textStep:
+			for _, c := range n.children {
+				if c.kind == textNode {
+					res = append(res, c)
+				}
+			}
+		default:
+			var matched []*node
+			for _, c := range n.children {
+				if c.kind == elementNode && (s.name == "" || c.name == s.name) {
+					matched = append(matched, c)
+				}
+			}
+			for i := range s.predicates {
+				matched = s.predicates[i].filter(matched)
+			}
+			res = append(res, matched...)
+		}
+		if len(res) > MaxResults {
+			return nil, fmt.Errorf("%w: too many nodes selected", ErrLimit)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].index < res[j].index })
+	return res, nil
+}
+
+// descendantsOrSelf returns all the nodes given with their element
+// descendants in the document order. Every node is visited at most once.
+func descendantsOrSelf(nodes []*node) []*node {
+	var (
+		seen = make(map[*node]bool)
+		res  []*node
+		walk func(n *node)
+	)
+	walk = func(n *node) {
+		if seen[n] {
+			return
+		}
+		seen[n] = true
+		res = append(res, n)
+		for _, c := range n.children {
+			if c.kind == elementNode {
+				walk(c)
+			}
+		}
+	}
+	for _, n := range nodes {
+		walk(n)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].index < res[j].index })
+	return res
+}
+
+func (p *predicate) filter(nodes []*node) []*node {
+	switch {
+	case p.last:
+		if len(nodes) == 0 {
+			return nil
+		}
+		return nodes[len(nodes)-1:]
+	case p.position != 0:
+		if p.position > len(nodes) {
+			return nil
+		}
+		return nodes[p.position-1 : p.position]
+	}
+	var res []*node
+	for _, n := range nodes {
+		if p.match(n) {
+			res = append(res, n)
+		}
+	}
+	return res
+}
+
+func (p *predicate) match(n *node) bool {
+	var candidates []*node
+	switch {
+	case p.operand == ".":
+		candidates = []*node{n}
+	case p.attr:
+		for _, a := range n.attrs {
+			if a.name == p.operand {
+				candidates = append(candidates, a)
+			}
+		}
+	default:
+		for _, c := range n.children {
+			if c.kind == elementNode && c.name == p.operand {
+				candidates = append(candidates, c)
+			}
+		}
+	}
+	if !p.hasValue {
+		return len(candidates) != 0
+	}
+	for _, c := range candidates {
+		if (c.stringValue() == p.value) != p.negate {
+			return true
+		}
+	}
+	return false
+}
+
+func (n *node) stringValue() string {
+	if n.kind != elementNode {
+		return n.value
+	}
+	var sb strings.Builder
+	n.writeText(&sb)
+	return sb.String()
+}
+
+func (n *node) writeText(sb *strings.Builder) {
+	for _, c := range n.children {
+		switch c.kind {
+		case textNode:
+			sb.WriteString(c.value)
+		case elementNode:
+			c.writeText(sb)
+		}
+	}
+}
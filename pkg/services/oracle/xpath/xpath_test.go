@@ -0,0 +1,135 @@
+package xpath
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testDoc = `<?xml version="1.0" encoding="UTF-8"?>
+<rates xmlns="http://example.com/rates" updated="2023-01-02">
+  <!-- Daily rates. -->
+  <rate currency="USD" source="a">1.07</rate>
+  <rate currency="GBP" source="b">0.88</rate>
+  <rate currency="JPY" source="a">140.5</rate>
+  <meta><name>Daily</name><rate currency="XXX">0</rate></meta>
+  <note><![CDATA[a <b>]]></note>
+</rates>`
+
+func TestGet(t *testing.T) {
+	testCases := []struct {
+		path   string
+		result []string
+	}{
+		{"/rates/@updated", []string{"2023-01-02"}},
+		{"/rates/rate", []string{"1.07", "0.88", "140.5"}},
+		{"/rates/rate[2]", []string{"0.88"}},
+		{"/rates/rate[last()]", []string{"140.5"}},
+		{"/rates/rate[5]", []string{}},
+		{"/rates/rate/@currency", []string{"USD", "GBP", "JPY"}},
+		{"/rates/rate[@currency='GBP']", []string{"0.88"}},
+		{`/rates/rate[@currency="GBP"]`, []string{"0.88"}},
+		{"/rates/rate[@source!='a']/@currency", []string{"GBP"}},
+		{"/rates/rate[@source='a'][2]", []string{"140.5"}},
+		{"/rates/rate[.='1.07']/@currency", []string{"USD"}},
+		{"/rates/meta[name='Daily']/rate", []string{"0"}},
+		{"/rates/*[name]/name", []string{"Daily"}},
+		{"//rate/@currency", []string{"USD", "GBP", "JPY", "XXX"}},
+		{"//rate[1]", []string{"1.07", "0"}},
+		{"/rates/meta", []string{"Daily0"}},
+		{"/rates/meta//text()", []string{"Daily", "0"}},
+		{"/rates/note", []string{"a <b>"}},
+		{"/rates/note/@*", []string{}},
+		{"/rates/rate[@currency='a]b']", []string{}},
+		{"/*/missing", []string{}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.path, func(t *testing.T) {
+			res, err := Get(tc.path, []byte(testDoc))
+			require.NoError(t, err)
+			require.Equal(t, tc.result, res)
+		})
+	}
+}
+
+func TestGetInvalidPath(t *testing.T) {
+	paths := []string{
+		"",
+		"rates",
+		"/",
+		"/rates/",
+		"/rates/rate[",
+		"/rates/rate[0]",
+		"/rates/rate[-1]",
+		"/rates/rate[@]",
+		"/rates/rate[.]",
+		"/rates/rate[@currency=USD]",
+		"/rates/rate[@currency='USD]",
+		"/rates/@currency[1]",
+		"/rates/@currency/rate",
+		"/rates/text()/rate",
+		"/rates/1rate",
+		"/rates/ra te",
+	}
+	for _, p := range paths {
+		t.Run(p, func(t *testing.T) {
+			_, err := Get(p, []byte(testDoc))
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestGetInvalidDocument(t *testing.T) {
+	docs := []string{
+		"",
+		"not xml",
+		"<a>",
+		"<a></b>",
+		"<a/><b/>",
+		"<a>&unknown;</a>",
+	}
+	for _, d := range docs {
+		t.Run(d, func(t *testing.T) {
+			_, err := Get("/a", []byte(d))
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestGetLimits(t *testing.T) {
+	t.Run("depth", func(t *testing.T) {
+		doc := strings.Repeat("<a>", MaxDepth) + strings.Repeat("</a>", MaxDepth)
+		_, err := Get("/a", []byte(doc))
+		require.NoError(t, err)
+
+		doc = strings.Repeat("<a>", MaxDepth+1) + strings.Repeat("</a>", MaxDepth+1)
+		_, err = Get("/a", []byte(doc))
+		require.True(t, errors.Is(err, ErrLimit), err)
+	})
+	t.Run("nodes", func(t *testing.T) {
+		doc := "<a>" + strings.Repeat("<b/>", MaxNodes) + "</a>"
+		_, err := Get("/a", []byte(doc))
+		require.True(t, errors.Is(err, ErrLimit), err)
+	})
+	t.Run("results", func(t *testing.T) {
+		doc := "<a>" + strings.Repeat("<b/>", MaxResults) + "</a>"
+		res, err := Get("/a/b", []byte(doc))
+		require.NoError(t, err)
+		require.Equal(t, MaxResults, len(res))
+
+		doc = "<a>" + strings.Repeat("<b/>", MaxResults+1) + "</a>"
+		_, err = Get("/a/b", []byte(doc))
+		require.True(t, errors.Is(err, ErrLimit), err)
+		res, err = Get("/a/b[last()]", []byte(doc))
+		require.NoError(t, err)
+		require.Equal(t, 1, len(res))
+	})
+	t.Run("nested descendants", func(t *testing.T) {
+		doc := strings.Repeat("<a>", MaxDepth) + strings.Repeat("</a>", MaxDepth)
+		res, err := Get("//a//a//a//a//a[last()]", []byte(doc))
+		require.NoError(t, err)
+		require.Equal(t, MaxDepth-4, len(res))
+	})
+}
@@ -54,6 +54,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/nef"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
@@ -1049,6 +1050,88 @@ func TestNotaryActor(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestNotaryMultisigActor(t *testing.T) {
+	chain, rpcSrv, httpSrv := initServerWithInMemoryChainAndServices(t, false, true, false)
+	go rpcSrv.coreServer.Start() // Notary request events are emitted by the started server only.
+	defer chain.Close()
+	defer rpcSrv.Shutdown()
+
+	c, err := rpcclient.New(context.Background(), httpSrv.URL, rpcclient.Options{})
+	require.NoError(t, err)
+	require.NoError(t, c.Init())
+	ws, err := rpcclient.NewWS(context.Background(), "ws"+strings.TrimPrefix(httpSrv.URL, "http")+"/ws", rpcclient.WSOptions{})
+	require.NoError(t, err)
+	require.NoError(t, ws.Init())
+	defer ws.Close()
+
+	sender := wallet.NewAccountFromPrivateKey(testchain.PrivateKeyByID(0)) // owner of the deposit in testchain
+	comm, err := c.GetCommittee()
+	require.NoError(t, err)
+	nSigs := smartcontract.GetMajorityHonestNodeCount(len(comm))
+
+	acts := make([]*notary.MultisigActor, nSigs)
+	for i := range acts {
+		multiAcc := wallet.NewAccountFromPrivateKey(testchain.PrivateKeyByID(i))
+		require.NoError(t, multiAcc.ConvertMultisig(nSigs, comm))
+		acts[i], err = notary.NewMultisigActor(c, actor.SignerAccount{
+			Signer: transaction.Signer{
+				Account: multiAcc.Contract.ScriptHash(),
+				Scopes:  transaction.CalledByEntry,
+			},
+			Account: multiAcc,
+		}, sender)
+		require.NoError(t, err)
+	}
+
+	_, err = acts[0].GetProposal(util.Uint256{1, 2, 3})
+	require.ErrorIs(t, err, notary.ErrUnknownProposal)
+
+	p, err := acts[0].Propose(neo.New(acts[0]).SetRegisterPriceTransaction(1_0000_0000))
+	require.NoError(t, err)
+	require.Equal(t, nSigs, p.Required)
+	require.Equal(t, keys.PublicKeys{testchain.PrivateKeyByID(0).PublicKey()}, p.Approvals)
+	require.Equal(t, 1, len(p.Fallbacks))
+	mainHash := p.Tx.Hash()
+
+	_, _, _, err = acts[0].Approve(p)
+	require.ErrorIs(t, err, notary.ErrAlreadyApproved)
+
+	// Status can be fetched without any wallet.
+	st, err := notary.GetProposal(c, acts[0].GetNetwork(), mainHash, nil)
+	require.NoError(t, err)
+	require.Equal(t, p.Account, st.Account)
+	require.Equal(t, p.Approvals, st.Approvals)
+	require.Equal(t, len(comm)-1, len(st.Missing()))
+	require.False(t, st.Completed())
+
+	var (
+		updates int
+		waitErr = make(chan error)
+	)
+	go func() {
+		waitErr <- notary.WaitProposal(context.Background(), ws, st, func(*notary.Proposal) { updates++ })
+	}()
+
+	for i := 1; i < nSigs; i++ {
+		p, err := acts[i].GetProposal(mainHash)
+		require.NoError(t, err)
+		require.Equal(t, i, len(p.Approvals))
+		h, _, _, err := acts[i].Approve(p)
+		require.NoError(t, err)
+		require.Equal(t, mainHash, h)
+	}
+	select {
+	case err := <-waitErr:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("proposal is not completed")
+	}
+	require.True(t, st.Completed())
+	require.Equal(t, nSigs, len(st.Approvals))
+	require.Equal(t, nSigs, len(st.Fallbacks))
+	require.NotZero(t, updates)
+}
+
 func TestSignAndPushP2PNotaryRequest(t *testing.T) {
 	chain, rpcSrv, httpSrv := initServerWithInMemoryChainAndServices(t, false, true, false)
 	defer chain.Close()
@@ -1361,6 +1444,147 @@ func TestInvokeVerify(t *testing.T) {
 	})
 }
 
+func TestClient_InvokeWithStateDiff(t *testing.T) {
+	chain, rpcSrv, httpSrv := initServerWithInMemoryChain(t)
+	defer chain.Close()
+	defer rpcSrv.Shutdown()
+
+	c, err := rpcclient.New(context.Background(), httpSrv.URL, rpcclient.Options{})
+	require.NoError(t, err)
+	require.NoError(t, c.Init())
+
+	owner := testchain.PrivateKeyByID(0).PublicKey().GetScriptHash()
+	inv := invoker.New(c, []transaction.Signer{{Account: owner, Scopes: transaction.CalledByEntry}})
+
+	t.Run("NEP-11 transfer", func(t *testing.T) {
+		to := util.Uint160{1, 2, 3}
+		res, err := inv.CallWithStateDiff(nnsHash, "transfer", to, "neo.com", nil)
+		require.NoError(t, err)
+		require.Equal(t, "HALT", res.State, res.FaultException)
+		require.NotNil(t, res.StateDiff)
+		require.NotEqual(t, 0, len(res.StateDiff.Storage))
+		for _, sd := range res.StateDiff.Storage {
+			require.Equal(t, nnsHash, sd.Contract)
+		}
+		var nep11 []result.BalanceDiff
+		for _, b := range res.StateDiff.Balances {
+			if b.Standard == manifest.NEP11StandardName {
+				nep11 = append(nep11, b)
+			}
+		}
+		require.Equal(t, 2, len(nep11))
+		for _, b := range nep11 {
+			require.Equal(t, nnsHash, b.Asset)
+			require.Equal(t, "", b.ID)
+			if b.Account == to {
+				require.Equal(t, "0", b.OldAmount)
+				require.Equal(t, "1", b.NewAmount)
+			} else {
+				require.Equal(t, owner, b.Account)
+				oldAmount, ok := new(big.Int).SetString(b.OldAmount, 10)
+				require.True(t, ok)
+				require.Equal(t, new(big.Int).Sub(oldAmount, big.NewInt(1)).String(), b.NewAmount)
+			}
+		}
+		require.Equal(t, 0, len(res.StateDiff.Contracts))
+	})
+	t.Run("deploy", func(t *testing.T) {
+		ne, err := nef.NewFile([]byte{byte(opcode.PUSH1), byte(opcode.RET)})
+		require.NoError(t, err)
+		rawNef, err := ne.Bytes()
+		require.NoError(t, err)
+		m := manifest.DefaultManifest("StateDiffTest")
+		m.ABI.Methods = []manifest.Method{{Name: "main", ReturnType: smartcontract.IntegerType}}
+		rawManifest, err := json.Marshal(m)
+		require.NoError(t, err)
+
+		w := io.NewBufBinWriter()
+		emit.AppCall(w.BinWriter, chain.ManagementContractHash(), "deploy", callflag.All, rawNef, rawManifest)
+		require.NoError(t, w.Err)
+		res, err := inv.RunWithStateDiff(w.Bytes())
+		require.NoError(t, err)
+		require.Equal(t, "HALT", res.State, res.FaultException)
+		require.NotNil(t, res.StateDiff)
+
+		h := state.CreateContractHash(owner, ne.Checksum, m.Name)
+		require.Equal(t, []result.ContractDiff{{
+			Hash:  h,
+			State: "Deployed",
+			Name:  m.Name,
+		}}, res.StateDiff.Contracts)
+		var found bool
+		for _, sd := range res.StateDiff.Storage {
+			if sd.Contract == chain.ManagementContractHash() && bytes.Equal(sd.Key, append([]byte{native.PrefixContract}, h.BytesBE()...)) {
+				require.Equal(t, "Added", sd.State)
+				require.Nil(t, sd.OldValue)
+				found = true
+			}
+		}
+		require.True(t, found)
+		require.Nil(t, chain.GetContractState(h))
+	})
+	t.Run("historic", func(t *testing.T) {
+		inv := invoker.NewHistoricAtHeight(chain.BlockHeight(), c, nil)
+		_, err := inv.CallWithStateDiff(nnsHash, "symbol")
+		require.ErrorIs(t, err, invoker.ErrStateDiffUnsupported)
+	})
+}
+
+func TestClient_Trace(t *testing.T) {
+	chain, rpcSrv, httpSrv := initServerWithInMemoryChain(t)
+	defer chain.Close()
+	defer rpcSrv.Shutdown()
+
+	c, err := rpcclient.New(context.Background(), httpSrv.URL, rpcclient.Options{})
+	require.NoError(t, err)
+	require.NoError(t, c.Init())
+
+	// Replayed executions must match the original ones for every block.
+	opts := &neorpc.TraceOptions{Level: neorpc.TraceSyscalls, MaxSteps: 1}
+	for i := uint32(1); i <= chain.BlockHeight(); i++ {
+		b, err := chain.GetBlock(chain.GetHeaderHash(i))
+		require.NoError(t, err)
+		traces, err := c.TraceBlockByIndex(i, opts)
+		require.NoError(t, err)
+		require.Equal(t, len(b.Transactions)+2, len(traces))
+
+		aers, err := chain.GetAppExecResults(b.Hash(), trigger.All)
+		require.NoError(t, err)
+		for _, tx := range b.Transactions {
+			txAER, err := chain.GetAppExecResults(tx.Hash(), trigger.Application)
+			require.NoError(t, err)
+			aers = append(aers[:len(aers)-1], txAER[0], aers[len(aers)-1])
+		}
+		for j, aer := range aers {
+			require.Equal(t, aer.Container, traces[j].Container, i)
+			require.Equal(t, aer.Trigger.String(), traces[j].Trigger, i)
+			require.Equal(t, aer.VMState.String(), traces[j].VMState, i)
+			require.Equal(t, aer.GasConsumed, traces[j].GasConsumed, i)
+			require.Equal(t, aer.FaultException, traces[j].FaultException, i)
+		}
+	}
+
+	t.Run("transaction", func(t *testing.T) {
+		h, err := util.Uint256DecodeStringLE(faultedTxHashLE)
+		require.NoError(t, err)
+		_, height, err := chain.GetTransaction(h)
+		require.NoError(t, err)
+		traces, err := c.TraceBlockByHash(chain.GetHeaderHash(height), nil)
+		require.NoError(t, err)
+		trace, err := c.TraceTransaction(h, nil)
+		require.NoError(t, err)
+		var found bool
+		for _, tr := range traces {
+			if tr.Container.Equals(h) {
+				require.Equal(t, tr, *trace)
+				found = true
+			}
+		}
+		require.True(t, found)
+		require.Equal(t, vmstate.Fault.String(), trace.VMState)
+	})
+}
+
 func TestClient_GetNativeContracts(t *testing.T) {
 	chain, rpcSrv, httpSrv := initServerWithInMemoryChain(t)
 	defer chain.Close()
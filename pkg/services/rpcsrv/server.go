@@ -43,6 +43,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/neorpc/rpcevent"
 	"github.com/nspcc-dev/neo-go/pkg/network"
 	"github.com/nspcc-dev/neo-go/pkg/network/payload"
+	"github.com/nspcc-dev/neo-go/pkg/services/devnet"
 	"github.com/nspcc-dev/neo-go/pkg/services/oracle/broadcaster"
 	"github.com/nspcc-dev/neo-go/pkg/services/rpcsrv/params"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
@@ -77,6 +78,7 @@ type (
 		GetContractScriptHash(id int32) (util.Uint160, error)
 		GetContractState(hash util.Uint160) *state.Contract
 		GetEnrollments() ([]state.Validator, error)
+		GetFuturePool() *mempool.Future
 		GetGoverningTokenBalance(acc util.Uint160) (*big.Int, uint32)
 		GetHeader(hash util.Uint256) (*block.Header, error)
 		GetHeaderHash(uint32) util.Uint256
@@ -98,12 +100,15 @@ type (
 		GetValidators() ([]*keys.PublicKey, error)
 		HeaderHeight() uint32
 		InitVerificationContext(ic *interop.Context, hash util.Uint160, witness *transaction.Witness) error
+		ReplayBlock(index uint32, run func(ic *interop.Context) error) error
 		SubscribeForBlocks(ch chan *block.Block)
 		SubscribeForExecutions(ch chan *state.AppExecResult)
+		SubscribeForMempoolEvents(ch chan<- mempoolevent.Event)
 		SubscribeForNotifications(ch chan *state.ContainedNotificationEvent)
 		SubscribeForTransactions(ch chan *transaction.Transaction)
 		UnsubscribeFromBlocks(ch chan *block.Block)
 		UnsubscribeFromExecutions(ch chan *state.AppExecResult)
+		UnsubscribeFromMempoolEvents(ch chan<- mempoolevent.Event)
 		UnsubscribeFromNotifications(ch chan *state.ContainedNotificationEvent)
 		UnsubscribeFromTransactions(ch chan *transaction.Transaction)
 		VerifyTx(*transaction.Transaction) error
@@ -114,6 +119,31 @@ type (
 	// OracleHandler is the interface oracle service needs to provide for the Server.
 	OracleHandler interface {
 		AddResponse(pub *keys.PublicKey, reqID uint64, txSig []byte)
+		GetRequest(id uint64) (result.OracleRequestRecord, bool)
+		GetRequests(status result.OracleRequestStatus) []result.OracleRequestRecord
+	}
+
+	// ConsensusHandler is the interface consensus service needs to provide for
+	// the Server to handle `getconsensusstate` and `getconsensushistory` calls.
+	ConsensusHandler interface {
+		GetState() result.ConsensusState
+		GetHistory(n int) []result.ConsensusTimeline
+	}
+
+	// NotaryHandler is the interface notary service needs to provide for the
+	// Server to handle `getnotaryrequests` and `getnotaryrequeststate` calls
+	// and notary request state change events.
+	NotaryHandler interface {
+		GetRequests() []result.NotaryRequestState
+		GetRequestState(h util.Uint256) (result.NotaryRequestState, bool)
+		SubscribeForStateChanges(ch chan result.NotaryRequestState)
+		UnsubscribeFromStateChanges(ch chan result.NotaryRequestState)
+	}
+
+	// BlockMiner is the interface block generator of development networks needs
+	// to provide for the Server to handle `mineblocks` calls.
+	BlockMiner interface {
+		MineBlocks(n int) ([]util.Uint256, error)
 	}
 
 	// Server represents the JSON-RPC 2.0 server.
@@ -130,6 +160,9 @@ type (
 		stateRootEnabled bool
 		coreServer       *network.Server
 		oracle           *atomic.Value
+		miner            *atomic.Value
+		consensus        *atomic.Value
+		notary           *atomic.Value
 		log              *zap.Logger
 		shutdown         chan struct{}
 		started          *atomic.Bool
@@ -146,13 +179,17 @@ type (
 		executionSubs     int
 		notificationSubs  int
 		transactionSubs   int
+		mempoolSubs       int
 		notaryRequestSubs int
+		notaryStateSubs   int
 
 		blockCh           chan *block.Block
 		executionCh       chan *state.AppExecResult
 		notificationCh    chan *state.ContainedNotificationEvent
 		transactionCh     chan *transaction.Transaction
+		mempoolCh         chan mempoolevent.Event
 		notaryRequestCh   chan mempoolevent.Event
+		notaryStateCh     chan result.NotaryRequestState
 		subEventsToExitCh chan struct{}
 	}
 
@@ -197,6 +234,8 @@ const (
 )
 
 var rpcHandlers = map[string]func(*Server, params.Params) (any, *neorpc.Error){
+	"addpeer":                      (*Server).addPeer,
+	"banpeer":                      (*Server).banPeer,
 	"calculatenetworkfee":          (*Server).calculateNetworkFee,
 	"findstates":                   (*Server).findStates,
 	"getapplicationlog":            (*Server).getApplicationLog,
@@ -210,6 +249,8 @@ var rpcHandlers = map[string]func(*Server, params.Params) (any, *neorpc.Error){
 	"getcandidates":                (*Server).getCandidates,
 	"getcommittee":                 (*Server).getCommittee,
 	"getconnectioncount":           (*Server).getConnectionCount,
+	"getconsensushistory":          (*Server).getConsensusHistory,
+	"getconsensusstate":            (*Server).getConsensusState,
 	"getcontractstate":             (*Server).getContractState,
 	"getnativecontracts":           (*Server).getNativeContracts,
 	"getnep11balances":             (*Server).getNEP11Balances,
@@ -220,6 +261,7 @@ var rpcHandlers = map[string]func(*Server, params.Params) (any, *neorpc.Error){
 	"getpeers":                     (*Server).getPeers,
 	"getproof":                     (*Server).getProof,
 	"getrawmempool":                (*Server).getRawMempool,
+	"getrawnotaryrequests":         (*Server).getRawNotaryRequests,
 	"getrawtransaction":            (*Server).getrawtransaction,
 	"getstate":                     (*Server).getState,
 	"getstateheight":               (*Server).getStateHeight,
@@ -228,6 +270,10 @@ var rpcHandlers = map[string]func(*Server, params.Params) (any, *neorpc.Error){
 	"gettransactionheight":         (*Server).getTransactionHeight,
 	"getunclaimedgas":              (*Server).getUnclaimedGas,
 	"getnextblockvalidators":       (*Server).getNextBlockValidators,
+	"getnotaryrequests":            (*Server).getNotaryRequests,
+	"getnotaryrequeststate":        (*Server).getNotaryRequestState,
+	"getoraclerequests":            (*Server).getOracleRequests,
+	"getoracleresponse":            (*Server).getOracleResponse,
 	"getversion":                   (*Server).getVersion,
 	"invokefunction":               (*Server).invokeFunction,
 	"invokefunctionhistoric":       (*Server).invokeFunctionHistoric,
@@ -235,12 +281,17 @@ var rpcHandlers = map[string]func(*Server, params.Params) (any, *neorpc.Error){
 	"invokescripthistoric":         (*Server).invokescripthistoric,
 	"invokecontractverify":         (*Server).invokeContractVerify,
 	"invokecontractverifyhistoric": (*Server).invokeContractVerifyHistoric,
+	"mineblocks":                   (*Server).mineBlocks,
+	"removepeer":                   (*Server).removePeer,
 	"sendrawtransaction":           (*Server).sendrawtransaction,
 	"submitblock":                  (*Server).submitBlock,
 	"submitnotaryrequest":          (*Server).submitNotaryRequest,
 	"submitoracleresponse":         (*Server).submitOracleResponse,
 	"terminatesession":             (*Server).terminateSession,
+	"traceblock":                   (*Server).traceBlock,
+	"tracetransaction":             (*Server).traceTransaction,
 	"traverseiterator":             (*Server).traverseIterator,
+	"unbanpeer":                    (*Server).unbanPeer,
 	"validateaddress":              (*Server).validateAddress,
 	"verifyproof":                  (*Server).verifyProof,
 }
@@ -312,6 +363,9 @@ func New(chain Ledger, conf config.RPC, coreServer *network.Server,
 		coreServer:       coreServer,
 		log:              log,
 		oracle:           oracleWrapped,
+		miner:            new(atomic.Value),
+		consensus:        new(atomic.Value),
+		notary:           new(atomic.Value),
 		shutdown:         make(chan struct{}),
 		started:          atomic.NewBool(false),
 		errChan:          errChan,
@@ -324,7 +378,9 @@ func New(chain Ledger, conf config.RPC, coreServer *network.Server,
 		executionCh:       make(chan *state.AppExecResult),
 		notificationCh:    make(chan *state.ContainedNotificationEvent),
 		transactionCh:     make(chan *transaction.Transaction),
+		mempoolCh:         make(chan mempoolevent.Event),
 		notaryRequestCh:   make(chan mempoolevent.Event),
+		notaryStateCh:     make(chan result.NotaryRequestState),
 		subEventsToExitCh: make(chan struct{}),
 	}
 }
@@ -448,6 +504,36 @@ func (s *Server) SetOracleHandler(orc OracleHandler) {
 	s.oracle.Store(&orc)
 }
 
+// SetConsensusHandler allows to update consensus service used by the Server
+// to provide consensus state, nil disables consensus state calls.
+func (s *Server) SetConsensusHandler(h ConsensusHandler) {
+	s.consensus.Store(&h)
+}
+
+// SetNotaryHandler allows to update notary service used by the Server to
+// provide notary request states, nil disables notary request state calls and
+// events.
+func (s *Server) SetNotaryHandler(h NotaryHandler) {
+	s.subsCounterLock.Lock()
+	defer s.subsCounterLock.Unlock()
+	if s.notaryStateSubs != 0 {
+		if old, ok := s.notary.Load().(*NotaryHandler); ok && old != nil && *old != nil {
+			(*old).UnsubscribeFromStateChanges(s.notaryStateCh)
+		}
+		if h != nil {
+			h.SubscribeForStateChanges(s.notaryStateCh)
+		}
+	}
+	s.notary.Store(&h)
+}
+
+// SetBlockMiner allows to set block generator used by the Server to handle
+// `mineblocks` calls. It's only applicable to development networks, the call
+// is rejected if no miner is set.
+func (s *Server) SetBlockMiner(m BlockMiner) {
+	s.miner.Store(&m)
+}
+
 func (s *Server) handleHTTPRequest(w http.ResponseWriter, httpRequest *http.Request) {
 	req := params.NewRequest()
 
@@ -719,7 +805,7 @@ func (s *Server) dropSubscriber(subscr *subscriber) {
 	s.subsCounterLock.Lock()
 	for _, e := range subscr.feeds {
 		if e.event != neorpc.InvalidEventID {
-			s.unsubscribeFromChannel(e.event)
+			s.unsubscribeFromChannel(e.event, e.filter)
 		}
 	}
 	s.subsCounterLock.Unlock()
@@ -840,10 +926,74 @@ func (s *Server) getPeers(_ params.Params) (any, *neorpc.Error) {
 	peers := result.NewGetPeers()
 	peers.AddUnconnected(s.coreServer.UnconnectedPeers())
 	peers.AddConnected(s.coreServer.ConnectedPeers())
+	for i := range peers.Connected {
+		score := s.coreServer.PeerScore(peers.Connected[i].Address)
+		peers.Connected[i].Score = &score
+	}
 	peers.AddBad(s.coreServer.BadPeers())
+	peers.AddStatic(s.coreServer.StaticPeers())
+	peers.Banned = s.coreServer.BannedPeers()
 	return peers, nil
 }
 
+// getAdminStringParam checks whether administration methods are enabled and
+// returns the first string parameter.
+func (s *Server) getAdminStringParam(reqParams params.Params) (string, *neorpc.Error) {
+	if !s.config.AdminEnabled {
+		return "", neorpc.NewInvalidRequestError("admin methods are disabled")
+	}
+	str, err := reqParams.Value(0).GetString()
+	if err != nil {
+		return "", neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, err.Error())
+	}
+	return str, nil
+}
+
+// addPeer adds a static peer and connects to it.
+func (s *Server) addPeer(reqParams params.Params) (any, *neorpc.Error) {
+	addr, respErr := s.getAdminStringParam(reqParams)
+	if respErr != nil {
+		return nil, respErr
+	}
+	if err := s.coreServer.AddPeer(addr); err != nil {
+		return nil, neorpc.NewInvalidParamsError(fmt.Sprintf("can't add peer: %s", err))
+	}
+	return true, nil
+}
+
+// removePeer removes a peer and drops connections to it.
+func (s *Server) removePeer(reqParams params.Params) (any, *neorpc.Error) {
+	addr, respErr := s.getAdminStringParam(reqParams)
+	if respErr != nil {
+		return nil, respErr
+	}
+	return s.coreServer.RemovePeer(addr), nil
+}
+
+// banPeer adds an IP address or CIDR network to the denylist.
+func (s *Server) banPeer(reqParams params.Params) (any, *neorpc.Error) {
+	rule, respErr := s.getAdminStringParam(reqParams)
+	if respErr != nil {
+		return nil, respErr
+	}
+	if err := s.coreServer.BanPeer(rule); err != nil {
+		return nil, neorpc.NewInvalidParamsError(fmt.Sprintf("can't ban peer: %s", err))
+	}
+	return true, nil
+}
+
+// unbanPeer removes an IP address or CIDR network from the denylist.
+func (s *Server) unbanPeer(reqParams params.Params) (any, *neorpc.Error) {
+	rule, respErr := s.getAdminStringParam(reqParams)
+	if respErr != nil {
+		return nil, respErr
+	}
+	if err := s.coreServer.UnbanPeer(rule); err != nil {
+		return nil, neorpc.NewInvalidParamsError(fmt.Sprintf("can't unban peer: %s", err))
+	}
+	return true, nil
+}
+
 func (s *Server) getRawMempool(reqParams params.Params) (any, *neorpc.Error) {
 	verbose, _ := reqParams.Value(0).GetBoolean()
 	mp := s.chain.GetMemPool()
@@ -854,11 +1004,19 @@ func (s *Server) getRawMempool(reqParams params.Params) (any, *neorpc.Error) {
 	if !verbose {
 		return hashList, nil
 	}
-	return result.RawMempool{
+	res := result.RawMempool{
 		Height:     s.chain.BlockHeight(),
 		Verified:   hashList,
 		Unverified: []util.Uint256{}, // avoid `null` result
-	}, nil
+	}
+	if withFuture, _ := reqParams.Value(1).GetBoolean(); withFuture {
+		if fp := s.chain.GetFuturePool(); fp != nil {
+			for _, tx := range fp.GetTransactions() {
+				res.Future = append(res.Future, tx.Hash())
+			}
+		}
+	}
+	return res, nil
 }
 
 func (s *Server) validateAddress(reqParams params.Params) (any, *neorpc.Error) {
@@ -1943,11 +2101,11 @@ func (s *Server) getCommittee(_ params.Params) (any, *neorpc.Error) {
 
 // invokeFunction implements the `invokeFunction` RPC call.
 func (s *Server) invokeFunction(reqParams params.Params) (any, *neorpc.Error) {
-	tx, verbose, respErr := s.getInvokeFunctionParams(reqParams)
+	tx, opts, respErr := s.getInvokeFunctionParams(reqParams)
 	if respErr != nil {
 		return nil, respErr
 	}
-	return s.runScriptInVM(trigger.Application, tx.Script, util.Uint160{}, tx, nil, verbose)
+	return s.runScriptInVM(trigger.Application, tx.Script, util.Uint160{}, tx, nil, opts)
 }
 
 // invokeFunctionHistoric implements the `invokeFunctionHistoric` RPC call.
@@ -1959,24 +2117,25 @@ func (s *Server) invokeFunctionHistoric(reqParams params.Params) (any, *neorpc.E
 	if len(reqParams) < 2 {
 		return nil, neorpc.ErrInvalidParams
 	}
-	tx, verbose, respErr := s.getInvokeFunctionParams(reqParams[1:])
+	tx, opts, respErr := s.getInvokeFunctionParams(reqParams[1:])
 	if respErr != nil {
 		return nil, respErr
 	}
-	return s.runScriptInVM(trigger.Application, tx.Script, util.Uint160{}, tx, &nextH, verbose)
+	return s.runScriptInVM(trigger.Application, tx.Script, util.Uint160{}, tx, &nextH, opts)
 }
 
-func (s *Server) getInvokeFunctionParams(reqParams params.Params) (*transaction.Transaction, bool, *neorpc.Error) {
+func (s *Server) getInvokeFunctionParams(reqParams params.Params) (*transaction.Transaction, invokeOptions, *neorpc.Error) {
+	var opts invokeOptions
 	if len(reqParams) < 2 {
-		return nil, false, neorpc.ErrInvalidParams
+		return nil, opts, neorpc.ErrInvalidParams
 	}
 	scriptHash, responseErr := s.contractScriptHashFromParam(reqParams.Value(0))
 	if responseErr != nil {
-		return nil, false, responseErr
+		return nil, opts, responseErr
 	}
 	method, err := reqParams[1].GetString()
 	if err != nil {
-		return nil, false, neorpc.ErrInvalidParams
+		return nil, opts, neorpc.ErrInvalidParams
 	}
 	var invparams *params.Param
 	if len(reqParams) > 2 {
@@ -1986,15 +2145,20 @@ func (s *Server) getInvokeFunctionParams(reqParams params.Params) (*transaction.
 	if len(reqParams) > 3 {
 		signers, _, err := reqParams[3].GetSignersWithWitnesses()
 		if err != nil {
-			return nil, false, neorpc.ErrInvalidParams
+			return nil, opts, neorpc.ErrInvalidParams
 		}
 		tx.Signers = signers
 	}
-	var verbose bool
 	if len(reqParams) > 4 {
-		verbose, err = reqParams[4].GetBoolean()
+		opts.verbose, err = reqParams[4].GetBoolean()
 		if err != nil {
-			return nil, false, neorpc.ErrInvalidParams
+			return nil, opts, neorpc.ErrInvalidParams
+		}
+	}
+	if len(reqParams) > 5 {
+		opts.stateDiff, err = reqParams[5].GetBoolean()
+		if err != nil {
+			return nil, opts, neorpc.ErrInvalidParams
 		}
 	}
 	if len(tx.Signers) == 0 {
@@ -2002,19 +2166,19 @@ func (s *Server) getInvokeFunctionParams(reqParams params.Params) (*transaction.
 	}
 	script, err := params.CreateFunctionInvocationScript(scriptHash, method, invparams)
 	if err != nil {
-		return nil, false, neorpc.NewInternalServerError(fmt.Sprintf("can't create invocation script: %s", err))
+		return nil, opts, neorpc.NewInternalServerError(fmt.Sprintf("can't create invocation script: %s", err))
 	}
 	tx.Script = script
-	return tx, verbose, nil
+	return tx, opts, nil
 }
 
 // invokescript implements the `invokescript` RPC call.
 func (s *Server) invokescript(reqParams params.Params) (any, *neorpc.Error) {
-	tx, verbose, respErr := s.getInvokeScriptParams(reqParams)
+	tx, opts, respErr := s.getInvokeScriptParams(reqParams)
 	if respErr != nil {
 		return nil, respErr
 	}
-	return s.runScriptInVM(trigger.Application, tx.Script, util.Uint160{}, tx, nil, verbose)
+	return s.runScriptInVM(trigger.Application, tx.Script, util.Uint160{}, tx, nil, opts)
 }
 
 // invokescripthistoric implements the `invokescripthistoric` RPC call.
@@ -2026,40 +2190,46 @@ func (s *Server) invokescripthistoric(reqParams params.Params) (any, *neorpc.Err
 	if len(reqParams) < 2 {
 		return nil, neorpc.ErrInvalidParams
 	}
-	tx, verbose, respErr := s.getInvokeScriptParams(reqParams[1:])
+	tx, opts, respErr := s.getInvokeScriptParams(reqParams[1:])
 	if respErr != nil {
 		return nil, respErr
 	}
-	return s.runScriptInVM(trigger.Application, tx.Script, util.Uint160{}, tx, &nextH, verbose)
+	return s.runScriptInVM(trigger.Application, tx.Script, util.Uint160{}, tx, &nextH, opts)
 }
 
-func (s *Server) getInvokeScriptParams(reqParams params.Params) (*transaction.Transaction, bool, *neorpc.Error) {
+func (s *Server) getInvokeScriptParams(reqParams params.Params) (*transaction.Transaction, invokeOptions, *neorpc.Error) {
+	var opts invokeOptions
 	script, err := reqParams.Value(0).GetBytesBase64()
 	if err != nil {
-		return nil, false, neorpc.ErrInvalidParams
+		return nil, opts, neorpc.ErrInvalidParams
 	}
 
 	tx := &transaction.Transaction{}
 	if len(reqParams) > 1 {
 		signers, witnesses, err := reqParams[1].GetSignersWithWitnesses()
 		if err != nil {
-			return nil, false, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, err.Error())
+			return nil, opts, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, err.Error())
 		}
 		tx.Signers = signers
 		tx.Scripts = witnesses
 	}
-	var verbose bool
 	if len(reqParams) > 2 {
-		verbose, err = reqParams[2].GetBoolean()
+		opts.verbose, err = reqParams[2].GetBoolean()
+		if err != nil {
+			return nil, opts, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, err.Error())
+		}
+	}
+	if len(reqParams) > 3 {
+		opts.stateDiff, err = reqParams[3].GetBoolean()
 		if err != nil {
-			return nil, false, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, err.Error())
+			return nil, opts, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, err.Error())
 		}
 	}
 	if len(tx.Signers) == 0 {
 		tx.Signers = []transaction.Signer{{Account: util.Uint160{}, Scopes: transaction.None}}
 	}
 	tx.Script = script
-	return tx, verbose, nil
+	return tx, opts, nil
 }
 
 // invokeContractVerify implements the `invokecontractverify` RPC call.
@@ -2068,7 +2238,7 @@ func (s *Server) invokeContractVerify(reqParams params.Params) (any, *neorpc.Err
 	if respErr != nil {
 		return nil, respErr
 	}
-	return s.runScriptInVM(trigger.Verification, invocationScript, scriptHash, tx, nil, false)
+	return s.runScriptInVM(trigger.Verification, invocationScript, scriptHash, tx, nil, invokeOptions{})
 }
 
 // invokeContractVerifyHistoric implements the `invokecontractverifyhistoric` RPC call.
@@ -2084,7 +2254,7 @@ func (s *Server) invokeContractVerifyHistoric(reqParams params.Params) (any, *ne
 	if respErr != nil {
 		return nil, respErr
 	}
-	return s.runScriptInVM(trigger.Verification, invocationScript, scriptHash, tx, &nextH, false)
+	return s.runScriptInVM(trigger.Verification, invocationScript, scriptHash, tx, &nextH, invokeOptions{})
 }
 
 func (s *Server) getInvokeContractVerifyParams(reqParams params.Params) (util.Uint160, *transaction.Transaction, []byte, *neorpc.Error) {
@@ -2197,17 +2367,26 @@ func (s *Server) prepareInvocationContext(t trigger.Type, script []byte, contrac
 // witness invocation script in case of `verification` trigger (it pushes `verify`
 // arguments on stack before verification). In case of contract verification
 // contractScriptHash should be specified.
-func (s *Server) runScriptInVM(t trigger.Type, script []byte, contractScriptHash util.Uint160, tx *transaction.Transaction, nextH *uint32, verbose bool) (*result.Invoke, *neorpc.Error) {
-	ic, respErr := s.prepareInvocationContext(t, script, contractScriptHash, tx, nextH, verbose)
+func (s *Server) runScriptInVM(t trigger.Type, script []byte, contractScriptHash util.Uint160, tx *transaction.Transaction, nextH *uint32, opts invokeOptions) (*result.Invoke, *neorpc.Error) {
+	ic, respErr := s.prepareInvocationContext(t, script, contractScriptHash, tx, nextH, opts.verbose)
 	if respErr != nil {
 		return nil, respErr
 	}
-	err := ic.VM.Run()
+	v := ic.VM // It's replaced by state diff calculation.
+	err := v.Run()
 	var faultException string
 	if err != nil {
 		faultException = err.Error()
 	}
-	items := ic.VM.Estack().ToArray()
+	items := v.Estack().ToArray()
+	var stateDiff *result.StateDiff
+	if opts.stateDiff {
+		stateDiff, respErr = s.getStateDiff(ic, t, tx, nextH)
+		if respErr != nil {
+			ic.Finalize()
+			return nil, respErr
+		}
+	}
 	sess := s.postProcessExecStack(items)
 	var id uuid.UUID
 
@@ -2217,7 +2396,7 @@ func (s *Server) runScriptInVM(t trigger.Type, script []byte, contractScriptHash
 		if s.config.SessionBackedByMPT && nextH == nil {
 			ic.Finalize()
 			// Rerun with MPT-backed storage.
-			return s.runScriptInVM(t, script, contractScriptHash, tx, &ic.Block.Index, verbose)
+			return s.runScriptInVM(t, script, contractScriptHash, tx, &ic.Block.Index, opts)
 		}
 		id = uuid.New()
 		sessionID := id.String()
@@ -2249,7 +2428,7 @@ func (s *Server) runScriptInVM(t trigger.Type, script []byte, contractScriptHash
 		ic.Finalize()
 	}
 	var diag *result.InvokeDiag
-	tree := ic.VM.GetInvocationTree()
+	tree := v.GetInvocationTree()
 	if tree != nil {
 		diag = &result.InvokeDiag{
 			Invocations: tree.Calls,
@@ -2261,13 +2440,14 @@ func (s *Server) runScriptInVM(t trigger.Type, script []byte, contractScriptHash
 		notifications = make([]state.NotificationEvent, 0)
 	}
 	res := &result.Invoke{
-		State:          ic.VM.State().String(),
-		GasConsumed:    ic.VM.GasConsumed(),
+		State:          v.State().String(),
+		GasConsumed:    v.GasConsumed(),
 		Script:         script,
 		Stack:          items,
 		FaultException: faultException,
 		Notifications:  notifications,
 		Diagnostics:    diag,
+		StateDiff:      stateDiff,
 		Session:        id,
 	}
 
@@ -2442,6 +2622,36 @@ func (s *Server) submitNotaryRequest(ps params.Params) (any, *neorpc.Error) {
 	return getRelayResult(s.coreServer.RelayP2PNotaryRequest(r), r.FallbackTransaction.Hash())
 }
 
+// getRawNotaryRequests returns serialized notary requests with the given main
+// transaction hash from the node's notary request pool.
+func (s *Server) getRawNotaryRequests(ps params.Params) (any, *neorpc.Error) {
+	if !s.chain.P2PSigExtensionsEnabled() {
+		return nil, neorpc.NewRPCError("P2PSignatureExtensions are disabled", "")
+	}
+	mainHash, err := ps.Value(0).GetUint256()
+	if err != nil {
+		return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, err.Error())
+	}
+	pool := s.coreServer.GetNotaryPool()
+	res := [][]byte{}
+	for _, fb := range pool.GetVerifiedTransactions() {
+		data, ok := pool.TryGetData(fb.Hash())
+		if !ok {
+			continue // Removed concurrently.
+		}
+		req := data.(*payload.P2PNotaryRequest)
+		if req.MainTransaction.Hash() != mainHash {
+			continue
+		}
+		b, err := req.Bytes()
+		if err != nil {
+			return nil, neorpc.NewInternalServerError(fmt.Sprintf("failed to encode notary request: %s", err))
+		}
+		res = append(res, b)
+	}
+	return res, nil
+}
+
 // getRelayResult returns successful relay result or an error.
 func getRelayResult(err error, hash util.Uint256) (any, *neorpc.Error) {
 	switch {
@@ -2460,11 +2670,21 @@ func getRelayResult(err error, hash util.Uint256) (any, *neorpc.Error) {
 	}
 }
 
-func (s *Server) submitOracleResponse(ps params.Params) (any, *neorpc.Error) {
-	oracle := s.oracle.Load().(*OracleHandler)
-	if oracle == nil || *oracle == nil {
+// getOracleHandler returns the oracle service set for the Server or an error
+// if there is none.
+func (s *Server) getOracleHandler() (OracleHandler, *neorpc.Error) {
+	h, ok := s.oracle.Load().(*OracleHandler)
+	if !ok || h == nil || *h == nil {
 		return nil, neorpc.NewRPCError("Oracle is not enabled", "")
 	}
+	return *h, nil
+}
+
+func (s *Server) submitOracleResponse(ps params.Params) (any, *neorpc.Error) {
+	orc, respErr := s.getOracleHandler()
+	if respErr != nil {
+		return nil, respErr
+	}
 	var pub *keys.PublicKey
 	pubBytes, err := ps.Value(0).GetBytesBase64()
 	if err == nil {
@@ -2489,10 +2709,112 @@ func (s *Server) submitOracleResponse(ps params.Params) (any, *neorpc.Error) {
 	if !pub.Verify(msgSig, hash.Sha256(data).BytesBE()) {
 		return nil, neorpc.NewRPCError("Invalid request signature", "")
 	}
-	(*oracle).AddResponse(pub, uint64(reqID), txSig)
+	orc.AddResponse(pub, uint64(reqID), txSig)
 	return json.RawMessage([]byte("{}")), nil
 }
 
+// getOracleRequests returns audit records of oracle requests known to the
+// node optionally filtered by the processing status.
+func (s *Server) getOracleRequests(ps params.Params) (any, *neorpc.Error) {
+	orc, respErr := s.getOracleHandler()
+	if respErr != nil {
+		return nil, respErr
+	}
+	var status result.OracleRequestStatus
+	if len(ps) > 0 {
+		str, err := ps.Value(0).GetString()
+		if err != nil {
+			return nil, neorpc.NewInvalidParamsError(fmt.Sprintf("invalid status: %s", err))
+		}
+		status = result.OracleRequestStatus(str)
+		switch status {
+		case result.OracleRequestPending, result.OracleRequestSigning, result.OracleRequestSent,
+			result.OracleRequestFinished, result.OracleRequestExpired:
+		default:
+			return nil, neorpc.NewInvalidParamsError(fmt.Sprintf("unknown status %q", str))
+		}
+	}
+	return orc.GetRequests(status), nil
+}
+
+// getOracleResponse returns the audit record of the oracle request with the
+// given ID.
+func (s *Server) getOracleResponse(ps params.Params) (any, *neorpc.Error) {
+	orc, respErr := s.getOracleHandler()
+	if respErr != nil {
+		return nil, respErr
+	}
+	reqID, err := ps.Value(0).GetInt()
+	if err != nil || reqID < 0 {
+		return nil, neorpc.NewInvalidParamsError("invalid request ID")
+	}
+	r, ok := orc.GetRequest(uint64(reqID))
+	if !ok {
+		return nil, neorpc.NewRPCError("Unknown oracle request", "")
+	}
+	return r, nil
+}
+
+// mineBlocks generates the requested number of blocks using BlockMiner set for
+// the Server.
+func (s *Server) mineBlocks(ps params.Params) (any, *neorpc.Error) {
+	m, ok := s.miner.Load().(*BlockMiner)
+	if !ok || m == nil || *m == nil {
+		return nil, neorpc.NewRPCError("Block mining is not enabled", "")
+	}
+	var count = 1
+	if len(ps) > 0 {
+		num, err := ps.Value(0).GetInt()
+		if err != nil || num <= 0 || num > devnet.MaxBlocksPerRequest {
+			return nil, neorpc.NewInvalidParamsError(fmt.Sprintf("invalid number of blocks: should be in [1, %d] range", devnet.MaxBlocksPerRequest))
+		}
+		count = num
+	}
+	hashes, err := (*m).MineBlocks(count)
+	if err != nil {
+		return nil, neorpc.NewInternalServerError(fmt.Sprintf("failed to mine blocks: %s", err))
+	}
+	return hashes, nil
+}
+
+// getConsensusHandler returns the consensus service set for the Server or
+// an error if there is none.
+func (s *Server) getConsensusHandler() (ConsensusHandler, *neorpc.Error) {
+	h, ok := s.consensus.Load().(*ConsensusHandler)
+	if !ok || h == nil || *h == nil {
+		return nil, neorpc.NewRPCError("Consensus is not enabled", "")
+	}
+	return *h, nil
+}
+
+// getConsensusState returns the current consensus round timeline and
+// per-validator statistics.
+func (s *Server) getConsensusState(_ params.Params) (any, *neorpc.Error) {
+	h, respErr := s.getConsensusHandler()
+	if respErr != nil {
+		return nil, respErr
+	}
+	return h.GetState(), nil
+}
+
+// getConsensusHistory returns timelines of the latest finished consensus
+// rounds, all of the rounds kept are returned if the number is not specified.
+func (s *Server) getConsensusHistory(ps params.Params) (any, *neorpc.Error) {
+	h, respErr := s.getConsensusHandler()
+	if respErr != nil {
+		return nil, respErr
+	}
+	var count int
+	if len(ps) > 0 {
+		num, err := ps.Value(0).GetInt()
+		if err != nil || num <= 0 {
+			return nil, neorpc.NewInvalidParamsError("invalid number of rounds")
+		}
+		count = num
+	}
+	return h.GetHistory(count), nil
+}
+
 func (s *Server) sendrawtransaction(reqParams params.Params) (any, *neorpc.Error) {
 	if len(reqParams) < 1 {
 		return nil, neorpc.NewInvalidParamsError("not enough parameters")
@@ -2508,6 +2830,44 @@ func (s *Server) sendrawtransaction(reqParams params.Params) (any, *neorpc.Error
 	return getRelayResult(s.coreServer.RelayTxn(tx), tx.Hash())
 }
 
+// getNotaryHandler returns the notary service set for the Server or an error
+// if there is none.
+func (s *Server) getNotaryHandler() (NotaryHandler, *neorpc.Error) {
+	h, ok := s.notary.Load().(*NotaryHandler)
+	if !ok || h == nil || *h == nil {
+		return nil, neorpc.NewRPCError("Notary is not enabled", "")
+	}
+	return *h, nil
+}
+
+// getNotaryRequests returns states of notary requests being processed by the
+// notary service.
+func (s *Server) getNotaryRequests(_ params.Params) (any, *neorpc.Error) {
+	h, respErr := s.getNotaryHandler()
+	if respErr != nil {
+		return nil, respErr
+	}
+	return h.GetRequests(), nil
+}
+
+// getNotaryRequestState returns the state of the notary request with the
+// given main transaction hash.
+func (s *Server) getNotaryRequestState(ps params.Params) (any, *neorpc.Error) {
+	h, respErr := s.getNotaryHandler()
+	if respErr != nil {
+		return nil, respErr
+	}
+	hash, err := ps.Value(0).GetUint256()
+	if err != nil {
+		return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, err.Error())
+	}
+	st, ok := h.GetRequestState(hash)
+	if !ok {
+		return nil, neorpc.NewRPCError("Unknown notary request", "")
+	}
+	return st, nil
+}
+
 // subscribe handles subscription requests from websocket clients.
 func (s *Server) subscribe(reqParams params.Params, sub *subscriber) (any, *neorpc.Error) {
 	streamName, err := reqParams.Value(0).GetString()
@@ -2532,9 +2892,16 @@ func (s *Server) subscribe(reqParams params.Params, sub *subscriber) (any, *neor
 			flt := new(neorpc.BlockFilter)
 			err = jd.Decode(flt)
 			filter = *flt
-		case neorpc.TransactionEventID, neorpc.NotaryRequestEventID:
+		case neorpc.TransactionEventID, neorpc.NotaryRequestEventID, neorpc.MempoolEventID:
 			flt := new(neorpc.TxFilter)
 			err = jd.Decode(flt)
+			if err == nil && flt.StateChanges {
+				if event != neorpc.NotaryRequestEventID {
+					err = errors.New("statechanges flag is only supported for notary_request_event events")
+				} else if _, respErr := s.getNotaryHandler(); respErr != nil {
+					err = errors.New("notary service is not enabled")
+				}
+			}
 			filter = *flt
 		case neorpc.NotificationEventID:
 			flt := new(neorpc.NotificationFilter)
@@ -2576,15 +2943,22 @@ func (s *Server) subscribe(reqParams params.Params, sub *subscriber) (any, *neor
 		return nil, neorpc.NewInternalServerError("server is shutting down")
 	default:
 	}
-	s.subscribeToChannel(event)
+	s.subscribeToChannel(event, filter)
 	s.subsCounterLock.Unlock()
 	return strconv.FormatInt(int64(id), 10), nil
 }
 
+// isNotaryStateFeed checks whether the subscription with the given event and
+// filter is a notary_request_event subscription for request state changes.
+func isNotaryStateFeed(event neorpc.EventID, filter any) bool {
+	flt, ok := filter.(neorpc.TxFilter)
+	return ok && event == neorpc.NotaryRequestEventID && flt.StateChanges
+}
+
 // subscribeToChannel subscribes RPC server to appropriate chain events if
 // it's not yet subscribed for them. It's supposed to be called with s.subsCounterLock
 // taken by the caller.
-func (s *Server) subscribeToChannel(event neorpc.EventID) {
+func (s *Server) subscribeToChannel(event neorpc.EventID, filter any) {
 	switch event {
 	case neorpc.BlockEventID:
 		if s.blockSubs == 0 {
@@ -2596,6 +2970,11 @@ func (s *Server) subscribeToChannel(event neorpc.EventID) {
 			s.chain.SubscribeForTransactions(s.transactionCh)
 		}
 		s.transactionSubs++
+	case neorpc.MempoolEventID:
+		if s.mempoolSubs == 0 {
+			s.chain.SubscribeForMempoolEvents(s.mempoolCh)
+		}
+		s.mempoolSubs++
 	case neorpc.NotificationEventID:
 		if s.notificationSubs == 0 {
 			s.chain.SubscribeForNotifications(s.notificationCh)
@@ -2611,6 +2990,14 @@ func (s *Server) subscribeToChannel(event neorpc.EventID) {
 			s.coreServer.SubscribeForNotaryRequests(s.notaryRequestCh)
 		}
 		s.notaryRequestSubs++
+		if isNotaryStateFeed(event, filter) {
+			if s.notaryStateSubs == 0 {
+				if h, err := s.getNotaryHandler(); err == nil {
+					h.SubscribeForStateChanges(s.notaryStateCh)
+				}
+			}
+			s.notaryStateSubs++
+		}
 	}
 }
 
@@ -2626,12 +3013,13 @@ func (s *Server) unsubscribe(reqParams params.Params, sub *subscriber) (any, *ne
 		return nil, neorpc.ErrInvalidParams
 	}
 	event := sub.feeds[id].event
+	filter := sub.feeds[id].filter
 	sub.feeds[id].event = neorpc.InvalidEventID
 	sub.feeds[id].filter = nil
 	s.subsLock.Unlock()
 
 	s.subsCounterLock.Lock()
-	s.unsubscribeFromChannel(event)
+	s.unsubscribeFromChannel(event, filter)
 	s.subsCounterLock.Unlock()
 	return true, nil
 }
@@ -2639,7 +3027,7 @@ func (s *Server) unsubscribe(reqParams params.Params, sub *subscriber) (any, *ne
 // unsubscribeFromChannel unsubscribes RPC server from appropriate chain events
 // if there are no other subscribers for it. It must be called with s.subsConutersLock
 // holding by the caller.
-func (s *Server) unsubscribeFromChannel(event neorpc.EventID) {
+func (s *Server) unsubscribeFromChannel(event neorpc.EventID, filter any) {
 	switch event {
 	case neorpc.BlockEventID:
 		s.blockSubs--
@@ -2651,6 +3039,11 @@ func (s *Server) unsubscribeFromChannel(event neorpc.EventID) {
 		if s.transactionSubs == 0 {
 			s.chain.UnsubscribeFromTransactions(s.transactionCh)
 		}
+	case neorpc.MempoolEventID:
+		s.mempoolSubs--
+		if s.mempoolSubs == 0 {
+			s.chain.UnsubscribeFromMempoolEvents(s.mempoolCh)
+		}
 	case neorpc.NotificationEventID:
 		s.notificationSubs--
 		if s.notificationSubs == 0 {
@@ -2666,6 +3059,14 @@ func (s *Server) unsubscribeFromChannel(event neorpc.EventID) {
 		if s.notaryRequestSubs == 0 {
 			s.coreServer.UnsubscribeFromNotaryRequests(s.notaryRequestCh)
 		}
+		if isNotaryStateFeed(event, filter) {
+			s.notaryStateSubs--
+			if s.notaryStateSubs == 0 {
+				if h, err := s.getNotaryHandler(); err == nil {
+					h.UnsubscribeFromStateChanges(s.notaryStateCh)
+				}
+			}
+		}
 	}
 }
 
@@ -2709,12 +3110,35 @@ chloop:
 		case tx := <-s.transactionCh:
 			resp.Event = neorpc.TransactionEventID
 			resp.Payload[0] = tx
+		case e := <-s.mempoolCh:
+			ev := &result.MempoolEvent{
+				Type:        e.Type,
+				Transaction: e.Tx,
+			}
+			if e.ReplacedBy != nil {
+				h := e.ReplacedBy.Hash()
+				ev.ReplacedBy = &h
+			}
+			resp.Event = neorpc.MempoolEventID
+			resp.Payload[0] = ev
 		case e := <-s.notaryRequestCh:
-			resp.Event = neorpc.NotaryRequestEventID
-			resp.Payload[0] = &result.NotaryRequestEvent{
+			ev := &result.NotaryRequestEvent{
 				Type:          e.Type,
 				NotaryRequest: e.Data.(*payload.P2PNotaryRequest),
 			}
+			if h, err := s.getNotaryHandler(); err == nil {
+				if st, ok := h.GetRequestState(ev.NotaryRequest.MainTransaction.Hash()); ok {
+					ev.State = &st
+				}
+			}
+			resp.Event = neorpc.NotaryRequestEventID
+			resp.Payload[0] = ev
+		case st := <-s.notaryStateCh:
+			resp.Event = neorpc.NotaryRequestEventID
+			resp.Payload[0] = &result.NotaryRequestEvent{
+				Type:  mempoolevent.TransactionUpdated,
+				State: &st,
+			}
 		}
 		s.subsLock.RLock()
 	subloop:
@@ -2767,9 +3191,15 @@ chloop:
 	s.chain.UnsubscribeFromTransactions(s.transactionCh)
 	s.chain.UnsubscribeFromNotifications(s.notificationCh)
 	s.chain.UnsubscribeFromExecutions(s.executionCh)
+	if s.mempoolSubs != 0 {
+		s.chain.UnsubscribeFromMempoolEvents(s.mempoolCh)
+	}
 	if s.chain.P2PSigExtensionsEnabled() {
 		s.coreServer.UnsubscribeFromNotaryRequests(s.notaryRequestCh)
 	}
+	if h, err := s.getNotaryHandler(); err == nil && s.notaryStateSubs != 0 {
+		h.UnsubscribeFromStateChanges(s.notaryStateCh)
+	}
 	s.subsCounterLock.Unlock()
 drainloop:
 	for {
@@ -2778,7 +3208,9 @@ drainloop:
 		case <-s.executionCh:
 		case <-s.notificationCh:
 		case <-s.transactionCh:
+		case <-s.mempoolCh:
 		case <-s.notaryRequestCh:
+		case <-s.notaryStateCh:
 		default:
 			break drainloop
 		}
@@ -2787,9 +3219,11 @@ drainloop:
 	// this is safe.
 	close(s.blockCh)
 	close(s.transactionCh)
+	close(s.mempoolCh)
 	close(s.notificationCh)
 	close(s.executionCh)
 	close(s.notaryRequestCh)
+	close(s.notaryStateCh)
 	// notify Shutdown routine
 	close(s.subEventsToExitCh)
 }
@@ -123,7 +123,11 @@ func wrapUnitTestChain(t testing.TB, chain *core.Blockchain, orc *oracle.Oracle,
 	server, err := network.NewServer(serverConfig, chain, chain.GetStateSyncModule(), logger)
 	require.NoError(t, err)
 	errCh := make(chan error, 2)
-	rpcServer := New(chain, cfg.ApplicationConfiguration.RPC, server, orc, logger, errCh)
+	var orcHandler OracleHandler
+	if orc != nil {
+		orcHandler = orc
+	}
+	rpcServer := New(chain, cfg.ApplicationConfiguration.RPC, server, orcHandler, logger, errCh)
 	rpcServer.Start()
 
 	handler := http.HandlerFunc(rpcServer.handleHTTPRequest)
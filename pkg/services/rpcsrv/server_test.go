@@ -14,6 +14,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -37,9 +38,11 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
 	"github.com/nspcc-dev/neo-go/pkg/network"
 	"github.com/nspcc-dev/neo-go/pkg/network/payload"
+	"github.com/nspcc-dev/neo-go/pkg/services/devnet"
 	rpc2 "github.com/nspcc-dev/neo-go/pkg/services/oracle/broadcaster"
 	"github.com/nspcc-dev/neo-go/pkg/services/rpcsrv/params"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
@@ -752,6 +755,30 @@ var rpcTestCases = map[string][]rpcTestCase{
 			},
 		},
 	},
+	"getrawnotaryrequests": {
+		{
+			name:   "no params",
+			params: `[]`,
+			fail:   true,
+		},
+		{
+			name:   "invalid hash",
+			params: `["notahex"]`,
+			fail:   true,
+		},
+		{
+			name:   "unknown transaction",
+			params: `["` + util.Uint256{}.String() + `"]`,
+			result: func(*executor) any {
+				return &[][]byte{}
+			},
+			check: func(t *testing.T, e *executor, res any) {
+				reqs, ok := res.(*[][]byte)
+				require.True(t, ok)
+				require.Equal(t, 0, len(*reqs))
+			},
+		},
+	},
 	"getrawtransaction": {
 		{
 			name:   "no params",
@@ -957,6 +984,68 @@ var rpcTestCases = map[string][]rpcTestCase{
 				assert.ElementsMatch(t, chg, res.Diagnostics.Changes)
 			},
 		},
+		{
+			name:   "positive, with state diff",
+			params: `["0xef4073a0f2b305a38ec4050e4d3d28bc40ea63f5", "transfer", [{"type":"Hash160", "value":"0xb248508f4ef7088e10c48f14d04be3272ca29eee"},{"type":"Hash160", "value":"0x0bcd2978634d961c24f5aea0802297ff128724d6"},{"type":"Integer", "value":1},{"type":"Any", "value":null}],["0xb248508f4ef7088e10c48f14d04be3272ca29eee"],false,true]`,
+			result: func(e *executor) any { return &result.Invoke{} },
+			check: func(t *testing.T, e *executor, inv any) {
+				res, ok := inv.(*result.Invoke)
+				require.True(t, ok)
+				assert.Equal(t, "HALT", res.State)
+				assert.Nil(t, res.Diagnostics)
+				require.NotNil(t, res.StateDiff)
+
+				var (
+					neoHash    = e.chain.GoverningTokenHash()
+					gasHash    = e.chain.UtilityTokenHash()
+					from       = util.Uint160{0xee, 0x9e, 0xa2, 0x2c, 0x27, 0xe3, 0x4b, 0xd0, 0x14, 0x8f, 0xc4, 0x10, 0x8e, 0x08, 0xf7, 0x4e, 0x8f, 0x50, 0x48, 0xb2}
+					to         = util.Uint160{0xd6, 0x24, 0x87, 0x12, 0xff, 0x97, 0x22, 0x80, 0xa0, 0xae, 0xf5, 0x24, 0x1c, 0x96, 0x4d, 0x63, 0x78, 0x29, 0xcd, 0x0b}
+					neoFrom, _ = e.chain.GetGoverningTokenBalance(from)
+					gasFrom    = e.chain.GetUtilityTokenBalance(from)
+					storages   = res.StateDiff.Storage
+				)
+				require.Equal(t, 4, len(storages))
+				for i := range storages {
+					assert.True(t, storages[i].Contract == neoHash || storages[i].Contract == gasHash)
+					if i > 0 {
+						assert.True(t, storages[i-1].Contract.Less(storages[i].Contract) || storages[i-1].Contract == storages[i].Contract)
+					}
+					if bytes.Equal(storages[i].Key, append([]byte{0x14}, to.BytesBE()...)) {
+						assert.Equal(t, "Added", storages[i].State)
+						assert.Nil(t, storages[i].OldValue)
+					} else {
+						assert.Equal(t, "Changed", storages[i].State)
+						assert.NotNil(t, storages[i].OldValue)
+					}
+					assert.NotNil(t, storages[i].NewValue)
+				}
+				require.Equal(t, 3, len(res.StateDiff.Balances))
+				var gasDiff result.BalanceDiff
+				for _, b := range res.StateDiff.Balances {
+					if b.Asset == gasHash {
+						gasDiff = b
+					}
+				}
+				// Unclaimed GAS is distributed to the sender.
+				assert.Equal(t, from, gasDiff.Account)
+				assert.Equal(t, gasFrom.String(), gasDiff.OldAmount)
+				assert.NotEqual(t, gasDiff.OldAmount, gasDiff.NewAmount)
+				assert.ElementsMatch(t, []result.BalanceDiff{gasDiff, {
+					Asset:     neoHash,
+					Standard:  manifest.NEP17StandardName,
+					Account:   from,
+					OldAmount: neoFrom.String(),
+					NewAmount: new(big.Int).Sub(neoFrom, big.NewInt(1)).String(),
+				}, {
+					Asset:     neoHash,
+					Standard:  manifest.NEP17StandardName,
+					Account:   to,
+					OldAmount: "0",
+					NewAmount: "1",
+				}}, res.StateDiff.Balances)
+				assert.Equal(t, []result.ContractDiff{}, res.StateDiff.Contracts)
+			},
+		},
 		{
 			name:   "positive, verbose",
 			params: `["` + nnsContractHash + `", "resolve", [{"type":"String", "value":"neo.com"},{"type":"Integer","value":1}], [], true]`,
@@ -1639,6 +1728,27 @@ var rpcTestCases = map[string][]rpcTestCase{
 			fail:   true,
 		},
 	},
+	"mineblocks": {
+		{
+			name:   "not enabled",
+			params: `[1]`,
+			fail:   true,
+		},
+	},
+	"getconsensusstate": {
+		{
+			name:   "not enabled",
+			params: `[]`,
+			fail:   true,
+		},
+	},
+	"getconsensushistory": {
+		{
+			name:   "not enabled",
+			params: `[]`,
+			fail:   true,
+		},
+	},
 	"submitblock": {
 		{
 			name:   "invalid base64",
@@ -1670,6 +1780,191 @@ var rpcTestCases = map[string][]rpcTestCase{
 			fail:   true,
 		},
 	},
+	"tracetransaction": {
+		{
+			name:   "positive",
+			params: `["` + deploymentTxHash + `"]`,
+			result: func(e *executor) any { return &result.ExecutionTrace{} },
+			check: func(t *testing.T, e *executor, res any) {
+				trace, ok := res.(*result.ExecutionTrace)
+				require.True(t, ok)
+				h, err := util.Uint256DecodeStringLE(deploymentTxHash)
+				require.NoError(t, err)
+				aer, err := e.chain.GetAppExecResults(h, trigger.Application)
+				require.NoError(t, err)
+				require.Equal(t, h, trace.Container)
+				require.Equal(t, trigger.Application.String(), trace.Trigger)
+				require.Equal(t, aer[0].VMState.String(), trace.VMState)
+				require.Equal(t, aer[0].GasConsumed, trace.GasConsumed)
+				require.False(t, trace.Truncated)
+				require.NotEqual(t, 0, len(trace.Steps))
+				require.Equal(t, 0, trace.Steps[0].IP)
+				require.Equal(t, 1, trace.Steps[0].Depth)
+				require.Equal(t, int64(0), trace.Steps[0].GasConsumed)
+				var deployed bool
+				for _, step := range trace.Steps {
+					require.LessOrEqual(t, len(step.Stack), 1)
+					if step.Syscall != nil && step.Syscall.Name == "System.Contract.Call" {
+						deployed = true
+					}
+				}
+				require.True(t, deployed)
+			},
+		},
+		{
+			name:   "positive, syscalls",
+			params: `["` + deploymentTxHash + `", {"level": "syscalls"}]`,
+			result: func(e *executor) any { return &result.ExecutionTrace{} },
+			check: func(t *testing.T, e *executor, res any) {
+				trace, ok := res.(*result.ExecutionTrace)
+				require.True(t, ok)
+				require.NotEqual(t, 0, len(trace.Steps))
+				for _, step := range trace.Steps {
+					require.Equal(t, opcode.SYSCALL.String(), step.Opcode)
+					require.NotNil(t, step.Syscall)
+					require.Nil(t, step.Stack)
+				}
+			},
+		},
+		{
+			name:   "positive, stack items",
+			params: `["` + deploymentTxHash + `", {"level": "full", "stackdepth": 3, "maxitemsize": 64}]`,
+			result: func(e *executor) any { return &result.ExecutionTrace{} },
+			check: func(t *testing.T, e *executor, res any) {
+				trace, ok := res.(*result.ExecutionTrace)
+				require.True(t, ok)
+				var deep, truncated bool
+				for _, step := range trace.Steps {
+					require.LessOrEqual(t, len(step.Stack), 3)
+					deep = deep || len(step.Stack) == 3
+					for _, item := range step.Stack {
+						require.LessOrEqual(t, len(item), 64)
+						truncated = truncated || strings.Contains(string(item), `"truncated":true`)
+					}
+				}
+				require.True(t, deep)
+				require.True(t, truncated) // NEF and manifest are big.
+			},
+		},
+		{
+			name:   "positive, truncated",
+			params: `["` + deploymentTxHash + `", {"level": "opcodes", "maxsteps": 2}]`,
+			result: func(e *executor) any { return &result.ExecutionTrace{} },
+			check: func(t *testing.T, e *executor, res any) {
+				trace, ok := res.(*result.ExecutionTrace)
+				require.True(t, ok)
+				h, err := util.Uint256DecodeStringLE(deploymentTxHash)
+				require.NoError(t, err)
+				aer, err := e.chain.GetAppExecResults(h, trigger.Application)
+				require.NoError(t, err)
+				require.True(t, trace.Truncated)
+				require.Equal(t, 2, len(trace.Steps))
+				require.Nil(t, trace.Steps[1].Stack)
+				require.Equal(t, aer[0].VMState.String(), trace.VMState)
+				require.Equal(t, aer[0].GasConsumed, trace.GasConsumed)
+			},
+		},
+		{
+			name:   "positive, FAULT",
+			params: `["` + faultedTxHashLE + `", {"level": "syscalls"}]`,
+			result: func(e *executor) any { return &result.ExecutionTrace{} },
+			check: func(t *testing.T, e *executor, res any) {
+				trace, ok := res.(*result.ExecutionTrace)
+				require.True(t, ok)
+				h, err := util.Uint256DecodeStringLE(faultedTxHashLE)
+				require.NoError(t, err)
+				aer, err := e.chain.GetAppExecResults(h, trigger.Application)
+				require.NoError(t, err)
+				require.Equal(t, vmstate.Fault.String(), trace.VMState)
+				require.Equal(t, aer[0].GasConsumed, trace.GasConsumed)
+				require.Equal(t, aer[0].FaultException, trace.FaultException)
+			},
+		},
+		{
+			name:   "no params",
+			params: `[]`,
+			fail:   true,
+		},
+		{
+			name:   "invalid hash",
+			params: `["notahash"]`,
+			fail:   true,
+		},
+		{
+			name:   "unknown transaction",
+			params: `["` + util.Uint256{1, 2, 3}.StringLE() + `"]`,
+			fail:   true,
+		},
+		{
+			name:   "unknown level",
+			params: `["` + deploymentTxHash + `", {"level": "everything"}]`,
+			fail:   true,
+		},
+		{
+			name:   "unknown option",
+			params: `["` + deploymentTxHash + `", {"depth": 1}]`,
+			fail:   true,
+		},
+		{
+			name:   "negative limit",
+			params: `["` + deploymentTxHash + `", {"maxsteps": -1}]`,
+			fail:   true,
+		},
+	},
+	"traceblock": {
+		{
+			name:   "genesis block",
+			params: `["` + genesisBlockHash + `"]`,
+			fail:   true, // There is no state before genesis.
+		},
+		{
+			name:   "positive, by index",
+			params: `[1, {"level": "syscalls"}]`,
+			result: func(e *executor) any { return &[]result.ExecutionTrace{} },
+			check: func(t *testing.T, e *executor, res any) {
+				traces, ok := res.(*[]result.ExecutionTrace)
+				require.True(t, ok)
+				b, err := e.chain.GetBlock(e.chain.GetHeaderHash(1))
+				require.NoError(t, err)
+				require.Equal(t, len(b.Transactions)+2, len(*traces))
+				for i, trace := range *traces {
+					var (
+						container = b.Hash()
+						trig      = trigger.Application
+					)
+					switch i {
+					case 0:
+						trig = trigger.OnPersist
+					case len(*traces) - 1:
+						trig = trigger.PostPersist
+					default:
+						container = b.Transactions[i-1].Hash()
+					}
+					aer, err := e.chain.GetAppExecResults(container, trig)
+					require.NoError(t, err)
+					require.Equal(t, container, trace.Container)
+					require.Equal(t, trig.String(), trace.Trigger)
+					require.Equal(t, aer[0].VMState.String(), trace.VMState)
+					require.Equal(t, aer[0].GasConsumed, trace.GasConsumed)
+				}
+			},
+		},
+		{
+			name:   "no params",
+			params: `[]`,
+			fail:   true,
+		},
+		{
+			name:   "unknown block",
+			params: `[100500]`,
+			fail:   true,
+		},
+		{
+			name:   "invalid options",
+			params: `[1, {"level": 1}]`,
+			fail:   true,
+		},
+	},
 	"validateaddress": {
 		{
 			name:   "positive",
@@ -1705,6 +2000,38 @@ func TestRPC(t *testing.T) {
 	})
 }
 
+type fakeMiner struct {
+	mined int
+}
+
+func (m *fakeMiner) MineBlocks(n int) ([]util.Uint256, error) {
+	m.mined += n
+	return make([]util.Uint256, n), nil
+}
+
+func TestMineBlocks(t *testing.T) {
+	chain, rpcSrv, httpSrv := initClearServerWithServices(t, false, false, false)
+	defer chain.Close()
+	defer rpcSrv.Shutdown()
+
+	m := new(fakeMiner)
+	rpcSrv.SetBlockMiner(m)
+
+	rpc := `{"jsonrpc": "2.0", "id": 1, "method": "mineblocks", "params": %s}`
+	runCase := func(t *testing.T, fail bool, params string) func(t *testing.T) {
+		return func(t *testing.T) {
+			body := doRPCCallOverHTTP(fmt.Sprintf(rpc, params), httpSrv.URL, t)
+			checkErrGetResult(t, body, fail, "Invalid Params")
+		}
+	}
+	t.Run("zero", runCase(t, true, `[0]`))
+	t.Run("too many", runCase(t, true, fmt.Sprintf(`[%d]`, devnet.MaxBlocksPerRequest+1)))
+	require.Equal(t, 0, m.mined)
+	t.Run("default", runCase(t, false, `[]`))
+	t.Run("max", runCase(t, false, fmt.Sprintf(`[%d]`, devnet.MaxBlocksPerRequest)))
+	require.Equal(t, devnet.MaxBlocksPerRequest+1, m.mined)
+}
+
 func TestSubmitOracle(t *testing.T) {
 	chain, rpcSrv, httpSrv := initClearServerWithServices(t, true, false, false)
 	defer chain.Close()
@@ -1736,6 +2063,237 @@ func TestSubmitOracle(t *testing.T) {
 	msg := rpc2.GetMessage(priv.PublicKey().Bytes(), 1, txSig)
 	msgSigStr := `"` + base64.StdEncoding.EncodeToString(priv.Sign(msg)) + `"`
 	t.Run("Valid", runCase(t, false, pubStr, `1`, txSigStr, msgSigStr))
+
+	t.Run("Audit", func(t *testing.T) {
+		call := func(t *testing.T, method string, fail bool, params string) json.RawMessage {
+			req := fmt.Sprintf(`{"jsonrpc": "2.0", "id": 1, "method": "%s", "params": [%s]}`, method, params)
+			return checkErrGetResult(t, doRPCCallOverHTTP(req, httpSrv.URL, t), fail)
+		}
+
+		var r result.OracleRequestRecord
+		require.NoError(t, json.Unmarshal(call(t, "getoracleresponse", false, `1`), &r))
+		require.Equal(t, uint64(1), r.ID)
+		require.Equal(t, result.OracleRequestPending, r.Status)
+		require.Nil(t, r.Code)
+		call(t, "getoracleresponse", true, `2`)
+		call(t, "getoracleresponse", true, `"notanumber"`)
+		call(t, "getoracleresponse", true, ``)
+
+		var rs []result.OracleRequestRecord
+		require.NoError(t, json.Unmarshal(call(t, "getoraclerequests", false, ``), &rs))
+		require.Equal(t, []result.OracleRequestRecord{r}, rs)
+		require.NoError(t, json.Unmarshal(call(t, "getoraclerequests", false, `"sent"`), &rs))
+		require.Empty(t, rs)
+		call(t, "getoraclerequests", true, `"unknown"`)
+	})
+}
+
+func TestOracleRequestsDisabled(t *testing.T) {
+	chain, rpcSrv, httpSrv := initClearServerWithInMemoryChain(t)
+	defer chain.Close()
+	defer rpcSrv.Shutdown()
+
+	for _, m := range []string{"getoraclerequests", "getoracleresponse", "submitoracleresponse"} {
+		req := fmt.Sprintf(`{"jsonrpc": "2.0", "id": 1, "method": "%s", "params": [1]}`, m)
+		checkErrGetResult(t, doRPCCallOverHTTP(req, httpSrv.URL, t), true, "Oracle is not enabled")
+	}
+}
+
+func TestAdminMethods(t *testing.T) {
+	rpc := `{"jsonrpc": "2.0", "id": 1, "method": "%s", "params": [%q]}`
+	call := func(t *testing.T, url string, method string, param string, fail bool) json.RawMessage {
+		body := doRPCCallOverHTTP(fmt.Sprintf(rpc, method, param), url, t)
+		return checkErrGetResult(t, body, fail)
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		chain, rpcSrv, httpSrv := initClearServerWithInMemoryChain(t)
+		defer chain.Close()
+		defer rpcSrv.Shutdown()
+		for _, m := range []string{"addpeer", "removepeer", "banpeer", "unbanpeer"} {
+			body := doRPCCallOverHTTP(fmt.Sprintf(rpc, m, "127.0.0.1"), httpSrv.URL, t)
+			checkErrGetResult(t, body, true, "admin methods are disabled")
+		}
+	})
+
+	chain, rpcSrv, httpSrv := initClearServerWithCustomConfig(t, func(c *config.Config) {
+		c.ApplicationConfiguration.RPC.AdminEnabled = true
+	})
+	defer chain.Close()
+	defer rpcSrv.Shutdown()
+
+	call(t, httpSrv.URL, "addpeer", "127.0.0.1", true)
+	call(t, httpSrv.URL, "banpeer", "127.0.0", true)
+	call(t, httpSrv.URL, "unbanpeer", "127.0.0.1", true)
+
+	require.Equal(t, json.RawMessage("true"), call(t, httpSrv.URL, "banpeer", "10.0.0.0/8", false))
+	call(t, httpSrv.URL, "addpeer", "10.0.0.1:20333", true)
+	require.Equal(t, json.RawMessage("true"), call(t, httpSrv.URL, "addpeer", "127.0.0.1:1", false))
+
+	var peers result.GetPeers
+	res := checkErrGetResult(t, doRPCCallOverHTTP(`{"jsonrpc": "2.0", "id": 1, "method": "getpeers", "params": []}`, httpSrv.URL, t), false)
+	require.NoError(t, json.Unmarshal(res, &peers))
+	require.Equal(t, result.Peers{{Address: "127.0.0.1", Port: 1}}, peers.Static)
+	require.Equal(t, []string{"10.0.0.0/8"}, peers.Banned)
+
+	require.Equal(t, json.RawMessage("true"), call(t, httpSrv.URL, "removepeer", "127.0.0.1:1", false))
+	require.Equal(t, json.RawMessage("false"), call(t, httpSrv.URL, "removepeer", "127.0.0.2:1", false))
+	require.Equal(t, json.RawMessage("true"), call(t, httpSrv.URL, "unbanpeer", "10.0.0.0/8", false))
+}
+
+type fakeConsensusHandler struct {
+	state   result.ConsensusState
+	history []result.ConsensusTimeline
+}
+
+func (f *fakeConsensusHandler) GetState() result.ConsensusState { return f.state }
+func (f *fakeConsensusHandler) GetHistory(n int) []result.ConsensusTimeline {
+	if n > 0 && n < len(f.history) {
+		return f.history[:n]
+	}
+	return f.history
+}
+
+func TestConsensusMethods(t *testing.T) {
+	chain, rpcSrv, httpSrv := initClearServerWithInMemoryChain(t)
+	defer chain.Close()
+	defer rpcSrv.Shutdown()
+
+	rpc := `{"jsonrpc": "2.0", "id": 1, "method": "%s", "params": %s}`
+	call := func(t *testing.T, method string, params string, fail bool) json.RawMessage {
+		body := doRPCCallOverHTTP(fmt.Sprintf(rpc, method, params), httpSrv.URL, t)
+		return checkErrGetResult(t, body, fail)
+	}
+	h := &fakeConsensusHandler{
+		state: result.ConsensusState{
+			Index: 1,
+			Round: &result.ConsensusTimeline{Height: 5, View: 1, Messages: []result.ConsensusMessage{}, ViewChanges: []result.ConsensusViewChange{{View: 1, Time: 123, Reason: "Timeout"}}},
+		},
+		history: []result.ConsensusTimeline{{Height: 4}, {Height: 3}},
+	}
+	rpcSrv.SetConsensusHandler(h)
+
+	var st result.ConsensusState
+	require.NoError(t, json.Unmarshal(call(t, "getconsensusstate", "[]", false), &st))
+	require.Equal(t, h.state, st)
+
+	var hist []result.ConsensusTimeline
+	require.NoError(t, json.Unmarshal(call(t, "getconsensushistory", "[]", false), &hist))
+	require.Equal(t, h.history, hist)
+	require.NoError(t, json.Unmarshal(call(t, "getconsensushistory", "[1]", false), &hist))
+	require.Equal(t, h.history[:1], hist)
+	call(t, "getconsensushistory", "[0]", true)
+	call(t, "getconsensushistory", `["one"]`, true)
+
+	rpcSrv.SetConsensusHandler(nil)
+	call(t, "getconsensusstate", "[]", true)
+}
+
+type fakeNotaryHandler struct {
+	lock   sync.Mutex
+	states []result.NotaryRequestState
+	subs   map[chan result.NotaryRequestState]struct{}
+}
+
+func newFakeNotaryHandler(states ...result.NotaryRequestState) *fakeNotaryHandler {
+	return &fakeNotaryHandler{
+		states: states,
+		subs:   make(map[chan result.NotaryRequestState]struct{}),
+	}
+}
+
+func (f *fakeNotaryHandler) GetRequests() []result.NotaryRequestState { return f.states }
+func (f *fakeNotaryHandler) GetRequestState(h util.Uint256) (result.NotaryRequestState, bool) {
+	for _, st := range f.states {
+		if st.Hash == h {
+			return st, true
+		}
+	}
+	return result.NotaryRequestState{}, false
+}
+func (f *fakeNotaryHandler) SubscribeForStateChanges(ch chan result.NotaryRequestState) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.subs[ch] = struct{}{}
+}
+func (f *fakeNotaryHandler) UnsubscribeFromStateChanges(ch chan result.NotaryRequestState) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	delete(f.subs, ch)
+}
+func (f *fakeNotaryHandler) subscribers() int {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return len(f.subs)
+}
+func (f *fakeNotaryHandler) send(st result.NotaryRequestState) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	for ch := range f.subs {
+		ch <- st
+	}
+}
+
+func TestNotaryRequestMethods(t *testing.T) {
+	chain, rpcSrv, httpSrv := initClearServerWithInMemoryChain(t)
+	defer chain.Close()
+	defer rpcSrv.Shutdown()
+
+	rpc := `{"jsonrpc": "2.0", "id": 1, "method": "%s", "params": %s}`
+	call := func(t *testing.T, method string, params string, fail bool) json.RawMessage {
+		body := doRPCCallOverHTTP(fmt.Sprintf(rpc, method, params), httpSrv.URL, t)
+		return checkErrGetResult(t, body, fail)
+	}
+	for _, m := range []string{"getnotaryrequests", "getnotaryrequeststate"} {
+		body := doRPCCallOverHTTP(fmt.Sprintf(rpc, m, `["`+util.Uint256{1}.StringLE()+`"]`), httpSrv.URL, t)
+		checkErrGetResult(t, body, true, "Notary is not enabled")
+	}
+
+	sentHash := util.Uint256{3}
+	h := newFakeNotaryHandler(result.NotaryRequestState{
+		Hash:            util.Uint256{1},
+		Status:          result.NotaryRequestPending,
+		Received:        123,
+		Updated:         456,
+		ValidUntilBlock: 100,
+		FallbackHeight:  50,
+		Witnesses: []result.NotaryWitnessState{{
+			Account:   util.Uint160{1},
+			Type:      "multisignature",
+			Required:  2,
+			Collected: keys.PublicKeys{testchain.PrivateKeyByID(0).PublicKey()},
+			Missing:   keys.PublicKeys{testchain.PrivateKeyByID(1).PublicKey(), testchain.PrivateKeyByID(2).PublicKey()},
+		}},
+		Fallbacks: []result.NotaryFallbackState{{
+			Hash:           util.Uint256{2},
+			NotValidBefore: 50,
+			Sender:         util.Uint160{2},
+			Deposit:        result.NotaryDeposit{Amount: 1_0000_0000, Till: 1000},
+		}},
+	}, result.NotaryRequestState{
+		Hash:           util.Uint256{4},
+		Status:         result.NotaryRequestFallback,
+		Witnesses:      []result.NotaryWitnessState{},
+		Fallbacks:      []result.NotaryFallbackState{},
+		SentHash:       &sentHash,
+		FallbackReason: result.FallbackIncomplete,
+	})
+	rpcSrv.SetNotaryHandler(h)
+
+	var states []result.NotaryRequestState
+	require.NoError(t, json.Unmarshal(call(t, "getnotaryrequests", "[]", false), &states))
+	require.Equal(t, h.states, states)
+
+	var st result.NotaryRequestState
+	body := doRPCCallOverHTTP(fmt.Sprintf(rpc, "getnotaryrequeststate", `["`+sentHash.StringLE()+`"]`), httpSrv.URL, t)
+	checkErrGetResult(t, body, true, "Unknown notary request")
+	require.NoError(t, json.Unmarshal(call(t, "getnotaryrequeststate", `["`+h.states[1].Hash.StringLE()+`"]`, false), &st))
+	require.Equal(t, h.states[1], st)
+	call(t, "getnotaryrequeststate", "[]", true)
+	call(t, "getnotaryrequeststate", `["not-a-hash"]`, true)
+
+	rpcSrv.SetNotaryHandler(nil)
+	call(t, "getnotaryrequests", "[]", true)
 }
 
 func TestSubmitNotaryRequest(t *testing.T) {
@@ -2332,6 +2890,18 @@ func testRPCProtocol(t *testing.T, doRPCCall func(string, string, *testing.T) []
 		require.NoErrorf(t, err, "could not parse response: %s", res)
 
 		assert.ElementsMatch(t, expected, actual)
+
+		t.Run("verbose with future", func(t *testing.T) {
+			rpc := `{"jsonrpc": "2.0", "id": 1, "method": "getrawmempool", "params": [true, true]}`
+			body := doRPCCall(rpc, httpSrv.URL, t)
+			res := checkErrGetResult(t, body, false)
+
+			var actual result.RawMempool
+			require.NoErrorf(t, json.Unmarshal(res, &actual), "could not parse response: %s", res)
+			assert.ElementsMatch(t, expected, actual.Verified)
+			// Future transactions queue is disabled for this chain.
+			require.Nil(t, actual.Future)
+		})
 	})
 
 	t.Run("getnep17transfers", func(t *testing.T) {
@@ -0,0 +1,283 @@
+package rpcsrv
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/nspcc-dev/neo-go/pkg/core"
+	"github.com/nspcc-dev/neo-go/pkg/core/interop"
+	"github.com/nspcc-dev/neo-go/pkg/core/native"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+)
+
+// invokeOptions are optional parameters of invoke* calls.
+type invokeOptions struct {
+	verbose   bool
+	stateDiff bool
+}
+
+// balanceKey identifies a token balance tracked in the state diff.
+type balanceKey struct {
+	asset    util.Uint160
+	standard string
+	account  util.Uint160
+	id       string
+}
+
+// getStateDiff returns state changes made by the invocation in ic, the
+// original state is the one used for the invocation (current or historic
+// one if nextH is given).
+func (s *Server) getStateDiff(ic *interop.Context, t trigger.Type, tx *transaction.Transaction, nextH *uint32) (*result.StateDiff, *neorpc.Error) {
+	var (
+		old *interop.Context
+		err error
+	)
+	if nextH == nil {
+		old, err = s.chain.GetTestVM(t, tx, ic.Block)
+	} else {
+		old, err = s.chain.GetTestHistoricVM(t, tx, *nextH)
+	}
+	if err != nil {
+		return nil, neorpc.NewInternalServerError(fmt.Sprintf("failed to create VM for the original state: %s", err))
+	}
+	defer old.Finalize()
+	diff, err := stateDiff(ic, old)
+	if err != nil {
+		return nil, neorpc.NewInternalServerError(fmt.Sprintf("failed to compute state diff: %s", err))
+	}
+	return diff, nil
+}
+
+// stateDiff computes changes made by the invocation using its storage
+// changeset. ic is the context after invocation, old is the context with the
+// original state. Both are used for additional balanceOf invocations, so
+// ic.VM is replaced. Balances that can't be retrieved are omitted.
+func stateDiff(ic *interop.Context, old *interop.Context) (*result.StateDiff, error) {
+	var (
+		batch  = ic.DAO.GetBatch()
+		hashes = make(map[int32]util.Uint160)
+		diff   = &result.StateDiff{
+			Storage:   []result.StorageDiff{},
+			Balances:  []result.BalanceDiff{},
+			Contracts: []result.ContractDiff{},
+		}
+	)
+	add := func(kv storage.KeyValueExists, deleted bool) error {
+		if len(kv.Key) < 5 || (kv.Key[0] != byte(storage.STStorage) && kv.Key[0] != byte(storage.STTempStorage)) {
+			return nil
+		}
+		if deleted && !kv.Exists {
+			return nil
+		}
+		id := int32(binary.LittleEndian.Uint32(kv.Key[1:]))
+		h, ok := hashes[id]
+		if !ok {
+			var err error
+			h, err = native.GetContractScriptHash(ic.DAO, id)
+			if err != nil {
+				h, err = native.GetContractScriptHash(old.DAO, id)
+			}
+			if err != nil {
+				return fmt.Errorf("unknown contract %d: %w", id, err)
+			}
+			hashes[id] = h
+		}
+		sd := result.StorageDiff{
+			Contract: h,
+			Key:      kv.Key[5:],
+		}
+		switch {
+		case deleted:
+			sd.State = "Deleted"
+			sd.OldValue = old.DAO.GetStorageItem(id, sd.Key)
+		case kv.Exists:
+			sd.State = "Changed"
+			sd.OldValue = old.DAO.GetStorageItem(id, sd.Key)
+			sd.NewValue = kv.Value
+			if bytes.Equal(sd.OldValue, sd.NewValue) {
+				return nil
+			}
+		default:
+			sd.State = "Added"
+			sd.NewValue = kv.Value
+		}
+		diff.Storage = append(diff.Storage, sd)
+		if id == native.ManagementContractID && len(sd.Key) == 1+util.Uint160Size && sd.Key[0] == native.PrefixContract {
+			cd, err := contractDiff(sd)
+			if err != nil {
+				return err
+			}
+			diff.Contracts = append(diff.Contracts, *cd)
+		}
+		return nil
+	}
+	for i := range batch.Put {
+		if err := add(batch.Put[i], false); err != nil {
+			return nil, err
+		}
+	}
+	for i := range batch.Deleted {
+		if err := add(batch.Deleted[i], true); err != nil {
+			return nil, err
+		}
+	}
+	sort.Slice(diff.Storage, func(i, j int) bool {
+		a, b := diff.Storage[i], diff.Storage[j]
+		if a.Contract != b.Contract {
+			return a.Contract.Less(b.Contract)
+		}
+		return bytes.Compare(a.Key, b.Key) < 0
+	})
+	sort.Slice(diff.Contracts, func(i, j int) bool {
+		return diff.Contracts[i].Hash.Less(diff.Contracts[j].Hash)
+	})
+
+	for _, k := range transferBalances(ic) {
+		oldAmount, err := getBalance(old, k)
+		if err != nil {
+			continue // Not a proper token, nothing to report.
+		}
+		newAmount, err := getBalance(ic, k)
+		if err != nil || oldAmount.Cmp(newAmount) == 0 {
+			continue
+		}
+		diff.Balances = append(diff.Balances, result.BalanceDiff{
+			Asset:     k.asset,
+			Standard:  k.standard,
+			Account:   k.account,
+			ID:        k.id,
+			OldAmount: oldAmount.String(),
+			NewAmount: newAmount.String(),
+		})
+	}
+	return diff, nil
+}
+
+// contractDiff creates a ContractDiff from the Management contract state
+// storage change.
+func contractDiff(sd result.StorageDiff) (*result.ContractDiff, error) {
+	var (
+		cs  = new(state.Contract)
+		val = sd.NewValue
+		cd  = new(result.ContractDiff)
+	)
+	switch sd.State {
+	case "Added":
+		cd.State = "Deployed"
+	case "Changed":
+		cd.State = "Updated"
+	default:
+		cd.State = "Destroyed"
+		val = sd.OldValue
+	}
+	if err := stackitem.DeserializeConvertible(val, cs); err != nil {
+		return nil, fmt.Errorf("failed to decode contract state: %w", err)
+	}
+	cd.Hash = cs.Hash
+	cd.Name = cs.Manifest.Name
+	cd.UpdateCounter = cs.UpdateCounter
+	return cd, nil
+}
+
+// transferBalances returns balances affected by Transfer notifications of the
+// invocation.
+func transferBalances(ic *interop.Context) []balanceKey {
+	var (
+		res  []balanceKey
+		seen = make(map[balanceKey]bool)
+	)
+	for _, n := range ic.Notifications {
+		if n.Name != "Transfer" {
+			continue
+		}
+		arr, ok := n.Item.Value().([]stackitem.Item)
+		if !ok || !(len(arr) == 3 || len(arr) == 4) {
+			continue
+		}
+		var (
+			standard = manifest.NEP17StandardName
+			id       string
+		)
+		if len(arr) == 4 {
+			standard = manifest.NEP11StandardName
+			b, err := arr[3].TryBytes()
+			if err != nil {
+				continue
+			}
+			if isDivisibleNEP11(ic, n.ScriptHash) {
+				id = hex.EncodeToString(b)
+			}
+		}
+		for _, itm := range arr[:2] {
+			if _, ok := itm.(stackitem.Null); ok { // Minting or burning.
+				continue
+			}
+			b, err := itm.TryBytes()
+			if err != nil {
+				continue
+			}
+			acc, err := util.Uint160DecodeBytesBE(b)
+			if err != nil {
+				continue
+			}
+			k := balanceKey{asset: n.ScriptHash, standard: standard, account: acc, id: id}
+			if !seen[k] {
+				seen[k] = true
+				res = append(res, k)
+			}
+		}
+	}
+	return res
+}
+
+func isDivisibleNEP11(ic *interop.Context, h util.Uint160) bool {
+	cs, err := native.GetContract(ic.DAO, h)
+	return err == nil && cs.Manifest.ABI.GetMethod("balanceOf", 2) != nil
+}
+
+// getBalance returns the balance using the given context state, it's zero for
+// missing contracts.
+func getBalance(ic *interop.Context, k balanceKey) (*big.Int, error) {
+	if _, err := native.GetContract(ic.DAO, k.asset); err != nil {
+		return big.NewInt(0), nil
+	}
+	var args = []any{k.account}
+	if k.id != "" {
+		id, _ := hex.DecodeString(k.id)
+		args = append(args, id)
+	}
+	bw := io.NewBufBinWriter()
+	emit.AppCall(bw.BinWriter, k.asset, "balanceOf", callflag.ReadStates|callflag.AllowCall, args...)
+	if bw.Err != nil {
+		return nil, fmt.Errorf("failed to create balanceOf invocation script: %w", bw.Err)
+	}
+	v := ic.SpawnVM()
+	v.GasLimit = core.HeaderVerificationGasLimit
+	v.LoadScriptWithFlags(bw.Bytes(), callflag.All)
+	if err := v.Run(); err != nil {
+		return nil, fmt.Errorf("failed to get %s balance of %s: %w", k.asset.StringLE(), k.account.StringLE(), err)
+	}
+	if v.Estack().Len() != 1 {
+		return nil, fmt.Errorf("invalid %s balanceOf result", k.asset.StringLE())
+	}
+	res, err := v.Estack().Pop().Item().TryInteger()
+	if err != nil {
+		return nil, fmt.Errorf("unexpected %s balanceOf result: %w", k.asset.StringLE(), err)
+	}
+	return res, nil
+}
@@ -11,9 +11,13 @@ import (
 	"github.com/nspcc-dev/neo-go/internal/testchain"
 	"github.com/nspcc-dev/neo-go/pkg/config"
 	"github.com/nspcc-dev/neo-go/pkg/core"
+	"github.com/nspcc-dev/neo-go/pkg/core/mempoolevent"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
 	"github.com/nspcc-dev/neo-go/pkg/neorpc"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
 	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/atomic"
 )
@@ -412,6 +416,84 @@ func TestFilteredNotaryRequestSubscriptions(t *testing.T) {
 	c.Close()
 }
 
+func TestNotaryRequestStateSubscriptions(t *testing.T) {
+	priv0 := testchain.PrivateKeyByID(0)
+	sender := priv0.GetScriptHash()
+
+	chain, rpcSrv, c, respMsgs, finishedFlag := initCleanServerAndWSClient(t)
+	go rpcSrv.coreServer.Start()
+
+	defer chain.Close()
+	defer rpcSrv.Shutdown()
+
+	// blocks are needed to make GAS deposit for priv0
+	for _, b := range getTestBlocks(t) {
+		require.NoError(t, chain.AddBlock(b))
+	}
+
+	// State changes can't be requested without notary service.
+	params := `["notary_request_event", {"sender":"` + sender.StringLE() + `", "statechanges":true}]`
+	resp := callWSGetRaw(t, c, fmt.Sprintf(`{"jsonrpc": "2.0","method": "subscribe","params": %s,"id": 1}`, params), respMsgs)
+	require.NotNil(t, resp.Error)
+
+	req := createValidNotaryRequest(chain, priv0, 100)
+	mainHash := req.MainTransaction.Hash()
+	h := newFakeNotaryHandler(result.NotaryRequestState{
+		Hash:      mainHash,
+		Status:    result.NotaryRequestPending,
+		Witnesses: []result.NotaryWitnessState{{Account: sender}},
+		Fallbacks: []result.NotaryFallbackState{{Sender: sender}},
+	})
+	rpcSrv.SetNotaryHandler(h)
+	subID := callSubscribe(t, c, respMsgs, params)
+	require.Equal(t, 1, h.subscribers())
+
+	getEvent := func(t *testing.T) *result.NotaryRequestEvent {
+		var resp = new(neorpc.Notification)
+		select {
+		case body := <-respMsgs:
+			require.NoError(t, json.Unmarshal(body, resp))
+		case <-time.After(time.Second):
+			t.Fatal("timeout waiting for event")
+		}
+		require.Equal(t, neorpc.NotaryRequestEventID, resp.Event)
+		data, err := json.Marshal(resp.Payload[0])
+		require.NoError(t, err)
+		ev := new(result.NotaryRequestEvent)
+		require.NoError(t, json.Unmarshal(data, ev))
+		return ev
+	}
+
+	// Pool events are enriched with the request state.
+	require.NoError(t, rpcSrv.coreServer.RelayP2PNotaryRequest(req))
+	ev := getEvent(t)
+	require.Equal(t, mempoolevent.TransactionAdded, ev.Type)
+	require.NotNil(t, ev.NotaryRequest)
+	require.NotNil(t, ev.State)
+	require.Equal(t, h.states[0], *ev.State)
+
+	// Non-matching state changes are filtered out.
+	h.send(result.NotaryRequestState{Hash: util.Uint256{1}, Status: result.NotaryRequestPending})
+	st := h.states[0]
+	st.Status = result.NotaryRequestCompleted
+	h.send(st)
+	ev = getEvent(t)
+	require.Equal(t, mempoolevent.TransactionUpdated, ev.Type)
+	require.Nil(t, ev.NotaryRequest)
+	require.Equal(t, st, *ev.State)
+
+	// Subscription is moved to the new handler.
+	h2 := newFakeNotaryHandler()
+	rpcSrv.SetNotaryHandler(h2)
+	require.Equal(t, 0, h.subscribers())
+	require.Equal(t, 1, h2.subscribers())
+
+	callUnsubscribe(t, c, respMsgs, subID)
+	require.Equal(t, 0, h2.subscribers())
+	finishedFlag.CompareAndSwap(false, true)
+	c.Close()
+}
+
 func TestFilteredBlockSubscriptions(t *testing.T) {
 	// We can't fit this into TestFilteredSubscriptions, because it uses
 	// blocks as EOF events to wait for.
@@ -452,6 +534,71 @@ func TestFilteredBlockSubscriptions(t *testing.T) {
 	c.Close()
 }
 
+func TestMempoolSubscriptions(t *testing.T) {
+	chain, rpcSrv, httpSrv := initClearServerWithCustomConfig(t, func(cfg *config.Config) {
+		cfg.ApplicationConfiguration.ReplaceByFee = true
+	})
+	defer chain.Close()
+	defer rpcSrv.Shutdown()
+
+	dialer := websocket.Dialer{HandshakeTimeout: time.Second}
+	url := "ws" + strings.TrimPrefix(httpSrv.URL, "http") + "/ws"
+	c, r, err := dialer.Dial(url, nil)
+	require.NoError(t, err)
+	defer r.Body.Close()
+	respMsgs := make(chan []byte, 16)
+	finishedFlag := atomic.NewBool(false)
+	go wsReader(t, c, respMsgs, finishedFlag)
+
+	// In-block transactions subscription doesn't receive mempool events.
+	callSubscribe(t, c, respMsgs, `["transaction_added"]`)
+	subID := callSubscribe(t, c, respMsgs, `["mempool_event"]`)
+
+	newTx := func(netFee int64) *transaction.Transaction {
+		tx := transaction.New([]byte{byte(opcode.PUSH1)}, 0)
+		tx.Nonce = 42
+		tx.ValidUntilBlock = chain.BlockHeight() + 10
+		tx.NetworkFee = netFee
+		tx.Signers = []transaction.Signer{{Account: testchain.MultisigScriptHash()}}
+		require.NoError(t, testchain.SignTx(chain, tx))
+		return tx
+	}
+	checkEvent := func(typ mempoolevent.Type, tx *transaction.Transaction, replacedBy *util.Uint256) {
+		var resp = new(neorpc.Notification)
+		select {
+		case body := <-respMsgs:
+			require.NoError(t, json.Unmarshal(body, resp))
+		case <-time.After(time.Second):
+			t.Fatal("timeout waiting for event")
+		}
+		require.Equal(t, neorpc.MempoolEventID, resp.Event)
+		rmap := resp.Payload[0].(map[string]any)
+		require.Equal(t, typ.String(), rmap["type"])
+		require.Equal(t, "0x"+tx.Hash().StringLE(), rmap["transaction"].(map[string]any)["hash"])
+		if replacedBy != nil {
+			require.Equal(t, "0x"+replacedBy.StringLE(), rmap["replacedby"])
+		} else {
+			require.NotContains(t, rmap, "replacedby")
+		}
+	}
+
+	tx1 := newTx(0)
+	require.NoError(t, chain.PoolTx(tx1))
+	checkEvent(mempoolevent.TransactionAdded, tx1, nil)
+
+	require.ErrorIs(t, chain.PoolTx(newTx(1)), core.ErrReplaceUnderpriced)
+
+	tx2 := newTx(1000000)
+	require.NoError(t, chain.PoolTx(tx2))
+	h2 := tx2.Hash()
+	checkEvent(mempoolevent.TransactionReplaced, tx1, &h2)
+	checkEvent(mempoolevent.TransactionAdded, tx2, nil)
+
+	callUnsubscribe(t, c, respMsgs, subID)
+	finishedFlag.CompareAndSwap(false, true)
+	c.Close()
+}
+
 func TestMaxSubscriptions(t *testing.T) {
 	var subIDs = make([]string, 0)
 	chain, rpcSrv, c, respMsgs, finishedFlag := initCleanServerAndWSClient(t)
@@ -494,6 +641,8 @@ func TestBadSubUnsub(t *testing.T) {
 		"notification filter 2":  `{"jsonrpc": "2.0", "method": "subscribe", "params": ["notification_from_execution", "name"], "id": 1}`,
 		"execution filter 1":     `{"jsonrpc": "2.0", "method": "subscribe", "params": ["transaction_executed", "FAULT"], "id": 1}`,
 		"execution filter 2":     `{"jsonrpc": "2.0", "method": "subscribe", "params": ["transaction_executed", {"state": "STOP"}], "id": 1}`,
+		"tx mempool filter":      `{"jsonrpc": "2.0", "method": "subscribe", "params": ["transaction_added", {"mempool": true}], "id": 1}`,
+		"tx statechanges filter": `{"jsonrpc": "2.0", "method": "subscribe", "params": ["transaction_added", {"statechanges": true}], "id": 1}`,
 	}
 	var unsubCases = map[string]string{
 		"no params":         `{"jsonrpc": "2.0", "method": "unsubscribe", "params": [], "id": 1}`,
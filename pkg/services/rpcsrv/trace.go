@@ -0,0 +1,266 @@
+package rpcsrv
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/interop"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/services/rpcsrv/params"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+)
+
+const (
+	// defaultTraceStackDepth is the default number of stack items per trace step.
+	defaultTraceStackDepth = 1
+	// maxTraceStackDepth is the maximum number of stack items per trace step.
+	maxTraceStackDepth = 16
+	// defaultTraceItemSize is the default limit for stack item JSON size in
+	// traces.
+	defaultTraceItemSize = 256
+	// maxTraceItemSize is the maximum limit for stack item JSON size in traces.
+	maxTraceItemSize = 4096
+)
+
+// errTraceDone is used to stop block replay after the traced transaction.
+var errTraceDone = errors.New("trace is done")
+
+// tracer collects execution traces with the given options.
+type tracer struct {
+	level      neorpc.TraceLevel
+	steps      int // Steps left for the call.
+	stackDepth int
+	itemSize   int
+}
+
+// truncatedItem is a JSON representation of stack items exceeding size limit.
+type truncatedItem struct {
+	Type      string `json:"type"`
+	Truncated bool   `json:"truncated"`
+}
+
+// traceTransaction implements the `tracetransaction` RPC call.
+func (s *Server) traceTransaction(reqParams params.Params) (any, *neorpc.Error) {
+	txHash, err := reqParams.Value(0).GetUint256()
+	if err != nil {
+		return nil, neorpc.ErrInvalidParams
+	}
+	t, respErr := s.getTracer(reqParams.Value(1))
+	if respErr != nil {
+		return nil, respErr
+	}
+	_, height, err := s.chain.GetTransaction(txHash)
+	if err != nil || height == math.MaxUint32 {
+		return nil, neorpc.ErrUnknownTransaction
+	}
+	var res *result.ExecutionTrace
+	err = s.chain.ReplayBlock(height, func(ic *interop.Context) error {
+		if ic.Tx == nil || !ic.Tx.Hash().Equals(txHash) {
+			_ = ic.VM.Run() // Failures are OK, they're a part of block processing.
+			return nil
+		}
+		res = t.trace(ic, txHash)
+		return errTraceDone
+	})
+	if err != nil && !errors.Is(err, errTraceDone) {
+		return nil, neorpc.NewInternalServerError(fmt.Sprintf("failed to replay block %d: %s", height, err))
+	}
+	if res == nil {
+		return nil, neorpc.NewInternalServerError(fmt.Sprintf("transaction is not found in block %d", height))
+	}
+	return res, nil
+}
+
+// traceBlock implements the `traceblock` RPC call.
+func (s *Server) traceBlock(reqParams params.Params) (any, *neorpc.Error) {
+	hash, respErr := s.blockHashFromParam(reqParams.Value(0))
+	if respErr != nil {
+		return nil, respErr
+	}
+	t, respErr := s.getTracer(reqParams.Value(1))
+	if respErr != nil {
+		return nil, respErr
+	}
+	header, err := s.chain.GetHeader(hash)
+	if err != nil {
+		return nil, neorpc.ErrUnknownBlock
+	}
+	var res []result.ExecutionTrace
+	err = s.chain.ReplayBlock(header.Index, func(ic *interop.Context) error {
+		var container = hash
+		if ic.Tx != nil {
+			container = ic.Tx.Hash()
+		}
+		res = append(res, *t.trace(ic, container))
+		return nil
+	})
+	if err != nil {
+		return nil, neorpc.NewInternalServerError(fmt.Sprintf("failed to replay block %d: %s", header.Index, err))
+	}
+	return res, nil
+}
+
+// getTracer creates a tracer using the given optional trace options
+// parameter and server limits.
+func (s *Server) getTracer(param *params.Param) (*tracer, *neorpc.Error) {
+	var opts neorpc.TraceOptions
+	if param != nil {
+		jd := json.NewDecoder(bytes.NewReader(param.RawMessage))
+		jd.DisallowUnknownFields()
+		if err := jd.Decode(&opts); err != nil {
+			return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, fmt.Sprintf("invalid trace options: %s", err))
+		}
+	}
+	t := &tracer{
+		level:      opts.Level,
+		steps:      s.config.MaxTraceSteps,
+		stackDepth: defaultTraceStackDepth,
+		itemSize:   defaultTraceItemSize,
+	}
+	switch t.level {
+	case "":
+		t.level = neorpc.TraceFull
+	case neorpc.TraceSyscalls, neorpc.TraceOpcodes, neorpc.TraceFull:
+	default:
+		return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, fmt.Sprintf("unknown trace level: %s", t.level))
+	}
+	if opts.MaxSteps < 0 || opts.StackDepth < 0 || opts.MaxItemSize < 0 {
+		return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, "negative trace limit")
+	}
+	if opts.MaxSteps != 0 && opts.MaxSteps < t.steps {
+		t.steps = opts.MaxSteps
+	}
+	if opts.StackDepth != 0 {
+		t.stackDepth = opts.StackDepth
+		if t.stackDepth > maxTraceStackDepth {
+			t.stackDepth = maxTraceStackDepth
+		}
+	}
+	if opts.MaxItemSize != 0 {
+		t.itemSize = opts.MaxItemSize
+		if t.itemSize > maxTraceItemSize {
+			t.itemSize = maxTraceItemSize
+		}
+	}
+	return t, nil
+}
+
+// trace executes the script loaded into ic step by step collecting the trace.
+// Execution continues without tracing when the step limit is reached.
+func (t *tracer) trace(ic *interop.Context, container util.Uint256) *result.ExecutionTrace {
+	var (
+		v   = ic.VM
+		res = &result.ExecutionTrace{
+			Container: container,
+			Trigger:   ic.Trigger.String(),
+			Steps:     []result.TraceStep{},
+		}
+		cur     = -1 // Current step index if it's traced.
+		handler = v.SyscallHandler
+		err     error
+	)
+	v.SyscallHandler = func(v *vm.VM, id uint32) error {
+		if f := ic.GetFunction(id); cur >= 0 && f != nil {
+			res.Steps[cur].Syscall = &result.TraceSyscall{
+				Name: f.Name,
+				Args: t.stackItems(v, f.ParamCount),
+			}
+		}
+		return handler(v, id)
+	}
+	for !v.HasStopped() {
+		if t.steps <= 0 {
+			res.Truncated = true
+			cur = -1
+			err = v.Run()
+			break
+		}
+		var (
+			ctx    = v.Context()
+			ip, op = ctx.NextInstr()
+		)
+		cur = -1
+		if t.level != neorpc.TraceSyscalls || op == opcode.SYSCALL {
+			step := result.TraceStep{
+				IP:          ip,
+				Opcode:      op.String(),
+				ScriptHash:  ctx.ScriptHash(),
+				Depth:       len(v.Istack()),
+				GasConsumed: v.GasConsumed(),
+			}
+			if t.level == neorpc.TraceFull {
+				step.Stack = t.stackItems(v, t.stackDepth)
+			}
+			res.Steps = append(res.Steps, step)
+			cur = len(res.Steps) - 1
+			t.steps--
+		}
+		if err = v.Step(); err != nil {
+			break
+		}
+	}
+	res.VMState = v.State().String()
+	res.GasConsumed = v.GasConsumed()
+	if err != nil {
+		res.FaultException = err.Error()
+	}
+	return res
+}
+
+// stackItems returns JSON representations of at most n top evaluation stack
+// items.
+func (t *tracer) stackItems(v *vm.VM, n int) []json.RawMessage {
+	var estack = v.Estack()
+	if n > estack.Len() {
+		n = estack.Len()
+	}
+	res := make([]json.RawMessage, n)
+	for i := range res {
+		res[i] = traceItem(estack.Peek(i).Item(), t.itemSize)
+	}
+	return res
+}
+
+// traceItem returns JSON representation of the stack item with types if it
+// fits into the size limit and a stub otherwise.
+func traceItem(item stackitem.Item, limit int) json.RawMessage {
+	var budget = limit
+	if itemFits(item, &budget) {
+		data, err := stackitem.ToJSONWithTypes(item)
+		if err == nil && len(data) <= limit {
+			return data
+		}
+	}
+	data, _ := json.Marshal(truncatedItem{Type: item.Type().String(), Truncated: true})
+	return data
+}
+
+// itemFits is a cheap check for the item to possibly fit into the given JSON
+// size budget, it prevents serialization of big (or recursive) items.
+func itemFits(item stackitem.Item, budget *int) bool {
+	*budget--
+	switch item.Type() {
+	case stackitem.ByteArrayT, stackitem.BufferT:
+		*budget -= len(item.Value().([]byte))
+	case stackitem.ArrayT, stackitem.StructT:
+		for _, e := range item.Value().([]stackitem.Item) {
+			if !itemFits(e, budget) {
+				return false
+			}
+		}
+	case stackitem.MapT:
+		for _, e := range item.Value().([]stackitem.MapElement) {
+			if !itemFits(e.Key, budget) || !itemFits(e.Value, budget) {
+				return false
+			}
+		}
+	}
+	return *budget >= 0
+}
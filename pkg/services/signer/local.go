@@ -0,0 +1,186 @@
+package signer
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
+)
+
+// Local is an in-process signer holding decrypted private keys.
+type Local struct {
+	lock       sync.RWMutex
+	privs      map[string]*keys.PrivateKey
+	pubs       keys.PublicKeys
+	protection *Protection
+	wallet     *wallet.Wallet
+	// domains contains domains allowed per key, keys without an entry can
+	// be used for any known domain.
+	domains map[string]map[string]bool
+}
+
+var _ Signer = (*Local)(nil)
+
+// NewLocal creates a signer for the given keys with in-memory double-signing
+// protection.
+func NewLocal(privs ...*keys.PrivateKey) *Local {
+	l := &Local{
+		privs:   make(map[string]*keys.PrivateKey),
+		domains: make(map[string]map[string]bool),
+	}
+	l.protection, _ = NewProtection("") // Can't fail without a file.
+	for _, p := range privs {
+		l.add(p)
+	}
+	return l
+}
+
+// NewWalletSigner opens the wallet and creates a signer for all of its
+// accounts that can be decrypted with the configured password. If domains
+// are given, all keys are only allowed to be used for them. The wallet is
+// closed by Close.
+func NewWalletSigner(cfg config.Wallet, domains ...string) (*Local, error) {
+	w, err := wallet.NewWalletFromFile(cfg.Path)
+	if err != nil {
+		return nil, err
+	}
+	l := NewLocal()
+	for _, acc := range w.Accounts {
+		if !acc.CanSign() && acc.Decrypt(cfg.Password, w.Scrypt) != nil {
+			continue
+		}
+		l.add(acc.PrivateKey())
+	}
+	if len(l.pubs) == 0 {
+		w.Close()
+		return nil, errors.New("no account with provided password was found")
+	}
+	if len(domains) != 0 {
+		for _, pub := range l.pubs {
+			if err := l.AllowDomains(pub, domains...); err != nil {
+				w.Close()
+				return nil, err
+			}
+		}
+	}
+	l.wallet = w
+	return l, nil
+}
+
+func (l *Local) add(p *keys.PrivateKey) {
+	k := string(p.PublicKey().Bytes())
+	if _, ok := l.privs[k]; ok {
+		return
+	}
+	l.privs[k] = p
+	l.pubs = append(l.pubs, p.PublicKey())
+}
+
+// SetProtection replaces the double-signing protection of the signer.
+func (l *Local) SetProtection(p *Protection) {
+	l.lock.Lock()
+	l.protection = p
+	l.lock.Unlock()
+}
+
+// AllowDomains restricts domains the key can be used for (any known domain is
+// allowed by default). Consensus and state root domains can't be combined
+// with notary and oracle ones, since requests of the latter are not checked
+// for double signing.
+func (l *Local) AllowDomains(pub *keys.PublicKey, domains ...string) error {
+	var protected, unprotected bool
+	allowed := make(map[string]bool, len(domains))
+	for _, d := range domains {
+		p, ok := knownDomains[d]
+		if !ok {
+			return fmt.Errorf("%w: unknown domain %q", ErrDomainNotAllowed, d)
+		}
+		protected = protected || p
+		unprotected = unprotected || !p
+		allowed[d] = true
+	}
+	if protected && unprotected {
+		return fmt.Errorf("%w: key %s can't be used for both consensus (or state root) and other domains",
+			ErrDomainNotAllowed, pub.Address())
+	}
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	k := string(pub.Bytes())
+	if _, ok := l.privs[k]; !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownKey, pub.Address())
+	}
+	l.domains[k] = allowed
+	return nil
+}
+
+// checkDomain checks whether the key can be used for the domain. It must be
+// called with the lock held.
+func (l *Local) checkDomain(k string, domain string) error {
+	protected, ok := knownDomains[domain]
+	if !ok {
+		return fmt.Errorf("%w: unknown domain %q", ErrDomainNotAllowed, domain)
+	}
+	allowed, restricted := l.domains[k]
+	// Keys without restrictions can be used for consensus.
+	consensusKey := !restricted
+	for d := range allowed {
+		consensusKey = consensusKey || knownDomains[d]
+	}
+	if consensusKey && !protected {
+		return fmt.Errorf("%w: %s requests can't be signed with a key allowed for consensus or state root",
+			ErrDoubleSign, domain)
+	}
+	if restricted && !allowed[domain] {
+		return fmt.Errorf("%w: %s", ErrDomainNotAllowed, domain)
+	}
+	return nil
+}
+
+// PrivateKey returns the private key for the given public key (if the signer
+// has it). It's only to be used where signatures can't be made via Signer
+// interface.
+func (l *Local) PrivateKey(pub *keys.PublicKey) *keys.PrivateKey {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+	return l.privs[string(pub.Bytes())]
+}
+
+// PublicKeys implements the Signer interface.
+func (l *Local) PublicKeys() (keys.PublicKeys, error) {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+	return l.pubs.Copy(), nil
+}
+
+// Sign implements the Signer interface.
+func (l *Local) Sign(req Request) ([]byte, error) {
+	if req.PublicKey == nil {
+		return nil, fmt.Errorf("%w: no key specified", ErrUnknownKey)
+	}
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+	p, ok := l.privs[string(req.PublicKey.Bytes())]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownKey, req.PublicKey.Address())
+	}
+	if err := l.checkDomain(string(req.PublicKey.Bytes()), req.Domain); err != nil {
+		return nil, err
+	}
+	if err := l.protection.Check(req); err != nil {
+		return nil, err
+	}
+	return p.SignHash(req.Hash), nil
+}
+
+// Close implements the Signer interface. It closes the wallet (if any).
+func (l *Local) Close() {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if l.wallet != nil {
+		l.wallet.Close()
+		l.wallet = nil
+	}
+}
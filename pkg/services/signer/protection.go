@@ -0,0 +1,192 @@
+package signer
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/network/payload"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// ProtectionDepth is the number of heights (below the highest one signed)
+// the protection keeps records for. Protected requests for older heights are
+// rejected.
+const ProtectionDepth = 100
+
+// Protection is a double-signing protection. It remembers hashes signed for
+// protected requests and rejects requests with different hashes for the same
+// key, domain, height and view. It's safe for concurrent use.
+type Protection struct {
+	lock sync.Mutex
+	// path is the state file path, state is only kept in memory if it's empty.
+	path    string
+	records map[slot]util.Uint256
+	// top is the highest height signed per key and domain.
+	top map[slotKey]uint32
+}
+
+type slotKey struct {
+	key    string
+	domain string
+}
+
+type slot struct {
+	slotKey
+	height uint32
+	view   byte
+}
+
+// protectionRecord is a JSON representation of a signed slot.
+type protectionRecord struct {
+	Key    string       `json:"key"`
+	Domain string       `json:"domain"`
+	Height uint32       `json:"height"`
+	View   byte         `json:"view"`
+	Hash   util.Uint256 `json:"hash"`
+}
+
+// NewProtection creates a protection storing its state in the given file
+// (which is loaded if it exists). The state is kept in memory only if the
+// path is empty.
+func NewProtection(path string) (*Protection, error) {
+	p := &Protection{
+		path:    path,
+		records: make(map[slot]util.Uint256),
+		top:     make(map[slotKey]uint32),
+	}
+	if len(path) == 0 {
+		return p, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return p, nil
+		}
+		return nil, fmt.Errorf("failed to read protection state: %w", err)
+	}
+	var recs []protectionRecord
+	if err := json.Unmarshal(data, &recs); err != nil {
+		return nil, fmt.Errorf("failed to decode protection state: %w", err)
+	}
+	for _, r := range recs {
+		p.add(slot{slotKey{r.Key, r.Domain}, r.Height, r.View}, r.Hash)
+	}
+	return p, nil
+}
+
+// Check checks the request against the signed ones and remembers it. It
+// must be called before signing, ErrDoubleSign is returned if the request
+// must not be signed. Requests are classified by their domain (see
+// DomainConsensus and others), unprotected requests are only allowed for
+// domains that don't require protection, unknown domains are rejected.
+func (p *Protection) Check(req Request) error {
+	switch req.Domain {
+	case DomainConsensus:
+		// Genesis block is never signed.
+		if !req.Protected || req.Height == 0 {
+			return fmt.Errorf("%w: %s requests must be protected and have height set", ErrDoubleSign, req.Domain)
+		}
+	case DomainStateRoot:
+		if !req.Protected {
+			return fmt.Errorf("%w: %s requests must be protected", ErrDoubleSign, req.Domain)
+		}
+	case DomainConsensusMessage, DomainStateRootMessage:
+		return checkMessage(req)
+	case DomainNotary, DomainOracle:
+		if !req.Protected {
+			return nil
+		}
+	default:
+		return fmt.Errorf("%w: unknown domain %q", ErrDomainNotAllowed, req.Domain)
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	s := slot{slotKey{hex.EncodeToString(req.PublicKey.Bytes()), req.Domain}, req.Height, req.View}
+	if h, ok := p.records[s]; ok {
+		if h != req.Hash {
+			return fmt.Errorf("%w: %s at height %d view %d was signed with different hash %s",
+				ErrDoubleSign, req.Domain, req.Height, req.View, h.StringLE())
+		}
+		return nil
+	}
+	if top, ok := p.top[s.slotKey]; ok && top >= ProtectionDepth && req.Height <= top-ProtectionDepth {
+		return fmt.Errorf("%w: %s height %d is too old (latest is %d)", ErrDoubleSign, req.Domain, req.Height, top)
+	}
+	p.add(s, req.Hash)
+	return p.save()
+}
+
+// checkMessage checks that the request hash is a hash of the network payload
+// of the category expected for the request domain from its data.
+func checkMessage(req Request) error {
+	var (
+		ep = new(payload.Extensible)
+		r  = io.NewBinReaderFromBuf(req.Data)
+	)
+	ep.DecodeBinary(r)
+	if r.Err != nil {
+		return fmt.Errorf("%w: invalid %s payload: %v", ErrDoubleSign, req.Domain, r.Err) //nolint:errorlint // errorlint: non-wrapping format verb for fmt.Errorf. Use `%w` to format errors
+	}
+	if ep.Category != messageCategories[req.Domain] {
+		return fmt.Errorf("%w: invalid %s payload category %q", ErrDoubleSign, req.Domain, ep.Category)
+	}
+	if hash.NetSha256(req.Network, ep) != req.Hash {
+		return fmt.Errorf("%w: %s payload hash mismatch", ErrDoubleSign, req.Domain)
+	}
+	return nil
+}
+
+// add adds a record and drops the ones that are too old. It must be called
+// with the lock held.
+func (p *Protection) add(s slot, h util.Uint256) {
+	p.records[s] = h
+	if top, ok := p.top[s.slotKey]; ok && top >= s.height {
+		return
+	}
+	p.top[s.slotKey] = s.height
+	if s.height < ProtectionDepth {
+		return
+	}
+	for r := range p.records {
+		if r.slotKey == s.slotKey && r.height <= s.height-ProtectionDepth {
+			delete(p.records, r)
+		}
+	}
+}
+
+// save writes the state to the file (if any). It must be called with the
+// lock held.
+func (p *Protection) save() error {
+	if len(p.path) == 0 {
+		return nil
+	}
+	recs := make([]protectionRecord, 0, len(p.records))
+	for s, h := range p.records {
+		recs = append(recs, protectionRecord{
+			Key:    s.key,
+			Domain: s.domain,
+			Height: s.height,
+			View:   s.view,
+			Hash:   h,
+		})
+	}
+	data, err := json.Marshal(recs)
+	if err != nil {
+		return fmt.Errorf("failed to encode protection state: %w", err)
+	}
+	tmp := p.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("failed to save protection state: %w", err)
+	}
+	if err := os.Rename(tmp, p.path); err != nil {
+		return fmt.Errorf("failed to save protection state: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package signer
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/internal/random"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProtection(t *testing.T) {
+	var (
+		pub  = newTestKeys(t, 1)[0].PublicKey()
+		path = filepath.Join(t.TempDir(), "protection.json")
+		req  = func(height uint32, view byte) Request {
+			return Request{PublicKey: pub, Hash: random.Uint256(), Domain: DomainConsensus, Height: height, View: view, Protected: true}
+		}
+	)
+	p, err := NewProtection(path)
+	require.NoError(t, err)
+
+	signed := req(10, 0)
+	require.NoError(t, p.Check(signed))
+	require.NoError(t, p.Check(signed))
+	require.ErrorIs(t, p.Check(req(10, 0)), ErrDoubleSign)
+	require.NoError(t, p.Check(req(9, 0)))
+
+	t.Run("restore", func(t *testing.T) {
+		p, err := NewProtection(path)
+		require.NoError(t, err)
+		require.NoError(t, p.Check(signed))
+		require.ErrorIs(t, p.Check(req(10, 0)), ErrDoubleSign)
+		require.ErrorIs(t, p.Check(req(9, 0)), ErrDoubleSign)
+	})
+	t.Run("old heights", func(t *testing.T) {
+		require.NoError(t, p.Check(req(10+ProtectionDepth, 0)))
+		// Records for old heights are dropped, so such requests can't be
+		// checked anymore.
+		require.ErrorIs(t, p.Check(signed), ErrDoubleSign)
+		require.ErrorIs(t, p.Check(req(10, 1)), ErrDoubleSign)
+		require.NoError(t, p.Check(req(11, 0)))
+		require.Len(t, p.records, 2)
+	})
+	t.Run("invalid file", func(t *testing.T) {
+		_, err := NewProtection(t.TempDir())
+		require.Error(t, err)
+	})
+}
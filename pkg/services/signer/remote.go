@@ -0,0 +1,197 @@
+package signer
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+)
+
+// DefaultRemoteTimeout is the default remote signer request timeout.
+const DefaultRemoteTimeout = 5 * time.Second
+
+// Remote signer protocol methods.
+const (
+	methodKeys = "keys"
+	methodSign = "sign"
+)
+
+// Remote signer protocol error codes.
+const (
+	codeDoubleSign = "doublesign"
+	codeUnknownKey = "unknownkey"
+	codeDomain     = "domain"
+	codeInternal   = "internal"
+)
+
+// remoteRequest is a single line of the remote signer protocol sent to the
+// signer.
+type remoteRequest struct {
+	Method  string   `json:"method"`
+	Request *Request `json:"request,omitempty"`
+}
+
+// remoteResponse is a single line of the remote signer protocol sent by the
+// signer in response to remoteRequest.
+type remoteResponse struct {
+	Keys      keys.PublicKeys `json:"keys,omitempty"`
+	Signature []byte          `json:"signature,omitempty"`
+	Code      string          `json:"code,omitempty"`
+	Error     string          `json:"error,omitempty"`
+}
+
+// Remote is a client of the signer working via a Unix socket (like the one
+// provided by Server). Requests are serialized over a single connection
+// which is reestablished after failures.
+type Remote struct {
+	socket  string
+	timeout time.Duration
+
+	lock sync.Mutex
+	conn net.Conn
+	r    *bufio.Reader
+}
+
+var _ Signer = (*Remote)(nil)
+
+// NewRemote creates a remote signer client and checks that the signer is
+// available.
+func NewRemote(cfg config.RemoteSigner) (*Remote, error) {
+	r := &Remote{
+		socket:  cfg.Socket,
+		timeout: cfg.Timeout,
+	}
+	if r.timeout <= 0 {
+		r.timeout = DefaultRemoteTimeout
+	}
+	if _, err := r.PublicKeys(); err != nil {
+		r.Close()
+		return nil, err
+	}
+	return r, nil
+}
+
+// PublicKeys implements the Signer interface.
+func (r *Remote) PublicKeys() (keys.PublicKeys, error) {
+	resp, err := r.call(remoteRequest{Method: methodKeys})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Keys, nil
+}
+
+// Sign implements the Signer interface.
+func (r *Remote) Sign(req Request) ([]byte, error) {
+	resp, err := r.call(remoteRequest{Method: methodSign, Request: &req})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Signature, nil
+}
+
+// Close implements the Signer interface.
+func (r *Remote) Close() {
+	r.lock.Lock()
+	r.disconnect()
+	r.lock.Unlock()
+}
+
+func (r *Remote) call(req remoteRequest) (*remoteResponse, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	resp, err := r.roundTrip(req)
+	if err != nil {
+		// The signer could have been restarted, so retry once with a
+		// new connection.
+		r.disconnect()
+		resp, err = r.roundTrip(req)
+	}
+	if err != nil {
+		r.disconnect()
+		return nil, fmt.Errorf("remote signer: %w", err)
+	}
+	switch resp.Code {
+	case "":
+		return resp, nil
+	case codeDoubleSign:
+		return nil, remoteError(ErrDoubleSign, resp.Error)
+	case codeUnknownKey:
+		return nil, remoteError(ErrUnknownKey, resp.Error)
+	case codeDomain:
+		return nil, remoteError(ErrDomainNotAllowed, resp.Error)
+	default:
+		return nil, fmt.Errorf("remote signer: %s", resp.Error)
+	}
+}
+
+// roundTrip sends the request and reads the response, it must be called with
+// the lock held.
+func (r *Remote) roundTrip(req remoteRequest) (*remoteResponse, error) {
+	if r.conn == nil {
+		conn, err := net.DialTimeout("unix", r.socket, r.timeout)
+		if err != nil {
+			return nil, err
+		}
+		r.conn = conn
+		r.r = bufio.NewReader(conn)
+	}
+	if err := r.conn.SetDeadline(time.Now().Add(r.timeout)); err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := r.conn.Write(append(data, '\n')); err != nil {
+		return nil, err
+	}
+	line, err := r.r.ReadBytes('\n')
+	if err != nil {
+		return nil, err
+	}
+	resp := new(remoteResponse)
+	if err := json.Unmarshal(line, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (r *Remote) disconnect() {
+	if r.conn != nil {
+		_ = r.conn.Close()
+		r.conn = nil
+		r.r = nil
+	}
+}
+
+// remoteError wraps the error with the message received from the signer
+// (which usually already starts with the error text).
+func remoteError(err error, msg string) error {
+	msg = strings.TrimPrefix(strings.TrimPrefix(msg, err.Error()), ": ")
+	if len(msg) == 0 {
+		return err
+	}
+	return fmt.Errorf("%w: %s", err, msg)
+}
+
+// errorCode returns the protocol error code for the signer error.
+func errorCode(err error) string {
+	switch {
+	case errors.Is(err, ErrDoubleSign):
+		return codeDoubleSign
+	case errors.Is(err, ErrUnknownKey):
+		return codeUnknownKey
+	case errors.Is(err, ErrDomainNotAllowed):
+		return codeDomain
+	default:
+		return codeInternal
+	}
+}
@@ -0,0 +1,152 @@
+package signer
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"net"
+	"os"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+// Server is a reference remote signer serving requests of Remote clients via
+// a Unix socket.
+type Server struct {
+	socket string
+	signer Signer
+	log    *zap.Logger
+
+	lock     sync.Mutex
+	listener net.Listener
+	conns    map[net.Conn]struct{}
+	wg       sync.WaitGroup
+}
+
+// NewServer creates a signing server for the given socket path and signer.
+func NewServer(socket string, s Signer, log *zap.Logger) *Server {
+	return &Server{
+		socket: socket,
+		signer: s,
+		log:    log,
+		conns:  make(map[net.Conn]struct{}),
+	}
+}
+
+// Start starts listening on the socket. A stale socket file is removed and
+// the new one is only accessible by the owner.
+func (s *Server) Start() error {
+	if err := os.Remove(s.socket); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	l, err := net.Listen("unix", s.socket)
+	if err != nil {
+		return err
+	}
+	if err := os.Chmod(s.socket, 0o600); err != nil {
+		_ = l.Close()
+		return err
+	}
+	s.lock.Lock()
+	s.listener = l
+	s.lock.Unlock()
+	s.log.Info("signing server started", zap.String("socket", s.socket))
+	s.wg.Add(1)
+	go s.accept(l)
+	return nil
+}
+
+// Shutdown stops the server and closes all connections. It doesn't close the
+// signer.
+func (s *Server) Shutdown() {
+	s.lock.Lock()
+	if s.listener != nil {
+		_ = s.listener.Close()
+		s.listener = nil
+	}
+	for c := range s.conns {
+		_ = c.Close()
+	}
+	s.lock.Unlock()
+	s.wg.Wait()
+	s.log.Info("signing server stopped")
+}
+
+func (s *Server) accept(l net.Listener) {
+	defer s.wg.Done()
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			if !errors.Is(err, net.ErrClosed) {
+				s.log.Error("failed to accept connection", zap.Error(err))
+			}
+			return
+		}
+		s.lock.Lock()
+		if s.listener == nil {
+			s.lock.Unlock()
+			_ = conn.Close()
+			return
+		}
+		s.conns[conn] = struct{}{}
+		s.lock.Unlock()
+		s.wg.Add(1)
+		go s.serve(conn)
+	}
+}
+
+func (s *Server) serve(conn net.Conn) {
+	defer func() {
+		s.lock.Lock()
+		delete(s.conns, conn)
+		s.lock.Unlock()
+		_ = conn.Close()
+		s.wg.Done()
+	}()
+	r := bufio.NewReader(conn)
+	for {
+		line, err := r.ReadBytes('\n')
+		if err != nil {
+			return
+		}
+		data, err := json.Marshal(s.handle(line))
+		if err != nil {
+			return
+		}
+		if _, err := conn.Write(append(data, '\n')); err != nil {
+			return
+		}
+	}
+}
+
+func (s *Server) handle(line []byte) *remoteResponse {
+	var req remoteRequest
+	if err := json.Unmarshal(line, &req); err != nil {
+		return &remoteResponse{Code: codeInternal, Error: "invalid request: " + err.Error()}
+	}
+	switch req.Method {
+	case methodKeys:
+		pubs, err := s.signer.PublicKeys()
+		if err != nil {
+			return &remoteResponse{Code: errorCode(err), Error: err.Error()}
+		}
+		return &remoteResponse{Keys: pubs}
+	case methodSign:
+		if req.Request == nil {
+			return &remoteResponse{Code: codeInternal, Error: "no request"}
+		}
+		sig, err := s.signer.Sign(*req.Request)
+		if err != nil {
+			s.log.Warn("signing request rejected",
+				zap.String("domain", req.Request.Domain),
+				zap.Uint32("height", req.Request.Height),
+				zap.Uint8("view", req.Request.View),
+				zap.Error(err))
+			return &remoteResponse{Code: errorCode(err), Error: err.Error()}
+		}
+		return &remoteResponse{Signature: sig}
+	default:
+		return &remoteResponse{Code: codeInternal, Error: "unknown method " + req.Method}
+	}
+}
@@ -0,0 +1,74 @@
+package signer
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/internal/random"
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestRemote(t *testing.T) {
+	var (
+		privs  = newTestKeys(t, 2)
+		socket = filepath.Join(t.TempDir(), "signer.sock")
+		local  = NewLocal(privs[0])
+		srv    = NewServer(socket, local, zaptest.NewLogger(t))
+	)
+	require.NoError(t, srv.Start())
+
+	r, err := NewRemote(config.RemoteSigner{Socket: socket})
+	require.NoError(t, err)
+	t.Cleanup(r.Close)
+
+	pubs, err := r.PublicKeys()
+	require.NoError(t, err)
+	require.Equal(t, keys.PublicKeys{privs[0].PublicKey()}, pubs)
+
+	req := Request{PublicKey: privs[0].PublicKey(), Hash: random.Uint256(), Domain: DomainConsensus, Height: 1, Protected: true}
+	sig, err := r.Sign(req)
+	require.NoError(t, err)
+	checkSignature(t, req, sig)
+
+	other := req
+	other.Hash = random.Uint256()
+	_, err = r.Sign(other)
+	require.ErrorIs(t, err, ErrDoubleSign)
+
+	// Server enforces the protection for consensus domain, so a conflicting
+	// block can't be signed with an unprotected request.
+	other.Protected = false
+	_, err = r.Sign(other)
+	require.ErrorIs(t, err, ErrDoubleSign)
+	other.Height, other.View = 0, 0
+	_, err = r.Sign(other)
+	require.ErrorIs(t, err, ErrDoubleSign)
+	// Other domains can't be used to sign it either.
+	other.Domain = DomainNotary
+	_, err = r.Sign(other)
+	require.ErrorIs(t, err, ErrDoubleSign)
+	other.Domain = "unknown"
+	_, err = r.Sign(other)
+	require.ErrorIs(t, err, ErrDomainNotAllowed)
+	other = req
+
+	_, err = r.Sign(Request{PublicKey: privs[1].PublicKey(), Hash: random.Uint256()})
+	require.ErrorIs(t, err, ErrUnknownKey)
+
+	// The client reconnects after the server restart.
+	srv.Shutdown()
+	_, err = r.PublicKeys()
+	require.Error(t, err)
+	srv = NewServer(socket, local, zaptest.NewLogger(t))
+	require.NoError(t, srv.Start())
+	t.Cleanup(srv.Shutdown)
+	other.Hash = random.Uint256()
+	_, err = r.Sign(other)
+	require.ErrorIs(t, err, ErrDoubleSign)
+	sig, err = r.Sign(req)
+	require.NoError(t, err)
+	checkSignature(t, req, sig)
+}
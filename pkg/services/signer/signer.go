@@ -0,0 +1,194 @@
+/*
+Package signer provides an interface for services (consensus, state root,
+notary and oracle) to sign data without keeping private keys in the node
+process. It contains an in-process implementation backed by a wallet, a
+client for remote signers working via a Unix socket and a reference signing
+server for it. Both implementations protect against signing different data
+for the same height/view.
+*/
+package signer
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/network/payload"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// Signing domains used by services. Signers enforce the double-signing
+// protection for DomainConsensus (blocks) and DomainStateRoot (state roots)
+// requests, so they must be protected and have a height (and a view for
+// blocks) set. DomainConsensusMessage and DomainStateRootMessage requests
+// must contain the network payload being signed (see SignExtensible), so
+// that they can't be used to sign blocks or state roots. Keys used for
+// consensus or state roots can't sign requests of other domains (notary and
+// oracle ones are not restricted otherwise), requests of unknown domains are
+// rejected.
+const (
+	DomainConsensus        = "consensus"
+	DomainConsensusMessage = "consensusmessage"
+	DomainStateRoot        = "stateroot"
+	DomainStateRootMessage = "staterootmessage"
+	DomainNotary           = "notary"
+	DomainOracle           = "oracle"
+)
+
+// knownDomains contains all known domains, the value is true for domains
+// that are checked for double signing (by the height or by the payload).
+var knownDomains = map[string]bool{
+	DomainConsensus:        true,
+	DomainConsensusMessage: true,
+	DomainStateRoot:        true,
+	DomainStateRootMessage: true,
+	DomainNotary:           false,
+	DomainOracle:           false,
+}
+
+// messageCategories contains payload categories allowed for message domains.
+var messageCategories = map[string]string{
+	DomainConsensusMessage: payload.ConsensusCategory,
+	DomainStateRootMessage: "StateService",
+}
+
+var (
+	// ErrDoubleSign is returned when the signer is asked to sign different
+	// data for the same height and view it has already signed something for
+	// (or for the height that is too old to be checked) and when the request
+	// can't be checked for double signing according to its domain.
+	ErrDoubleSign = errors.New("double signing attempt")
+	// ErrUnknownKey is returned when the signer has no private key for the
+	// requested public key.
+	ErrUnknownKey = errors.New("unknown key")
+	// ErrDomainNotAllowed is returned when the request domain is unknown or
+	// the requested key is not allowed to be used for it.
+	ErrDomainNotAllowed = errors.New("domain not allowed")
+)
+
+// Signer signs hashes with the keys it holds.
+type Signer interface {
+	// PublicKeys returns the list of keys the signer can sign with.
+	PublicKeys() (keys.PublicKeys, error)
+	// Sign returns a signature of the request hash made with the requested key.
+	Sign(req Request) ([]byte, error)
+	// Close releases signer resources.
+	Close()
+}
+
+// Request is a signing request.
+type Request struct {
+	// PublicKey is the key to sign with.
+	PublicKey *keys.PublicKey `json:"publickey"`
+	// Hash is the hash to be signed (usually it's a network-dependent hash of
+	// some hashable item).
+	Hash util.Uint256 `json:"hash"`
+	// Domain is the name of the service requesting the signature.
+	Domain string `json:"domain"`
+	// Height and View identify the signing slot for protected requests.
+	Height uint32 `json:"height"`
+	View   byte   `json:"view"`
+	// Protected requests can't be signed twice for the same domain, height
+	// and view with different hashes.
+	Protected bool `json:"protected"`
+	// Network and Data are only used for message domain requests, Data is
+	// a serialized network payload (payload.Extensible) and Hash must be its
+	// hash for the given Network.
+	Network uint32 `json:"network,omitempty"`
+	Data    []byte `json:"data,omitempty"`
+}
+
+// New creates a signer for the given service configuration. It's a remote
+// signer if the socket is specified and a wallet-based one if the wallet
+// is (its keys are only allowed to be used for the given domains then, see
+// Local.AllowDomains). Nil signer is returned if neither is configured.
+func New(w config.Wallet, r config.RemoteSigner, domains ...string) (Signer, error) {
+	switch {
+	case len(r.Socket) != 0 && len(w.Path) != 0:
+		return nil, errors.New("both wallet and remote signer are configured")
+	case len(r.Socket) != 0:
+		return NewRemote(r)
+	case len(w.Path) != 0:
+		return NewWalletSigner(w, domains...)
+	default:
+		return nil, nil
+	}
+}
+
+// Account is a key of the Signer. It can be used by services in place of
+// wallet.Account.
+type Account struct {
+	signer Signer
+	pub    *keys.PublicKey
+}
+
+// NewAccount returns an account for the given key of the signer.
+func NewAccount(s Signer, pub *keys.PublicKey) *Account {
+	return &Account{signer: s, pub: pub}
+}
+
+// Find returns the index of the first key from the list the signer can sign
+// with along with the account for it. -1 and nil are returned if there is no
+// such key.
+func Find(s Signer, pubs keys.PublicKeys) (int, *Account, error) {
+	own, err := s.PublicKeys()
+	if err != nil {
+		return -1, nil, fmt.Errorf("failed to get signer keys: %w", err)
+	}
+	for i, pub := range pubs {
+		if own.Contains(pub) {
+			return i, NewAccount(s, pub), nil
+		}
+	}
+	return -1, nil, nil
+}
+
+// PublicKey returns the public key of the account.
+func (a *Account) PublicKey() *keys.PublicKey {
+	return a.pub
+}
+
+// ScriptHash returns the script hash of the account's verification script.
+func (a *Account) ScriptHash() util.Uint160 {
+	return a.pub.GetScriptHash()
+}
+
+// GetVerificationScript returns the standard signature verification script
+// of the account.
+func (a *Account) GetVerificationScript() []byte {
+	return a.pub.GetVerificationScript()
+}
+
+// Sign signs the request with the account key, the key of the request is
+// set by this method.
+func (a *Account) Sign(req Request) ([]byte, error) {
+	req.PublicKey = a.pub
+	return a.signer.Sign(req)
+}
+
+// SignHashable signs the hashable item for the given network without any
+// double-signing protection.
+func (a *Account) SignHashable(net uint32, hh hash.Hashable, domain string) ([]byte, error) {
+	return a.Sign(Request{Hash: hash.NetSha256(net, hh), Domain: domain})
+}
+
+// SignExtensible signs the network payload for the given network with a
+// message domain request (like DomainConsensusMessage). The payload is
+// passed to the signer, so it must not be changed after its hash is
+// calculated.
+func (a *Account) SignExtensible(net uint32, ep *payload.Extensible, domain string) ([]byte, error) {
+	buf := io.NewBufBinWriter()
+	ep.EncodeBinary(buf.BinWriter)
+	if buf.Err != nil {
+		return nil, buf.Err
+	}
+	return a.Sign(Request{
+		Hash:    hash.NetSha256(net, ep),
+		Domain:  domain,
+		Network: net,
+		Data:    buf.Bytes(),
+	})
+}
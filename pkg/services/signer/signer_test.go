@@ -0,0 +1,240 @@
+package signer
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/internal/random"
+	"github.com/nspcc-dev/neo-go/internal/testserdes"
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/network/payload"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestKeys(t *testing.T, n int) []*keys.PrivateKey {
+	privs := make([]*keys.PrivateKey, n)
+	for i := range privs {
+		p, err := keys.NewPrivateKey()
+		require.NoError(t, err)
+		privs[i] = p
+	}
+	return privs
+}
+
+// newTestWallet creates a wallet with the given keys, every key is encrypted
+// with the corresponding password.
+func newTestWallet(t *testing.T, privs []*keys.PrivateKey, passwords []string) string {
+	path := filepath.Join(t.TempDir(), "wallet.json")
+	w, err := wallet.NewWallet(path)
+	require.NoError(t, err)
+	w.Scrypt = keys.ScryptParams{N: 2, R: 1, P: 1}
+	for i, p := range privs {
+		acc := wallet.NewAccountFromPrivateKey(p)
+		require.NoError(t, acc.Encrypt(passwords[i], w.Scrypt))
+		w.AddAccount(acc)
+	}
+	require.NoError(t, w.Save())
+	return path
+}
+
+func checkSignature(t *testing.T, req Request, sig []byte) {
+	require.True(t, req.PublicKey.Verify(sig, req.Hash.BytesBE()))
+}
+
+func TestNew(t *testing.T) {
+	privs := newTestKeys(t, 3)
+	path := newTestWallet(t, privs, []string{"one", "two", "one"})
+
+	s, err := New(config.Wallet{}, config.RemoteSigner{})
+	require.NoError(t, err)
+	require.Nil(t, s)
+
+	_, err = New(config.Wallet{Path: path}, config.RemoteSigner{Socket: "signer.sock"})
+	require.Error(t, err)
+
+	_, err = New(config.Wallet{Path: path, Password: "three"}, config.RemoteSigner{})
+	require.Error(t, err)
+
+	_, err = New(config.Wallet{}, config.RemoteSigner{Socket: filepath.Join(t.TempDir(), "none.sock")})
+	require.Error(t, err)
+
+	s, err = New(config.Wallet{Path: path, Password: "one"}, config.RemoteSigner{})
+	require.NoError(t, err)
+	t.Cleanup(s.Close)
+	pubs, err := s.PublicKeys()
+	require.NoError(t, err)
+	require.Equal(t, keys.PublicKeys{privs[0].PublicKey(), privs[2].PublicKey()}, pubs)
+	require.Equal(t, privs[2], s.(*Local).PrivateKey(privs[2].PublicKey()))
+	require.Nil(t, s.(*Local).PrivateKey(privs[1].PublicKey()))
+
+	_, err = New(config.Wallet{Path: path, Password: "one"}, config.RemoteSigner{}, "unknown")
+	require.ErrorIs(t, err, ErrDomainNotAllowed)
+	s, err = New(config.Wallet{Path: path, Password: "one"}, config.RemoteSigner{}, DomainNotary)
+	require.NoError(t, err)
+	t.Cleanup(s.Close)
+	_, err = s.Sign(Request{PublicKey: privs[0].PublicKey(), Hash: random.Uint256(), Domain: DomainNotary})
+	require.NoError(t, err)
+	_, err = s.Sign(Request{PublicKey: privs[2].PublicKey(), Hash: random.Uint256(), Domain: DomainConsensus, Height: 1, Protected: true})
+	require.ErrorIs(t, err, ErrDomainNotAllowed)
+}
+
+func TestLocal(t *testing.T) {
+	privs := newTestKeys(t, 3)
+	s := NewLocal(privs[0], privs[1], privs[0])
+
+	pubs, err := s.PublicKeys()
+	require.NoError(t, err)
+	require.Equal(t, keys.PublicKeys{privs[0].PublicKey(), privs[1].PublicKey()}, pubs)
+
+	_, err = s.Sign(Request{Hash: random.Uint256()})
+	require.ErrorIs(t, err, ErrUnknownKey)
+	_, err = s.Sign(Request{PublicKey: privs[2].PublicKey(), Hash: random.Uint256()})
+	require.ErrorIs(t, err, ErrUnknownKey)
+
+	t.Run("find", func(t *testing.T) {
+		i, acc, err := Find(s, keys.PublicKeys{privs[2].PublicKey(), privs[1].PublicKey(), privs[0].PublicKey()})
+		require.NoError(t, err)
+		require.Equal(t, 1, i)
+		require.Equal(t, privs[1].PublicKey(), acc.PublicKey())
+		require.Equal(t, privs[1].GetScriptHash(), acc.ScriptHash())
+		require.Equal(t, privs[1].PublicKey().GetVerificationScript(), acc.GetVerificationScript())
+
+		h := random.Uint256()
+		sig, err := acc.Sign(Request{Hash: h, Domain: DomainConsensus, Height: 1, Protected: true})
+		require.NoError(t, err)
+		checkSignature(t, Request{PublicKey: privs[1].PublicKey(), Hash: h}, sig)
+
+		i, acc, err = Find(s, keys.PublicKeys{privs[2].PublicKey()})
+		require.NoError(t, err)
+		require.Equal(t, -1, i)
+		require.Nil(t, acc)
+	})
+	t.Run("double sign", func(t *testing.T) {
+		req := Request{PublicKey: privs[0].PublicKey(), Hash: random.Uint256(), Domain: DomainConsensus, Height: 5, View: 1, Protected: true}
+		sig, err := s.Sign(req)
+		require.NoError(t, err)
+		checkSignature(t, req, sig)
+
+		// The same data can be signed again.
+		_, err = s.Sign(req)
+		require.NoError(t, err)
+
+		other := req
+		other.Hash = random.Uint256()
+		_, err = s.Sign(other)
+		require.ErrorIs(t, err, ErrDoubleSign)
+
+		// Unprotected requests are not allowed for consensus domain.
+		other.Protected = false
+		_, err = s.Sign(other)
+		require.ErrorIs(t, err, ErrDoubleSign)
+
+		// Other views, domains and keys are fine.
+		other.Protected = true
+		other.View = 2
+		_, err = s.Sign(other)
+		require.NoError(t, err)
+		other.Domain = DomainStateRoot
+		other.View = 1
+		_, err = s.Sign(other)
+		require.NoError(t, err)
+		other.Domain = DomainConsensus
+		other.PublicKey = privs[1].PublicKey()
+		_, err = s.Sign(other)
+		require.NoError(t, err)
+	})
+	t.Run("domains", func(t *testing.T) {
+		pub := privs[0].PublicKey()
+		for _, req := range []Request{
+			{PublicKey: pub, Hash: random.Uint256(), Domain: DomainConsensus, Height: 10},
+			{PublicKey: pub, Hash: random.Uint256(), Domain: DomainConsensus, Protected: true},
+			{PublicKey: pub, Hash: random.Uint256(), Domain: DomainStateRoot, Height: 10},
+		} {
+			_, err := s.Sign(req)
+			require.ErrorIs(t, err, ErrDoubleSign)
+		}
+
+		_, err := s.Sign(Request{PublicKey: pub, Hash: random.Uint256(), Domain: "unknown"})
+		require.ErrorIs(t, err, ErrDomainNotAllowed)
+
+		// A block signed for some height can't be signed again with another
+		// domain.
+		block := Request{PublicKey: pub, Hash: random.Uint256(), Domain: DomainConsensus, Height: 20, Protected: true}
+		_, err = s.Sign(block)
+		require.NoError(t, err)
+		for _, d := range []string{DomainNotary, DomainOracle} {
+			other := block
+			other.Hash = random.Uint256()
+			other.Domain = d
+			_, err = s.Sign(other)
+			require.ErrorIs(t, err, ErrDoubleSign)
+			other.Protected = false
+			_, err = s.Sign(other)
+			require.ErrorIs(t, err, ErrDoubleSign)
+		}
+	})
+	t.Run("allowed domains", func(t *testing.T) {
+		s := NewLocal(privs[0], privs[1])
+		cons, notary := privs[0].PublicKey(), privs[1].PublicKey()
+		require.ErrorIs(t, s.AllowDomains(cons, "unknown"), ErrDomainNotAllowed)
+		require.ErrorIs(t, s.AllowDomains(cons, DomainConsensus, DomainNotary), ErrDomainNotAllowed)
+		require.ErrorIs(t, s.AllowDomains(privs[2].PublicKey(), DomainNotary), ErrUnknownKey)
+		require.NoError(t, s.AllowDomains(cons, DomainConsensus, DomainConsensusMessage))
+		require.NoError(t, s.AllowDomains(notary, DomainNotary))
+
+		_, err := s.Sign(Request{PublicKey: cons, Hash: random.Uint256(), Domain: DomainConsensus, Height: 1, Protected: true})
+		require.NoError(t, err)
+		_, err = s.Sign(Request{PublicKey: cons, Hash: random.Uint256(), Domain: DomainStateRoot, Height: 1, Protected: true})
+		require.ErrorIs(t, err, ErrDomainNotAllowed)
+		_, err = s.Sign(Request{PublicKey: cons, Hash: random.Uint256(), Domain: DomainNotary})
+		require.ErrorIs(t, err, ErrDoubleSign)
+
+		_, err = s.Sign(Request{PublicKey: notary, Hash: random.Uint256(), Domain: DomainNotary})
+		require.NoError(t, err)
+		_, err = s.Sign(Request{PublicKey: notary, Hash: random.Uint256(), Domain: DomainOracle})
+		require.ErrorIs(t, err, ErrDomainNotAllowed)
+		_, err = s.Sign(Request{PublicKey: notary, Hash: random.Uint256(), Domain: DomainConsensus, Height: 1, Protected: true})
+		require.ErrorIs(t, err, ErrDomainNotAllowed)
+	})
+	t.Run("consensus message", func(t *testing.T) {
+		const net = 42
+		ep := &payload.Extensible{Category: payload.ConsensusCategory, ValidBlockEnd: 10, Data: []byte{1, 2, 3}}
+		data, err := testserdes.EncodeBinary(ep)
+		require.NoError(t, err)
+		req := Request{
+			PublicKey: privs[0].PublicKey(),
+			Hash:      hash.NetSha256(net, ep),
+			Domain:    DomainConsensusMessage,
+			Network:   net,
+			Data:      data,
+		}
+		sig, err := s.Sign(req)
+		require.NoError(t, err)
+		checkSignature(t, req, sig)
+
+		// Messages can't be used to sign anything else.
+		bad := req
+		bad.Hash = random.Uint256()
+		_, err = s.Sign(bad)
+		require.ErrorIs(t, err, ErrDoubleSign)
+		bad = req
+		bad.Network++
+		_, err = s.Sign(bad)
+		require.ErrorIs(t, err, ErrDoubleSign)
+		bad = req
+		bad.Data = nil
+		_, err = s.Sign(bad)
+		require.ErrorIs(t, err, ErrDoubleSign)
+
+		acc := NewAccount(s, privs[0].PublicKey())
+		ep = &payload.Extensible{Category: "StateService", ValidBlockEnd: 10, Data: []byte{1, 2, 3}}
+		_, err = acc.SignExtensible(net, ep, DomainConsensusMessage)
+		require.ErrorIs(t, err, ErrDoubleSign)
+		sig, err = acc.SignExtensible(net, ep, DomainStateRootMessage)
+		require.NoError(t, err)
+		checkSignature(t, Request{PublicKey: privs[0].PublicKey(), Hash: hash.NetSha256(net, ep)}, sig)
+	})
+}
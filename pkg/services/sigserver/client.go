@@ -0,0 +1,125 @@
+package sigserver
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	sccontext "github.com/nspcc-dev/neo-go/pkg/smartcontract/context"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// Client is a signing server client.
+type Client struct {
+	ctx      context.Context
+	endpoint string
+	token    string
+	cli      *http.Client
+}
+
+// NewClient creates a client for the signing server available at the given
+// endpoint (like "http://localhost:10340"). The context is used for all
+// requests made by the client, the token is sent with them if it's not empty
+// (see Config.Token).
+func NewClient(ctx context.Context, endpoint string, token string) *Client {
+	return &Client{
+		ctx:      ctx,
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		token:    token,
+		cli:      &http.Client{},
+	}
+}
+
+// Submit sends the context to the server adding it or merging its signatures
+// into the one stored. It returns the resulting context state.
+func (c *Client) Submit(pc *sccontext.ParameterContext) (*ContextState, error) {
+	data, err := json.Marshal(pc)
+	if err != nil {
+		return nil, err
+	}
+	res := new(ContextState)
+	return res, c.do(http.MethodPost, "/contexts", bytes.NewReader(data), res)
+}
+
+// GetContext returns the context with the given verifiable hash.
+func (c *Client) GetContext(h util.Uint256) (*sccontext.ParameterContext, error) {
+	res := new(sccontext.ParameterContext)
+	return res, c.do(http.MethodGet, "/contexts/"+h.StringLE(), nil, res)
+}
+
+// GetState returns the state of the context with the given verifiable hash.
+func (c *Client) GetState(h util.Uint256) (*ContextState, error) {
+	res := new(ContextState)
+	return res, c.do(http.MethodGet, "/contexts/"+h.StringLE()+"/state", nil, res)
+}
+
+// Delete removes the context with the given verifiable hash from the server,
+// it returns the state of the context removed.
+func (c *Client) Delete(h util.Uint256) (*ContextState, error) {
+	res := new(ContextState)
+	return res, c.do(http.MethodDelete, "/contexts/"+h.StringLE(), nil, res)
+}
+
+// List returns states of all contexts stored on the server.
+func (c *Client) List() ([]ContextState, error) {
+	var res []ContextState
+	return res, c.do(http.MethodGet, "/contexts", nil, &res)
+}
+
+// GetTransaction returns the completed transaction with the given hash, it
+// fails if there are not enough signatures for it.
+func (c *Client) GetTransaction(h util.Uint256) (*transaction.Transaction, error) {
+	var data []byte
+	err := c.do(http.MethodGet, "/contexts/"+h.StringLE()+"/transaction", nil, &data)
+	if err != nil {
+		return nil, err
+	}
+	return transaction.NewTransactionFromBytes(data)
+}
+
+// do performs the request decoding the response into res (which is filled
+// with raw response data if it's a *[]byte).
+func (c *Client) do(method string, path string, body io.Reader, res any) error {
+	req, err := http.NewRequestWithContext(c.ctx, method, c.endpoint+path, body)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", authPrefix+c.token)
+	}
+	resp, err := c.cli.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxRequestSize))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		var e errorResponse
+		if json.Unmarshal(data, &e) != nil || e.Error == "" {
+			return fmt.Errorf("signing server: %s", resp.Status)
+		}
+		if resp.StatusCode == http.StatusNotFound && e.Error == ErrUnknownContext.Error() {
+			return ErrUnknownContext
+		}
+		return fmt.Errorf("signing server: %s", e.Error)
+	}
+	if b, ok := res.(*[]byte); ok {
+		*b = data
+		return nil
+	}
+	if err := json.Unmarshal(data, res); err != nil {
+		return fmt.Errorf("invalid response: %w", err)
+	}
+	return nil
+}
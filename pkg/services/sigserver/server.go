@@ -0,0 +1,517 @@
+/*
+Package sigserver implements a ParameterContext coordination server. It stores
+partially signed transactions (as context.ParameterContext) and allows signers
+to fetch them, add their signatures and download completed transactions via a
+simple HTTP API, so that contexts don't have to be passed around as files.
+
+The API is:
+
+	GET    /contexts                    list of ContextState for all contexts
+	POST   /contexts                    add a context (or merge its signatures)
+	GET    /contexts/<hash>             context with the given verifiable hash
+	POST   /contexts/<hash>             merge signatures of the given context
+	DELETE /contexts/<hash>             remove the context (its ContextState is returned)
+	GET    /contexts/<hash>/state       ContextState of the context
+	GET    /contexts/<hash>/transaction completed transaction (binary)
+
+Errors are returned with an appropriate HTTP status and {"error": "..."} JSON
+body. Signatures are only accepted for transaction signers using standard
+signature or multisignature verification scripts, every one of them is
+verified before being stored. New contexts must contain at least one valid
+signature.
+
+If Config.Token is set every request must have "Authorization: Bearer <token>"
+header, requests without it are rejected with 401 status. Contexts can only be
+removed with DELETE requests if the token is set.
+
+Contexts of completed transactions are removed after Config.CompletedTTL,
+contexts of transactions that can't be accepted by the network anymore (with
+ValidUntilBlock not greater than the current height) are removed if
+Config.Height is set.
+*/
+package sigserver
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	sccontext "github.com/nspcc-dev/neo-go/pkg/smartcontract/context"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"go.uber.org/zap"
+)
+
+const (
+	// DefaultMaxContexts is the default limit on the number of contexts
+	// stored.
+	DefaultMaxContexts = 1024
+	// DefaultCompletedTTL is the default time completed contexts are kept
+	// for.
+	DefaultCompletedTTL = time.Hour
+	// cleanupInterval is the interval between stale contexts removals done
+	// by the server started with Start.
+	cleanupInterval = time.Minute
+	// maxRequestSize is the maximum size of the request body, it's enough for
+	// the maximum-sized transaction with a lot of signatures.
+	maxRequestSize = 1 << 20
+	// readHeaderTimeout is the time allowed to read request headers.
+	readHeaderTimeout = 5 * time.Second
+	// authPrefix is the Authorization header value prefix preceding the
+	// token.
+	authPrefix = "Bearer "
+)
+
+type (
+	// Config is the signing server configuration.
+	Config struct {
+		// Address is the address to listen on.
+		Address string
+		// Network restricts contexts to the given network, any network is
+		// accepted if it's zero.
+		Network netmode.Magic
+		// Dir is the directory to keep contexts in, they're only kept in
+		// memory if it's empty.
+		Dir string
+		// MaxContexts is the maximum number of contexts stored,
+		// DefaultMaxContexts is used if it's zero.
+		MaxContexts int
+		// CompletedTTL is the time contexts are kept for after they become
+		// complete (so that the transaction can be fetched by all signers),
+		// DefaultCompletedTTL is used if it's zero.
+		CompletedTTL time.Duration
+		// Height returns the current chain height, contexts of transactions
+		// with ValidUntilBlock not greater than it are removed. Contexts
+		// don't expire if it's not set.
+		Height func() (uint32, error)
+		// Token is a shared secret clients must provide to access the
+		// server, any client is allowed if it's empty. Contexts can't be
+		// removed via the API without it.
+		Token string
+	}
+
+	// Server is a ParameterContext coordination server. It implements
+	// http.Handler, so it can be used with any HTTP server (httptest.Server
+	// for example) in addition to the one controlled via Start/Shutdown.
+	Server struct {
+		config Config
+		log    *zap.Logger
+
+		lock     sync.RWMutex
+		contexts map[util.Uint256]*sccontext.ParameterContext
+		// completed contains the time contexts became complete at.
+		completed map[util.Uint256]time.Time
+		store     *dirStore
+
+		http *http.Server
+		quit chan struct{}
+	}
+
+	errorResponse struct {
+		Error string `json:"error"`
+	}
+
+	httpError struct {
+		code int
+		err  error
+	}
+)
+
+func (e *httpError) Error() string { return e.err.Error() }
+
+func (e *httpError) Unwrap() error { return e.err }
+
+// New creates a new signing server with the given configuration loading all
+// contexts stored in the configured directory (if any).
+func New(cfg Config, log *zap.Logger) (*Server, error) {
+	if cfg.MaxContexts == 0 {
+		cfg.MaxContexts = DefaultMaxContexts
+	}
+	if cfg.CompletedTTL == 0 {
+		cfg.CompletedTTL = DefaultCompletedTTL
+	}
+	s := &Server{
+		config:    cfg,
+		log:       log,
+		contexts:  make(map[util.Uint256]*sccontext.ParameterContext),
+		completed: make(map[util.Uint256]time.Time),
+	}
+	if cfg.Dir != "" {
+		s.store = &dirStore{dir: cfg.Dir}
+		pcs, err := s.store.load()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load contexts: %w", err)
+		}
+		now := time.Now()
+		for _, pc := range pcs {
+			h := pc.Verifiable.Hash()
+			s.contexts[h] = pc
+			if NewContextState(pc).Complete {
+				s.completed[h] = now
+			}
+		}
+		log.Info("contexts loaded", zap.Int("count", len(pcs)))
+	}
+	return s, nil
+}
+
+// Start starts listening on the configured address.
+func (s *Server) Start() error {
+	ln, err := net.Listen("tcp", s.config.Address)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", s.config.Address, err)
+	}
+	s.http = &http.Server{
+		Addr:              ln.Addr().String(), // Actual address.
+		Handler:           s,
+		ReadHeaderTimeout: readHeaderTimeout,
+	}
+	s.quit = make(chan struct{})
+	s.log.Info("signing server started", zap.String("endpoint", s.http.Addr))
+	go func() {
+		err := s.http.Serve(ln)
+		if !errors.Is(err, http.ErrServerClosed) {
+			s.log.Error("failed to serve", zap.Error(err))
+		}
+	}()
+	go s.cleanupLoop()
+	return nil
+}
+
+func (s *Server) cleanupLoop() {
+	t := time.NewTicker(cleanupInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-s.quit:
+			return
+		case <-t.C:
+			s.removeStale()
+		}
+	}
+}
+
+// Addr returns the address the server listens on after Start.
+func (s *Server) Addr() string {
+	if s.http == nil {
+		return ""
+	}
+	return s.http.Addr
+}
+
+// Shutdown stops the server started with Start.
+func (s *Server) Shutdown() {
+	if s.http == nil {
+		return
+	}
+	close(s.quit)
+	err := s.http.Shutdown(context.Background())
+	if err != nil {
+		s.log.Error("can't shut signing server down", zap.Error(err))
+	}
+	s.log.Info("signing server stopped")
+}
+
+// ServeHTTP implements http.Handler interface.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !s.authorized(r) {
+		writeError(w, &httpError{http.StatusUnauthorized, errors.New("invalid or missing token")})
+		return
+	}
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if parts[0] != "contexts" || len(parts) > 3 {
+		writeError(w, &httpError{http.StatusNotFound, errors.New("not found")})
+		return
+	}
+	var (
+		h   util.Uint256
+		err error
+	)
+	if len(parts) > 1 {
+		h, err = util.Uint256DecodeStringLE(strings.TrimPrefix(parts[1], "0x"))
+		if err != nil {
+			writeError(w, &httpError{http.StatusBadRequest, fmt.Errorf("invalid hash: %w", err)})
+			return
+		}
+	}
+	var res any
+	switch {
+	case len(parts) == 1 && r.Method == http.MethodGet:
+		res = s.list()
+	case len(parts) <= 2 && r.Method == http.MethodPost:
+		var hashPtr *util.Uint256
+		if len(parts) == 2 {
+			hashPtr = &h
+		}
+		res, err = s.submit(r.Body, hashPtr)
+	case len(parts) == 2 && r.Method == http.MethodGet:
+		res, err = s.getContext(h)
+	case len(parts) == 2 && r.Method == http.MethodDelete:
+		res, err = s.remove(h)
+	case len(parts) == 3 && parts[2] == "state" && r.Method == http.MethodGet:
+		res, err = s.getState(h)
+	case len(parts) == 3 && parts[2] == "transaction" && r.Method == http.MethodGet:
+		var b []byte
+		b, err = s.getTransaction(h)
+		if err == nil {
+			w.Header().Set("Content-Type", "application/octet-stream")
+			_, _ = w.Write(b)
+			return
+		}
+	case len(parts) == 3 && parts[2] != "state" && parts[2] != "transaction":
+		err = &httpError{http.StatusNotFound, errors.New("not found")}
+	default:
+		err = &httpError{http.StatusMethodNotAllowed, errors.New("method not allowed")}
+	}
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(res)
+}
+
+// authorized checks the request token if it's required.
+func (s *Server) authorized(r *http.Request) bool {
+	if s.config.Token == "" {
+		return true
+	}
+	auth := r.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, authPrefix) {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(strings.TrimPrefix(auth, authPrefix)), []byte(s.config.Token)) == 1
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	code := http.StatusInternalServerError
+	var herr *httpError
+	if errors.As(err, &herr) {
+		code = herr.code
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(errorResponse{Error: err.Error()})
+}
+
+func (s *Server) list() []ContextState {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	res := make([]ContextState, 0, len(s.contexts))
+	for _, pc := range s.contexts {
+		res = append(res, *NewContextState(pc))
+	}
+	sortStates(res)
+	return res
+}
+
+func (s *Server) getContext(h util.Uint256) (*sccontext.ParameterContext, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	pc, ok := s.contexts[h]
+	if !ok {
+		return nil, &httpError{http.StatusNotFound, ErrUnknownContext}
+	}
+	// Marshaling is performed after the lock is released, so a copy is needed.
+	return copyContext(pc)
+}
+
+func (s *Server) getState(h util.Uint256) (*ContextState, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	pc, ok := s.contexts[h]
+	if !ok {
+		return nil, &httpError{http.StatusNotFound, ErrUnknownContext}
+	}
+	return NewContextState(pc), nil
+}
+
+func (s *Server) getTransaction(h util.Uint256) ([]byte, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	pc, ok := s.contexts[h]
+	if !ok {
+		return nil, &httpError{http.StatusNotFound, ErrUnknownContext}
+	}
+	tx, err := completeTransaction(pc)
+	if err != nil {
+		return nil, &httpError{http.StatusConflict, err}
+	}
+	return tx.Bytes(), nil
+}
+
+// submit adds the context or merges its signatures into the stored one.
+func (s *Server) submit(body io.Reader, h *util.Uint256) (*ContextState, error) {
+	data, err := io.ReadAll(io.LimitReader(body, maxRequestSize+1))
+	if err != nil {
+		return nil, &httpError{http.StatusBadRequest, err}
+	}
+	if len(data) > maxRequestSize {
+		return nil, &httpError{http.StatusRequestEntityTooLarge, errors.New("request is too big")}
+	}
+	pc := new(sccontext.ParameterContext)
+	if err := json.Unmarshal(data, pc); err != nil {
+		return nil, &httpError{http.StatusBadRequest, fmt.Errorf("invalid context: %w", err)}
+	}
+	if s.config.Network != 0 && pc.Network != s.config.Network {
+		return nil, &httpError{http.StatusBadRequest, fmt.Errorf("unexpected network %d", pc.Network)}
+	}
+	hash := pc.Verifiable.Hash()
+	if h != nil && !h.Equals(hash) {
+		return nil, &httpError{http.StatusBadRequest, errors.New("context hash mismatch")}
+	}
+	sigs, err := collectSignatures(pc)
+	if err != nil {
+		return nil, &httpError{http.StatusBadRequest, err}
+	}
+
+	if h == nil && s.isFull(hash) {
+		s.removeStale()
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	stored, ok := s.contexts[hash]
+	if !ok {
+		if h != nil {
+			return nil, &httpError{http.StatusNotFound, ErrUnknownContext}
+		}
+		if len(s.contexts) >= s.config.MaxContexts {
+			return nil, &httpError{http.StatusInsufficientStorage, errors.New("too many contexts")}
+		}
+		stored = sccontext.NewParameterContext(pc.Type, pc.Network, pc.Verifiable)
+		// Keep items of deployed contracts, they can't be signed.
+		for acc, item := range pc.Items {
+			if item.Script == nil {
+				stored.Items[acc] = item
+			}
+		}
+	}
+	if stored.Network != pc.Network {
+		return nil, &httpError{http.StatusBadRequest, errors.New("network mismatch")}
+	}
+	var added int
+	for _, sig := range sigs {
+		ok, err := sig.addTo(stored)
+		if err != nil {
+			return nil, &httpError{http.StatusBadRequest, err}
+		}
+		if ok {
+			added++
+		}
+	}
+	// Unsigned contexts can't be checked in any way, so they're only
+	// accepted for the existing contexts.
+	if !ok && added == 0 {
+		return nil, &httpError{http.StatusBadRequest, errors.New("new context must have at least one signature")}
+	}
+	if s.store != nil && (!ok || added != 0) {
+		if err := s.store.save(stored); err != nil {
+			return nil, fmt.Errorf("failed to store context: %w", err)
+		}
+	}
+	if !ok {
+		s.contexts[hash] = stored
+		s.log.Info("context added", zap.String("hash", hash.StringLE()), zap.Int("signatures", added))
+	} else if added != 0 {
+		s.log.Info("signatures added", zap.String("hash", hash.StringLE()), zap.Int("signatures", added))
+	}
+	st := NewContextState(stored)
+	if _, done := s.completed[hash]; st.Complete && !done {
+		s.completed[hash] = time.Now()
+	}
+	return st, nil
+}
+
+// isFull checks whether there is no space for the new context with the given
+// hash.
+func (s *Server) isFull(h util.Uint256) bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	_, ok := s.contexts[h]
+	return !ok && len(s.contexts) >= s.config.MaxContexts
+}
+
+// remove removes the context with the given hash.
+func (s *Server) remove(h util.Uint256) (*ContextState, error) {
+	// Anyone can submit a context, so only token holders can remove them.
+	if s.config.Token == "" {
+		return nil, &httpError{http.StatusForbidden, errors.New("contexts can only be removed if the server token is set")}
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	pc, ok := s.contexts[h]
+	if !ok {
+		return nil, &httpError{http.StatusNotFound, ErrUnknownContext}
+	}
+	if err := s.removeContext(h); err != nil {
+		return nil, fmt.Errorf("failed to remove context: %w", err)
+	}
+	s.log.Info("context removed", zap.String("hash", h.StringLE()))
+	return NewContextState(pc), nil
+}
+
+// removeStale removes contexts completed more than CompletedTTL ago and
+// contexts of expired transactions.
+func (s *Server) removeStale() {
+	var (
+		height    uint32
+		heightErr = errors.New("height is unknown")
+	)
+	if s.config.Height != nil {
+		height, heightErr = s.config.Height()
+		if heightErr != nil {
+			s.log.Warn("can't get chain height", zap.Error(heightErr))
+		}
+	}
+	deadline := time.Now().Add(-s.config.CompletedTTL)
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for h, pc := range s.contexts {
+		var reason string
+		if t, ok := s.completed[h]; ok && t.Before(deadline) {
+			reason = "completed"
+		} else if tx, ok := pc.Verifiable.(*transaction.Transaction); ok && heightErr == nil && tx.ValidUntilBlock <= height {
+			reason = "expired"
+		} else {
+			continue
+		}
+		if err := s.removeContext(h); err != nil {
+			s.log.Warn("failed to remove context", zap.String("hash", h.StringLE()), zap.Error(err))
+			continue
+		}
+		s.log.Info("context removed", zap.String("hash", h.StringLE()), zap.String("reason", reason))
+	}
+}
+
+// removeContext removes the context from the storage and from memory, it
+// must be called with the lock held.
+func (s *Server) removeContext(h util.Uint256) error {
+	if s.store != nil {
+		if err := s.store.remove(h); err != nil {
+			return err
+		}
+	}
+	delete(s.contexts, h)
+	delete(s.completed, h)
+	return nil
+}
+
+func copyContext(pc *sccontext.ParameterContext) (*sccontext.ParameterContext, error) {
+	data, err := json.Marshal(pc)
+	if err != nil {
+		return nil, err
+	}
+	res := new(sccontext.ParameterContext)
+	return res, json.Unmarshal(data, res)
+}
@@ -0,0 +1,314 @@
+package sigserver
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	sccontext "github.com/nspcc-dev/neo-go/pkg/smartcontract/context"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+const testNet = netmode.UnitTestNet
+
+type testSigners struct {
+	simple *wallet.Account
+	multi  []*wallet.Account
+	tx     *transaction.Transaction
+}
+
+func newTestSigners(t *testing.T) *testSigners {
+	simple, err := wallet.NewAccount()
+	require.NoError(t, err)
+	multi := make([]*wallet.Account, 3)
+	pubs := make(keys.PublicKeys, len(multi))
+	for i := range multi {
+		multi[i], err = wallet.NewAccount()
+		require.NoError(t, err)
+		pubs[i] = multi[i].PublicKey()
+	}
+	for i := range multi {
+		require.NoError(t, multi[i].ConvertMultisig(2, pubs))
+	}
+	tx := transaction.New([]byte{1, 2, 3}, 100)
+	tx.ValidUntilBlock = 123
+	tx.Signers = []transaction.Signer{
+		{Account: simple.ScriptHash(), Scopes: transaction.CalledByEntry},
+		{Account: multi[0].ScriptHash(), Scopes: transaction.Global},
+	}
+	return &testSigners{simple: simple, multi: multi, tx: tx}
+}
+
+// signedContext returns a context for the test transaction signed by the
+// given accounts.
+func (s *testSigners) signedContext(t *testing.T, accs ...*wallet.Account) *sccontext.ParameterContext {
+	pc := sccontext.NewParameterContext("Neo.Network.P2P.Payloads.Transaction", testNet, s.tx)
+	for _, acc := range accs {
+		require.NoError(t, pc.AddSignature(acc.ScriptHash(), acc.Contract, acc.PublicKey(), acc.SignHashable(testNet, s.tx)))
+	}
+	return pc
+}
+
+func newTestServer(t *testing.T, cfg Config) (*Server, *Client) {
+	srv, err := New(cfg, zaptest.NewLogger(t))
+	require.NoError(t, err)
+	ts := httptest.NewServer(srv)
+	t.Cleanup(ts.Close)
+	return srv, NewClient(context.Background(), ts.URL, cfg.Token)
+}
+
+func TestServer(t *testing.T) {
+	s := newTestSigners(t)
+	h := s.tx.Hash()
+	_, c := newTestServer(t, Config{Network: testNet, MaxContexts: 2})
+
+	_, err := c.GetContext(h)
+	require.ErrorIs(t, err, ErrUnknownContext)
+	_, err = c.GetState(h)
+	require.ErrorIs(t, err, ErrUnknownContext)
+
+	t.Run("wrong network", func(t *testing.T) {
+		pc := sccontext.NewParameterContext("Neo.Network.P2P.Payloads.Transaction", netmode.MainNet, s.tx)
+		_, err := c.Submit(pc)
+		require.Error(t, err)
+	})
+	t.Run("not a signer", func(t *testing.T) {
+		other, err := wallet.NewAccount()
+		require.NoError(t, err)
+		pc := s.signedContext(t, other)
+		_, err = c.Submit(pc)
+		require.Error(t, err)
+	})
+	t.Run("invalid signature", func(t *testing.T) {
+		pc := s.signedContext(t)
+		sig := s.multi[1].SignHashable(netmode.MainNet, s.tx)
+		require.NoError(t, pc.AddSignature(s.multi[0].ScriptHash(), s.multi[0].Contract, s.multi[0].PublicKey(), sig))
+		_, err := c.Submit(pc)
+		require.Error(t, err)
+	})
+
+	t.Run("unsigned", func(t *testing.T) {
+		_, err := c.Submit(s.signedContext(t))
+		require.Error(t, err)
+		_, err = c.GetState(h)
+		require.ErrorIs(t, err, ErrUnknownContext)
+	})
+
+	st, err := c.Submit(s.signedContext(t, s.simple))
+	require.NoError(t, err)
+	require.Equal(t, h, st.Hash)
+	require.False(t, st.Complete)
+	require.Equal(t, 2, len(st.Signers))
+	require.True(t, st.Signers[0].Complete)
+	require.Equal(t, keys.PublicKeys{s.simple.PublicKey()}, st.Signers[0].Keys)
+	require.Equal(t, s.tx.Signers[1], st.Signers[1].Signer)
+	require.Equal(t, 0, st.Signers[1].Required) // No script is known yet.
+
+	_, err = c.GetTransaction(h)
+	require.Error(t, err)
+
+	// Unsigned context is fine for the existing one.
+	st, err = c.Submit(s.signedContext(t))
+	require.NoError(t, err)
+	require.False(t, st.Complete)
+
+	st, err = c.Submit(s.signedContext(t, s.simple, s.multi[2]))
+	require.NoError(t, err)
+	require.False(t, st.Complete)
+	require.True(t, st.Signers[0].Complete)
+	require.Equal(t, keys.PublicKeys{s.simple.PublicKey()}, st.Signers[0].Keys)
+	require.Equal(t, 2, st.Signers[1].Required)
+	require.Equal(t, keys.PublicKeys{s.multi[2].PublicKey()}, st.Signers[1].Keys)
+	require.False(t, st.Signers[1].Complete)
+
+	// Signatures already stored are fine to be sent again.
+	pc, err := c.GetContext(h)
+	require.NoError(t, err)
+	require.NoError(t, pc.AddSignature(s.multi[0].ScriptHash(), s.multi[0].Contract, s.multi[0].PublicKey(), s.multi[0].SignHashable(testNet, s.tx)))
+	st, err = c.Submit(pc)
+	require.NoError(t, err)
+	require.True(t, st.Complete)
+
+	list, err := c.List()
+	require.NoError(t, err)
+	require.Equal(t, []ContextState{*st}, list)
+
+	tx, err := c.GetTransaction(h)
+	require.NoError(t, err)
+	require.Equal(t, h, tx.Hash())
+	require.Equal(t, 2, len(tx.Scripts))
+	require.Equal(t, s.multi[0].Contract.Script, tx.Scripts[1].VerificationScript)
+
+	t.Run("limit", func(t *testing.T) {
+		s2 := newTestSigners(t)
+		_, err := c.Submit(s2.signedContext(t, s2.simple))
+		require.NoError(t, err)
+		s3 := newTestSigners(t)
+		_, err = c.Submit(s3.signedContext(t, s3.simple))
+		require.Error(t, err)
+	})
+}
+
+func TestServerHTTP(t *testing.T) {
+	const token = "secret"
+	s := newTestSigners(t)
+	srv, c := newTestServer(t, Config{Token: token})
+	_, err := c.Submit(s.signedContext(t, s.simple))
+	require.NoError(t, err)
+
+	checkAuth := func(auth string, method, path string, code int) {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(method, path, nil)
+		if auth != "" {
+			req.Header.Set("Authorization", auth)
+		}
+		srv.ServeHTTP(rec, req)
+		require.Equal(t, code, rec.Code, method+" "+path)
+	}
+	check := func(method, path string, code int) {
+		checkAuth("Bearer "+token, method, path, code)
+	}
+	h := s.tx.Hash().StringLE()
+	checkAuth("", http.MethodGet, "/contexts", http.StatusUnauthorized)
+	checkAuth(token, http.MethodGet, "/contexts", http.StatusUnauthorized)
+	checkAuth("Bearer other", http.MethodGet, "/contexts", http.StatusUnauthorized)
+	checkAuth("Bearer other", http.MethodDelete, "/contexts/"+h, http.StatusUnauthorized)
+	check(http.MethodGet, "/", http.StatusNotFound)
+	check(http.MethodGet, "/contexts/"+h+"/unknown", http.StatusNotFound)
+	check(http.MethodGet, "/contexts/"+util.Uint256{}.StringLE(), http.StatusNotFound)
+	check(http.MethodGet, "/contexts/notahash", http.StatusBadRequest)
+	check(http.MethodPut, "/contexts/"+h, http.StatusMethodNotAllowed)
+	check(http.MethodPost, "/contexts/"+h, http.StatusBadRequest)
+	check(http.MethodGet, "/contexts/0x"+h, http.StatusOK)
+	check(http.MethodGet, "/contexts/"+h+"/state", http.StatusOK)
+	check(http.MethodGet, "/contexts/"+h+"/transaction", http.StatusConflict)
+	check(http.MethodDelete, "/contexts/"+h, http.StatusOK)
+	check(http.MethodDelete, "/contexts/"+h, http.StatusNotFound)
+
+	_, err = NewClient(context.Background(), c.endpoint, "").List()
+	require.Error(t, err)
+}
+
+func TestServerRemove(t *testing.T) {
+	t.Run("delete without token", func(t *testing.T) {
+		s := newTestSigners(t)
+		_, c := newTestServer(t, Config{Network: testNet})
+		_, err := c.Submit(s.signedContext(t, s.simple))
+		require.NoError(t, err)
+		_, err = c.Delete(s.tx.Hash())
+		require.Error(t, err)
+		_, err = c.GetState(s.tx.Hash())
+		require.NoError(t, err)
+	})
+	t.Run("delete", func(t *testing.T) {
+		s := newTestSigners(t)
+		_, c := newTestServer(t, Config{Network: testNet, MaxContexts: 1, Token: "secret"})
+		_, err := c.Submit(s.signedContext(t, s.simple))
+		require.NoError(t, err)
+		s2 := newTestSigners(t)
+		_, err = c.Submit(s2.signedContext(t, s2.simple))
+		require.Error(t, err)
+
+		st, err := c.Delete(s.tx.Hash())
+		require.NoError(t, err)
+		require.Equal(t, s.tx.Hash(), st.Hash)
+		require.True(t, st.Signers[0].Complete)
+		_, err = c.Delete(s.tx.Hash())
+		require.ErrorIs(t, err, ErrUnknownContext)
+
+		_, err = c.Submit(s2.signedContext(t, s2.simple))
+		require.NoError(t, err)
+	})
+	t.Run("expired", func(t *testing.T) {
+		s := newTestSigners(t)
+		var height uint32
+		srv, c := newTestServer(t, Config{Network: testNet, MaxContexts: 1, Height: func() (uint32, error) {
+			return height, nil
+		}})
+		_, err := c.Submit(s.signedContext(t, s.simple))
+		require.NoError(t, err)
+		height = s.tx.ValidUntilBlock - 1
+		s2 := newTestSigners(t)
+		_, err = c.Submit(s2.signedContext(t, s2.simple))
+		require.Error(t, err)
+
+		height = s.tx.ValidUntilBlock
+		s3 := newTestSigners(t)
+		s3.tx.ValidUntilBlock = height + 1
+		_, err = c.Submit(s3.signedContext(t, s3.simple))
+		require.NoError(t, err)
+		_, err = c.GetState(s.tx.Hash())
+		require.ErrorIs(t, err, ErrUnknownContext)
+
+		height++
+		srv.removeStale()
+		list, err := c.List()
+		require.NoError(t, err)
+		require.Equal(t, 0, len(list))
+	})
+	t.Run("completed", func(t *testing.T) {
+		s := newTestSigners(t)
+		srv, c := newTestServer(t, Config{Network: testNet, CompletedTTL: time.Millisecond})
+		_, err := c.Submit(s.signedContext(t, s.simple))
+		require.NoError(t, err)
+		s2 := newTestSigners(t)
+		_, err = c.Submit(s2.signedContext(t, s2.simple, s2.multi[0], s2.multi[1]))
+		require.NoError(t, err)
+
+		time.Sleep(10 * time.Millisecond)
+		srv.removeStale()
+		list, err := c.List()
+		require.NoError(t, err)
+		require.Equal(t, 1, len(list))
+		require.Equal(t, s.tx.Hash(), list[0].Hash)
+	})
+	t.Run("persisted", func(t *testing.T) {
+		s := newTestSigners(t)
+		dir := t.TempDir()
+		_, c := newTestServer(t, Config{Dir: dir, Token: "secret"})
+		_, err := c.Submit(s.signedContext(t, s.simple))
+		require.NoError(t, err)
+		_, err = c.Delete(s.tx.Hash())
+		require.NoError(t, err)
+
+		_, c = newTestServer(t, Config{Dir: dir, Token: "secret"})
+		list, err := c.List()
+		require.NoError(t, err)
+		require.Equal(t, 0, len(list))
+	})
+}
+
+func TestServerPersistence(t *testing.T) {
+	s := newTestSigners(t)
+	dir := t.TempDir()
+
+	_, c := newTestServer(t, Config{Dir: dir})
+	_, err := c.Submit(s.signedContext(t, s.simple, s.multi[1]))
+	require.NoError(t, err)
+
+	_, c = newTestServer(t, Config{Dir: dir})
+	st, err := c.GetState(s.tx.Hash())
+	require.NoError(t, err)
+	require.True(t, st.Signers[0].Complete)
+	require.Equal(t, keys.PublicKeys{s.multi[1].PublicKey()}, st.Signers[1].Keys)
+}
+
+func TestServerStart(t *testing.T) {
+	srv, err := New(Config{Address: "localhost:0"}, zaptest.NewLogger(t))
+	require.NoError(t, err)
+	require.Equal(t, "", srv.Addr())
+	require.NoError(t, srv.Start())
+	t.Cleanup(srv.Shutdown)
+
+	list, err := NewClient(context.Background(), "http://"+srv.Addr(), "").List()
+	require.NoError(t, err)
+	require.Equal(t, 0, len(list))
+}
@@ -0,0 +1,221 @@
+package sigserver
+
+import (
+	"crypto/elliptic"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	sccontext "github.com/nspcc-dev/neo-go/pkg/smartcontract/context"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
+)
+
+// ErrUnknownContext is returned when there is no context with the given hash.
+var ErrUnknownContext = errors.New("unknown context")
+
+type (
+	// ContextState is a summary of the context stored.
+	ContextState struct {
+		Hash    util.Uint256  `json:"hash"`
+		Type    string        `json:"type"`
+		Network netmode.Magic `json:"network"`
+		// Complete is true when all witnesses of the transaction can be
+		// created.
+		Complete bool          `json:"complete"`
+		Signers  []SignerState `json:"signers"`
+	}
+
+	// SignerState is the state of a single transaction signer witness.
+	SignerState struct {
+		Signer transaction.Signer `json:"signer"`
+		// Required is the number of signatures required for the witness, it's
+		// zero for deployed contracts and unknown verification scripts.
+		Required int `json:"required"`
+		// Keys are the keys that have signed the transaction.
+		Keys keys.PublicKeys `json:"keys"`
+		// Complete is true when the witness can be created.
+		Complete bool `json:"complete"`
+	}
+
+	// signature is a verified signature of a context item.
+	signature struct {
+		account util.Uint160
+		script  []byte
+		pub     *keys.PublicKey
+		sig     []byte
+	}
+)
+
+// NewContextState creates a summary of the given context.
+func NewContextState(pc *sccontext.ParameterContext) *ContextState {
+	res := &ContextState{
+		Hash:     pc.Verifiable.Hash(),
+		Type:     pc.Type,
+		Network:  pc.Network,
+		Complete: true,
+		Signers:  []SignerState{},
+	}
+	tx, ok := pc.Verifiable.(*transaction.Transaction)
+	if !ok {
+		res.Complete = false
+		return res
+	}
+	for _, signer := range tx.Signers {
+		st := SignerState{Signer: signer, Keys: keys.PublicKeys{}}
+		if item, ok := pc.Items[signer.Account]; ok {
+			for _, s := range itemSignatures(item) {
+				st.Keys = append(st.Keys, s.pub)
+			}
+			if m, _, ok := vm.ParseMultiSigContract(item.Script); ok {
+				st.Required = m
+			} else if vm.IsSignatureContract(item.Script) {
+				st.Required = 1
+			}
+		}
+		_, err := pc.GetWitness(signer.Account)
+		st.Complete = err == nil
+		res.Complete = res.Complete && st.Complete
+		res.Signers = append(res.Signers, st)
+	}
+	return res
+}
+
+func sortStates(states []ContextState) {
+	sort.Slice(states, func(i, j int) bool {
+		return states[i].Hash.CompareTo(states[j].Hash) < 0
+	})
+}
+
+// completeTransaction returns a copy of the context transaction with all
+// witnesses filled in.
+func completeTransaction(pc *sccontext.ParameterContext) (*transaction.Transaction, error) {
+	tx, ok := pc.Verifiable.(*transaction.Transaction)
+	if !ok {
+		return nil, errors.New("verifiable item is not a transaction")
+	}
+	res := *tx
+	res.Scripts = make([]transaction.Witness, 0, len(tx.Signers))
+	for i := range tx.Signers {
+		w, err := pc.GetWitness(tx.Signers[i].Account)
+		if err != nil {
+			return nil, fmt.Errorf("can't create witness for signer #%d: %w", i, err)
+		}
+		res.Scripts = append(res.Scripts, *w)
+	}
+	return &res, nil
+}
+
+// collectSignatures returns all of the context signatures checking them. Only
+// transaction signers with signature or multisignature verification scripts
+// can be signed and signatures must be valid.
+func collectSignatures(pc *sccontext.ParameterContext) ([]signature, error) {
+	tx, ok := pc.Verifiable.(*transaction.Transaction)
+	if !ok {
+		return nil, errors.New("verifiable item is not a transaction")
+	}
+	var res []signature
+	for acc, item := range pc.Items {
+		var isSigner bool
+		for i := range tx.Signers {
+			if tx.Signers[i].Account.Equals(acc) {
+				isSigner = true
+				break
+			}
+		}
+		if !isSigner {
+			return nil, fmt.Errorf("%s is not a transaction signer", acc.StringLE())
+		}
+		if item.Script == nil {
+			for i := range item.Parameters {
+				if item.Parameters[i].Value != nil {
+					return nil, fmt.Errorf("can't check parameters for %s, only signatures are supported", acc.StringLE())
+				}
+			}
+			continue
+		}
+		if !hash.Hash160(item.Script).Equals(acc) {
+			return nil, fmt.Errorf("script doesn't match %s", acc.StringLE())
+		}
+		if !vm.IsSignatureContract(item.Script) && !vm.IsMultiSigContract(item.Script) {
+			return nil, fmt.Errorf("unsupported verification script for %s", acc.StringLE())
+		}
+		sigs := itemSignatures(item)
+		for _, s := range sigs {
+			if s.pub == nil {
+				return nil, fmt.Errorf("invalid signature item for %s", acc.StringLE())
+			}
+			if !s.pub.VerifyHashable(s.sig, uint32(pc.Network), pc.Verifiable) {
+				return nil, fmt.Errorf("invalid signature of %s for %s", hex.EncodeToString(s.pub.Bytes()), acc.StringLE())
+			}
+			s.account = acc
+			res = append(res, s)
+		}
+	}
+	return res, nil
+}
+
+// itemSignatures returns signatures found in the item, public key is nil for
+// the signatures that can't be attributed to the script keys.
+func itemSignatures(item *sccontext.Item) []signature {
+	var res []signature
+	if pub, ok := vm.ParseSignatureContract(item.Script); ok {
+		if len(item.Parameters) != 1 || item.Parameters[0].Value == nil {
+			return nil
+		}
+		sig, ok := item.Parameters[0].Value.([]byte)
+		k, err := keys.NewPublicKeyFromBytes(pub, elliptic.P256())
+		if !ok || err != nil {
+			return []signature{{script: item.Script}}
+		}
+		return []signature{{script: item.Script, pub: k, sig: sig}}
+	}
+	_, pubs, ok := vm.ParseMultiSigContract(item.Script)
+	if !ok {
+		return nil
+	}
+	// Keep the script keys order.
+	for _, pub := range pubs {
+		sig, ok := item.Signatures[hex.EncodeToString(pub)]
+		if !ok {
+			continue
+		}
+		k, err := keys.NewPublicKeyFromBytes(pub, elliptic.P256())
+		if err != nil {
+			return append(res, signature{script: item.Script})
+		}
+		res = append(res, signature{script: item.Script, pub: k, sig: sig})
+	}
+	if len(res) != len(item.Signatures) {
+		res = append(res, signature{script: item.Script}) // Unknown key.
+	}
+	return res
+}
+
+// addTo adds the signature to the context, it returns false if it's already
+// there.
+func (s signature) addTo(pc *sccontext.ParameterContext) (bool, error) {
+	ctr := &wallet.Contract{Script: s.script}
+	if m, _, ok := vm.ParseMultiSigContract(s.script); ok {
+		if item, ok := pc.Items[s.account]; ok && item.GetSignature(s.pub) != nil {
+			return false, nil
+		}
+		ctr.Parameters = make([]wallet.ContractParam, m)
+	} else {
+		if item, ok := pc.Items[s.account]; ok && len(item.Parameters) == 1 && item.Parameters[0].Value != nil {
+			return false, nil
+		}
+		ctr.Parameters = make([]wallet.ContractParam, 1)
+	}
+	for i := range ctr.Parameters {
+		ctr.Parameters[i] = wallet.ContractParam{Type: smartcontract.SignatureType}
+	}
+	return true, pc.AddSignature(s.account, ctr, s.pub, s.sig)
+}
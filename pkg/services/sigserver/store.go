@@ -0,0 +1,69 @@
+package sigserver
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	sccontext "github.com/nspcc-dev/neo-go/pkg/smartcontract/context"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// dirStore keeps every context in a separate JSON file named after the
+// verifiable item hash.
+type dirStore struct {
+	dir string
+}
+
+const fileExt = ".json"
+
+func (d *dirStore) load() ([]*sccontext.ParameterContext, error) {
+	if err := os.MkdirAll(d.dir, os.ModePerm); err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(d.dir)
+	if err != nil {
+		return nil, err
+	}
+	var res []*sccontext.ParameterContext
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), fileExt) {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(d.dir, e.Name()))
+		if err != nil {
+			return nil, err
+		}
+		pc := new(sccontext.ParameterContext)
+		if err := json.Unmarshal(data, pc); err != nil {
+			return nil, fmt.Errorf("%s: %w", e.Name(), err)
+		}
+		res = append(res, pc)
+	}
+	return res, nil
+}
+
+func (d *dirStore) save(pc *sccontext.ParameterContext) error {
+	data, err := json.Marshal(pc)
+	if err != nil {
+		return err
+	}
+	name := filepath.Join(d.dir, pc.Verifiable.Hash().StringLE()+fileExt)
+	tmp := name + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, name)
+}
+
+func (d *dirStore) remove(h util.Uint256) error {
+	err := os.Remove(filepath.Join(d.dir, h.StringLE()+fileExt))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
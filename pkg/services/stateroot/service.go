@@ -13,7 +13,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/io"
 	"github.com/nspcc-dev/neo-go/pkg/network/payload"
-	"github.com/nspcc-dev/neo-go/pkg/wallet"
+	"github.com/nspcc-dev/neo-go/pkg/services/signer"
 	"go.uber.org/atomic"
 	"go.uber.org/zap"
 )
@@ -54,8 +54,8 @@ type (
 		accMtx    sync.RWMutex
 		accHeight uint32
 		myIndex   byte
-		wallet    *wallet.Wallet
-		acc       *wallet.Account
+		signer    signer.Signer
+		acc       *signer.Account
 
 		srMtx           sync.Mutex
 		incompleteRoots map[uint32]*incompleteRoot
@@ -101,20 +101,11 @@ func New(cfg config.StateRoot, sm *stateroot.Module, log *zap.Logger, bc Ledger,
 			return nil, errors.New("`StateRootInHeader` should be disabled when state service is enabled")
 		}
 		var err error
-		w := cfg.UnlockWallet
-		if s.wallet, err = wallet.NewWalletFromFile(w.Path); err != nil {
+		if s.signer, err = signer.New(cfg.UnlockWallet, cfg.RemoteSigner, signer.DomainStateRoot, signer.DomainStateRootMessage); err != nil {
 			return nil, err
 		}
-
-		haveAccount := false
-		for _, acc := range s.wallet.Accounts {
-			if err := acc.Decrypt(w.Password, s.wallet.Scrypt); err == nil {
-				haveAccount = true
-				break
-			}
-		}
-		if !haveAccount {
-			return nil, errors.New("no wallet account could be unlocked")
+		if s.signer == nil {
+			return nil, errors.New("no wallet or remote signer configured")
 		}
 
 		s.SetUpdateValidatorsCallback(s.updateValidators)
@@ -162,15 +153,14 @@ func (s *service) updateValidators(height uint32, pubs keys.PublicKeys) {
 	defer s.accMtx.Unlock()
 
 	s.acc = nil
-	for i := range pubs {
-		if acc := s.wallet.GetAccount(pubs[i].GetScriptHash()); acc != nil {
-			err := acc.Decrypt(s.MainCfg.UnlockWallet.Password, s.wallet.Scrypt)
-			if err == nil {
-				s.acc = acc
-				s.accHeight = height
-				s.myIndex = byte(i)
-				break
-			}
-		}
+	i, acc, err := signer.Find(s.signer, pubs)
+	if err != nil {
+		s.log.Error("can't get signer keys", zap.Error(err))
+		return
+	}
+	if acc != nil {
+		s.acc = acc
+		s.accHeight = height
+		s.myIndex = byte(i)
 	}
 }
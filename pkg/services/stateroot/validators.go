@@ -1,14 +1,16 @@
 package stateroot
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
 	"github.com/nspcc-dev/neo-go/pkg/io"
 	"github.com/nspcc-dev/neo-go/pkg/network/payload"
+	"github.com/nspcc-dev/neo-go/pkg/services/signer"
 	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
-	"github.com/nspcc-dev/neo-go/pkg/wallet"
 	"go.uber.org/zap"
 )
 
@@ -74,8 +76,8 @@ func (s *service) Shutdown() {
 	s.log.Info("stopping state validation service")
 	close(s.stopCh)
 	<-s.done
-	if s.wallet != nil {
-		s.wallet.Close()
+	if s.signer != nil {
+		s.signer.Close()
 	}
 }
 
@@ -89,7 +91,16 @@ func (s *service) signAndSend(r *state.MPTRoot) error {
 		return nil
 	}
 
-	sig := acc.SignHashable(s.Network, r)
+	// Only one state root can be signed for the given height.
+	sig, err := acc.Sign(signer.Request{
+		Hash:      hash.NetSha256(uint32(s.Network), r),
+		Domain:    signer.DomainStateRoot,
+		Height:    r.Index,
+		Protected: true,
+	})
+	if err != nil {
+		return fmt.Errorf("can't sign state root: %w", err)
+	}
 	incRoot := s.getIncompleteRoot(r.Index, myIndex)
 	incRoot.Lock()
 	defer incRoot.Unlock()
@@ -119,7 +130,10 @@ func (s *service) signAndSend(r *state.MPTRoot) error {
 			VerificationScript: acc.GetVerificationScript(),
 		},
 	}
-	sig = acc.SignHashable(s.Network, e)
+	sig, err = acc.SignExtensible(uint32(s.Network), e, signer.DomainStateRootMessage)
+	if err != nil {
+		return fmt.Errorf("can't sign vote: %w", err)
+	}
 	buf := io.NewBufBinWriter()
 	emit.Bytes(buf.BinWriter, sig)
 	e.Witness.InvocationScript = buf.Bytes()
@@ -150,7 +164,7 @@ func (s *service) sendVote(ir *incompleteRoot) {
 }
 
 // getAccount returns the current index and account for the node running this service.
-func (s *service) getAccount() (byte, *wallet.Account) {
+func (s *service) getAccount() (byte, *signer.Account) {
 	s.accMtx.RLock()
 	defer s.accMtx.RUnlock()
 	return s.myIndex, s.acc
@@ -32,17 +32,54 @@ func {{.Name}}({{range $index, $arg := .Arguments -}}
 	{{- end}}
 }
 {{- end -}}
+{{- define "DYNAMICMETHOD" -}}
+// {{.Name}} {{.Comment}}
+func (c Contract) {{.Name}}({{range $index, $arg := .Arguments -}}
+	{{- if ne $index 0}}, {{end}}
+		{{- .Name}} {{.Type}}
+	{{- end}}) {{if .ReturnType }}{{ .ReturnType }} {
+	return contract.Call(c.Hash, "{{ .NameABI }}", contract.{{ .CallFlag }}
+		{{- range $arg := .Arguments -}}, {{.Name}}{{end}}).({{ .ReturnType }})
+	{{- else -}} {
+	contract.Call(c.Hash, "{{ .NameABI }}", contract.{{ .CallFlag }}
+		{{- range $arg := .Arguments -}}, {{.Name}}{{end}})
+	{{- end}}
+}
+{{- end -}}
 // Package {{.PackageName}} contains wrappers for {{.ContractName}} contract.
 package {{.PackageName}}
 
 import (
 {{range $m := .Imports}}	"{{ $m }}"
 {{end}})
-
+{{if .Hash}}
 // Hash contains contract hash in big-endian form.
 const Hash = "{{ .Hash }}"
 {{range $m := .Methods}}
 {{template "METHOD" $m }}
+{{end}}
+{{- else}}
+// Contract is a wrapper for {{.ContractName}} contract deployed with the
+// specified hash.
+type Contract struct {
+	Hash interop.Hash160
+}
+
+// New creates a wrapper for {{.ContractName}} contract with the given hash.
+func New(hash interop.Hash160) Contract {
+	return Contract{Hash: hash}
+}
+{{range $m := .Methods}}
+{{template "DYNAMICMETHOD" $m }}
+{{end}}
+{{- end}}
+{{- range $s := .Structs}}
+// {{.Name}} is a contract-specific {{.NameABI}} type used by its methods.
+type {{.Name}} struct {
+{{- range .Fields}}
+	{{.Name}} {{.Type}}
+{{- end}}
+}
 {{end}}`
 
 type (
@@ -89,6 +126,16 @@ type (
 		Imports      []string
 		Hash         string
 		Methods      []MethodTmpl
+		// Structs contains named structure types used by Methods, it's only
+		// filled in by Generate.
+		Structs []StructTmpl
+	}
+
+	// StructTmpl is a template for the named structure type.
+	StructTmpl struct {
+		Name    string
+		NameABI string
+		Fields  []ParamTmpl
 	}
 
 	MethodTmpl struct {
@@ -120,16 +167,129 @@ func NewConfig() Config {
 
 // Generate writes Go file containing smartcontract bindings to the `cfg.Output`.
 // It doesn't check manifest from Config for validity, incorrect manifest can
-// lead to unexpected results.
+// lead to unexpected results. Method parameter and return types are taken from
+// Overrides if specified there, then from extended Types and finally from the
+// manifest. If Hash is not specified in Config, Contract type is generated
+// with methods that can be used for any instance of the contract.
 func Generate(cfg Config) error {
-	ctr := TemplateFromManifest(cfg, scTypeToGo)
-	ctr.Imports = append(ctr.Imports, "github.com/nspcc-dev/neo-go/pkg/interop/contract")
-	ctr.Imports = append(ctr.Imports, "github.com/nspcc-dev/neo-go/pkg/interop/neogointernal")
+	var (
+		imports = make(map[string]struct{})
+		named   = make(map[string]bool)
+	)
+	ctr := TemplateFromManifest(cfg, func(name string, typ smartcontract.ParamType, cfg *Config) (string, string) {
+		return scTypeToInterop(name, typ, cfg, imports, named)
+	})
+	ctr.Structs = namedStructs(cfg, named, imports)
+	imports["github.com/nspcc-dev/neo-go/pkg/interop/contract"] = struct{}{}
+	if len(ctr.Hash) != 0 {
+		imports["github.com/nspcc-dev/neo-go/pkg/interop/neogointernal"] = struct{}{}
+	} else {
+		imports["github.com/nspcc-dev/neo-go/pkg/interop"] = struct{}{}
+	}
+	for _, imp := range ctr.Imports {
+		imports[imp] = struct{}{}
+	}
+	ctr.Imports = ctr.Imports[:0]
+	for imp := range imports {
+		ctr.Imports = append(ctr.Imports, imp)
+	}
 	sort.Strings(ctr.Imports)
 
 	return srcTemplate.Execute(cfg.Output, ctr)
 }
 
+// scTypeToInterop returns Go type name for the given parameter (or method
+// return value) along with the package this type is defined in. Overrides
+// have priority over extended types, named structures used are added to the
+// named map, additional imports needed for composite types are added to the
+// imports map.
+func scTypeToInterop(name string, typ smartcontract.ParamType, cfg *Config, imports map[string]struct{}, named map[string]bool) (string, string) {
+	if _, ok := cfg.Overrides[name]; !ok {
+		if et, ok := cfg.Types[name]; ok {
+			return extendedTypeToInterop(et, imports, named), ""
+		}
+	}
+	return scTypeToGo(name, typ, cfg)
+}
+
+// extendedTypeToInterop converts the given extended type to Go interop type.
+func extendedTypeToInterop(et ExtendedType, imports map[string]struct{}, named map[string]bool) string {
+	switch et.Base {
+	case smartcontract.ArrayType:
+		if len(et.Name) > 0 {
+			named[et.Name] = true
+			return "*" + toTypeName(et.Name)
+		}
+		if et.Value != nil {
+			return "[]" + extendedTypeToInterop(*et.Value, imports, named)
+		}
+		return "[]any"
+	case smartcontract.MapType:
+		var vt = "any"
+		if et.Value != nil {
+			vt = extendedTypeToInterop(*et.Value, imports, named)
+		}
+		return "map[" + extendedTypeToInterop(ExtendedType{Base: et.Key}, imports, named) + "]" + vt
+	case smartcontract.InteropInterfaceType:
+		if et.Interface == "iterator" {
+			imports["github.com/nspcc-dev/neo-go/pkg/interop/iterator"] = struct{}{}
+			return "iterator.Iterator"
+		}
+		return "any"
+	default:
+		t, pkg := scTypeToGo("", et.Base, &Config{})
+		if pkg != "" {
+			imports[pkg] = struct{}{}
+		}
+		return t
+	}
+}
+
+// namedStructs returns templates for all named structures used (including
+// the ones used by fields of other structures).
+func namedStructs(cfg Config, named map[string]bool, imports map[string]struct{}) []StructTmpl {
+	var (
+		res  []StructTmpl
+		done = make(map[string]bool)
+	)
+	for len(done) < len(named) {
+		var names = make([]string, 0, len(named))
+		for name := range named {
+			if !done[name] {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			done[name] = true
+			st := StructTmpl{
+				Name:    toTypeName(name),
+				NameABI: name,
+			}
+			for _, f := range cfg.NamedTypes[name].Fields {
+				st.Fields = append(st.Fields, ParamTmpl{
+					Name: upperFirst(f.Field),
+					Type: extendedTypeToInterop(f.ExtendedType, imports, named),
+				})
+			}
+			res = append(res, st)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Name < res[j].Name })
+	return res
+}
+
+// toTypeName converts structure name from the binding configuration
+// (`namespace.name`) to a valid exported Go type name.
+func toTypeName(s string) string {
+	return strings.Map(func(c rune) rune {
+		if c == '.' {
+			return -1
+		}
+		return c
+	}, upperFirst(s))
+}
+
 func scTypeToGo(name string, typ smartcontract.ParamType, cfg *Config) (string, string) {
 	if over, ok := cfg.Overrides[name]; ok {
 		return over.TypeName, over.Package
@@ -0,0 +1,140 @@
+package binding
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest/standard"
+	"github.com/stretchr/testify/require"
+)
+
+// rewriteStandardWrappers denotes whether pkg/interop/lib wrappers for
+// standards should be regenerated.
+const rewriteStandardWrappers = false
+
+// flattenStandard returns manifest containing all methods and events of the
+// given standard including its base standards and optional methods. Methods
+// of derived standards replace base ones with the same name.
+func flattenStandard(s *standard.Standard) *manifest.Manifest {
+	var m = new(manifest.Manifest)
+	if s.Base != nil {
+		m = flattenStandard(s.Base)
+	}
+	var mtds = append(append([]manifest.Method{}, s.ABI.Methods...), s.Optional...)
+	for _, mtd := range mtds {
+		var replaced bool
+		for i := range m.ABI.Methods {
+			if m.ABI.Methods[i].Name == mtd.Name {
+				m.ABI.Methods[i] = mtd
+				replaced = true
+			}
+		}
+		if !replaced {
+			m.ABI.Methods = append(m.ABI.Methods, mtd)
+		}
+	}
+	m.ABI.Events = append(m.ABI.Events, s.ABI.Events...)
+	return m
+}
+
+// loadNNS returns NNS contract manifest without methods that can't be called
+// by other contracts (deployment and update).
+func loadNNS(t *testing.T) *manifest.Manifest {
+	data, err := os.ReadFile(filepath.Join("..", "..", "..", "cli", "smartcontract", "testdata", "nameservice", "nns.manifest.json"))
+	require.NoError(t, err)
+	var m = new(manifest.Manifest)
+	require.NoError(t, json.Unmarshal(data, m))
+	var mtds = m.ABI.Methods[:0]
+	for _, mtd := range m.ABI.Methods {
+		if mtd.Name != manifest.MethodDeploy && mtd.Name != "_initialize" && mtd.Name != "update" {
+			mtds = append(mtds, mtd)
+		}
+	}
+	m.ABI.Methods = mtds
+	return m
+}
+
+func TestStandardWrappers(t *testing.T) {
+	var iter = ExtendedType{Base: smartcontract.InteropInterfaceType, Interface: "iterator"}
+	var libDir = filepath.Join("..", "..", "interop", "lib")
+
+	for _, tc := range []struct {
+		std      *standard.Standard
+		manifest *manifest.Manifest
+		name     string
+		pkg      string
+		types    map[string]ExtendedType
+	}{
+		{
+			std:  standard.Nep17,
+			name: "NEP-17",
+			pkg:  "nep17",
+		},
+		{
+			std:  standard.Nep11NonDivisible,
+			name: "NEP-11 non-divisible",
+			pkg:  "nep11",
+			types: map[string]ExtendedType{
+				"tokensOf":   iter,
+				"tokens":     iter,
+				"properties": {Base: smartcontract.MapType, Key: smartcontract.StringType, Value: &ExtendedType{Base: smartcontract.AnyType}},
+			},
+		},
+		{
+			std:  standard.Nep11Divisible,
+			name: "NEP-11 divisible",
+			pkg:  "nep11d",
+			types: map[string]ExtendedType{
+				"tokensOf":   iter,
+				"tokens":     iter,
+				"ownerOf":    iter,
+				"properties": {Base: smartcontract.MapType, Key: smartcontract.StringType, Value: &ExtendedType{Base: smartcontract.AnyType}},
+			},
+		},
+		{
+			manifest: loadNNS(t),
+			name:     "NNS",
+			pkg:      "nns",
+			types: map[string]ExtendedType{
+				"tokensOf":           iter,
+				"tokens":             iter,
+				"roots":              iter,
+				"getAllRecords":      iter,
+				"properties":         {Base: smartcontract.MapType, Key: smartcontract.StringType, Value: &ExtendedType{Base: smartcontract.AnyType}},
+				"setPrice.priceList": {Base: smartcontract.ArrayType, Value: &ExtendedType{Base: smartcontract.IntegerType}},
+			},
+		},
+	} {
+		t.Run(tc.pkg, func(t *testing.T) {
+			cfg := NewConfig()
+			if tc.std != nil {
+				cfg.Manifest = flattenStandard(tc.std)
+			} else {
+				cfg.Manifest = tc.manifest
+			}
+			cfg.Manifest.Name = tc.name
+			cfg.Package = tc.pkg
+			cfg.Types = tc.types
+			buf := bytes.NewBuffer(nil)
+			cfg.Output = buf
+			require.NoError(t, Generate(cfg))
+
+			file := filepath.Join(libDir, tc.pkg, tc.pkg+".go")
+			if rewriteStandardWrappers {
+				require.NoError(t, os.MkdirAll(filepath.Dir(file), os.ModePerm))
+				require.NoError(t, os.WriteFile(file, buf.Bytes(), 0644))
+				return
+			}
+			expected, err := os.ReadFile(file)
+			require.NoError(t, err)
+			expected = bytes.ReplaceAll(expected, []byte("\r"), []byte{}) // Windows.
+			require.Equal(t, string(expected), buf.String())
+		})
+	}
+	require.False(t, rewriteStandardWrappers)
+}
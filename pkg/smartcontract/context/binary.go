@@ -0,0 +1,185 @@
+package context
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+)
+
+// Binary encoding of context types.
+const (
+	binaryTransactionType byte = iota
+	binaryCompatTransactionType
+)
+
+// maxPublicKeyLen is the length of uncompressed public key.
+const maxPublicKeyLen = 65
+
+// EncodeBinary implements the io.Serializable interface. Binary format is
+// a compact alternative to JSON (useful for QR codes, for example), it
+// supports transaction contexts with parameter values being byte slices (like
+// signatures) only.
+func (c *ParameterContext) EncodeBinary(w *io.BinWriter) {
+	switch c.Type {
+	case TransactionType:
+		w.WriteB(binaryTransactionType)
+	case compatTransactionType:
+		w.WriteB(binaryCompatTransactionType)
+	default:
+		w.Err = fmt.Errorf("unsupported type: %s", c.Type)
+		return
+	}
+	w.WriteU32LE(uint32(c.Network))
+	verif, err := c.Verifiable.EncodeHashableFields()
+	if err != nil {
+		w.Err = fmt.Errorf("failed to encode hashable fields: %w", err)
+		return
+	}
+	w.WriteVarBytes(verif)
+
+	hashes := make([]util.Uint160, 0, len(c.Items))
+	for h := range c.Items {
+		hashes = append(hashes, h)
+	}
+	sort.Slice(hashes, func(i, j int) bool { return hashes[i].Less(hashes[j]) })
+	w.WriteVarUint(uint64(len(hashes)))
+	for _, h := range hashes {
+		w.WriteBytes(h[:])
+		c.Items[h].encodeBinary(w)
+	}
+}
+
+func (it *Item) encodeBinary(w *io.BinWriter) {
+	w.WriteVarBytes(it.Script)
+	w.WriteVarUint(uint64(len(it.Parameters)))
+	for i, p := range it.Parameters {
+		w.WriteB(byte(p.Type))
+		switch v := p.Value.(type) {
+		case nil:
+			w.WriteB(0)
+		case []byte:
+			w.WriteB(1)
+			w.WriteVarBytes(v)
+		default:
+			w.Err = fmt.Errorf("unsupported %s parameter #%d value", p.Type, i)
+			return
+		}
+	}
+	pubs := make([]string, 0, len(it.Signatures))
+	for pub := range it.Signatures {
+		pubs = append(pubs, pub)
+	}
+	sort.Strings(pubs)
+	w.WriteVarUint(uint64(len(pubs)))
+	for _, pub := range pubs {
+		b, err := hex.DecodeString(pub)
+		if err != nil {
+			w.Err = fmt.Errorf("invalid public key %s: %w", pub, err)
+			return
+		}
+		w.WriteVarBytes(b)
+		w.WriteVarBytes(it.Signatures[pub])
+	}
+}
+
+// DecodeBinary implements the io.Serializable interface.
+func (c *ParameterContext) DecodeBinary(r *io.BinReader) {
+	typ := r.ReadB()
+	network := r.ReadU32LE()
+	verif := r.ReadVarBytes(transaction.MaxTransactionSize)
+	if r.Err != nil {
+		return
+	}
+	switch typ {
+	case binaryTransactionType:
+		c.Type = TransactionType
+	case binaryCompatTransactionType:
+		c.Type = compatTransactionType
+	default:
+		r.Err = fmt.Errorf("unsupported type: %d", typ)
+		return
+	}
+	tx := new(transaction.Transaction)
+	if err := tx.DecodeHashableFields(verif); err != nil {
+		r.Err = err
+		return
+	}
+	c.Network = netmode.Magic(network)
+	c.Verifiable = tx
+
+	n := r.ReadVarUint()
+	if n > transaction.MaxAttributes {
+		r.Err = errors.New("too many items")
+		return
+	}
+	c.Items = make(map[util.Uint160]*Item, n)
+	var prev *util.Uint160
+	for i := 0; i < int(n); i++ {
+		var h util.Uint160
+		r.ReadBytes(h[:])
+		item := new(Item)
+		item.decodeBinary(r)
+		if r.Err != nil {
+			return
+		}
+		if prev != nil && !prev.Less(h) {
+			r.Err = errors.New("items are not sorted or duplicated")
+			return
+		}
+		prev = &h
+		c.Items[h] = item
+	}
+}
+
+func (it *Item) decodeBinary(r *io.BinReader) {
+	it.Script = r.ReadVarBytes(transaction.MaxScriptLength)
+	n := r.ReadVarUint()
+	if n > vm.MaxMultisigKeys {
+		r.Err = errors.New("too many parameters")
+		return
+	}
+	it.Parameters = make([]smartcontract.Parameter, n)
+	for i := range it.Parameters {
+		it.Parameters[i].Type = smartcontract.ParamType(r.ReadB())
+		switch r.ReadB() {
+		case 0:
+		case 1:
+			it.Parameters[i].Value = r.ReadVarBytes()
+		default:
+			if r.Err == nil {
+				r.Err = fmt.Errorf("invalid parameter #%d value", i)
+			}
+			return
+		}
+	}
+	n = r.ReadVarUint()
+	if n > vm.MaxMultisigKeys {
+		r.Err = errors.New("too many signatures")
+		return
+	}
+	it.Signatures = make(map[string][]byte, n)
+	var prev []byte
+	for i := 0; i < int(n); i++ {
+		pub := r.ReadVarBytes(maxPublicKeyLen)
+		sig := r.ReadVarBytes(keys.SignatureLen)
+		if r.Err != nil {
+			return
+		}
+		if prev != nil && bytes.Compare(prev, pub) >= 0 {
+			r.Err = errors.New("signatures are not sorted or duplicated")
+			return
+		}
+		prev = pub
+		it.Signatures[hex.EncodeToString(pub)] = sig
+	}
+}
@@ -24,6 +24,18 @@ const (
 	NEP11Payable = "NEP-11-Payable"
 	// NEP17Payable represents the name of contract interface which can receive NEP-17 tokens.
 	NEP17Payable = "NEP-17-Payable"
+	// NEP24StandardName represents the name of NEP-24 (NFT royalty) smartcontract standard.
+	NEP24StandardName = "NEP-24"
+	// NEP26StandardName represents the name of NEP-26 (NEP-11 receiver callback) smartcontract standard.
+	NEP26StandardName = "NEP-26"
+	// NEP27StandardName represents the name of NEP-27 (NEP-17 receiver callback) smartcontract standard.
+	NEP27StandardName = "NEP-27"
+	// NEP29StandardName represents the name of NEP-29 (contract _deploy method) smartcontract standard.
+	NEP29StandardName = "NEP-29"
+	// NEP30StandardName represents the name of NEP-30 (contract witness verification callback) smartcontract standard.
+	NEP30StandardName = "NEP-30"
+	// NEP31StandardName represents the name of NEP-31 (contract destroy method) smartcontract standard.
+	NEP31StandardName = "NEP-31"
 )
 
 // Manifest represens contract metadata.
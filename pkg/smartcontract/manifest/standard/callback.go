@@ -0,0 +1,59 @@
+package standard
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+)
+
+// Nep26 is a NEP-26 Standard (NEP-11 token receiver callback). It's an alias
+// of Nep11Payable (NEP-26 is the onNEP11Payment callback previously known as
+// NEP-11-Payable), both refer to the same Standard.
+var Nep26 = Nep11Payable
+
+// Nep27 is a NEP-27 Standard (NEP-17 token receiver callback). It's an alias
+// of Nep17Payable (NEP-27 is the onNEP17Payment callback previously known as
+// NEP-17-Payable), both refer to the same Standard.
+var Nep27 = Nep17Payable
+
+// Nep29 is a NEP-29 Standard describing contract _deploy method.
+var Nep29 = &Standard{
+	Manifest: manifest.Manifest{
+		ABI: manifest.ABI{
+			Methods: []manifest.Method{{
+				Name: manifest.MethodDeploy,
+				Parameters: []manifest.Parameter{
+					{Name: "data", Type: smartcontract.AnyType},
+					{Name: "isUpdate", Type: smartcontract.BoolType},
+				},
+				ReturnType: smartcontract.VoidType,
+			}},
+		},
+	},
+}
+
+// Nep30 is a NEP-30 Standard describing contract witness verification
+// callback. verify method can have any parameters.
+var Nep30 = &Standard{
+	Manifest: manifest.Manifest{
+		ABI: manifest.ABI{
+			Methods: []manifest.Method{{
+				Name:       manifest.MethodVerify,
+				ReturnType: smartcontract.BoolType,
+				Safe:       true,
+			}},
+		},
+	},
+	AnyParameters: []string{manifest.MethodVerify},
+}
+
+// Nep31 is a NEP-31 Standard describing contract destroy method.
+var Nep31 = &Standard{
+	Manifest: manifest.Manifest{
+		ABI: manifest.ABI{
+			Methods: []manifest.Method{{
+				Name:       "destroy",
+				ReturnType: smartcontract.VoidType,
+			}},
+		},
+	},
+}
@@ -12,4 +12,18 @@ type Standard struct {
 	// If contract contains method with the same name and parameter count,
 	// it must have signature declared by this contract.
 	Optional []manifest.Method
+	// AnyParameters contains names of mandatory methods that can have any
+	// parameters, only their return type and safe flag are checked.
+	AnyParameters []string
+}
+
+// anyParameters checks whether the method with the given name can have any
+// parameters.
+func (s *Standard) anyParameters(name string) bool {
+	for _, n := range s.AnyParameters {
+		if n == name {
+			return true
+		}
+	}
+	return false
 }
@@ -18,27 +18,46 @@ var (
 	ErrSafeMethodMismatch    = errors.New("method has wrong safe flag")
 )
 
-var checks = map[string][]*Standard{
+var builtin = map[string][]*Standard{
 	manifest.NEP11StandardName: {Nep11NonDivisible, Nep11Divisible},
 	manifest.NEP17StandardName: {Nep17},
 	manifest.NEP11Payable:      {Nep11Payable},
 	manifest.NEP17Payable:      {Nep17Payable},
+	manifest.NEP24StandardName: {Nep24},
+	manifest.NEP26StandardName: {Nep26},
+	manifest.NEP27StandardName: {Nep27},
+	manifest.NEP29StandardName: {Nep29},
+	manifest.NEP30StandardName: {Nep30},
+	manifest.NEP31StandardName: {Nep31},
 }
 
-// Check checks if the manifest complies with all provided standards.
-// Currently, only NEP-17 is supported.
+// Check checks if the manifest complies with all provided standards. Built-in
+// standards and the ones added via Register are checked, unknown standards
+// are ignored.
 func Check(m *manifest.Manifest, standards ...string) error {
-	return check(m, true, standards...)
+	return defaultRegistry.Check(m, standards...)
 }
 
 // CheckABI is similar to Check but doesn't check parameter names.
 func CheckABI(m *manifest.Manifest, standards ...string) error {
-	return check(m, false, standards...)
+	return defaultRegistry.CheckABI(m, standards...)
 }
 
-func check(m *manifest.Manifest, checkNames bool, standards ...string) error {
+// Check checks if the manifest complies with all provided standards. Built-in
+// standards and the ones registered in r are checked, unknown standards are
+// ignored.
+func (r *Registry) Check(m *manifest.Manifest, standards ...string) error {
+	return r.check(m, true, standards...)
+}
+
+// CheckABI is similar to Check but doesn't check parameter names.
+func (r *Registry) CheckABI(m *manifest.Manifest, standards ...string) error {
+	return r.check(m, false, standards...)
+}
+
+func (r *Registry) check(m *manifest.Manifest, checkNames bool, standards ...string) error {
 	for i := range standards {
-		ss, ok := checks[standards[i]]
+		ss, ok := r.lookup(standards[i])
 		if ok {
 			var err error
 			for i := range ss {
@@ -72,7 +91,7 @@ func comply(m *manifest.Manifest, checkNames bool, st *Standard) error {
 		}
 	}
 	for _, stm := range st.ABI.Methods {
-		if err := checkMethod(m, &stm, false, checkNames); err != nil {
+		if err := checkMethod(m, &stm, false, st.anyParameters(stm.Name), checkNames); err != nil {
 			return err
 		}
 	}
@@ -97,7 +116,7 @@ func comply(m *manifest.Manifest, checkNames bool, st *Standard) error {
 		}
 	}
 	for _, stm := range st.Optional {
-		if err := checkMethod(m, &stm, true, checkNames); err != nil {
+		if err := checkMethod(m, &stm, true, false, checkNames); err != nil {
 			return err
 		}
 	}
@@ -105,12 +124,19 @@ func comply(m *manifest.Manifest, checkNames bool, st *Standard) error {
 }
 
 func checkMethod(m *manifest.Manifest, expected *manifest.Method,
-	allowMissing bool, checkNames bool) error {
-	actual := m.ABI.GetMethod(expected.Name, len(expected.Parameters))
+	allowMissing bool, anyParams bool, checkNames bool) error {
+	var paramCount = len(expected.Parameters)
+	if anyParams {
+		paramCount = -1
+	}
+	actual := m.ABI.GetMethod(expected.Name, paramCount)
 	if actual == nil {
 		if allowMissing {
 			return nil
 		}
+		if anyParams {
+			return fmt.Errorf("%w: '%s'", ErrMethodMissing, expected.Name)
+		}
 		return fmt.Errorf("%w: '%s' with %d parameters", ErrMethodMissing,
 			expected.Name, len(expected.Parameters))
 	}
@@ -176,3 +176,39 @@ func TestOptional(t *testing.T) {
 		require.NoError(t, Comply(&actual, &m))
 	})
 }
+
+func TestCheckCallbacks(t *testing.T) {
+	for name, st := range map[string]*Standard{
+		manifest.NEP24StandardName: Nep24,
+		manifest.NEP26StandardName: Nep26,
+		manifest.NEP27StandardName: Nep27,
+		manifest.NEP29StandardName: Nep29,
+		manifest.NEP30StandardName: Nep30,
+		manifest.NEP31StandardName: Nep31,
+	} {
+		t.Run(name, func(t *testing.T) {
+			m := manifest.NewManifest("Test")
+			require.ErrorIs(t, Check(m, name), ErrMethodMissing)
+
+			m.ABI.Methods = append(m.ABI.Methods, st.ABI.Methods...)
+			require.NoError(t, Check(m, name))
+
+			m.ABI.Methods[0].ReturnType = smartcontract.StringType
+			require.ErrorIs(t, CheckABI(m, name), ErrInvalidReturnType)
+		})
+	}
+}
+
+func TestCheckNep30Parameters(t *testing.T) {
+	m := manifest.NewManifest("Test")
+	m.ABI.Methods = []manifest.Method{{
+		Name:       manifest.MethodVerify,
+		Parameters: []manifest.Parameter{{Name: "sig", Type: smartcontract.SignatureType}},
+		ReturnType: smartcontract.BoolType,
+		Safe:       true,
+	}}
+	require.NoError(t, Check(m, manifest.NEP30StandardName))
+
+	m.ABI.Methods[0].Safe = false
+	require.ErrorIs(t, Check(m, manifest.NEP30StandardName), ErrSafeMethodMismatch)
+}
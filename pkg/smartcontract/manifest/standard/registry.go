@@ -0,0 +1,163 @@
+package standard
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"gopkg.in/yaml.v3"
+)
+
+// Spec is a YAML-serializable description of a custom standard that can be
+// registered with RegisterSpec. Methods and events use the same field names as
+// the manifest ABI (name, parameters, returntype, safe), parameter types are
+// specified by their names (like Hash160 or Integer).
+type Spec struct {
+	// Name is the standard name as used in SupportedStandards manifest field.
+	Name string `yaml:"name"`
+	// Base is an optional name of a registered (or built-in) standard this
+	// one extends.
+	Base string `yaml:"base,omitempty"`
+	// Methods contains mandatory methods.
+	Methods []manifest.Method `yaml:"methods,omitempty"`
+	// Events contains mandatory events.
+	Events []manifest.Event `yaml:"events,omitempty"`
+	// Optional contains optional methods, they're checked if a contract has
+	// a method with the same name and parameter count.
+	Optional []manifest.Method `yaml:"optional,omitempty"`
+}
+
+// Registry is a set of custom standards known to its Check and CheckABI
+// methods in addition to the built-in ones. Package-level functions use the
+// default registry, separate registries can be created with NewRegistry to
+// avoid affecting other users of the package. It's safe for concurrent use.
+type Registry struct {
+	lock   sync.RWMutex
+	custom map[string][]*Standard
+}
+
+var defaultRegistry = NewRegistry()
+
+// NewRegistry creates an empty registry of custom standards.
+func NewRegistry() *Registry {
+	return &Registry{custom: make(map[string][]*Standard)}
+}
+
+// lookup returns all known variants of the standard with the given name.
+func (r *Registry) lookup(name string) ([]*Standard, bool) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	return r.lookupUnsafe(name)
+}
+
+// lookupUnsafe is the same as lookup, but must be called with the lock held.
+func (r *Registry) lookupUnsafe(name string) ([]*Standard, bool) {
+	if ss, ok := builtin[name]; ok {
+		return ss, true
+	}
+	ss, ok := r.custom[name]
+	return ss, ok
+}
+
+// Register adds a custom standard with the given name to the default
+// registry, see Registry.Register.
+func Register(name string, variants ...*Standard) error {
+	return defaultRegistry.Register(name, variants...)
+}
+
+// Register adds a custom standard with the given name to the registry. A
+// manifest complies with the standard if it complies with any of the given
+// variants. Built-in standards can't be replaced, registering a custom
+// standard with the same name again replaces the previous definition.
+func (r *Registry) Register(name string, variants ...*Standard) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.registerUnsafe(name, variants)
+}
+
+// registerUnsafe is the same as Register, but must be called with the lock
+// held.
+func (r *Registry) registerUnsafe(name string, variants []*Standard) error {
+	if len(name) == 0 {
+		return errors.New("empty standard name")
+	}
+	if len(variants) == 0 {
+		return fmt.Errorf("no definitions for standard '%s'", name)
+	}
+	if _, ok := builtin[name]; ok {
+		return fmt.Errorf("standard '%s' is built-in and can't be redefined", name)
+	}
+	r.custom[name] = variants
+	return nil
+}
+
+// LoadSpec decodes standard specification from YAML.
+func LoadSpec(data []byte) (*Spec, error) {
+	var s = new(Spec)
+	if err := yaml.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("invalid standard specification: %w", err)
+	}
+	if len(s.Name) == 0 {
+		return nil, errors.New("invalid standard specification: missing name")
+	}
+	return s, nil
+}
+
+// RegisterSpec registers a standard described by the given specification in
+// the default registry, see Registry.RegisterSpec.
+func RegisterSpec(s *Spec) error {
+	return defaultRegistry.RegisterSpec(s)
+}
+
+// RegisterSpec registers a standard described by the given specification. If
+// the specification has a base standard it must already be known and every
+// variant of the base standard produces a variant of the new one.
+func (r *Registry) RegisterSpec(s *Spec) error {
+	var st = Standard{
+		Manifest: manifest.Manifest{
+			ABI: manifest.ABI{
+				Methods: s.Methods,
+				Events:  s.Events,
+			},
+		},
+		Optional: s.Optional,
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if len(s.Base) == 0 {
+		return r.registerUnsafe(s.Name, []*Standard{&st})
+	}
+	bases, ok := r.lookupUnsafe(s.Base)
+	if !ok {
+		return fmt.Errorf("unknown base standard '%s' for '%s'", s.Base, s.Name)
+	}
+	var variants = make([]*Standard, len(bases))
+	for i := range bases {
+		v := st
+		v.Base = bases[i]
+		variants[i] = &v
+	}
+	return r.registerUnsafe(s.Name, variants)
+}
+
+// RegisterSpecFile reads YAML standard specification from the given file and
+// registers it in the default registry with RegisterSpec.
+func RegisterSpecFile(path string) error {
+	return defaultRegistry.RegisterSpecFile(path)
+}
+
+// RegisterSpecFile reads YAML standard specification from the given file and
+// registers it with RegisterSpec.
+func (r *Registry) RegisterSpecFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	s, err := LoadSpec(data)
+	if err != nil {
+		return err
+	}
+	return r.RegisterSpec(s)
+}
@@ -0,0 +1,124 @@
+package standard
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegister(t *testing.T) {
+	require.Error(t, Register("", Nep17))
+	require.Error(t, Register("NEP-X"))
+	require.Error(t, Register(manifest.NEP17StandardName, Nep17))
+
+	m := manifest.NewManifest("Test")
+	require.NoError(t, Check(m, "NEP-X")) // Unknown standards are not checked.
+
+	require.NoError(t, Register("NEP-X", Nep31, Nep29))
+	require.Error(t, Check(m, "NEP-X"))
+	m.ABI.Methods = append(m.ABI.Methods, Nep29.ABI.Methods...)
+	require.NoError(t, Check(m, "NEP-X"))
+
+	// Redefinition.
+	require.NoError(t, Register("NEP-X", Nep31))
+	require.Error(t, Check(m, "NEP-X"))
+}
+
+func TestRegistry(t *testing.T) {
+	r := NewRegistry()
+	require.NoError(t, r.Register("NEP-R", Nep31))
+
+	m := manifest.NewManifest("Test")
+	require.ErrorIs(t, r.Check(m, "NEP-R"), ErrMethodMissing)
+	require.ErrorIs(t, r.CheckABI(m, manifest.NEP31StandardName), ErrMethodMissing)
+	require.NoError(t, Check(m, "NEP-R")) // Not known to the default registry.
+
+	require.NoError(t, r.RegisterSpec(&Spec{Name: "NEP-S", Base: "NEP-R"}))
+	require.ErrorIs(t, r.Check(m, "NEP-S"), ErrMethodMissing)
+	require.Error(t, RegisterSpec(&Spec{Name: "NEP-S", Base: "NEP-R"}))
+}
+
+func TestRegisterSpec(t *testing.T) {
+	_, err := LoadSpec([]byte("methods: []"))
+	require.Error(t, err)
+	_, err = LoadSpec([]byte("name: [x"))
+	require.Error(t, err)
+
+	s, err := LoadSpec([]byte(`name: NEP-Y
+base: NEP-11
+methods:
+  - name: burn
+    parameters:
+      - name: tokenId
+        type: ByteArray
+    returntype: Boolean
+events:
+  - name: Burnt
+    parameters:
+      - name: tokenId
+        type: ByteArray
+optional:
+  - name: burnt
+    returntype: Integer
+    safe: true
+`))
+	require.NoError(t, err)
+	require.Equal(t, &Spec{
+		Name: "NEP-Y",
+		Base: manifest.NEP11StandardName,
+		Methods: []manifest.Method{{
+			Name:       "burn",
+			Parameters: []manifest.Parameter{{Name: "tokenId", Type: smartcontract.ByteArrayType}},
+			ReturnType: smartcontract.BoolType,
+		}},
+		Events: []manifest.Event{{
+			Name:       "Burnt",
+			Parameters: []manifest.Parameter{{Name: "tokenId", Type: smartcontract.ByteArrayType}},
+		}},
+		Optional: []manifest.Method{{
+			Name:       "burnt",
+			ReturnType: smartcontract.IntegerType,
+			Safe:       true,
+		}},
+	}, s)
+
+	require.Error(t, RegisterSpec(&Spec{Name: "NEP-Z", Base: "NEP-unknown"}))
+	require.NoError(t, RegisterSpec(s))
+
+	var m = manifest.NewManifest("Test")
+	for _, st := range []*Standard{DecimalTokenBase, Nep11Base, Nep11NonDivisible} {
+		for _, mtd := range st.ABI.Methods {
+			if m.ABI.GetMethod(mtd.Name, len(mtd.Parameters)) == nil {
+				m.ABI.Methods = append(m.ABI.Methods, mtd)
+			}
+		}
+		m.ABI.Events = append(m.ABI.Events, st.ABI.Events...)
+	}
+	require.NoError(t, Check(m, manifest.NEP11StandardName))
+	require.ErrorIs(t, Check(m, "NEP-Y"), ErrMethodMissing)
+
+	m.ABI.Methods = append(m.ABI.Methods, s.Methods...)
+	require.Error(t, Check(m, "NEP-Y"))
+
+	m.ABI.Events = append(m.ABI.Events, s.Events...)
+	require.NoError(t, Check(m, "NEP-Y"))
+
+	m.ABI.Methods = append(m.ABI.Methods, manifest.Method{Name: "burnt", ReturnType: smartcontract.IntegerType})
+	require.Error(t, Check(m, "NEP-Y"))
+
+	t.Run("file", func(t *testing.T) {
+		require.Error(t, RegisterSpecFile(filepath.Join(t.TempDir(), "unknown.yml")))
+
+		f := filepath.Join(t.TempDir(), "spec.yml")
+		require.NoError(t, os.WriteFile(f, []byte("name"), os.ModePerm))
+		require.Error(t, RegisterSpecFile(f))
+
+		require.NoError(t, os.WriteFile(f, []byte("name: NEP-F\nbase: NEP-31\n"), os.ModePerm))
+		require.NoError(t, RegisterSpecFile(f))
+		require.ErrorIs(t, Check(manifest.NewManifest("Test"), "NEP-F"), ErrMethodMissing)
+	})
+}
@@ -297,6 +297,83 @@ func {{$e.Name}}sFromApplicationLog(log *result.ApplicationLog) ([]*{{$e.Name}},
 	return res, nil
 }
 
+// Parse{{$e.Name}}s retrieves a set of all events with "{{$e.ManifestName}}"
+// name emitted by the contract from the provided [state.AppExecResult]. Events
+// with the same name emitted by other contracts are ignored.
+func Parse{{$e.Name}}s(aer *state.AppExecResult{{if not (len $.Hash)}}, hash util.Uint160{{end}}) ([]*{{$e.Name}}, error) {
+	if aer == nil {
+		return nil, errors.New("nil execution result")
+	}
+
+	var res []*{{$e.Name}}
+	for i, e := range aer.Events {
+		if e.Name != "{{$e.ManifestName}}" || !e.ScriptHash.Equals({{if len $.Hash}}Hash{{else}}hash{{end}}) {
+			continue
+		}
+		event := new({{$e.Name}})
+		err := event.FromStackItem(e.Item)
+		if err != nil {
+			return nil, fmt.Errorf("failed to deserialize {{$e.Name}} from stackitem (event #%d): %w", i, err)
+		}
+		res = append(res, event)
+	}
+
+	return res, nil
+}
+
+// Receive{{$e.Name}}s subscribes to "{{$e.ManifestName}}" events emitted by the
+// contract via the given [rpcclient.WSClient] and sends them to the provided
+// channel. Events that can't be deserialized are skipped. The channel must be
+// read from continuously to avoid blocking the client (including the time the
+// unsubscription is performed), it's closed when the subscription ends (see
+// [rpcclient.WSClient] for details) or when the function returned is called to
+// unsubscribe.
+func Receive{{$e.Name}}s(ws *rpcclient.WSClient, {{if not (len $.Hash)}}hash util.Uint160, {{end}}ch chan<- *{{$e.Name}}) (func() error, error) {
+	var (
+		{{if len $.Hash -}}
+		hash = Hash
+		{{end -}}
+		name = "{{$e.ManifestName}}"
+		rcvr = make(chan *state.ContainedNotificationEvent)
+		done = make(chan struct{})
+	)
+	id, err := ws.ReceiveExecutionNotifications(&neorpc.NotificationFilter{Contract: &hash, Name: &name}, rcvr)
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		defer close(ch)
+		for {
+			select {
+			case <-done:
+				return
+			case e, ok := <-rcvr:
+				if !ok {
+					return
+				}
+				event := new({{$e.Name}})
+				if event.FromStackItem(e.Item) != nil {
+					continue
+				}
+				select {
+				case <-done:
+					return
+				case ch <- event:
+				}
+			}
+		}
+	}()
+	return func() error {
+		// The client doesn't send anything to rcvr after successful
+		// unsubscription, it can't succeed twice.
+		err := ws.Unsubscribe(id)
+		if err == nil {
+			close(done)
+		}
+		return err
+	}, nil
+}
+
 // FromStackItem converts provided [stackitem.Array] to {{$e.Name}} or
 // returns an error if it's not possible to do to so.
 func (e *{{$e.Name}}) FromStackItem(item *stackitem.Array) error {
@@ -735,7 +812,10 @@ func scTemplateToRPC(cfg binding.Config, ctr ContractTmpl, imports map[string]st
 	}
 
 	if len(ctr.CustomEvents) > 0 {
+		imports["github.com/nspcc-dev/neo-go/pkg/core/state"] = struct{}{}
+		imports["github.com/nspcc-dev/neo-go/pkg/neorpc"] = struct{}{}
 		imports["github.com/nspcc-dev/neo-go/pkg/neorpc/result"] = struct{}{}
+		imports["github.com/nspcc-dev/neo-go/pkg/rpcclient"] = struct{}{}
 		imports["github.com/nspcc-dev/neo-go/pkg/vm/stackitem"] = struct{}{}
 		imports["fmt"] = struct{}{}
 		imports["errors"] = struct{}{}
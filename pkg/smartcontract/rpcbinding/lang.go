@@ -0,0 +1,318 @@
+package rpcbinding
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/binding"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+type (
+	// langContractTmpl is a language-neutral contract description used by
+	// non-Go binding generators.
+	langContractTmpl struct {
+		ContractName string
+		// Hash is a 0x-prefixed LE contract hash string, empty if not provided.
+		Hash        string
+		SafeMethods []langMethodTmpl
+		Methods     []langMethodTmpl
+		Events      []langEventTmpl
+		NamedTypes  []langNamedTypeTmpl
+	}
+
+	langMethodTmpl struct {
+		Name       string
+		NameABI    string
+		Parameters []langParamTmpl
+		Return     binding.ExtendedType
+	}
+
+	langParamTmpl struct {
+		Name string
+		Type binding.ExtendedType
+	}
+
+	langEventTmpl struct {
+		Name         string
+		ManifestName string
+		Parameters   []langParamTmpl
+	}
+
+	langNamedTypeTmpl struct {
+		Name   string
+		Fields []langParamTmpl
+	}
+
+	// langNaming contains language-specific identifier conversion functions.
+	langNaming struct {
+		method   func(string) string
+		param    func(string) string
+		field    func(string) string
+		reserved map[string]bool
+	}
+)
+
+// langTemplateFromConfig creates language-neutral contract template from the
+// given configuration using the specified naming conventions. Unlike Go
+// binding, it doesn't strip standard methods and events because there are no
+// prebuilt standard wrappers to embed for other languages.
+func langTemplateFromConfig(cfg binding.Config, naming langNaming) langContractTmpl {
+	var ctr = langContractTmpl{
+		ContractName: cfg.Manifest.Name,
+	}
+	if !cfg.Hash.Equals(util.Uint160{}) {
+		ctr.Hash = "0x" + cfg.Hash.StringLE()
+	}
+	var escape = func(name string) string {
+		for naming.reserved[name] {
+			name += "_"
+		}
+		return name
+	}
+	var typeOf = func(name string, typ smartcontract.ParamType) binding.ExtendedType {
+		if et, ok := cfg.Types[name]; ok {
+			return et
+		}
+		return binding.ExtendedType{Base: typ}
+	}
+	// Reuse Go binding method naming to handle overloaded methods the same way.
+	goTmpl := binding.TemplateFromManifest(cfg, func(string, smartcontract.ParamType, *binding.Config) (string, string) {
+		return "", ""
+	})
+	for _, gm := range goTmpl.Methods {
+		abim := cfg.Manifest.ABI.GetMethod(gm.NameABI, len(gm.Arguments))
+		mtd := langMethodTmpl{
+			Name:    escape(naming.method(gm.Name)),
+			NameABI: abim.Name,
+			Return:  typeOf(abim.Name, abim.ReturnType),
+		}
+		var seen = make(map[string]bool)
+		for _, p := range abim.Parameters {
+			name := escape(naming.param(p.Name))
+			for seen[name] {
+				name += "_"
+			}
+			seen[name] = true
+			mtd.Parameters = append(mtd.Parameters, langParamTmpl{
+				Name: name,
+				Type: typeOf(abim.Name+"."+p.Name, p.Type),
+			})
+		}
+		if abim.Safe {
+			ctr.SafeMethods = append(ctr.SafeMethods, mtd)
+		} else {
+			ctr.Methods = append(ctr.Methods, mtd)
+		}
+	}
+	for _, e := range cfg.Manifest.ABI.Events {
+		eName := ToEventBindingName(e.Name)
+		ev := langEventTmpl{
+			Name:         eName,
+			ManifestName: e.Name,
+		}
+		for _, p := range e.Parameters {
+			pName := ToParameterBindingName(p.Name)
+			ev.Parameters = append(ev.Parameters, langParamTmpl{
+				Name: escape(naming.field(pName)),
+				Type: typeOf(eName+"."+pName, p.Type),
+			})
+		}
+		ctr.Events = append(ctr.Events, ev)
+	}
+	for name, et := range cfg.NamedTypes {
+		nt := langNamedTypeTmpl{Name: toTypeName(name)}
+		for _, f := range et.Fields {
+			nt.Fields = append(nt.Fields, langParamTmpl{
+				Name: escape(naming.field(f.Field)),
+				Type: f.ExtendedType,
+			})
+		}
+		ctr.NamedTypes = append(ctr.NamedTypes, nt)
+	}
+	sort.Slice(ctr.NamedTypes, func(i, j int) bool {
+		return ctr.NamedTypes[i].Name < ctr.NamedTypes[j].Name
+	})
+	return ctr
+}
+
+// langConverters contains names of language-specific stack item to value
+// and value to contract parameter conversion routines along with the syntax
+// for anonymous functions.
+type langConverters struct {
+	item    map[smartcontract.ParamType]string
+	param   map[smartcontract.ParamType]string
+	itemTo  func(typeName string) string
+	paramOf func(typeName string) string
+	array   [2]string // Item and parameter converters for arrays.
+	mapping [2]string // Item and parameter converters for maps.
+	anyKey  string    // Item converter for untyped map keys.
+	lambda  func(arg, body string) string
+}
+
+// itemExpr returns an expression converting stack item v to the value of the
+// given type.
+func (c langConverters) itemExpr(et binding.ExtendedType, v string) string {
+	switch et.Base {
+	case smartcontract.ArrayType:
+		if len(et.Name) > 0 {
+			return c.itemTo(toTypeName(et.Name)) + "(" + v + ")"
+		}
+		var sub = binding.ExtendedType{Base: smartcontract.AnyType}
+		if et.Value != nil {
+			sub = *et.Value
+		}
+		return c.array[0] + "(" + v + ", " + c.itemRef(sub) + ")"
+	case smartcontract.MapType:
+		var sub = binding.ExtendedType{Base: smartcontract.AnyType}
+		if et.Value != nil {
+			sub = *et.Value
+		}
+		var key = c.anyKey
+		if et.Key != smartcontract.AnyType && et.Key != smartcontract.UnknownType {
+			key = c.itemRef(binding.ExtendedType{Base: et.Key})
+		}
+		return c.mapping[0] + "(" + v + ", " + key + ", " + c.itemRef(sub) + ")"
+	default:
+		return c.item[et.Base] + "(" + v + ")"
+	}
+}
+
+// itemRef returns a reference to the function converting stack item to the
+// value of the given type.
+func (c langConverters) itemRef(et binding.ExtendedType) string {
+	switch et.Base {
+	case smartcontract.ArrayType, smartcontract.MapType:
+		if et.Base == smartcontract.ArrayType && len(et.Name) > 0 {
+			return c.itemTo(toTypeName(et.Name))
+		}
+		return c.lambda("e", c.itemExpr(et, "e"))
+	default:
+		return c.item[et.Base]
+	}
+}
+
+// paramExpr returns an expression converting value v of the given type to
+// contract parameter.
+func (c langConverters) paramExpr(et binding.ExtendedType, v string) string {
+	switch et.Base {
+	case smartcontract.ArrayType:
+		if len(et.Name) > 0 {
+			return c.paramOf(toTypeName(et.Name)) + "(" + v + ")"
+		}
+		var sub = binding.ExtendedType{Base: smartcontract.AnyType}
+		if et.Value != nil {
+			sub = *et.Value
+		}
+		return c.array[1] + "(" + v + ", " + c.paramRef(sub) + ")"
+	case smartcontract.MapType:
+		var sub = binding.ExtendedType{Base: smartcontract.AnyType}
+		if et.Value != nil {
+			sub = *et.Value
+		}
+		return c.mapping[1] + "(" + v + ", " + c.paramRef(binding.ExtendedType{Base: et.Key}) + ", " + c.paramRef(sub) + ")"
+	default:
+		return c.param[et.Base] + "(" + v + ")"
+	}
+}
+
+// paramRef returns a reference to the function converting the value of the
+// given type to contract parameter.
+func (c langConverters) paramRef(et binding.ExtendedType) string {
+	switch et.Base {
+	case smartcontract.ArrayType, smartcontract.MapType:
+		if et.Base == smartcontract.ArrayType && len(et.Name) > 0 {
+			return c.paramOf(toTypeName(et.Name))
+		}
+		return c.lambda("e", c.paramExpr(et, "e"))
+	default:
+		return c.param[et.Base]
+	}
+}
+
+// funcMap returns template functions common for all languages.
+func (c langConverters) funcMap() template.FuncMap {
+	return template.FuncMap{
+		"itemExpr":  c.itemExpr,
+		"paramExpr": c.paramExpr,
+		"itemTo":    c.itemTo,
+		"paramOf":   c.paramOf,
+		"isVoid": func(et binding.ExtendedType) bool {
+			return et.Base == smartcontract.VoidType
+		},
+		"isIterator": func(et binding.ExtendedType) bool {
+			return et.Base == smartcontract.InteropInterfaceType
+		},
+		"quote": func(s string) string {
+			return fmt.Sprintf("%q", s)
+		},
+	}
+}
+
+func lowerFirst(s string) string {
+	if len(s) == 0 {
+		return s
+	}
+	return strings.ToLower(s[0:1]) + s[1:]
+}
+
+// toCamelCase converts the given identifier (possibly containing characters
+// that are not allowed in identifiers) to camelCase.
+func toCamelCase(s string) string {
+	var b strings.Builder
+	var upNext bool
+	for _, r := range s {
+		switch {
+		case unicode.IsLetter(r) || (b.Len() > 0 && unicode.IsDigit(r)):
+			if upNext && b.Len() > 0 {
+				r = unicode.ToUpper(r)
+			}
+			upNext = false
+			b.WriteRune(r)
+		case r == '_' && b.Len() > 0:
+			b.WriteRune(r)
+		default:
+			upNext = true
+		}
+	}
+	return lowerFirst(b.String())
+}
+
+// toSnakeCase converts the given camelCase or PascalCase identifier (possibly
+// containing characters that are not allowed in identifiers) to snake_case.
+func toSnakeCase(s string) string {
+	var (
+		b    strings.Builder
+		rs   = []rune(s)
+		last rune // The last rune written.
+		sep  bool // Whether there is a word boundary before the current rune.
+	)
+	for i, r := range rs {
+		switch {
+		case unicode.IsLetter(r) || (b.Len() > 0 && unicode.IsDigit(r)):
+			if unicode.IsUpper(r) && i > 0 {
+				// Keep abbreviations like "ID" or "NEP" together.
+				prev := rs[i-1]
+				sep = sep || unicode.IsLower(prev) || unicode.IsDigit(prev) ||
+					(unicode.IsUpper(prev) && i+1 < len(rs) && unicode.IsLower(rs[i+1]))
+			}
+			if sep && b.Len() > 0 && last != '_' {
+				b.WriteRune('_')
+			}
+			sep = false
+			last = unicode.ToLower(r)
+			b.WriteRune(last)
+		case r == '_' && b.Len() > 0:
+			sep = false
+			last = r
+			b.WriteRune(r)
+		default:
+			sep = true
+		}
+	}
+	return b.String()
+}
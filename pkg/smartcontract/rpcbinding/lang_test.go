@@ -0,0 +1,80 @@
+package rpcbinding
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/binding"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+// rewriteExpectedOutputs denotes whether expected output files should be
+// rewritten for TestGenerateLang.
+const rewriteExpectedOutputs = false
+
+func TestGenerateLang(t *testing.T) {
+	mBytes, err := os.ReadFile(filepath.Join("testdata", "extended.manifest.json"))
+	require.NoError(t, err)
+	cBytes, err := os.ReadFile(filepath.Join("testdata", "extended.yml"))
+	require.NoError(t, err)
+
+	var check = func(t *testing.T, gen func(binding.Config) error, expectedFile string, h util.Uint160) {
+		m := new(manifest.Manifest)
+		require.NoError(t, json.Unmarshal(mBytes, m))
+		cfg := NewConfig()
+		require.NoError(t, yaml.Unmarshal(cBytes, &cfg))
+		cfg.Manifest = m
+		cfg.Hash = h
+		buf := bytes.NewBuffer(nil)
+		cfg.Output = buf
+		require.NoError(t, gen(cfg))
+
+		if rewriteExpectedOutputs {
+			require.NoError(t, os.WriteFile(expectedFile, buf.Bytes(), 0644))
+		} else {
+			expected, err := os.ReadFile(expectedFile)
+			require.NoError(t, err)
+			expected = bytes.ReplaceAll(expected, []byte("\r"), []byte{}) // Windows.
+			require.Equal(t, string(expected), buf.String())
+		}
+	}
+
+	h := util.Uint160{0x00, 0x11, 0x22, 0x33, 0x44, 0x55, 0x66, 0x77, 0x88, 0x99, 0xaa, 0xbb, 0xcc, 0xdd, 0xee, 0xff, 0x00, 0x11, 0x22, 0x33}
+	t.Run("typescript", func(t *testing.T) {
+		check(t, GenerateTypeScript, filepath.Join("testdata", "extended.ts.out"), h)
+	})
+	t.Run("typescript, dynamic hash", func(t *testing.T) {
+		check(t, GenerateTypeScript, filepath.Join("testdata", "extended_dynamic_hash.ts.out"), util.Uint160{})
+	})
+	t.Run("python", func(t *testing.T) {
+		check(t, GeneratePython, filepath.Join("testdata", "extended.py.out"), h)
+	})
+	t.Run("python, dynamic hash", func(t *testing.T) {
+		check(t, GeneratePython, filepath.Join("testdata", "extended_dynamic_hash.py.out"), util.Uint160{})
+	})
+
+	require.False(t, rewriteExpectedOutputs)
+}
+
+func TestLangNaming(t *testing.T) {
+	for in, out := range map[string][2]string{
+		"balanceOf":         {"balanceOf", "balance_of"},
+		"BlockAddedEvent":   {"blockAddedEvent", "block_added_event"},
+		"getNEP17Transfers": {"getNEP17Transfers", "get_nep17_transfers"},
+		"tokenID":           {"tokenID", "token_id"},
+		"! weird name %$#":  {"weirdName", "weird_name"},
+		"snake_case":        {"snake_case", "snake_case"},
+		"PrevHash":          {"prevHash", "prev_hash"},
+		"NEX Token":         {"nEXToken", "nex_token"},
+		"NEXToken":          {"nEXToken", "nex_token"},
+	} {
+		require.Equal(t, out[0], toCamelCase(in), in)
+		require.Equal(t, out[1], toSnakeCase(in), in)
+	}
+}
@@ -0,0 +1,442 @@
+package rpcbinding
+
+import (
+	"text/template"
+
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/binding"
+)
+
+// The set of Python keywords and builtin names used by the generated code
+// that can't be used as identifiers.
+var pyReserved = map[string]bool{
+	"False": true, "None": true, "True": true, "and": true, "as": true,
+	"assert": true, "async": true, "await": true, "break": true,
+	"class": true, "continue": true, "def": true, "del": true, "elif": true,
+	"else": true, "except": true, "finally": true, "for": true, "from": true,
+	"global": true, "if": true, "import": true, "in": true, "is": true,
+	"lambda": true, "nonlocal": true, "not": true, "or": true, "pass": true,
+	"raise": true, "return": true, "try": true, "while": true, "with": true,
+	"yield": true, "self": true,
+}
+
+var pyConverters = langConverters{
+	item: map[smartcontract.ParamType]string{
+		smartcontract.AnyType:              "item_to_any",
+		smartcontract.BoolType:             "item_to_bool",
+		smartcontract.IntegerType:          "item_to_int",
+		smartcontract.ByteArrayType:        "item_to_bytes",
+		smartcontract.StringType:           "item_to_str",
+		smartcontract.Hash160Type:          "item_to_hash160",
+		smartcontract.Hash256Type:          "item_to_hash256",
+		smartcontract.PublicKeyType:        "item_to_public_key",
+		smartcontract.SignatureType:        "item_to_bytes",
+		smartcontract.InteropInterfaceType: "item_to_any",
+	},
+	param: map[smartcontract.ParamType]string{
+		smartcontract.AnyType:              "param_any",
+		smartcontract.BoolType:             "param_bool",
+		smartcontract.IntegerType:          "param_int",
+		smartcontract.ByteArrayType:        "param_bytes",
+		smartcontract.StringType:           "param_str",
+		smartcontract.Hash160Type:          "param_hash160",
+		smartcontract.Hash256Type:          "param_hash256",
+		smartcontract.PublicKeyType:        "param_public_key",
+		smartcontract.SignatureType:        "param_signature",
+		smartcontract.InteropInterfaceType: "param_any",
+	},
+	itemTo:  func(name string) string { return "item_to_" + toSnakeCase(name) },
+	paramOf: func(name string) string { return "param_" + toSnakeCase(name) },
+	array:   [2]string{"item_to_list", "param_list"},
+	mapping: [2]string{"item_to_dict", "param_dict"},
+	anyKey:  "item_to_key",
+	lambda: func(arg, body string) string {
+		return "lambda " + arg + ": " + body
+	},
+}
+
+const pySrcTmpl = `# Code generated by neo-go contract generate-rpcwrapper --manifest <file.json> --out <file.py> --lang py [--hash <hash>] [--config <config>]; DO NOT EDIT.
+
+"""Module {{snake .ContractName}} contains RPC wrappers for {{.ContractName}} contract."""
+
+from __future__ import annotations
+
+import base64
+import json
+import urllib.request
+from dataclasses import dataclass
+from typing import Any, Callable, Optional, Protocol, TypeVar
+{{if .Hash}}
+# HASH contains contract hash (0x-prefixed LE string).
+HASH = "{{.Hash}}"
+{{end}}
+# ContractParam is a JSON representation of a contract invocation parameter.
+ContractParam = dict[str, Any]
+# StackItem is a JSON representation of a VM stack item.
+StackItem = dict[str, Any]
+# InvokeResult is a JSON representation of a test invocation result.
+InvokeResult = dict[str, Any]
+# ApplicationLog is a JSON representation of a transaction or block application log.
+ApplicationLog = dict[str, Any]
+
+_K = TypeVar("_K")
+_T = TypeVar("_T")
+
+
+@dataclass
+class IteratorRef:
+    """IteratorRef is a reference to the iterator stored in the RPC server session."""
+
+    session: str
+    id: str
+
+
+class Invoker(Protocol):
+    """Invoker is used by ContractReader to call various safe methods."""
+
+    def call(self, contract: str, method: str, params: list[ContractParam]) -> InvokeResult: ...
+
+
+class Actor(Invoker, Protocol):
+    """Actor is used by Contract to call state-changing methods."""
+
+    def send_call(self, contract: str, method: str, params: list[ContractParam]) -> str:
+        """Create, sign and send a transaction invoking the method, return transaction hash."""
+        ...
+
+
+class RPCInvoker:
+    """RPCInvoker is an Invoker implementation using invokefunction JSON-RPC call."""
+
+    def __init__(self, endpoint: str, signers: Optional[list[dict[str, Any]]] = None):
+        self.endpoint = endpoint
+        self.signers = signers or []
+        self._id = 0
+
+    def call(self, contract: str, method: str, params: list[ContractParam]) -> InvokeResult:
+        return self.request("invokefunction", [contract, method, params, self.signers])
+
+    def traverse_iterator(self, iterator: IteratorRef, count: int) -> list[StackItem]:
+        """Return up to count next items of the given iterator."""
+        return self.request("traverseiterator", [iterator.session, iterator.id, count])
+
+    def terminate_session(self, session: str) -> bool:
+        """Close the given iterator session."""
+        return self.request("terminatesession", [session])
+
+    def request(self, method: str, params: list[Any]) -> Any:
+        self._id += 1
+        body = json.dumps({"jsonrpc": "2.0", "id": self._id, "method": method, "params": params})
+        req = urllib.request.Request(self.endpoint, data=body.encode(), headers={"Content-Type": "application/json"})
+        with urllib.request.urlopen(req) as resp:
+            res = json.load(resp)
+        if res.get("error"):
+            raise RuntimeError(f"RPC error {res['error']['code']}: {res['error']['message']}")
+        return res["result"]
+{{range .NamedTypes}}
+
+@dataclass
+class {{.Name}}:
+    """{{.Name}} is a contract-specific {{.Name}} type used by its methods."""
+{{range .Fields}}
+    {{.Name}}: {{pyType .Type false}}
+{{- end}}
+{{- if not .Fields}}
+    pass
+{{- end}}
+{{end -}}
+{{range .Events}}
+
+@dataclass
+class {{.Name}}:
+    """{{.Name}} represents "{{.ManifestName}}" event emitted by the contract."""
+{{range .Parameters}}
+    {{.Name}}: {{pyType .Type false}}
+{{- end}}
+{{- if not .Parameters}}
+    pass
+{{- end}}
+{{end -}}
+{{if .SafeMethods}}
+
+class ContractReader:
+    """ContractReader implements safe contract methods."""
+
+    def __init__(self, invoker: Invoker, hash: str{{if .Hash}} = HASH{{end}}):
+        self.invoker = invoker
+        self.hash = hash
+{{range .SafeMethods}}
+    def {{.Name}}(self{{range .Parameters}}, {{.Name}}: {{pyType .Type true}}{{end}}) -> {{pyType .Return false}}:
+        """Invoke ` + "`{{.NameABI}}`" + ` method of contract."""
+        res = self.invoker.call(self.hash, "{{.NameABI}}", [{{range $i, $p := .Parameters}}{{if $i}}, {{end}}{{paramExpr .Type .Name}}{{end}}])
+        {{if isVoid .Return}}_unwrap_item(res){{else if isIterator .Return}}return _unwrap_iterator(res){{else}}return {{itemExpr .Return "_unwrap_item(res)"}}{{end}}
+{{end -}}
+{{end -}}
+{{if .Methods}}
+
+class Contract{{if .SafeMethods}}(ContractReader){{end}}:
+    """Contract implements all contract methods."""
+
+    def __init__(self, actor: Actor, hash: str{{if .Hash}} = HASH{{end}}):
+        {{- if .SafeMethods}}
+        super().__init__(actor, hash)
+        {{- else}}
+        self.hash = hash
+        {{- end}}
+        self.actor = actor
+{{range .Methods}}
+    def {{.Name}}(self{{range .Parameters}}, {{.Name}}: {{pyType .Type true}}{{end}}) -> str:
+        """Create a transaction invoking ` + "`{{.NameABI}}`" + ` method of the contract and return its hash."""
+        return self.actor.send_call(self.hash, "{{.NameABI}}", [{{range $i, $p := .Parameters}}{{if $i}}, {{end}}{{paramExpr .Type .Name}}{{end}}])
+{{end -}}
+{{end -}}
+{{range .Events}}
+
+def {{snake .Name}}s_from_application_log(log: ApplicationLog) -> list[{{.Name}}]:
+    """Retrieve a set of all emitted events with "{{.ManifestName}}" name from the provided application log."""
+    res = []
+    for ex in log["executions"]:
+        for e in ex["notifications"]:
+            if e["eventname"] == "{{.ManifestName}}":
+                res.append({{itemTo .Name}}(e["state"]))
+    return res
+
+
+def {{itemTo .Name}}(item: StackItem) -> {{.Name}}:
+    """Convert notification state to {{.Name}}."""
+    {{if .Parameters}}arr = {{end}}_item_to_fields(item, {{len .Parameters}})
+    return {{.Name}}({{if .Parameters}}{{range $i, $p := .Parameters}}
+        {{.Name}}={{itemExpr .Type (printf "arr[%d]" $i)}},{{end}}
+    {{end}})
+{{end -}}
+{{range .NamedTypes}}
+
+def {{itemTo .Name}}(item: StackItem) -> {{.Name}}:
+    """Convert stack item to {{.Name}}."""
+    {{if .Fields}}arr = {{end}}_item_to_fields(item, {{len .Fields}})
+    return {{.Name}}({{if .Fields}}{{range $i, $f := .Fields}}
+        {{.Name}}={{itemExpr .Type (printf "arr[%d]" $i)}},{{end}}
+    {{end}})
+
+
+def {{paramOf .Name}}(v: {{.Name}}) -> ContractParam:
+    """Convert {{.Name}} to contract parameter."""
+    return {"type": "Array", "value": [{{range $i, $f := .Fields}}{{if $i}}, {{end}}{{paramExpr .Type (printf "v.%s" .Name)}}{{end}}]}
+{{end}}
+
+def _unwrap_item(res: InvokeResult) -> StackItem:
+    if res["state"] != "HALT":
+        raise RuntimeError(f"invocation failed: {res.get('exception')}")
+    if len(res["stack"]) != 1:
+        raise RuntimeError(f"result stack has {len(res['stack'])} items, expected 1")
+    return res["stack"][0]
+
+
+def _unwrap_iterator(res: InvokeResult) -> IteratorRef:
+    item = _unwrap_item(res)
+    if item["type"] != "InteropInterface" or item.get("interface") != "IIterator" or not item.get("id") or not res.get("session"):
+        raise RuntimeError("result is not an iterator or sessions are disabled")
+    return IteratorRef(session=res["session"], id=item["id"])
+
+
+def _item_to_fields(item: StackItem, n: int) -> list[StackItem]:
+    if item["type"] not in ("Array", "Struct") or len(item["value"]) != n:
+        raise ValueError(f"expected {n} fields structure, got {item['type']}")
+    return item["value"]
+
+
+def item_to_any(item: StackItem) -> StackItem:
+    return item
+
+
+def item_to_bool(item: StackItem) -> bool:
+    if item["type"] == "Boolean":
+        return item["value"]
+    if item["type"] == "Integer":
+        return int(item["value"]) != 0
+    if item["type"] in ("ByteString", "Buffer"):
+        return any(item_to_bytes(item))
+    raise ValueError(f"can't convert {item['type']} to boolean")
+
+
+def item_to_int(item: StackItem) -> int:
+    if item["type"] == "Integer":
+        return int(item["value"])
+    if item["type"] == "Boolean":
+        return 1 if item["value"] else 0
+    if item["type"] in ("ByteString", "Buffer"):
+        return int.from_bytes(item_to_bytes(item), "little", signed=True)
+    raise ValueError(f"can't convert {item['type']} to integer")
+
+
+def item_to_bytes(item: StackItem) -> bytes:
+    if item["type"] not in ("ByteString", "Buffer"):
+        raise ValueError(f"can't convert {item['type']} to bytes")
+    return base64.b64decode(item["value"])
+
+
+def item_to_str(item: StackItem) -> str:
+    return item_to_bytes(item).decode("utf-8")
+
+
+def _item_to_fixed_bytes(item: StackItem, n: int) -> bytes:
+    b = item_to_bytes(item)
+    if len(b) != n:
+        raise ValueError(f"expected {n} bytes, got {len(b)}")
+    return b
+
+
+def item_to_hash160(item: StackItem) -> str:
+    return "0x" + _item_to_fixed_bytes(item, 20)[::-1].hex()
+
+
+def item_to_hash256(item: StackItem) -> str:
+    return "0x" + _item_to_fixed_bytes(item, 32)[::-1].hex()
+
+
+def item_to_public_key(item: StackItem) -> str:
+    return _item_to_fixed_bytes(item, 33).hex()
+
+
+def item_to_key(item: StackItem) -> Any:
+    if item["type"] == "Integer":
+        return int(item["value"])
+    if item["type"] == "Boolean":
+        return item["value"]
+    if item["type"] in ("ByteString", "Buffer"):
+        return item_to_bytes(item)
+    raise ValueError(f"can't use {item['type']} as a map key")
+
+
+def item_to_list(item: StackItem, f: Callable[[StackItem], _T]) -> list[_T]:
+    if item["type"] not in ("Array", "Struct"):
+        raise ValueError(f"can't convert {item['type']} to list")
+    return [f(e) for e in item["value"]]
+
+
+def item_to_dict(item: StackItem, kf: Callable[[StackItem], _K], vf: Callable[[StackItem], _T]) -> dict[_K, _T]:
+    if item["type"] != "Map":
+        raise ValueError(f"can't convert {item['type']} to dict")
+    return {kf(e["key"]): vf(e["value"]) for e in item["value"]}
+
+
+def param_any(v: Any) -> ContractParam:
+    if v is None:
+        return {"type": "Any"}
+    if isinstance(v, bool):
+        return param_bool(v)
+    if isinstance(v, int):
+        return param_int(v)
+    if isinstance(v, str):
+        return param_str(v)
+    if isinstance(v, (bytes, bytearray)):
+        return param_bytes(v)
+    if isinstance(v, (list, tuple)):
+        return param_list(v, param_any)
+    if isinstance(v, dict):
+        if "type" in v and set(v) <= {"type", "value"}:
+            return v
+        return param_dict(v, param_any, param_any)
+    raise ValueError(f"unsupported parameter value {v!r}")
+
+
+def param_bool(v: bool) -> ContractParam:
+    return {"type": "Boolean", "value": v}
+
+
+def param_int(v: int) -> ContractParam:
+    return {"type": "Integer", "value": str(v)}
+
+
+def param_bytes(v: bytes) -> ContractParam:
+    return {"type": "ByteArray", "value": base64.b64encode(v).decode()}
+
+
+def param_str(v: str) -> ContractParam:
+    return {"type": "String", "value": v}
+
+
+def param_hash160(v: str) -> ContractParam:
+    return {"type": "Hash160", "value": v}
+
+
+def param_hash256(v: str) -> ContractParam:
+    return {"type": "Hash256", "value": v}
+
+
+def param_public_key(v: str) -> ContractParam:
+    return {"type": "PublicKey", "value": v}
+
+
+def param_signature(v: bytes) -> ContractParam:
+    return {"type": "Signature", "value": base64.b64encode(v).decode()}
+
+
+def param_list(v: list[_T], f: Callable[[_T], ContractParam]) -> ContractParam:
+    return {"type": "Array", "value": [f(e) for e in v]}
+
+
+def param_dict(v: dict[_K, _T], kf: Callable[[_K], ContractParam], vf: Callable[[_T], ContractParam]) -> ContractParam:
+    return {"type": "Map", "value": [{"key": kf(k), "value": vf(e)} for k, e in v.items()]}
+`
+
+// pyType returns Python type annotation for the given extended type, param
+// specifies whether it's used for method parameter.
+func pyType(et binding.ExtendedType, param bool) string {
+	switch et.Base {
+	case smartcontract.AnyType:
+		if param {
+			return "Any"
+		}
+		return "StackItem"
+	case smartcontract.BoolType:
+		return "bool"
+	case smartcontract.IntegerType:
+		return "int"
+	case smartcontract.ByteArrayType, smartcontract.SignatureType:
+		return "bytes"
+	case smartcontract.StringType, smartcontract.Hash160Type, smartcontract.Hash256Type, smartcontract.PublicKeyType:
+		return "str"
+	case smartcontract.ArrayType:
+		if len(et.Name) > 0 {
+			return toTypeName(et.Name)
+		}
+		var sub = binding.ExtendedType{Base: smartcontract.AnyType}
+		if et.Value != nil {
+			sub = *et.Value
+		}
+		return "list[" + pyType(sub, param) + "]"
+	case smartcontract.MapType:
+		var sub = binding.ExtendedType{Base: smartcontract.AnyType}
+		if et.Value != nil {
+			sub = *et.Value
+		}
+		k := pyType(binding.ExtendedType{Base: et.Key}, true)
+		return "dict[" + k + ", " + pyType(sub, param) + "]"
+	case smartcontract.InteropInterfaceType:
+		return "IteratorRef"
+	case smartcontract.VoidType:
+		return "None"
+	default:
+		panic("unreachable")
+	}
+}
+
+// GeneratePython writes Python module containing smartcontract bindings to
+// the `cfg.Output`. It uses the same configuration as Generate (including
+// extended types), but the resulting module only depends on the Python
+// standard library. It doesn't check manifest from Config for validity,
+// incorrect manifest can lead to unexpected results.
+func GeneratePython(cfg binding.Config) error {
+	ctr := langTemplateFromConfig(cfg, langNaming{
+		method:   toSnakeCase,
+		param:    toSnakeCase,
+		field:    toSnakeCase,
+		reserved: pyReserved,
+	})
+	funcs := pyConverters.funcMap()
+	funcs["pyType"] = pyType
+	funcs["snake"] = toSnakeCase
+	var srcTemplate = template.Must(template.New("generate").Funcs(funcs).Parse(pySrcTmpl))
+	return srcTemplate.Execute(cfg.Output, ctr)
+}
@@ -0,0 +1,526 @@
+package rpcbinding
+
+import (
+	"text/template"
+
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/binding"
+)
+
+// The set of TypeScript/JavaScript reserved words that can't be used as
+// identifiers.
+var tsReserved = map[string]bool{
+	"await": true, "break": true, "case": true, "catch": true, "class": true,
+	"const": true, "continue": true, "debugger": true, "default": true,
+	"delete": true, "do": true, "else": true, "enum": true, "export": true,
+	"extends": true, "false": true, "finally": true, "for": true,
+	"function": true, "if": true, "implements": true, "import": true,
+	"in": true, "instanceof": true, "interface": true, "let": true,
+	"new": true, "null": true, "package": true, "private": true,
+	"protected": true, "public": true, "return": true, "static": true,
+	"super": true, "switch": true, "this": true, "throw": true, "true": true,
+	"try": true, "typeof": true, "var": true, "void": true, "while": true,
+	"with": true, "yield": true,
+}
+
+var tsConverters = langConverters{
+	item: map[smartcontract.ParamType]string{
+		smartcontract.AnyType:              "itemToAny",
+		smartcontract.BoolType:             "itemToBool",
+		smartcontract.IntegerType:          "itemToBigInt",
+		smartcontract.ByteArrayType:        "itemToBytes",
+		smartcontract.StringType:           "itemToString",
+		smartcontract.Hash160Type:          "itemToHash160",
+		smartcontract.Hash256Type:          "itemToHash256",
+		smartcontract.PublicKeyType:        "itemToPublicKey",
+		smartcontract.SignatureType:        "itemToBytes",
+		smartcontract.InteropInterfaceType: "itemToAny",
+	},
+	param: map[smartcontract.ParamType]string{
+		smartcontract.AnyType:              "paramAny",
+		smartcontract.BoolType:             "paramBool",
+		smartcontract.IntegerType:          "paramInteger",
+		smartcontract.ByteArrayType:        "paramBytes",
+		smartcontract.StringType:           "paramString",
+		smartcontract.Hash160Type:          "paramHash160",
+		smartcontract.Hash256Type:          "paramHash256",
+		smartcontract.PublicKeyType:        "paramPublicKey",
+		smartcontract.SignatureType:        "paramSignature",
+		smartcontract.InteropInterfaceType: "paramAny",
+	},
+	itemTo:  func(name string) string { return "itemTo" + name },
+	paramOf: func(name string) string { return "param" + name },
+	array:   [2]string{"itemToArray", "paramArray"},
+	mapping: [2]string{"itemToMap", "paramMap"},
+	anyKey:  "itemToKey",
+	lambda: func(arg, body string) string {
+		return "(" + arg + ") => " + body
+	},
+}
+
+const tsSrcTmpl = `// Code generated by neo-go contract generate-rpcwrapper --manifest <file.json> --out <file.ts> --lang ts [--hash <hash>] [--config <config>]; DO NOT EDIT.
+
+/**
+ * Module {{.ContractName}} contains RPC wrappers for {{.ContractName}} contract.
+ */
+{{if .Hash}}
+/** Hash contains contract hash (0x-prefixed LE string). */
+export const Hash = "{{.Hash}}";
+{{end}}
+/** ContractParam is a JSON representation of a contract invocation parameter. */
+export interface ContractParam {
+	type: string;
+	value?: unknown;
+}
+
+/** StackItem is a JSON representation of a VM stack item. */
+export interface StackItem {
+	type: string;
+	value?: any;
+	interface?: string;
+	id?: string;
+}
+
+/** MapKey is a primitive map key, byte strings are kept base64-encoded. */
+export type MapKey = bigint | boolean | string;
+
+/** InvokeResult is a JSON representation of a test invocation result. */
+export interface InvokeResult {
+	state: string;
+	gasconsumed: string;
+	script: string;
+	stack: StackItem[];
+	exception?: string | null;
+	session?: string;
+}
+
+/** Notification is a JSON representation of a contract notification. */
+export interface Notification {
+	contract: string;
+	eventname: string;
+	state: StackItem;
+}
+
+/** ApplicationLog is a JSON representation of a transaction or block application log. */
+export interface ApplicationLog {
+	executions: {
+		trigger: string;
+		vmstate: string;
+		notifications: Notification[];
+	}[];
+}
+
+/** IteratorRef is a reference to the iterator stored in the RPC server session. */
+export interface IteratorRef {
+	session: string;
+	id: string;
+}
+
+/** Invoker is used by ContractReader to call various safe methods. */
+export interface Invoker {
+	call(contract: string, method: string, params: ContractParam[]): Promise<InvokeResult>;
+}
+
+/** Actor is used by Contract to call state-changing methods. */
+export interface Actor extends Invoker {
+	/** sendCall creates, signs and sends a transaction invoking the method, it returns transaction hash. */
+	sendCall(contract: string, method: string, params: ContractParam[]): Promise<string>;
+}
+
+/** RPCInvoker is an Invoker implementation using invokefunction JSON-RPC call. */
+export class RPCInvoker implements Invoker {
+	private id = 0;
+
+	constructor(readonly endpoint: string, readonly signers: object[] = []) {}
+
+	call(contract: string, method: string, params: ContractParam[]): Promise<InvokeResult> {
+		return this.request("invokefunction", [contract, method, params, this.signers]);
+	}
+
+	/** traverseIterator returns up to count next items of the given iterator. */
+	traverseIterator(iterator: IteratorRef, count: number): Promise<StackItem[]> {
+		return this.request("traverseiterator", [iterator.session, iterator.id, count]);
+	}
+
+	/** terminateSession closes the given iterator session. */
+	terminateSession(session: string): Promise<boolean> {
+		return this.request("terminatesession", [session]);
+	}
+
+	async request(method: string, params: unknown[]): Promise<any> {
+		const resp = await fetch(this.endpoint, {
+			method: "POST",
+			headers: {"Content-Type": "application/json"},
+			body: JSON.stringify({jsonrpc: "2.0", id: ++this.id, method, params}),
+		});
+		const res = await resp.json();
+		if (res.error) {
+			throw new Error(` + "`RPC error ${res.error.code}: ${res.error.message}`" + `);
+		}
+		return res.result;
+	}
+}
+{{range $name, $typ := .NamedTypes}}
+/** {{.Name}} is a contract-specific {{.Name}} type used by its methods. */
+export interface {{.Name}} {
+{{- range .Fields}}
+	{{.Name}}: {{tsType .Type false}};
+{{- end}}
+}
+{{end -}}
+{{range $e := .Events}}
+/** {{.Name}} represents "{{.ManifestName}}" event emitted by the contract. */
+export interface {{.Name}} {
+{{- range .Parameters}}
+	{{.Name}}: {{tsType .Type false}};
+{{- end}}
+}
+{{end -}}
+{{if .SafeMethods}}
+/** ContractReader implements safe contract methods. */
+export class ContractReader {
+	constructor(readonly invoker: Invoker, readonly hash: string{{if .Hash}} = Hash{{end}}) {}
+{{range .SafeMethods}}
+	/** {{.Name}} invokes ` + "`{{.NameABI}}`" + ` method of contract. */
+	async {{.Name}}({{range $i, $p := .Parameters}}{{if $i}}, {{end}}{{.Name}}: {{tsType .Type true}}{{end}}): Promise<{{tsType .Return false}}> {
+		const res = await this.invoker.call(this.hash, "{{.NameABI}}", [{{range $i, $p := .Parameters}}{{if $i}}, {{end}}{{paramExpr .Type .Name}}{{end}}]);
+		{{if isVoid .Return}}unwrapItem(res);{{else if isIterator .Return}}return unwrapIterator(res);{{else}}return {{itemExpr .Return "unwrapItem(res)"}};{{end}}
+	}
+{{end -}}
+}
+{{end -}}
+{{if .Methods}}
+/** Contract implements all contract methods. */
+export class Contract {{if .SafeMethods}}extends ContractReader {{end}}{
+	constructor(readonly actor: Actor, {{if .SafeMethods}}hash{{else}}readonly hash{{end}}: string{{if .Hash}} = Hash{{end}}) {
+		{{- if .SafeMethods}}
+		super(actor, hash);
+		{{- end}}
+	}
+{{range .Methods}}
+	/** {{.Name}} creates a transaction invoking ` + "`{{.NameABI}}`" + ` method of the contract and returns its hash. */
+	{{.Name}}({{range $i, $p := .Parameters}}{{if $i}}, {{end}}{{.Name}}: {{tsType .Type true}}{{end}}): Promise<string> {
+		return this.actor.sendCall(this.hash, "{{.NameABI}}", [{{range $i, $p := .Parameters}}{{if $i}}, {{end}}{{paramExpr .Type .Name}}{{end}}]);
+	}
+{{end -}}
+}
+{{end -}}
+{{range .Events}}
+/** {{lowerFirst .Name}}sFromApplicationLog retrieves a set of all emitted events with "{{.ManifestName}}" name from the provided application log. */
+export function {{lowerFirst .Name}}sFromApplicationLog(log: ApplicationLog): {{.Name}}[] {
+	const res: {{.Name}}[] = [];
+	for (const ex of log.executions) {
+		for (const e of ex.notifications) {
+			if (e.eventname === "{{.ManifestName}}") {
+				res.push(itemTo{{.Name}}(e.state));
+			}
+		}
+	}
+	return res;
+}
+
+/** itemTo{{.Name}} converts notification state to {{.Name}}. */
+export function itemTo{{.Name}}(item: StackItem): {{.Name}} {
+	{{if .Parameters}}const arr = {{end}}itemToFields(item, {{len .Parameters}});
+	return {{"{"}}{{if .Parameters}}
+{{- range $i, $p := .Parameters}}
+		{{.Name}}: {{itemExpr .Type (printf "arr[%d]" $i)}},
+{{- end}}
+	{{end}}};
+}
+{{end -}}
+{{range .NamedTypes}}
+/** itemTo{{.Name}} converts stack item to {{.Name}}. */
+export function itemTo{{.Name}}(item: StackItem): {{.Name}} {
+	{{if .Fields}}const arr = {{end}}itemToFields(item, {{len .Fields}});
+	return {
+{{- range $i, $f := .Fields}}
+		{{.Name}}: {{itemExpr .Type (printf "arr[%d]" $i)}},
+{{- end}}
+	};
+}
+
+/** param{{.Name}} converts {{.Name}} to contract parameter. */
+export function param{{.Name}}(v: {{.Name}}): ContractParam {
+	return {type: "Array", value: [{{range $i, $f := .Fields}}{{if $i}}, {{end}}{{paramExpr .Type (printf "v.%s" .Name)}}{{end}}]};
+}
+{{end}}
+function unwrapItem(res: InvokeResult): StackItem {
+	if (res.state !== "HALT") {
+		throw new Error(` + "`invocation failed: ${res.exception}`" + `);
+	}
+	if (res.stack.length !== 1) {
+		throw new Error(` + "`result stack has ${res.stack.length} items, expected 1`" + `);
+	}
+	return res.stack[0];
+}
+
+function unwrapIterator(res: InvokeResult): IteratorRef {
+	const item = unwrapItem(res);
+	if (item.type !== "InteropInterface" || item.interface !== "IIterator" || !item.id || !res.session) {
+		throw new Error("result is not an iterator or sessions are disabled");
+	}
+	return {session: res.session, id: item.id};
+}
+
+function itemToFields(item: StackItem, n: number): StackItem[] {
+	if ((item.type !== "Array" && item.type !== "Struct") || item.value.length !== n) {
+		throw new Error(` + "`expected ${n} fields structure, got ${item.type}`" + `);
+	}
+	return item.value;
+}
+
+function fromBase64(s: string): Uint8Array {
+	return Uint8Array.from(atob(s), (c) => c.charCodeAt(0));
+}
+
+function toBase64(b: Uint8Array): string {
+	return btoa(String.fromCharCode(...b));
+}
+
+function toHex(b: Uint8Array): string {
+	return Array.from(b, (x) => x.toString(16).padStart(2, "0")).join("");
+}
+
+export function itemToAny(item: StackItem): StackItem {
+	return item;
+}
+
+export function itemToBool(item: StackItem): boolean {
+	switch (item.type) {
+	case "Boolean":
+		return item.value;
+	case "Integer":
+		return BigInt(item.value) !== 0n;
+	case "ByteString":
+	case "Buffer":
+		return itemToBytes(item).some((x) => x !== 0);
+	}
+	throw new Error(` + "`can't convert ${item.type} to boolean`" + `);
+}
+
+export function itemToBigInt(item: StackItem): bigint {
+	switch (item.type) {
+	case "Integer":
+		return BigInt(item.value);
+	case "Boolean":
+		return item.value ? 1n : 0n;
+	case "ByteString":
+	case "Buffer": {
+		const b = itemToBytes(item);
+		if (b.length === 0) {
+			return 0n;
+		}
+		const v = BigInt("0x" + toHex(b.reverse()));
+		return (b[0] & 0x80) !== 0 ? v - (1n << BigInt(b.length * 8)) : v;
+	}
+	}
+	throw new Error(` + "`can't convert ${item.type} to integer`" + `);
+}
+
+export function itemToBytes(item: StackItem): Uint8Array {
+	if (item.type !== "ByteString" && item.type !== "Buffer") {
+		throw new Error(` + "`can't convert ${item.type} to bytes`" + `);
+	}
+	return fromBase64(item.value);
+}
+
+export function itemToString(item: StackItem): string {
+	return new TextDecoder("utf-8", {fatal: true}).decode(itemToBytes(item));
+}
+
+function itemToFixedBytes(item: StackItem, n: number): Uint8Array {
+	const b = itemToBytes(item);
+	if (b.length !== n) {
+		throw new Error(` + "`expected ${n} bytes, got ${b.length}`" + `);
+	}
+	return b;
+}
+
+export function itemToHash160(item: StackItem): string {
+	return "0x" + toHex(itemToFixedBytes(item, 20).reverse());
+}
+
+export function itemToHash256(item: StackItem): string {
+	return "0x" + toHex(itemToFixedBytes(item, 32).reverse());
+}
+
+export function itemToPublicKey(item: StackItem): string {
+	return toHex(itemToFixedBytes(item, 33));
+}
+
+export function itemToKey(item: StackItem): MapKey {
+	switch (item.type) {
+	case "Integer":
+		return BigInt(item.value);
+	case "Boolean":
+		return item.value;
+	case "ByteString":
+	case "Buffer":
+		return item.value;
+	}
+	throw new Error(` + "`can't use ${item.type} as a map key`" + `);
+}
+
+export function itemToArray<T>(item: StackItem, f: (e: StackItem) => T): T[] {
+	if (item.type !== "Array" && item.type !== "Struct") {
+		throw new Error(` + "`can't convert ${item.type} to array`" + `);
+	}
+	return item.value.map(f);
+}
+
+export function itemToMap<K, V>(item: StackItem, kf: (e: StackItem) => K, vf: (e: StackItem) => V): Map<K, V> {
+	if (item.type !== "Map") {
+		throw new Error(` + "`can't convert ${item.type} to map`" + `);
+	}
+	return new Map(item.value.map((e: {key: StackItem; value: StackItem}) => [kf(e.key), vf(e.value)]));
+}
+
+export function paramAny(v: unknown): ContractParam {
+	if (v === null || v === undefined) {
+		return {type: "Any"};
+	}
+	switch (typeof v) {
+	case "boolean":
+		return paramBool(v);
+	case "bigint":
+	case "number":
+		return paramInteger(v);
+	case "string":
+		return paramString(v);
+	}
+	if (v instanceof Uint8Array) {
+		return paramBytes(v);
+	}
+	if (Array.isArray(v)) {
+		return paramArray(v, paramAny);
+	}
+	if (v instanceof Map) {
+		return paramMap(v, paramAny, paramAny);
+	}
+	if (typeof v === "object" && "type" in v) {
+		return v as ContractParam;
+	}
+	throw new Error("unsupported parameter value");
+}
+
+export function paramBool(v: boolean): ContractParam {
+	return {type: "Boolean", value: v};
+}
+
+export function paramInteger(v: bigint | number): ContractParam {
+	return {type: "Integer", value: v.toString()};
+}
+
+export function paramBytes(v: Uint8Array): ContractParam {
+	return {type: "ByteArray", value: toBase64(v)};
+}
+
+export function paramString(v: string): ContractParam {
+	return {type: "String", value: v};
+}
+
+export function paramHash160(v: string): ContractParam {
+	return {type: "Hash160", value: v};
+}
+
+export function paramHash256(v: string): ContractParam {
+	return {type: "Hash256", value: v};
+}
+
+export function paramPublicKey(v: string): ContractParam {
+	return {type: "PublicKey", value: v};
+}
+
+export function paramSignature(v: Uint8Array): ContractParam {
+	return {type: "Signature", value: toBase64(v)};
+}
+
+export function paramArray<T>(v: T[], f: (e: T) => ContractParam): ContractParam {
+	return {type: "Array", value: v.map(f)};
+}
+
+export function paramMap<K, V>(v: Map<K, V>, kf: (k: K) => ContractParam, vf: (e: V) => ContractParam): ContractParam {
+	return {type: "Map", value: Array.from(v, ([k, e]) => ({key: kf(k), value: vf(e)}))};
+}
+`
+
+// tsType returns TypeScript type for the given extended type, param specifies
+// whether it's used for method parameter (parameters accept a wider set of
+// values).
+func tsType(et binding.ExtendedType, param bool) string {
+	switch et.Base {
+	case smartcontract.AnyType:
+		if param {
+			return "unknown"
+		}
+		return "StackItem"
+	case smartcontract.BoolType:
+		return "boolean"
+	case smartcontract.IntegerType:
+		if param {
+			return "bigint | number"
+		}
+		return "bigint"
+	case smartcontract.ByteArrayType, smartcontract.SignatureType:
+		return "Uint8Array"
+	case smartcontract.StringType, smartcontract.Hash160Type, smartcontract.Hash256Type, smartcontract.PublicKeyType:
+		return "string"
+	case smartcontract.ArrayType:
+		if len(et.Name) > 0 {
+			return toTypeName(et.Name)
+		}
+		var sub = binding.ExtendedType{Base: smartcontract.AnyType}
+		if et.Value != nil {
+			sub = *et.Value
+		}
+		s := tsType(sub, param)
+		if sub.Base == smartcontract.IntegerType && param {
+			// Element type can't be a union here.
+			s = "bigint"
+		}
+		return s + "[]"
+	case smartcontract.MapType:
+		var sub = binding.ExtendedType{Base: smartcontract.AnyType}
+		if et.Value != nil {
+			sub = *et.Value
+		}
+		k := tsType(binding.ExtendedType{Base: et.Key}, false)
+		if et.Key == smartcontract.AnyType || et.Key == smartcontract.UnknownType {
+			k = "MapKey"
+			if param {
+				k = "unknown"
+			}
+		}
+		v := tsType(sub, false)
+		if param && sub.Base == smartcontract.AnyType {
+			v = "unknown"
+		}
+		return "Map<" + k + ", " + v + ">"
+	case smartcontract.InteropInterfaceType:
+		return "IteratorRef"
+	case smartcontract.VoidType:
+		return "void"
+	default:
+		panic("unreachable")
+	}
+}
+
+// GenerateTypeScript writes TypeScript module containing smartcontract
+// bindings to the `cfg.Output`. It uses the same configuration as Generate
+// (including extended types), but the resulting module is self-contained
+// and doesn't depend on any Neo SDK. It doesn't check manifest from Config
+// for validity, incorrect manifest can lead to unexpected results.
+func GenerateTypeScript(cfg binding.Config) error {
+	ctr := langTemplateFromConfig(cfg, langNaming{
+		method:   lowerFirst,
+		param:    toCamelCase,
+		field:    lowerFirst,
+		reserved: tsReserved,
+	})
+	funcs := tsConverters.funcMap()
+	funcs["tsType"] = tsType
+	funcs["lowerFirst"] = lowerFirst
+	var srcTemplate = template.Must(template.New("generate").Funcs(funcs).Parse(tsSrcTmpl))
+	return srcTemplate.Execute(cfg.Output, ctr)
+}